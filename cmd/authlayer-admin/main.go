@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/bernardoforcillo/authlayer/internal/config"
+	"github.com/bernardoforcillo/authlayer/internal/database"
+	"github.com/bernardoforcillo/authlayer/internal/rbac"
+	"github.com/bernardoforcillo/authlayer/internal/repository"
+
+	"go.uber.org/zap"
+)
+
+const usage = `usage: authlayer-admin <command>
+
+commands:
+  rebuild-permissions  recompute the effective_permissions table from scratch
+  check-permissions    compare the effective_permissions table against live resolution`
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	// 1. Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+
+	// 2. Initialize logger
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		log.Fatalf("failed to create logger: %v", err)
+	}
+	defer logger.Sync()
+
+	// 3. Connect to database
+	db, err := database.New(cfg, logger)
+	if err != nil {
+		logger.Fatal("failed to connect to database", zap.Error(err))
+	}
+	if err := database.Migrate(db); err != nil {
+		logger.Fatal("failed to run migrations", zap.Error(err))
+	}
+
+	// 4. Create the materializer over the same sources the server resolves from
+	rbacResolver := rbac.NewResolver(
+		repository.NewRolePermissionRepository(db),
+		repository.NewUserRepository(db),
+		repository.NewServiceAccountRepository(db),
+		rbac.NewMemoryCache(cfg.CacheTTL, cfg.CacheMaxEntries),
+	)
+	materializer := rbac.NewMaterializer(rbacResolver, repository.NewEffectivePermissionRepository(db), repository.NewTransactor(db), nil, logger)
+
+	// 5. Run the command
+	ctx := context.Background()
+	switch os.Args[1] {
+	case "rebuild-permissions":
+		n, err := materializer.Rebuild(ctx)
+		if err != nil {
+			logger.Fatal("failed to rebuild effective permissions", zap.Int("principals_refreshed", n), zap.Error(err))
+		}
+		fmt.Printf("rebuilt effective permissions for %d principals\n", n)
+	case "check-permissions":
+		discrepancies, err := materializer.Verify(ctx)
+		if err != nil {
+			logger.Fatal("failed to check effective permissions", zap.Error(err))
+		}
+		for _, d := range discrepancies {
+			fmt.Println(d)
+		}
+		if len(discrepancies) > 0 {
+			fmt.Printf("%d discrepancies found; run rebuild-permissions to repair\n", len(discrepancies))
+			os.Exit(1)
+		}
+		fmt.Println("effective permissions are consistent")
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
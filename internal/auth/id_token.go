@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// OrgClaim describes one of the user's organizations in the orgs claim.
+type OrgClaim struct {
+	ID   string `json:"id"`
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+// UserClaims are the OpenID Connect claims about the user, released according to the
+// scopes granted: profile (name, picture), email (email, email_verified), orgs and roles.
+type UserClaims struct {
+	Email         string            `json:"email,omitempty"`
+	EmailVerified *bool             `json:"email_verified,omitempty"`
+	Name          string            `json:"name,omitempty"`
+	Picture       string            `json:"picture,omitempty"`
+	Orgs          []OrgClaim        `json:"orgs,omitempty"`
+	Roles         map[string]string `json:"roles,omitempty"` // org ID -> role name
+}
+
+// IDTokenClaims are the claims of an OpenID Connect ID token.
+type IDTokenClaims struct {
+	jwt.RegisteredClaims
+	UserClaims
+	Nonce    string           `json:"nonce,omitempty"`
+	AuthTime *jwt.NumericDate `json:"auth_time,omitempty"`
+	AMR      []string         `json:"amr,omitempty"`
+}
+
+// ErrNoIDTokenKey is returned by GenerateIDToken without an asymmetric signing key.
+var ErrNoIDTokenKey = errors.New("ID tokens require an asymmetric JWT signing key")
+
+// CanSignIDTokens reports whether an asymmetric signing key is configured. ID tokens are
+// never signed with the access secret: clients could not verify them, and anyone holding
+// the secret could forge access tokens.
+func (m *JWTManager) CanSignIDTokens() bool {
+	return m.signingKey != nil
+}
+
+// IDTokenSigningAlg returns the alg ID tokens are signed with, or "" when CanSignIDTokens
+// is false.
+func (m *JWTManager) IDTokenSigningAlg() string {
+	if m.signingKey == nil {
+		return ""
+	}
+	return m.signingMethod.Alg()
+}
+
+// GenerateIDToken creates an ID token for the user, addressed to the OAuth client.
+func (m *JWTManager) GenerateIDToken(userID, clientID, nonce string, authn Authentication, user UserClaims) (string, error) {
+	if m.signingKey == nil {
+		return "", ErrNoIDTokenKey
+	}
+	now := time.Now()
+	claims := IDTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    m.issuer,
+			Subject:   userID,
+			Audience:  jwt.ClaimStrings{clientID},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(m.accessExpiration)),
+		},
+		UserClaims: user,
+		Nonce:      nonce,
+		AMR:        authn.Methods,
+	}
+	if !authn.Time.IsZero() {
+		claims.AuthTime = jwt.NewNumericDate(authn.Time)
+	}
+	return m.signAccessToken(claims)
+}
@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JSONWebKey is the public half of a signing key as published in a JWKS document.
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Kid string `json:"kid,omitempty"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// EC
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JSONWebKeySet is a JWKS document.
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// ParseSigningKey parses a PEM-encoded RSA or ECDSA private key (PKCS#1, SEC 1 or PKCS#8)
+// and returns it with the JWT signing method it implies.
+func ParseSigningKey(pemData string) (crypto.Signer, jwt.SigningMethod, error) {
+	block, _ := pem.Decode([]byte(pemData))
+	if block == nil {
+		return nil, nil, errors.New("signing key is not PEM encoded")
+	}
+
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse signing key: %w", err)
+	}
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return k, jwt.SigningMethodRS256, nil
+	case *ecdsa.PrivateKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return k, jwt.SigningMethodES256, nil
+		case elliptic.P384():
+			return k, jwt.SigningMethodES384, nil
+		case elliptic.P521():
+			return k, jwt.SigningMethodES512, nil
+		}
+		return nil, nil, errors.New("unsupported ECDSA curve")
+	}
+	return nil, nil, fmt.Errorf("unsupported signing key type %T", key)
+}
+
+// PublicJWK describes a public key as a JWK whose kid is its RFC 7638 thumbprint.
+func PublicJWK(pub crypto.PublicKey, method jwt.SigningMethod) (JSONWebKey, error) {
+	var jwk JSONWebKey
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		jwk = JSONWebKey{
+			Kty: "RSA",
+			N:   encodeBase64URL(k.N.Bytes()),
+			E:   encodeBase64URL(big.NewInt(int64(k.E)).Bytes()),
+		}
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+		ecdh, err := k.ECDH()
+		if err != nil {
+			return JSONWebKey{}, err
+		}
+		// Uncompressed point: 0x04 || X || Y, each padded to the curve size.
+		point := ecdh.Bytes()
+		jwk = JSONWebKey{
+			Kty: "EC",
+			Crv: k.Curve.Params().Name,
+			X:   encodeBase64URL(point[1 : 1+size]),
+			Y:   encodeBase64URL(point[1+size:]),
+		}
+	default:
+		return JSONWebKey{}, fmt.Errorf("unsupported public key type %T", pub)
+	}
+
+	thumbprint, err := jwkThumbprint(jwk)
+	if err != nil {
+		return JSONWebKey{}, err
+	}
+	jwk.Use = "sig"
+	jwk.Alg = method.Alg()
+	jwk.Kid = thumbprint
+	return jwk, nil
+}
+
+// jwkThumbprint computes the RFC 7638 thumbprint: the hash of the required members in
+// lexicographic order.
+func jwkThumbprint(jwk JSONWebKey) (string, error) {
+	var members interface{}
+	switch jwk.Kty {
+	case "RSA":
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.Kty, jwk.N}
+	case "EC":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		}{jwk.Crv, jwk.Kty, jwk.X, jwk.Y}
+	}
+	data, err := json.Marshal(members)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return encodeBase64URL(sum[:]), nil
+}
+
+func encodeBase64URL(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
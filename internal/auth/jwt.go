@@ -1,7 +1,9 @@
 package auth
 
 import (
+	"crypto"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/bernardoforcillo/authlayer/internal/config"
@@ -22,31 +24,179 @@ type Claims struct {
 	Email       string `json:"email"`
 	TokenType   string `json:"type"`   // "access" or "refresh"
 	TokenFamily string `json:"family"` // for refresh token rotation detection
+	// Set on tokens issued to an OAuth client
+	ClientID string `json:"client_id,omitempty"`
+	Scope    string `json:"scope,omitempty"` // space-separated
+	// When and how the user signed in, carried unchanged across refreshes
+	AuthTime *jwt.NumericDate `json:"auth_time,omitempty"`
+	AMR      []string         `json:"amr,omitempty"`
+	// Set instead of UserID on service account tokens
+	ServiceAccountID string `json:"sa_id,omitempty"`
+	OrgID            string `json:"org_id,omitempty"`
+	// Set on tokens obtained by token exchange, naming who acts on the user's behalf
+	Actor *Actor `json:"act,omitempty"`
+}
+
+// Actor is the RFC 8693 act claim. A chain of delegations nests the previous actor.
+type Actor struct {
+	Subject string `json:"sub"`
+	Actor   *Actor `json:"act,omitempty"`
+}
+
+// Scopes returns the token's OAuth scopes.
+func (c *Claims) Scopes() []string {
+	return strings.Fields(c.Scope)
+}
+
+// Authentication returns when and how the user signed in. Time is zero for tokens issued
+// before this was recorded.
+func (c *Claims) Authentication() Authentication {
+	var authn Authentication
+	if c.AuthTime != nil {
+		authn.Time = c.AuthTime.Time
+	}
+	authn.Methods = c.AMR
+	return authn
+}
+
+// Authentication method references (RFC 8176) recorded on tokens.
+const (
+	AMRPassword  = "pwd"
+	AMRFederated = "fed" // signed in through an external OAuth provider
+)
+
+// Authentication describes a user's sign-in, reported to OIDC clients as auth_time and amr.
+type Authentication struct {
+	Time    time.Time
+	Methods []string
+}
+
+// NewAuthentication records a sign-in happening now.
+func NewAuthentication(methods ...string) Authentication {
+	return Authentication{Time: time.Now(), Methods: methods}
+}
+
+// ClientGrant binds a token pair to an OAuth client.
+type ClientGrant struct {
+	ClientID string
+	// Audience is the access token's aud claim.
+	Audience string
+	Scopes   []string
+}
+
+// ServiceAccountGrant describes an access token issued to a service account.
+type ServiceAccountGrant struct {
+	ServiceAccountID string
+	OrgID            string
+	Scopes           []string
 }
 
 // JWTManager handles JWT token generation and validation.
+//
+// Access tokens are signed with the HMAC access secret unless an asymmetric signing key is
+// configured, in which case they carry the key's kid and other services can verify them
+// against JWKS. HMAC access tokens stay valid either way, so enabling the key does not log
+// anyone out. Refresh tokens are only ever read by authlayer and always use HMAC.
 type JWTManager struct {
+	issuer            string
 	accessSecret      []byte
 	refreshSecret     []byte
 	accessExpiration  time.Duration
 	refreshExpiration time.Duration
+	saExpiration      time.Duration
+
+	signingKey    crypto.Signer
+	signingMethod jwt.SigningMethod
+	signingJWK    JSONWebKey
 }
 
 // NewJWTManager creates a new JWTManager from config.
-func NewJWTManager(cfg *config.Config) *JWTManager {
-	return &JWTManager{
+func NewJWTManager(cfg *config.Config) (*JWTManager, error) {
+	m := &JWTManager{
+		issuer:            cfg.OAuthIssuer,
 		accessSecret:      []byte(cfg.JWTAccessSecret),
 		refreshSecret:     []byte(cfg.JWTRefreshSecret),
 		accessExpiration:  cfg.JWTAccessExpiration,
 		refreshExpiration: cfg.JWTRefreshExpiration,
+		saExpiration:      cfg.ServiceAccountTokenTTL,
+	}
+	if cfg.JWTSigningKey != "" {
+		key, method, err := ParseSigningKey(cfg.JWTSigningKey)
+		if err != nil {
+			return nil, err
+		}
+		jwk, err := PublicJWK(key.Public(), method)
+		if err != nil {
+			return nil, err
+		}
+		m.signingKey, m.signingMethod, m.signingJWK = key, method, jwk
+	}
+	return m, nil
+}
+
+// Issuer returns the iss claim of issued tokens.
+func (m *JWTManager) Issuer() string {
+	return m.issuer
+}
+
+// JWKS returns the public keys access tokens can be verified with; it is empty when tokens
+// are signed with the HMAC secret.
+func (m *JWTManager) JWKS() JSONWebKeySet {
+	set := JSONWebKeySet{Keys: []JSONWebKey{}}
+	if m.signingKey != nil {
+		set.Keys = append(set.Keys, m.signingJWK)
+	}
+	return set
+}
+
+func (m *JWTManager) signAccessToken(claims jwt.Claims) (string, error) {
+	if m.signingKey == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.accessSecret)
+	}
+	token := jwt.NewWithClaims(m.signingMethod, claims)
+	token.Header["kid"] = m.signingJWK.Kid
+	return token.SignedString(m.signingKey)
+}
+
+func (m *JWTManager) accessKey(token *jwt.Token) (interface{}, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		return m.accessSecret, nil
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		if m.signingKey != nil && token.Method.Alg() == m.signingMethod.Alg() {
+			return m.signingKey.Public(), nil
+		}
+	}
+	return nil, ErrInvalidToken
+}
+
+func (m *JWTManager) refreshKey(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		return nil, ErrInvalidToken
 	}
+	return m.refreshSecret, nil
 }
 
 // GenerateTokenPair creates a new access + refresh token pair.
-func (m *JWTManager) GenerateTokenPair(userID, email, tokenFamily string) (*TokenPair, error) {
+func (m *JWTManager) GenerateTokenPair(userID, email, tokenFamily string, authn Authentication) (*TokenPair, error) {
+	return m.generateTokenPair(userID, email, tokenFamily, authn, nil)
+}
+
+// GenerateClientTokenPair creates a token pair for an OAuth client acting on behalf of
+// the user. Both tokens carry the client ID and scopes, and the access token the
+// client's audience.
+func (m *JWTManager) GenerateClientTokenPair(userID, email, tokenFamily string, authn Authentication, grant ClientGrant) (*TokenPair, error) {
+	return m.generateTokenPair(userID, email, tokenFamily, authn, &grant)
+}
+
+func (m *JWTManager) generateTokenPair(userID, email, tokenFamily string, authn Authentication, grant *ClientGrant) (*TokenPair, error) {
 	if tokenFamily == "" {
 		tokenFamily = uuid.New().String()
 	}
+	var authTime *jwt.NumericDate
+	if !authn.Time.IsZero() {
+		authTime = jwt.NewNumericDate(authn.Time)
+	}
 
 	now := time.Now()
 	accessExp := now.Add(m.accessExpiration)
@@ -55,6 +205,7 @@ func (m *JWTManager) GenerateTokenPair(userID, email, tokenFamily string) (*Toke
 	// Access token
 	accessClaims := Claims{
 		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    m.issuer,
 			Subject:   userID,
 			IssuedAt:  jwt.NewNumericDate(now),
 			ExpiresAt: jwt.NewNumericDate(accessExp),
@@ -64,8 +215,15 @@ func (m *JWTManager) GenerateTokenPair(userID, email, tokenFamily string) (*Toke
 		Email:       email,
 		TokenType:   "access",
 		TokenFamily: tokenFamily,
+		AuthTime:    authTime,
+		AMR:         authn.Methods,
+	}
+	if grant != nil {
+		accessClaims.Audience = jwt.ClaimStrings{grant.Audience}
+		accessClaims.ClientID = grant.ClientID
+		accessClaims.Scope = strings.Join(grant.Scopes, " ")
 	}
-	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims).SignedString(m.accessSecret)
+	accessToken, err := m.signAccessToken(accessClaims)
 	if err != nil {
 		return nil, err
 	}
@@ -73,6 +231,7 @@ func (m *JWTManager) GenerateTokenPair(userID, email, tokenFamily string) (*Toke
 	// Refresh token
 	refreshClaims := Claims{
 		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    m.issuer,
 			Subject:   userID,
 			IssuedAt:  jwt.NewNumericDate(now),
 			ExpiresAt: jwt.NewNumericDate(refreshExp),
@@ -82,6 +241,12 @@ func (m *JWTManager) GenerateTokenPair(userID, email, tokenFamily string) (*Toke
 		Email:       email,
 		TokenType:   "refresh",
 		TokenFamily: tokenFamily,
+		AuthTime:    authTime,
+		AMR:         authn.Methods,
+	}
+	if grant != nil {
+		refreshClaims.ClientID = grant.ClientID
+		refreshClaims.Scope = accessClaims.Scope
 	}
 	refreshToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims).SignedString(m.refreshSecret)
 	if err != nil {
@@ -97,25 +262,79 @@ func (m *JWTManager) GenerateTokenPair(userID, email, tokenFamily string) (*Toke
 	}, nil
 }
 
+// GenerateServiceAccountToken creates a short-lived access token for a service account,
+// addressed to authlayer. There is no refresh token: the service account authenticates
+// again once it expires.
+func (m *JWTManager) GenerateServiceAccountToken(grant ServiceAccountGrant) (string, time.Time, error) {
+	now := time.Now()
+	exp := now.Add(m.saExpiration)
+	token, err := m.signAccessToken(Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    m.issuer,
+			Subject:   grant.ServiceAccountID,
+			Audience:  jwt.ClaimStrings{m.issuer},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(exp),
+			ID:        uuid.New().String(),
+		},
+		TokenType:        "access",
+		Scope:            strings.Join(grant.Scopes, " "),
+		ServiceAccountID: grant.ServiceAccountID,
+		OrgID:            grant.OrgID,
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, exp, nil
+}
+
+// GenerateDelegatedToken creates an access token for the subject token's user, addressed to
+// audience, with the service account as actor. It expires with the subject token at the
+// latest and has no refresh token.
+func (m *JWTManager) GenerateDelegatedToken(subject *Claims, serviceAccountID, audience string, scopes []string) (string, time.Time, error) {
+	now := time.Now()
+	exp := now.Add(m.saExpiration)
+	if subject.ExpiresAt != nil && subject.ExpiresAt.Before(exp) {
+		exp = subject.ExpiresAt.Time
+	}
+	token, err := m.signAccessToken(Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    m.issuer,
+			Subject:   subject.UserID,
+			Audience:  jwt.ClaimStrings{audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(exp),
+			ID:        uuid.New().String(),
+		},
+		UserID:      subject.UserID,
+		Email:       subject.Email,
+		TokenType:   "access",
+		TokenFamily: subject.TokenFamily,
+		Scope:       strings.Join(scopes, " "),
+		AuthTime:    subject.AuthTime,
+		AMR:         subject.AMR,
+		Actor:       &Actor{Subject: serviceAccountID, Actor: subject.Actor},
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, exp, nil
+}
+
 // ValidateAccessToken validates an access JWT and returns its claims.
 func (m *JWTManager) ValidateAccessToken(tokenStr string) (*Claims, error) {
-	return m.validateToken(tokenStr, m.accessSecret, "access")
+	return m.validateToken(tokenStr, m.accessKey, "access")
 }
 
 // ValidateRefreshToken validates a refresh JWT and returns its claims.
 func (m *JWTManager) ValidateRefreshToken(tokenStr string) (*Claims, error) {
-	return m.validateToken(tokenStr, m.refreshSecret, "refresh")
+	return m.validateToken(tokenStr, m.refreshKey, "refresh")
 }
 
-func (m *JWTManager) validateToken(tokenStr string, secret []byte, expectedType string) (*Claims, error) {
+func (m *JWTManager) validateToken(tokenStr string, keyFunc jwt.Keyfunc, expectedType string) (*Claims, error) {
 	claims := &Claims{}
 
-	token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, ErrInvalidToken
-		}
-		return secret, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenStr, claims, keyFunc)
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
 			return nil, ErrExpiredToken
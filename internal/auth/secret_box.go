@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// SecretBox encrypts secrets authlayer must read back, unlike those it only compares
+// hashes of, with AES-256-GCM.
+type SecretBox struct {
+	aead cipher.AEAD
+}
+
+// NewSecretBox creates a SecretBox from a base64-encoded 32-byte key.
+func NewSecretBox(encodedKey string) (*SecretBox, error) {
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("decode encryption key: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, errors.New("encryption key must be 32 bytes")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &SecretBox{aead: aead}, nil
+}
+
+// Seal encrypts plaintext under a random nonce and returns both, base64 encoded.
+func (b *SecretBox) Seal(plaintext []byte) (string, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b.aead.Seal(nonce, nonce, plaintext, nil)), nil
+}
+
+// Open decrypts a value returned by Seal.
+func (b *SecretBox) Open(sealed string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < b.aead.NonceSize() {
+		return nil, errors.New("sealed secret is too short")
+	}
+	nonce, ciphertext := data[:b.aead.NonceSize()], data[b.aead.NonceSize():]
+	return b.aead.Open(nil, nonce, ciphertext, nil)
+}
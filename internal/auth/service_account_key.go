@@ -0,0 +1,198 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// MaxAssertionLifetime bounds how far in the future a service account assertion may
+// expire.
+const MaxAssertionLifetime = 5 * time.Minute
+
+// ParsePublicKey parses a service account public key, PEM encoded (PKIX or PKCS#1) or as a
+// JWK, and returns it with the JWT signing method assertions must use.
+func ParsePublicKey(data string) (crypto.PublicKey, jwt.SigningMethod, error) {
+	data = strings.TrimSpace(data)
+	var (
+		pub interface{}
+		err error
+	)
+	if strings.HasPrefix(data, "{") {
+		pub, err = parseJWK(data)
+	} else {
+		block, _ := pem.Decode([]byte(data))
+		if block == nil {
+			return nil, nil, errors.New("public key is neither PEM encoded nor a JWK")
+		}
+		if block.Type == "RSA PUBLIC KEY" {
+			pub, err = x509.ParsePKCS1PublicKey(block.Bytes)
+		} else {
+			pub, err = x509.ParsePKIXPublicKey(block.Bytes)
+		}
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse public key: %w", err)
+	}
+
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		if k.N.BitLen() < 2048 {
+			return nil, nil, errors.New("RSA public keys must be at least 2048 bits")
+		}
+		return k, jwt.SigningMethodRS256, nil
+	case *ecdsa.PublicKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return k, jwt.SigningMethodES256, nil
+		case elliptic.P384():
+			return k, jwt.SigningMethodES384, nil
+		case elliptic.P521():
+			return k, jwt.SigningMethodES512, nil
+		}
+		return nil, nil, errors.New("unsupported ECDSA curve")
+	}
+	return nil, nil, fmt.Errorf("unsupported public key type %T", pub)
+}
+
+// parseJWK decodes the public part of an RSA or EC JWK.
+func parseJWK(data string) (interface{}, error) {
+	var jwk JSONWebKey
+	if err := json.Unmarshal([]byte(data), &jwk); err != nil {
+		return nil, err
+	}
+	switch jwk.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		if len(e) == 0 || len(e) > 4 {
+			return nil, errors.New("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) != size || len(y) != size {
+			return nil, errors.New("invalid EC point")
+		}
+		return ecdsa.ParseUncompressedPublicKey(curve, slices.Concat([]byte{4}, x, y))
+	}
+	return nil, fmt.Errorf("unsupported key type %q", jwk.Kty)
+}
+
+// EncodePublicKey PEM encodes a public key in PKIX form.
+func EncodePublicKey(pub crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
+
+// GenerateKeyPair generates an ECDSA P-256 key pair for a service account. The private
+// key is PEM encoded in PKCS#8 form, for the caller to hand over once.
+func GenerateKeyPair() (string, crypto.PublicKey, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", nil, err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", nil, err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), key.Public(), nil
+}
+
+// AssertionKey is what verifying an assertion needs to know of the service account key
+// named by its kid header.
+type AssertionKey struct {
+	ServiceAccountID string
+	// PublicKey is the registered public key in PEM form. Keys without one are secrets,
+	// and assertions are signed with HS256 keyed by Secret, the plaintext secret. Secret
+	// keys whose plaintext is not kept cannot sign assertions.
+	PublicKey string
+	Secret    []byte
+}
+
+// VerifyServiceAccountAssertion checks a JWT a service account signed with one of its keys
+// (RFC 7523) and returns its claims. lookup resolves the kid header to the key. The
+// assertion's iss and sub must be the key's service account, its aud one of audiences, it
+// must carry a jti and expire within MaxAssertionLifetime. Callers must claim the jti to
+// reject replays.
+func VerifyServiceAccountAssertion(assertion string, lookup func(keyID string) (AssertionKey, error), audiences ...string) (*jwt.RegisteredClaims, error) {
+	var key AssertionKey
+	claims := &jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(assertion, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		var err error
+		if key, err = lookup(kid); err != nil {
+			return nil, ErrInvalidToken
+		}
+		if key.PublicKey == "" {
+			if len(key.Secret) == 0 || t.Method != jwt.SigningMethodHS256 {
+				return nil, ErrInvalidToken
+			}
+			return key.Secret, nil
+		}
+		pub, method, err := ParsePublicKey(key.PublicKey)
+		if err != nil || t.Method.Alg() != method.Alg() {
+			return nil, ErrInvalidToken
+		}
+		return pub, nil
+	}, jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if claims.Issuer != key.ServiceAccountID || claims.Subject != key.ServiceAccountID {
+		return nil, errors.New("assertion iss and sub must be the service account ID")
+	}
+	if !slices.ContainsFunc(claims.Audience, func(aud string) bool { return slices.Contains(audiences, aud) }) {
+		return nil, errors.New("assertion audience is not authlayer")
+	}
+	if time.Until(claims.ExpiresAt.Time) > MaxAssertionLifetime {
+		return nil, errors.New("assertion lifetime is too long")
+	}
+	if claims.ID == "" {
+		return nil, errors.New("assertion jti is required")
+	}
+	return claims, nil
+}
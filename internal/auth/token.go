@@ -3,9 +3,18 @@ package auth
 import (
 	"crypto/rand"
 	"encoding/base64"
+	"math/big"
+	"strings"
 	"time"
 )
 
+// userCodeAlphabet has no vowels, so user codes cannot spell words, and no easily confused
+// characters (RFC 8628 section 6.1).
+const (
+	userCodeAlphabet = "BCDFGHJKLMNPQRSTVWXZ"
+	userCodeLength   = 8
+)
+
 // TokenPair holds an access/refresh token pair with expiration times.
 type TokenPair struct {
 	AccessToken           string
@@ -23,3 +32,35 @@ func GenerateRandomToken(byteLength int) (string, error) {
 	}
 	return base64.URLEncoding.EncodeToString(b), nil
 }
+
+// GenerateUserCode generates a device authorization user code, in normalized form.
+func GenerateUserCode() (string, error) {
+	var b strings.Builder
+	max := big.NewInt(int64(len(userCodeAlphabet)))
+	for range userCodeLength {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(userCodeAlphabet[n.Int64()])
+	}
+	return b.String(), nil
+}
+
+// NormalizeUserCode uppercases a user code as typed and drops dashes and spaces.
+func NormalizeUserCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(code))
+}
+
+// FormatUserCode formats a normalized user code for display, as "BCDF-GHJK".
+func FormatUserCode(code string) string {
+	if len(code) != userCodeLength {
+		return code
+	}
+	return code[:4] + "-" + code[4:]
+}
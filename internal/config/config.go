@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/caarlos0/env/v11"
+	"github.com/google/uuid"
 )
 
 // Config holds all application configuration, populated from environment variables.
@@ -22,10 +23,76 @@ type Config struct {
 	JWTRefreshSecret     string        `env:"JWT_REFRESH_SECRET,required"`
 	JWTAccessExpiration  time.Duration `env:"JWT_ACCESS_EXPIRATION" envDefault:"15m"`
 	JWTRefreshExpiration time.Duration `env:"JWT_REFRESH_EXPIRATION" envDefault:"168h"`
+	// PEM-encoded RSA or ECDSA private key; when set, access tokens are signed with it and
+	// published at /.well-known/jwks.json. OpenID Connect is only enabled with one, as ID
+	// tokens are never signed with the access secret.
+	JWTSigningKey string `env:"JWT_SIGNING_KEY"`
 
 	// OAuth providers as JSON string
 	OAuthProvidersJSON string `env:"OAUTH_PROVIDERS" envDefault:"{}"`
 
+	// Permission cache: "memory" (per instance, LRU-bounded) or "redis" (shared)
+	CacheBackend    string        `env:"CACHE_BACKEND" envDefault:"memory"`
+	CacheTTL        time.Duration `env:"CACHE_TTL" envDefault:"5m"`
+	CacheMaxEntries int           `env:"CACHE_MAX_ENTRIES" envDefault:"100000"`
+	RedisURL        string        `env:"REDIS_URL" envDefault:"redis://localhost:6379/0"`
+
+	// Permission cache invalidation across instances: "postgres" (LISTEN/NOTIFY) or "none"
+	CacheInvalidationBus string `env:"CACHE_INVALIDATION_BUS" envDefault:"postgres"`
+
+	// Answer permission checks from the materialized effective_permissions table
+	MaterializedPermissions bool `env:"MATERIALIZED_PERMISSIONS" envDefault:"false"`
+	// How long recorded permission changes stay available to resuming watchers
+	PermissionChangeRetention time.Duration `env:"PERMISSION_CHANGE_RETENTION" envDefault:"168h"`
+
+	// OPA bundle endpoint, served only when a token is set
+	OPABundleToken    string        `env:"OPA_BUNDLE_TOKEN"`
+	OPABundleCacheTTL time.Duration `env:"OPA_BUNDLE_CACHE_TTL" envDefault:"10s"`
+
+	// Envoy ext_authz gRPC server, started when the port is non-zero
+	ExtAuthzPort      int    `env:"EXT_AUTHZ_PORT" envDefault:"0"`
+	ExtAuthzRulesJSON string `env:"EXT_AUTHZ_RULES" envDefault:"[]"`
+	// Request header proxies use to pass the org a request acts in
+	ProxyOrgHeader string `env:"PROXY_ORG_HEADER" envDefault:"X-Org-ID"`
+	// Audience access tokens must include, when they name one, to pass ext_authz and
+	// forward-auth checks; defaults to OAUTH_ISSUER
+	ProxyAudience string `env:"PROXY_AUDIENCE"`
+
+	// Forward-auth endpoint (/auth/verify) for nginx auth_request and Traefik ForwardAuth
+	ForwardAuthRulesJSON string        `env:"FORWARD_AUTH_RULES" envDefault:"[]"`
+	ForwardAuthCookie    string        `env:"FORWARD_AUTH_COOKIE" envDefault:"authlayer_access_token"`
+	ForwardAuthCacheTTL  time.Duration `env:"FORWARD_AUTH_CACHE_TTL" envDefault:"5s"`
+
+	// Kubernetes TokenReview/SubjectAccessReview webhooks, served only when a token is set
+	K8sWebhookToken   string `env:"K8S_WEBHOOK_TOKEN"`
+	K8sGroupPrefix    string `env:"K8S_GROUP_PREFIX" envDefault:"authlayer:"`
+	K8sAuthzRulesJSON string `env:"K8S_AUTHZ_RULES" envDefault:"[]"`
+
+	// OAuth 2.0 authorization server. The issuer is the public base URL of the HTTP port.
+	// Signed-out users are sent to the login URL, and users who have not yet approved a
+	// third-party client to the consent URL, each with a return_to parameter.
+	OAuthIssuer        string        `env:"OAUTH_ISSUER" envDefault:"http://localhost:8080"`
+	OAuthLoginURL      string        `env:"OAUTH_LOGIN_URL"`
+	OAuthConsentURL    string        `env:"OAUTH_CONSENT_URL"`
+	OAuthSessionCookie string        `env:"OAUTH_SESSION_COOKIE" envDefault:"authlayer_access_token"`
+	OAuthCodeTTL       time.Duration `env:"OAUTH_CODE_TTL" envDefault:"1m"`
+	// Device authorization grant (RFC 8628), served only when the verification URL is set:
+	// the page where users enter the code shown on the device
+	OAuthDeviceVerificationURL string        `env:"OAUTH_DEVICE_VERIFICATION_URL"`
+	OAuthDeviceCodeTTL         time.Duration `env:"OAUTH_DEVICE_CODE_TTL" envDefault:"10m"`
+	OAuthDevicePollInterval    time.Duration `env:"OAUTH_DEVICE_POLL_INTERVAL" envDefault:"5s"`
+	// Lifetime of the access tokens service accounts get from the client_credentials grant
+	ServiceAccountTokenTTL time.Duration `env:"SERVICE_ACCOUNT_TOKEN_TTL" envDefault:"10m"`
+	// Token exchange (RFC 8693): which service accounts may trade user tokens for which
+	// audiences; exchanges no policy allows are refused
+	TokenExchangePoliciesJSON string `env:"TOKEN_EXCHANGE_POLICIES" envDefault:"[]"`
+
+	// Signed requests with service account keys, available only with an encryption key: a
+	// base64 AES-256 key protecting the stored signing secrets. Requests are accepted within
+	// the clock skew of their timestamp, and nonces are remembered in the CACHE_BACKEND.
+	ServiceKeyEncryptionKey string        `env:"SERVICE_KEY_ENCRYPTION_KEY"`
+	RequestSigningClockSkew time.Duration `env:"REQUEST_SIGNING_CLOCK_SKEW" envDefault:"5m"`
+
 	// Rate Limiting
 	RateLimitPerSecond int `env:"RATE_LIMIT_PER_SECOND" envDefault:"100"`
 
@@ -34,6 +101,14 @@ type Config struct {
 
 	// Parsed OAuth providers (not from env directly)
 	OAuthProviders map[string]OAuthProviderConfig `env:"-"`
+	// Parsed ext_authz route rules (not from env directly)
+	ExtAuthzRules []RouteRule `env:"-"`
+	// Parsed forward-auth route rules (not from env directly)
+	ForwardAuthRules []RouteRule `env:"-"`
+	// Parsed Kubernetes authorization rules (not from env directly)
+	K8sAuthzRules []K8sAuthzRule `env:"-"`
+	// Parsed token exchange policies (not from env directly)
+	TokenExchangePolicies []TokenExchangePolicy `env:"-"`
 }
 
 // OAuthProviderConfig holds configuration for a single OAuth/OIDC provider.
@@ -45,6 +120,49 @@ type OAuthProviderConfig struct {
 	Scopes       []string `json:"scopes"`
 }
 
+// RouteRule maps proxied requests to the permission they require. Rules are matched in
+// order and the first whose method and path prefix match wins; unmatched requests are denied.
+// Prefixes match whole path segments of the decoded, cleaned path.
+// Forward auth matches them against the original request reported by the proxy.
+type RouteRule struct {
+	// Methods lists the HTTP methods the rule applies to; empty matches any method.
+	Methods    []string `json:"methods"`
+	PathPrefix string   `json:"path_prefix"`
+	// Permission is checked in the request's org; empty only requires authentication.
+	Permission string `json:"permission"`
+	// Public requests are allowed without credentials.
+	Public bool `json:"public"`
+}
+
+// K8sAuthzRule maps Kubernetes requests to the permission they require. Rules are matched in
+// order and the first match wins. A rule matches either resource requests or, when
+// NonResourcePaths is set, non-resource requests; empty lists match anything and "*" matches
+// any value.
+type K8sAuthzRule struct {
+	Verbs     []string `json:"verbs"`
+	APIGroups []string `json:"api_groups"`
+	// Resources may name a subresource as "pods/log".
+	Resources  []string `json:"resources"`
+	Namespaces []string `json:"namespaces"`
+	// NonResourcePaths are path prefixes such as "/healthz".
+	NonResourcePaths []string `json:"non_resource_paths"`
+	Permission       string   `json:"permission"`
+	// OrgID is the org the permission is checked in. Users hold no permissions outside an
+	// org, so rules meant for users must set it.
+	OrgID *uuid.UUID `json:"org_id"`
+}
+
+// TokenExchangePolicy lets a service account exchange the tokens of users it serves for
+// tokens addressed to other services, acting on the users' behalf.
+type TokenExchangePolicy struct {
+	ServiceAccountID uuid.UUID `json:"service_account_id"`
+	// Audiences the service account may request tokens for.
+	Audiences []string `json:"audiences"`
+	// Scopes caps the scopes of exchanged tokens, which also never exceed the scopes of the
+	// user's token.
+	Scopes []string `json:"scopes"`
+}
+
 // Load parses environment variables and returns a Config.
 func Load() (*Config, error) {
 	cfg := &Config{}
@@ -60,5 +178,18 @@ func Load() (*Config, error) {
 		}
 	}
 
+	if err := json.Unmarshal([]byte(cfg.ExtAuthzRulesJSON), &cfg.ExtAuthzRules); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(cfg.ForwardAuthRulesJSON), &cfg.ForwardAuthRules); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(cfg.K8sAuthzRulesJSON), &cfg.K8sAuthzRules); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(cfg.TokenExchangePoliciesJSON), &cfg.TokenExchangePolicies); err != nil {
+		return nil, err
+	}
+
 	return cfg, nil
 }
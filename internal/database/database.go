@@ -58,5 +58,19 @@ func Migrate(db *gorm.DB) error {
 		&model.ServiceAccount{},
 		&model.ServiceAccountKey{},
 		&model.ServiceAccountRole{},
+		&model.WorkloadIdentityTrust{},
+		&model.RoleConstraint{},
+		&model.RoleConstraintRole{},
+		&model.RelationNamespace{},
+		&model.RelationTuple{},
+		&model.Project{},
+		&model.ProjectMember{},
+		&model.LabelRoleBinding{},
+		&model.EffectivePermission{},
+		&model.PermissionChange{},
+		&model.OAuthClient{},
+		&model.OAuthAuthorizationCode{},
+		&model.OAuthConsent{},
+		&model.OAuthDeviceCode{},
 	)
 }
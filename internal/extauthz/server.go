@@ -0,0 +1,139 @@
+// Package extauthz implements Envoy's external authorization service
+// (envoy.service.auth.v3.Authorization) on top of authlayer's credentials and RBAC.
+package extauthz
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/bernardoforcillo/authlayer/internal/config"
+	"github.com/bernardoforcillo/authlayer/internal/middleware"
+	"github.com/bernardoforcillo/authlayer/internal/proxyauth"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server answers Envoy's Check calls. It listens on its own port so proxy traffic is not
+// subject to the API's per-client rate limit.
+type Server struct {
+	authv3.UnimplementedAuthorizationServer
+
+	authorizer *proxyauth.Authorizer
+	rules      []config.RouteRule
+	orgHeader  string
+	grpcServer *grpc.Server
+	logger     *zap.Logger
+}
+
+// NewServer creates an ext_authz server that maps routes to permissions with rules and reads
+// the request's org from orgHeader.
+func NewServer(authorizer *proxyauth.Authorizer, rules []config.RouteRule, orgHeader string, logger *zap.Logger) *Server {
+	s := &Server{
+		authorizer: authorizer,
+		rules:      rules,
+		orgHeader:  strings.ToLower(orgHeader),
+		logger:     logger,
+	}
+	s.grpcServer = grpc.NewServer(grpc.ChainUnaryInterceptor(
+		middleware.RecoveryUnaryInterceptor(logger),
+	))
+	authv3.RegisterAuthorizationServer(s.grpcServer, s)
+	return s
+}
+
+// Start listens on port and serves until GracefulStop is called.
+func (s *Server) Start(port int) error {
+	addr := fmt.Sprintf(":%d", port)
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	s.logger.Info("ext_authz server starting", zap.String("address", addr))
+	return s.grpcServer.Serve(lis)
+}
+
+// GracefulStop gracefully shuts down the server.
+func (s *Server) GracefulStop() {
+	s.grpcServer.GracefulStop()
+}
+
+// Check authorizes one proxied HTTP request. Envoy lowercases header names.
+func (s *Server) Check(ctx context.Context, req *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+	httpReq := req.GetAttributes().GetRequest().GetHttp()
+	headers := httpReq.GetHeaders()
+
+	rule, ok := proxyauth.MatchRoute(s.rules, httpReq.GetMethod(), httpReq.GetPath())
+	if !ok {
+		return denied(http.StatusForbidden, "no route rule matches the request"), nil
+	}
+	if rule.Public {
+		return &authv3.CheckResponse{
+			Status: &rpcstatus.Status{Code: int32(codes.OK)},
+			HttpResponse: &authv3.CheckResponse_OkResponse{OkResponse: &authv3.OkHttpResponse{
+				HeadersToRemove: proxyauth.IdentityHeaders,
+			}},
+		}, nil
+	}
+
+	var orgID *uuid.UUID
+	if v := headers[s.orgHeader]; v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return denied(http.StatusBadRequest, "invalid org header"), nil
+		}
+		orgID = &id
+	}
+
+	decision, err := s.authorizer.Authorize(ctx, headers["authorization"], orgID, rule.Permission)
+	if err != nil {
+		switch status.Code(err) {
+		case codes.Unauthenticated:
+			return denied(http.StatusUnauthorized, status.Convert(err).Message()), nil
+		case codes.PermissionDenied:
+			return denied(http.StatusForbidden, status.Convert(err).Message()), nil
+		default:
+			// Let Envoy's failure_mode_allow decide what happens when we cannot answer.
+			s.logger.Error("ext_authz check failed", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	var injected []*corev3.HeaderValueOption
+	for name, value := range decision.Headers() {
+		injected = append(injected, &corev3.HeaderValueOption{
+			Header:       &corev3.HeaderValue{Key: name, Value: value},
+			AppendAction: corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+		})
+	}
+	return &authv3.CheckResponse{
+		Status: &rpcstatus.Status{Code: int32(codes.OK)},
+		HttpResponse: &authv3.CheckResponse_OkResponse{OkResponse: &authv3.OkHttpResponse{
+			Headers: injected,
+		}},
+	}, nil
+}
+
+func denied(httpStatus int, message string) *authv3.CheckResponse {
+	code := codes.PermissionDenied
+	if httpStatus == http.StatusUnauthorized {
+		code = codes.Unauthenticated
+	}
+	return &authv3.CheckResponse{
+		Status: &rpcstatus.Status{Code: int32(code), Message: message},
+		HttpResponse: &authv3.CheckResponse_DeniedResponse{DeniedResponse: &authv3.DeniedHttpResponse{
+			Status: &typev3.HttpStatus{Code: typev3.StatusCode(httpStatus)},
+			Body:   message,
+		}},
+	}
+}
@@ -0,0 +1,137 @@
+package k8swebhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/bernardoforcillo/authlayer/internal/config"
+	"github.com/bernardoforcillo/authlayer/internal/model"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// SubjectAccessReview handles authorization.k8s.io/v1 SubjectAccessReview requests. The
+// webhook only ever allows or has no opinion: requests from other authenticators, requests
+// no rule matches and missing permissions all fall through to the next authorizer, so
+// native Kubernetes RBAC keeps working alongside it.
+func (h *Handler) SubjectAccessReview(w http.ResponseWriter, r *http.Request) {
+	var review subjectAccessReview
+	if !h.decode(w, r, &review) {
+		return
+	}
+	review.typeMeta = typeMeta{APIVersion: authorizationAPIVersion, Kind: "SubjectAccessReview"}
+	review.Status = h.authorize(r, &review.Spec)
+	writeJSON(w, review)
+}
+
+func (h *Handler) authorize(r *http.Request, spec *subjectAccessReviewSpec) subjectAccessReviewStatus {
+	principalType, principalID, ok, err := h.principal(r.Context(), spec.User)
+	if err != nil {
+		h.logger.Error("failed to identify kubernetes user", zap.Error(err), zap.String("user", spec.User))
+		return subjectAccessReviewStatus{EvaluationError: "failed to identify user"}
+	}
+	if !ok {
+		return subjectAccessReviewStatus{Reason: "not an authlayer identity"}
+	}
+
+	rule, ok := matchRule(h.rules, spec)
+	if !ok {
+		return subjectAccessReviewStatus{Reason: "no authlayer rule matches the request"}
+	}
+
+	var allowed bool
+	switch principalType {
+	case model.PrincipalTypeUser:
+		allowed, _, err = h.checker.CheckPermission(r.Context(), principalID, rule.Permission, rule.OrgID)
+	case model.PrincipalTypeServiceAccount:
+		allowed, err = h.checker.CheckServiceAccountPermission(r.Context(), principalID, rule.Permission, rule.OrgID)
+	}
+	if err != nil {
+		h.logger.Error("kubernetes permission check failed", zap.Error(err), zap.String("principal_id", principalID.String()))
+		return subjectAccessReviewStatus{EvaluationError: "permission check failed"}
+	}
+	if !allowed {
+		return subjectAccessReviewStatus{Reason: "authlayer permission " + rule.Permission + " not granted"}
+	}
+	return subjectAccessReviewStatus{Allowed: true, Reason: "authlayer permission " + rule.Permission}
+}
+
+// principal identifies the authlayer principal behind a Kubernetes username. Only
+// usernames carrying the prefix, which TokenReview issues, are authlayer identities; the
+// uid and extra of a review come from whichever authenticator the API server used, so
+// they are never trusted.
+func (h *Handler) principal(ctx context.Context, username string) (model.PrincipalType, uuid.UUID, bool, error) {
+	name, ok := strings.CutPrefix(username, h.prefix)
+	if !ok || name == "" {
+		return "", uuid.Nil, false, nil
+	}
+	if id, ok := strings.CutPrefix(name, "serviceaccount:"); ok {
+		saID, err := uuid.Parse(id)
+		if err != nil {
+			return "", uuid.Nil, false, nil
+		}
+		return model.PrincipalTypeServiceAccount, saID, true, nil
+	}
+	user, err := h.userRepo.GetByEmail(ctx, name)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", uuid.Nil, false, nil
+	}
+	if err != nil {
+		return "", uuid.Nil, false, err
+	}
+	return model.PrincipalTypeUser, user.ID, true, nil
+}
+
+// matchRule returns the first rule matching the request.
+func matchRule(rules []config.K8sAuthzRule, spec *subjectAccessReviewSpec) (config.K8sAuthzRule, bool) {
+	for _, rule := range rules {
+		if ruleMatches(rule, spec) {
+			return rule, true
+		}
+	}
+	return config.K8sAuthzRule{}, false
+}
+
+func ruleMatches(rule config.K8sAuthzRule, spec *subjectAccessReviewSpec) bool {
+	if attrs := spec.NonResourceAttributes; attrs != nil {
+		if len(rule.NonResourcePaths) == 0 || !matches(rule.Verbs, attrs.Verb) {
+			return false
+		}
+		for _, prefix := range rule.NonResourcePaths {
+			if strings.HasPrefix(attrs.Path, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+
+	attrs := spec.ResourceAttributes
+	if attrs == nil || len(rule.NonResourcePaths) > 0 {
+		return false
+	}
+	resource := attrs.Resource
+	if attrs.Subresource != "" {
+		resource += "/" + attrs.Subresource
+	}
+	return matches(rule.Verbs, attrs.Verb) &&
+		matches(rule.APIGroups, attrs.Group) &&
+		matches(rule.Resources, resource) &&
+		matches(rule.Namespaces, attrs.Namespace)
+}
+
+// matches reports whether value is in allowed; an empty list or "*" matches anything.
+func matches(allowed []string, value string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, a := range allowed {
+		if a == "*" || a == value {
+			return true
+		}
+	}
+	return false
+}
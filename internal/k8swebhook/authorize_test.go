@@ -0,0 +1,82 @@
+package k8swebhook
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bernardoforcillo/authlayer/internal/config"
+	"github.com/bernardoforcillo/authlayer/internal/model"
+	"github.com/bernardoforcillo/authlayer/internal/rbac"
+	"github.com/bernardoforcillo/authlayer/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// grantingRepo grants pods:list to the principals in granted.
+type grantingRepo struct {
+	repository.RolePermissionRepository
+	granted map[uuid.UUID]bool
+}
+
+func (r *grantingRepo) ResolveGrants(_ context.Context, seed repository.RoleSeed, _ int) ([]repository.RoleGrant, []repository.LabelCandidate, error) {
+	for _, id := range []*uuid.UUID{seed.UserID, seed.ServiceAccountID} {
+		if id != nil && r.granted[*id] {
+			perm := &model.Permission{Name: "pods:list"}
+			return []repository.RoleGrant{{RoleID: uuid.New(), Permission: perm}}, nil, nil
+		}
+	}
+	return nil, nil, nil
+}
+
+type emailUserRepo struct {
+	repository.UserRepository
+	users map[string]*model.User
+}
+
+func (r *emailUserRepo) GetByEmail(_ context.Context, email string) (*model.User, error) {
+	if u, ok := r.users[email]; ok {
+		return u, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func TestAuthorizeIdentifiesPrincipalByUsername(t *testing.T) {
+	alice := &model.User{Email: "alice@example.com"}
+	alice.ID = uuid.New()
+	saID := uuid.New()
+	orgID := uuid.New()
+
+	resolver := rbac.NewResolver(&grantingRepo{granted: map[uuid.UUID]bool{alice.ID: true, saID: true}}, nil, nil, rbac.NewMemoryCache(time.Minute, 0))
+	h := NewHandler(nil, rbac.NewChecker(resolver, nil, nil, zap.NewNop()),
+		&emailUserRepo{users: map[string]*model.User{alice.Email: alice}}, nil, nil, nil, nil,
+		[]config.K8sAuthzRule{{Verbs: []string{"list"}, Resources: []string{"pods"}, Permission: "pods:list", OrgID: &orgID}},
+		"token", "authlayer:", zap.NewNop())
+
+	review := func(user, uid, principalType string) subjectAccessReviewStatus {
+		spec := &subjectAccessReviewSpec{
+			User:               user,
+			UID:                uid,
+			Extra:              map[string][]string{ExtraPrincipalType: {principalType}},
+			ResourceAttributes: &resourceAttributes{Verb: "list", Resource: "pods"},
+		}
+		return h.authorize(httptest.NewRequest("POST", "/k8s/subjectaccessreview", nil), spec)
+	}
+
+	assert.True(t, review("authlayer:alice@example.com", "", "").Allowed)
+	assert.True(t, review("authlayer:serviceaccount:"+saID.String(), "", "").Allowed)
+
+	// Another authenticator's user claiming an authlayer principal by uid and extra
+	forged := review("oidc:mallory", alice.ID.String(), string(model.PrincipalTypeUser))
+	assert.False(t, forged.Allowed)
+	assert.Equal(t, "not an authlayer identity", forged.Reason)
+	assert.False(t, review("mallory", saID.String(), string(model.PrincipalTypeServiceAccount)).Allowed)
+
+	// The username decides, not the uid
+	assert.False(t, review("authlayer:bob@example.com", alice.ID.String(), string(model.PrincipalTypeUser)).Allowed)
+	assert.False(t, review("authlayer:serviceaccount:not-an-id", saID.String(), string(model.PrincipalTypeServiceAccount)).Allowed)
+}
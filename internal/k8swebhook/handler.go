@@ -0,0 +1,251 @@
+// Package k8swebhook implements the Kubernetes TokenReview and SubjectAccessReview webhooks,
+// so kubectl users sign in with authlayer credentials and org and team memberships become
+// Kubernetes RBAC groups.
+package k8swebhook
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/bernardoforcillo/authlayer/internal/config"
+	"github.com/bernardoforcillo/authlayer/internal/middleware"
+	"github.com/bernardoforcillo/authlayer/internal/model"
+	"github.com/bernardoforcillo/authlayer/internal/rbac"
+	"github.com/bernardoforcillo/authlayer/internal/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/status"
+)
+
+// ExtraPrincipalType is the user extra key carrying the authlayer principal type, next to
+// the principal ID as uid. Both are informational: SubjectAccessReviews identify the
+// principal by its prefixed username, as other authenticators may set any uid and extra.
+const ExtraPrincipalType = "authlayer.io/principal-type"
+
+// maxReviewBytes bounds the size of review request bodies.
+const maxReviewBytes = 1 << 20
+
+// Handler serves both webhooks. Usernames and groups carry a configurable prefix so they
+// cannot collide with identities from other authenticators, which must never issue
+// usernames with it:
+//
+//	users              <prefix><email>
+//	service accounts   <prefix>serviceaccount:<id>
+//	groups             <prefix>org:<slug>, <prefix>org:<slug>:role:<role>, <prefix>org:<slug>:team:<team>
+type Handler struct {
+	authenticator  *middleware.AuthInterceptor
+	checker        *rbac.Checker
+	userRepo       repository.UserRepository
+	orgRepo        repository.OrganizationRepository
+	orgMemberRepo  repository.OrganizationMemberRepository
+	teamMemberRepo repository.TeamMemberRepository
+	saRepo         repository.ServiceAccountRepository
+	rules          []config.K8sAuthzRule
+	token          string
+	prefix         string
+	logger         *zap.Logger
+}
+
+// NewHandler creates the webhook handler. The API server must present token as a bearer
+// credential, configured in the webhook kubeconfig.
+func NewHandler(
+	authenticator *middleware.AuthInterceptor,
+	checker *rbac.Checker,
+	userRepo repository.UserRepository,
+	orgRepo repository.OrganizationRepository,
+	orgMemberRepo repository.OrganizationMemberRepository,
+	teamMemberRepo repository.TeamMemberRepository,
+	saRepo repository.ServiceAccountRepository,
+	rules []config.K8sAuthzRule,
+	token, prefix string,
+	logger *zap.Logger,
+) *Handler {
+	return &Handler{
+		authenticator:  authenticator,
+		checker:        checker,
+		userRepo:       userRepo,
+		orgRepo:        orgRepo,
+		orgMemberRepo:  orgMemberRepo,
+		teamMemberRepo: teamMemberRepo,
+		saRepo:         saRepo,
+		rules:          rules,
+		token:          token,
+		prefix:         prefix,
+		logger:         logger,
+	}
+}
+
+// TokenReview handles authentication.k8s.io/v1 TokenReview requests. The token is an
+// access token addressed to authlayer or a service account key; API keys, tokens issued to
+// OAuth clients and delegated tokens are not accepted.
+func (h *Handler) TokenReview(w http.ResponseWriter, r *http.Request) {
+	var review tokenReview
+	if !h.decode(w, r, &review) {
+		return
+	}
+	review.typeMeta = typeMeta{APIVersion: authenticationAPIVersion, Kind: "TokenReview"}
+
+	user, err := h.authenticate(r.Context(), review.Spec.Token)
+	if err != nil {
+		review.Status = tokenReviewStatus{Error: err.Error()}
+	} else {
+		review.Status = tokenReviewStatus{Authenticated: true, User: *user}
+	}
+	writeJSON(w, review)
+}
+
+func (h *Handler) authenticate(ctx context.Context, token string) (*userInfo, error) {
+	if token == "" {
+		return nil, errors.New("missing token")
+	}
+	// Access tokens are JWTs; service account keys are base64url and never contain dots.
+	scheme := "ServiceKey "
+	if strings.Count(token, ".") == 2 {
+		scheme = "Bearer "
+	}
+	ctx, err := h.authenticator.Authenticate(ctx, scheme+token, h.authenticator.Audience())
+	if err != nil {
+		return nil, errors.New(status.Convert(err).Message())
+	}
+	// Tokens issued to OAuth clients or obtained by token exchange act for someone within
+	// limits Kubernetes would not see, so they cannot stand in for the principal.
+	if _, ok := middleware.OAuthClientFromContext(ctx); ok {
+		return nil, errors.New("tokens issued to OAuth clients are not accepted")
+	}
+	if _, ok := middleware.ActorFromContext(ctx); ok {
+		return nil, errors.New("delegated tokens are not accepted")
+	}
+
+	switch middleware.AuthTypeFromContext(ctx) {
+	case middleware.AuthTypeUser:
+		userID, err := middleware.UserIDFromContext(ctx)
+		if err != nil {
+			return nil, errors.New("no user in token")
+		}
+		groups, err := h.userGroups(ctx, userID)
+		if err != nil {
+			h.logger.Error("failed to resolve user groups", zap.Error(err), zap.String("user_id", userID.String()))
+			return nil, errors.New("failed to resolve groups")
+		}
+		return &userInfo{
+			Username: h.prefix + middleware.UserEmailFromContext(ctx),
+			UID:      userID.String(),
+			Groups:   groups,
+			Extra:    map[string][]string{ExtraPrincipalType: {string(model.PrincipalTypeUser)}},
+		}, nil
+
+	case middleware.AuthTypeServiceAccount:
+		saID, err := middleware.ServiceAccountIDFromContext(ctx)
+		if err != nil {
+			return nil, errors.New("no service account in token")
+		}
+		groups, err := h.serviceAccountGroups(ctx, saID)
+		if err != nil {
+			h.logger.Error("failed to resolve service account groups", zap.Error(err), zap.String("service_account_id", saID.String()))
+			return nil, errors.New("failed to resolve groups")
+		}
+		return &userInfo{
+			Username: h.prefix + "serviceaccount:" + saID.String(),
+			UID:      saID.String(),
+			Groups:   groups,
+			Extra:    map[string][]string{ExtraPrincipalType: {string(model.PrincipalTypeServiceAccount)}},
+		}, nil
+	}
+	return nil, errors.New("unsupported credential")
+}
+
+func (h *Handler) userGroups(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	memberships, err := h.orgMemberRepo.ListAllByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	var groups []string
+	for _, m := range memberships {
+		if m.Organization.ID == uuid.Nil {
+			continue // org deleted
+		}
+		org := h.prefix + "org:" + m.Organization.Slug
+		groups = append(groups, org)
+		if m.Role.ID != uuid.Nil {
+			groups = append(groups, org+":role:"+m.Role.Name)
+		}
+		teams, err := h.teamMemberRepo.ListByUserIDAndOrgID(ctx, userID, m.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range teams {
+			groups = append(groups, org+":team:"+t.Team.Name)
+		}
+	}
+	sort.Strings(groups)
+	return groups, nil
+}
+
+func (h *Handler) serviceAccountGroups(ctx context.Context, saID uuid.UUID) ([]string, error) {
+	sa, err := h.saRepo.GetByID(ctx, saID)
+	if err != nil {
+		return nil, err
+	}
+	slugs := make(map[uuid.UUID]string)
+	slug := func(orgID uuid.UUID) (string, error) {
+		if s, ok := slugs[orgID]; ok {
+			return s, nil
+		}
+		org, err := h.orgRepo.GetByID(ctx, orgID)
+		if err != nil {
+			return "", err
+		}
+		slugs[orgID] = org.Slug
+		return org.Slug, nil
+	}
+
+	own, err := slug(sa.OrgID)
+	if err != nil {
+		return nil, err
+	}
+	groups := []string{h.prefix + "org:" + own}
+	for _, sar := range sa.Roles {
+		if sar.Role.ID == uuid.Nil {
+			continue // role deleted
+		}
+		s, err := slug(sar.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, h.prefix+"org:"+s+":role:"+sar.Role.Name)
+	}
+	sort.Strings(groups)
+	return groups, nil
+}
+
+// decode checks the caller's token and reads the review body, writing the error response
+// itself when it returns false.
+func (h *Handler) decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return false
+	}
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if h.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="authlayer"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return false
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxReviewBytes)).Decode(v); err != nil {
+		http.Error(w, "invalid review: "+err.Error(), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
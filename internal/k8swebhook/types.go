@@ -0,0 +1,76 @@
+package k8swebhook
+
+// The subset of the authentication.k8s.io/v1 and authorization.k8s.io/v1 review objects the
+// webhooks read and write. They are declared here rather than imported from k8s.io/api to
+// keep the Kubernetes dependency tree out of the server.
+
+const (
+	authenticationAPIVersion = "authentication.k8s.io/v1"
+	authorizationAPIVersion  = "authorization.k8s.io/v1"
+)
+
+type typeMeta struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+}
+
+type tokenReview struct {
+	typeMeta
+	Spec   tokenReviewSpec   `json:"spec"`
+	Status tokenReviewStatus `json:"status"`
+}
+
+type tokenReviewSpec struct {
+	Token     string   `json:"token"`
+	Audiences []string `json:"audiences,omitempty"`
+}
+
+type tokenReviewStatus struct {
+	Authenticated bool     `json:"authenticated"`
+	User          userInfo `json:"user,omitempty"`
+	Error         string   `json:"error,omitempty"`
+}
+
+type userInfo struct {
+	Username string              `json:"username,omitempty"`
+	UID      string              `json:"uid,omitempty"`
+	Groups   []string            `json:"groups,omitempty"`
+	Extra    map[string][]string `json:"extra,omitempty"`
+}
+
+type subjectAccessReview struct {
+	typeMeta
+	Spec   subjectAccessReviewSpec   `json:"spec"`
+	Status subjectAccessReviewStatus `json:"status"`
+}
+
+type subjectAccessReviewSpec struct {
+	ResourceAttributes    *resourceAttributes    `json:"resourceAttributes,omitempty"`
+	NonResourceAttributes *nonResourceAttributes `json:"nonResourceAttributes,omitempty"`
+	User                  string                 `json:"user,omitempty"`
+	Groups                []string               `json:"groups,omitempty"`
+	Extra                 map[string][]string    `json:"extra,omitempty"`
+	UID                   string                 `json:"uid,omitempty"`
+}
+
+type resourceAttributes struct {
+	Namespace   string `json:"namespace,omitempty"`
+	Verb        string `json:"verb,omitempty"`
+	Group       string `json:"group,omitempty"`
+	Version     string `json:"version,omitempty"`
+	Resource    string `json:"resource,omitempty"`
+	Subresource string `json:"subresource,omitempty"`
+	Name        string `json:"name,omitempty"`
+}
+
+type nonResourceAttributes struct {
+	Path string `json:"path,omitempty"`
+	Verb string `json:"verb,omitempty"`
+}
+
+type subjectAccessReviewStatus struct {
+	Allowed         bool   `json:"allowed"`
+	Denied          bool   `json:"denied,omitempty"`
+	Reason          string `json:"reason,omitempty"`
+	EvaluationError string `json:"evaluationError,omitempty"`
+}
@@ -0,0 +1,216 @@
+// Package labels implements label validation and label selectors.
+//
+// Selectors use the Kubernetes syntax: a comma-separated list of requirements
+// that must all hold, for example
+//
+//	env=prod,tier!=frontend,region in (eu-west,eu-central),!deprecated,team
+package labels
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bernardoforcillo/authlayer/internal/model"
+)
+
+var (
+	ErrInvalidLabel    = errors.New("labels: invalid label")
+	ErrInvalidSelector = errors.New("labels: invalid selector")
+)
+
+const maxLabels = 64
+
+var (
+	keyPattern   = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9._/-]{0,61}[a-zA-Z0-9])?$`)
+	valuePattern = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9._-]{0,61}[a-zA-Z0-9])?)?$`)
+	setPattern   = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\((.*)\)$`)
+)
+
+// Validate checks label keys, values and count.
+func Validate(l model.Labels) error {
+	if len(l) > maxLabels {
+		return fmt.Errorf("%w: at most %d labels are allowed", ErrInvalidLabel, maxLabels)
+	}
+	for k, v := range l {
+		if !keyPattern.MatchString(k) {
+			return fmt.Errorf("%w: invalid key %q", ErrInvalidLabel, k)
+		}
+		if !valuePattern.MatchString(v) {
+			return fmt.Errorf("%w: invalid value %q for key %q", ErrInvalidLabel, v, k)
+		}
+	}
+	return nil
+}
+
+// Operator is the comparison a requirement applies to a label.
+type Operator string
+
+const (
+	Equals       Operator = "="
+	NotEquals    Operator = "!="
+	In           Operator = "in"
+	NotIn        Operator = "notin"
+	Exists       Operator = "exists"
+	DoesNotExist Operator = "!"
+)
+
+// Requirement is a single condition on one label key.
+type Requirement struct {
+	Key      string
+	Operator Operator
+	Values   []string
+}
+
+// Matches reports whether the labels satisfy the requirement. As in Kubernetes,
+// != and notin also match when the key is absent.
+func (r Requirement) Matches(l model.Labels) bool {
+	v, ok := l[r.Key]
+	switch r.Operator {
+	case Equals:
+		return ok && v == r.Values[0]
+	case NotEquals:
+		return !ok || v != r.Values[0]
+	case In:
+		return ok && contains(r.Values, v)
+	case NotIn:
+		return !ok || !contains(r.Values, v)
+	case Exists:
+		return ok
+	case DoesNotExist:
+		return !ok
+	default:
+		return false
+	}
+}
+
+func (r Requirement) String() string {
+	switch r.Operator {
+	case Equals, NotEquals:
+		return r.Key + string(r.Operator) + r.Values[0]
+	case In, NotIn:
+		return r.Key + " " + string(r.Operator) + " (" + strings.Join(r.Values, ",") + ")"
+	case DoesNotExist:
+		return "!" + r.Key
+	default:
+		return r.Key
+	}
+}
+
+// Selector is a conjunction of requirements. The empty selector matches everything.
+type Selector []Requirement
+
+// Matches reports whether the labels satisfy every requirement.
+func (s Selector) Matches(l model.Labels) bool {
+	for _, r := range s {
+		if !r.Matches(l) {
+			return false
+		}
+	}
+	return true
+}
+
+// Empty reports whether the selector has no requirements.
+func (s Selector) Empty() bool {
+	return len(s) == 0
+}
+
+// String returns the selector in canonical form.
+func (s Selector) String() string {
+	parts := make([]string, len(s))
+	for i, r := range s {
+		parts[i] = r.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+// Parse parses a selector expression. Requirements are returned sorted by key
+// so that equivalent selectors have the same canonical string.
+func Parse(expr string) (Selector, error) {
+	var sel Selector
+	for _, term := range splitTerms(expr) {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		req, err := parseTerm(term)
+		if err != nil {
+			return nil, err
+		}
+		sel = append(sel, req)
+	}
+	sort.SliceStable(sel, func(i, j int) bool { return sel[i].Key < sel[j].Key })
+	return sel, nil
+}
+
+func parseTerm(term string) (Requirement, error) {
+	var req Requirement
+
+	switch {
+	case strings.HasPrefix(term, "!") && !strings.Contains(term, "="):
+		req = Requirement{Key: strings.TrimSpace(term[1:]), Operator: DoesNotExist}
+
+	case setPattern.MatchString(term):
+		m := setPattern.FindStringSubmatch(term)
+		req = Requirement{Key: m[1], Operator: Operator(m[2])}
+		for _, v := range strings.Split(m[3], ",") {
+			v = strings.TrimSpace(v)
+			if !valuePattern.MatchString(v) {
+				return Requirement{}, fmt.Errorf("%w: invalid value %q in %q", ErrInvalidSelector, v, term)
+			}
+			req.Values = append(req.Values, v)
+		}
+		sort.Strings(req.Values)
+
+	case strings.Contains(term, "!="):
+		k, v, _ := strings.Cut(term, "!=")
+		req = Requirement{Key: strings.TrimSpace(k), Operator: NotEquals, Values: []string{strings.TrimSpace(v)}}
+
+	case strings.Contains(term, "="):
+		k, v, _ := strings.Cut(term, "=")
+		v = strings.TrimPrefix(v, "=")
+		req = Requirement{Key: strings.TrimSpace(k), Operator: Equals, Values: []string{strings.TrimSpace(v)}}
+
+	default:
+		req = Requirement{Key: term, Operator: Exists}
+	}
+
+	if !keyPattern.MatchString(req.Key) {
+		return Requirement{}, fmt.Errorf("%w: invalid key in %q", ErrInvalidSelector, term)
+	}
+	if (req.Operator == Equals || req.Operator == NotEquals) && !valuePattern.MatchString(req.Values[0]) {
+		return Requirement{}, fmt.Errorf("%w: invalid value in %q", ErrInvalidSelector, term)
+	}
+	return req, nil
+}
+
+// splitTerms splits on commas that are not inside a parenthesized value set.
+func splitTerms(expr string) []string {
+	var terms []string
+	depth, start := 0, 0
+	for i, c := range expr {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				terms = append(terms, expr[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(terms, expr[start:])
+}
+
+func contains(values []string, v string) bool {
+	for _, candidate := range values {
+		if candidate == v {
+			return true
+		}
+	}
+	return false
+}
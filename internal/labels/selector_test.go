@@ -0,0 +1,83 @@
+package labels
+
+import (
+	"testing"
+
+	"github.com/bernardoforcillo/authlayer/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCanonicalizes(t *testing.T) {
+	sel, err := Parse(" tier!=frontend , env=prod,region in (eu-west, eu-central),!deprecated,team ")
+	require.NoError(t, err)
+	assert.Equal(t, "!deprecated,env=prod,region in (eu-central,eu-west),team,tier!=frontend", sel.String())
+
+	again, err := Parse(sel.String())
+	require.NoError(t, err)
+	assert.Equal(t, sel, again)
+}
+
+func TestParseEmpty(t *testing.T) {
+	sel, err := Parse("")
+	require.NoError(t, err)
+	assert.True(t, sel.Empty())
+	assert.True(t, sel.Matches(nil))
+}
+
+func TestParseRejectsInvalid(t *testing.T) {
+	for _, expr := range []string{
+		"-env=prod",
+		"env=-prod",
+		"env in (prod,-dev)",
+		"=prod",
+		"env==prod!",
+	} {
+		_, err := Parse(expr)
+		assert.ErrorIs(t, err, ErrInvalidSelector, expr)
+	}
+}
+
+func TestSelectorMatches(t *testing.T) {
+	l := model.Labels{"env": "prod", "tier": "backend", "region": "eu-west"}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"env=prod", true},
+		{"env==prod", true},
+		{"env=dev", false},
+		{"env!=dev", true},
+		{"missing!=dev", true},
+		{"region in (eu-west,eu-central)", true},
+		{"region in (us-east)", false},
+		{"missing in (x)", false},
+		{"region notin (us-east)", true},
+		{"missing notin (x)", true},
+		{"tier", true},
+		{"missing", false},
+		{"!missing", true},
+		{"!tier", false},
+		{"env=prod,tier=backend", true},
+		{"env=prod,tier=frontend", false},
+	}
+	for _, tt := range tests {
+		sel, err := Parse(tt.expr)
+		require.NoError(t, err, tt.expr)
+		assert.Equal(t, tt.want, sel.Matches(l), tt.expr)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate(model.Labels{"app.kubernetes.io/name": "authlayer", "empty": ""}))
+	assert.ErrorIs(t, Validate(model.Labels{"-bad": "x"}), ErrInvalidLabel)
+	assert.ErrorIs(t, Validate(model.Labels{"key": "bad value"}), ErrInvalidLabel)
+
+	tooMany := make(model.Labels, maxLabels+1)
+	for i := range maxLabels + 1 {
+		tooMany[string(rune('a'+i%26))+string(rune('a'+i/26))] = "v"
+	}
+	assert.ErrorIs(t, Validate(tooMany), ErrInvalidLabel)
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceAccountAssertionReplay(t *testing.T) {
+	interceptor, key, signer := newSigningInterceptor(t)
+	const audience = "https://auth.example.com"
+	ctx := context.Background()
+
+	assertion := func(jti string) string {
+		now := time.Now()
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+			Issuer:    key.ServiceAccountID.String(),
+			Subject:   key.ServiceAccountID.String(),
+			Audience:  jwt.ClaimStrings{audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+			ID:        jti,
+		})
+		token.Header["kid"] = signer.keyID
+		signed, err := token.SignedString(signer.secret)
+		require.NoError(t, err)
+		return signed
+	}
+
+	first := assertion(rand.Text())
+	verified, err := interceptor.VerifyServiceAccountAssertion(ctx, first, audience)
+	require.NoError(t, err)
+	assert.Equal(t, key.ID, verified.ID)
+
+	_, err = interceptor.VerifyServiceAccountAssertion(ctx, first, audience)
+	assert.ErrorContains(t, err, "already used")
+
+	_, err = interceptor.VerifyServiceAccountAssertion(ctx, assertion(""), audience)
+	assert.ErrorContains(t, err, "jti is required")
+
+	_, err = interceptor.VerifyServiceAccountAssertion(ctx, assertion(rand.Text()), audience)
+	assert.NoError(t, err)
+}
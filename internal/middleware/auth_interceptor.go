@@ -1,11 +1,21 @@
 package middleware
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
 	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/bernardoforcillo/authlayer/internal/auth"
+	"github.com/bernardoforcillo/authlayer/internal/model"
 	"github.com/bernardoforcillo/authlayer/internal/repository"
 
 	"github.com/google/uuid"
@@ -15,12 +25,20 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-// AuthInterceptor validates JWT Bearer tokens, API keys, and service account keys.
+// AuthInterceptor validates JWT Bearer tokens, API keys, and service account keys,
+// assertions and signed requests.
 type AuthInterceptor struct {
 	jwtManager *auth.JWTManager
 	apiKeyRepo repository.APIKeyRepository
 	saKeyRepo  repository.ServiceAccountKeyRepository
 	publicMethods map[string]bool
+
+	// Signed requests, accepted only with a secret box to open the signing secrets
+	secretBox *auth.SecretBox
+	nonces    NonceStore
+	clockSkew time.Duration
+	// Keys of signed gateway requests, by handoff, while the gateway serves them
+	gatewayRequests sync.Map
 }
 
 // NewAuthInterceptor creates a new authentication interceptor.
@@ -28,6 +46,9 @@ func NewAuthInterceptor(
 	jwtManager *auth.JWTManager,
 	apiKeyRepo repository.APIKeyRepository,
 	saKeyRepo repository.ServiceAccountKeyRepository,
+	secretBox *auth.SecretBox,
+	nonces NonceStore,
+	clockSkew time.Duration,
 	publicMethods []string,
 ) *AuthInterceptor {
 	pm := make(map[string]bool)
@@ -39,6 +60,9 @@ func NewAuthInterceptor(
 		apiKeyRepo:    apiKeyRepo,
 		saKeyRepo:     saKeyRepo,
 		publicMethods: pm,
+		secretBox:     secretBox,
+		nonces:        nonces,
+		clockSkew:     clockSkew,
 	}
 }
 
@@ -54,7 +78,7 @@ func (i *AuthInterceptor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 			return handler(ctx, req)
 		}
 
-		newCtx, err := i.authenticate(ctx)
+		newCtx, err := i.authenticate(ctx, info.FullMethod)
 		if err != nil {
 			return nil, err
 		}
@@ -75,16 +99,27 @@ func (i *AuthInterceptor) StreamServerInterceptor() grpc.StreamServerInterceptor
 			return handler(srv, ss)
 		}
 
-		_, err := i.authenticate(ss.Context())
+		// Stream messages are not signed, so a signed stream request has an empty body
+		newCtx, err := i.authenticate(ss.Context(), info.FullMethod)
 		if err != nil {
 			return err
 		}
 
-		return handler(srv, ss)
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: newCtx})
 	}
 }
 
-func (i *AuthInterceptor) authenticate(ctx context.Context) (context.Context, error) {
+// authenticatedStream carries the authenticated identity to stream handlers.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (i *AuthInterceptor) authenticate(ctx context.Context, method string) (context.Context, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return nil, status.Errorf(codes.Unauthenticated, "missing metadata")
@@ -95,8 +130,35 @@ func (i *AuthInterceptor) authenticate(ctx context.Context) (context.Context, er
 		return nil, status.Errorf(codes.Unauthenticated, "missing authorization header")
 	}
 
-	authHeader := values[0]
+	var newCtx context.Context
+	var err error
+	if credential, ok := strings.CutPrefix(values[0], "ServiceSignature "); ok {
+		newCtx, err = i.authenticateSignedRequest(ctx, credential, method)
+	} else if handoff, ok := strings.CutPrefix(values[0], "GatewaySignature "); ok {
+		newCtx, err = i.authenticateGatewayRequest(ctx, handoff)
+	} else {
+		newCtx, err = i.Authenticate(ctx, values[0], i.Audience())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newCtx, nil
+}
+
+// Audience is the audience of authlayer's own API: its issuer.
+func (i *AuthInterceptor) Audience() string {
+	return i.jwtManager.Issuer()
+}
 
+// Authenticate validates an authorization header value (Bearer, ApiKey, ServiceKey or
+// ServiceAssertion) and returns ctx carrying the authenticated principal. Errors are gRPC
+// status errors. It lets other front doors, such as proxy authorization endpoints, share
+// the interceptor's logic.
+//
+// Access tokens naming an audience are accepted only when audience is among it, so tokens
+// addressed to other services, such as those issued to OAuth clients or obtained by token
+// exchange, cannot be replayed at a front door they were not meant for.
+func (i *AuthInterceptor) Authenticate(ctx context.Context, authHeader, audience string) (context.Context, error) {
 	// JWT Bearer token
 	if strings.HasPrefix(authHeader, "Bearer ") {
 		token := strings.TrimPrefix(authHeader, "Bearer ")
@@ -104,11 +166,34 @@ func (i *AuthInterceptor) authenticate(ctx context.Context) (context.Context, er
 		if err != nil {
 			return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
 		}
+		if len(claims.Audience) > 0 && !slices.Contains(claims.Audience, audience) {
+			return nil, status.Errorf(codes.Unauthenticated, "token audience does not include %s", audience)
+		}
+		// Service account tokens are short-lived and trusted as issued, without a key lookup
+		if claims.ServiceAccountID != "" {
+			saID, err := uuid.Parse(claims.ServiceAccountID)
+			if err != nil {
+				return nil, status.Errorf(codes.Unauthenticated, "invalid service account ID in token")
+			}
+			ctx = SetServiceAccountInContext(ctx, saID)
+			ctx = SetTokenAudienceInContext(ctx, claims.Audience)
+			return context.WithValue(ctx, apiScopesKey, claims.Scopes()), nil
+		}
 		userID, err := uuid.Parse(claims.UserID)
 		if err != nil {
 			return nil, status.Errorf(codes.Unauthenticated, "invalid user ID in token")
 		}
-		return SetUserInContext(ctx, userID, claims.Email), nil
+		ctx = SetUserInContext(ctx, userID, claims.Email)
+		ctx = SetAuthenticationInContext(ctx, claims.Authentication())
+		ctx = SetTokenAudienceInContext(ctx, claims.Audience)
+		if claims.ClientID != "" {
+			ctx = SetOAuthClientInContext(ctx, OAuthClient{ID: claims.ClientID}, claims.Scopes())
+		}
+		if claims.Actor != nil {
+			ctx = SetActorInContext(ctx, *claims.Actor)
+			ctx = context.WithValue(ctx, apiScopesKey, claims.Scopes())
+		}
+		return ctx, nil
 	}
 
 	// API Key (user)
@@ -144,19 +229,205 @@ func (i *AuthInterceptor) authenticate(ctx context.Context) (context.Context, er
 		if err != nil {
 			return nil, status.Errorf(codes.Unauthenticated, "invalid service account key")
 		}
+		return i.serviceAccountKeyContext(ctx, saKey)
+	}
+
+	// Service account assertion, signed with one of its keys
+	if strings.HasPrefix(authHeader, "ServiceAssertion ") {
+		assertion := strings.TrimPrefix(authHeader, "ServiceAssertion ")
+
+		saKey, err := i.VerifyServiceAccountAssertion(ctx, assertion, i.jwtManager.Issuer())
+		if status.Code(err) == codes.Unavailable {
+			return nil, err
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, "invalid service account assertion: %v", err)
+		}
+		return i.serviceAccountKeyContext(ctx, saKey)
+	}
+
+	// Signed requests are verified against the request itself, which only the API sees
+	if strings.HasPrefix(authHeader, "ServiceSignature ") {
+		return nil, status.Errorf(codes.Unauthenticated, "signed requests are only accepted by the gRPC API and its gateway")
+	}
+
+	return nil, status.Errorf(codes.Unauthenticated, "unsupported authorization scheme")
+}
+
+// authenticateSignedRequest verifies a request signed with a service account key, which
+// unlike a ServiceKey header never exposes the key. Over gRPC, the signature covers the
+// method POST, the full gRPC method as path and, as body, the request message exactly as
+// it was encoded on the wire. Streams are signed with an empty body.
+func (i *AuthInterceptor) authenticateSignedRequest(ctx context.Context, credential, method string) (context.Context, error) {
+	body, ok := requestBodyFromContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "signed requests need the server's RequestBodyOptions")
+	}
+	saKey, err := i.verifySignedRequest(ctx, credential, "POST", method, body)
+	if err != nil {
+		return nil, err
+	}
+	return i.serviceAccountKeyContext(ctx, saKey)
+}
+
+// verifySignedRequest checks the ServiceSignature credential against the request and
+// returns the key that signed it.
+func (i *AuthInterceptor) verifySignedRequest(ctx context.Context, credential, method, path string, body []byte) (*model.ServiceAccountKey, error) {
+	if i.secretBox == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "request signing is not enabled")
+	}
+	sig, err := auth.ParseRequestSignature(credential)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "%v", err)
+	}
+
+	timestamp, err := strconv.ParseInt(sig.Timestamp, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid request timestamp")
+	}
+	if skew := timeNow().Sub(time.Unix(timestamp, 0)); skew > i.clockSkew || skew < -i.clockSkew {
+		return nil, status.Errorf(codes.Unauthenticated, "request timestamp is outside the allowed clock skew")
+	}
+
+	keyID, err := uuid.Parse(sig.KeyID)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid service account key")
+	}
+	saKey, err := i.saKeyRepo.GetByID(ctx, keyID)
+	if err != nil || saKey.SigningSecret == "" {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid service account key")
+	}
+	secret, err := i.secretBox.Open(saKey.SigningSecret)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to open signing secret")
+	}
+
+	if !auth.VerifyRequestSignature(secret, auth.StringToSign(method, path, sig.Timestamp, sig.Nonce, body), sig.Signature) {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid request signature")
+	}
+
+	// Only verified requests claim their nonce, so forged ones cannot burn nonces. A request
+	// can be replayed until its timestamp falls out of the skew, at most twice the skew later.
+	fresh, err := i.nonces.Claim(ctx, sig.KeyID+":"+sig.Nonce, 2*i.clockSkew)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to check request nonce")
+	}
+	if !fresh {
+		return nil, status.Errorf(codes.Unauthenticated, "request nonce was already used")
+	}
+	return saKey, nil
+}
 
-		if saKey.ExpiresAt != nil && saKey.ExpiresAt.Before(timeNow()) {
-			return nil, status.Errorf(codes.Unauthenticated, "service account key expired")
+// maxSignedBodySize bounds the body of a signed gateway request, as gRPC bounds messages.
+const maxSignedBodySize = 4 << 20
+
+// GatewayHandler verifies signed requests to the HTTP gateway before next translates them
+// to gRPC, as the interceptor only sees the gateway's re-encoding of the body. Over HTTP,
+// the signature covers the HTTP method, the path and query as sent and the raw body. The
+// verified key reaches the interceptor through a random one-time handoff, which only
+// exists in this process while the request is served.
+func (i *AuthInterceptor) GatewayHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		credential, ok := strings.CutPrefix(r.Header.Get("Authorization"), "ServiceSignature ")
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
 		}
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSignedBodySize))
+		if err != nil {
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		saKey, err := i.verifySignedRequest(r.Context(), credential, r.Method, r.URL.RequestURI(), body)
+		if err != nil {
+			switch status.Code(err) {
+			case codes.Unauthenticated:
+				http.Error(w, status.Convert(err).Message(), http.StatusUnauthorized)
+			case codes.Unavailable:
+				http.Error(w, status.Convert(err).Message(), http.StatusServiceUnavailable)
+			default:
+				http.Error(w, "failed to verify request signature", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		handoff := rand.Text()
+		i.gatewayRequests.Store(handoff, saKey)
+		defer i.gatewayRequests.Delete(handoff)
 
-		if saKey.ServiceAccount.Status != "active" {
-			return nil, status.Errorf(codes.PermissionDenied, "service account is disabled")
+		r = r.Clone(r.Context())
+		r.Header.Set("Authorization", "GatewaySignature "+handoff)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authenticateGatewayRequest claims the handoff of a signed request GatewayHandler verified.
+func (i *AuthInterceptor) authenticateGatewayRequest(ctx context.Context, handoff string) (context.Context, error) {
+	saKey, ok := i.gatewayRequests.LoadAndDelete(handoff)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid request signature")
+	}
+	return i.serviceAccountKeyContext(ctx, saKey.(*model.ServiceAccountKey))
+}
+
+// VerifyServiceAccountAssertion checks a JWT signed with the service account key named by
+// its kid header and addressed to one of audiences, and returns the key. Public keys verify
+// assertions signed with their private key. Secret keys verify HS256 assertions keyed by the
+// secret, which only keys sealed for request signing keep; the stored hash is never a key,
+// as anyone reading the database could sign with it.
+//
+// Assertions are single use: each claims its jti until it expires. The error is an
+// Unavailable status when the nonce store cannot be reached.
+func (i *AuthInterceptor) VerifyServiceAccountAssertion(ctx context.Context, assertion string, audiences ...string) (*model.ServiceAccountKey, error) {
+	var saKey *model.ServiceAccountKey
+	claims, err := auth.VerifyServiceAccountAssertion(assertion, func(kid string) (auth.AssertionKey, error) {
+		keyID, err := uuid.Parse(kid)
+		if err != nil {
+			return auth.AssertionKey{}, err
+		}
+		if saKey, err = i.saKeyRepo.GetByID(ctx, keyID); err != nil {
+			return auth.AssertionKey{}, err
+		}
+		key := auth.AssertionKey{
+			ServiceAccountID: saKey.ServiceAccountID.String(),
+			PublicKey:        saKey.PublicKey,
 		}
+		if saKey.PublicKey == "" && saKey.SigningSecret != "" && i.secretBox != nil {
+			if key.Secret, err = i.secretBox.Open(saKey.SigningSecret); err != nil {
+				return auth.AssertionKey{}, err
+			}
+		}
+		return key, nil
+	}, audiences...)
+	if err != nil {
+		return nil, err
+	}
 
-		go func() { _ = i.saKeyRepo.UpdateLastUsed(context.Background(), saKey.ID) }()
+	// Only verified assertions claim their jti, so forged ones cannot burn them
+	ttl := max(claims.ExpiresAt.Sub(timeNow()), time.Second)
+	fresh, err := i.nonces.Claim(ctx, "assertion:"+saKey.ID.String()+":"+claims.ID, ttl)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to check assertion jti")
+	}
+	if !fresh {
+		return nil, errors.New("assertion was already used")
+	}
+	return saKey, nil
+}
 
-		return SetServiceAccountInContext(ctx, saKey.ServiceAccountID), nil
+// serviceAccountKeyContext authenticates the service account of a key it presented or
+// signed with.
+func (i *AuthInterceptor) serviceAccountKeyContext(ctx context.Context, saKey *model.ServiceAccountKey) (context.Context, error) {
+	if saKey.ExpiresAt != nil && saKey.ExpiresAt.Before(timeNow()) {
+		return nil, status.Errorf(codes.Unauthenticated, "service account key expired")
 	}
 
-	return nil, status.Errorf(codes.Unauthenticated, "unsupported authorization scheme")
+	if saKey.ServiceAccount.Status != "active" {
+		return nil, status.Errorf(codes.PermissionDenied, "service account is disabled")
+	}
+
+	go func() { _ = i.saKeyRepo.UpdateLastUsed(context.Background(), saKey.ID) }()
+
+	return SetServiceAccountInContext(ctx, saKey.ServiceAccountID), nil
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 
+	"github.com/bernardoforcillo/authlayer/internal/auth"
+
 	"github.com/google/uuid"
 )
 
@@ -15,6 +17,10 @@ const (
 	apiScopesKey      contextKey = "api_scopes"
 	serviceAccountKey contextKey = "service_account_id"
 	authTypeKey       contextKey = "auth_type"
+	oauthClientKey    contextKey = "oauth_client"
+	authenticationKey contextKey = "authentication"
+	tokenAudienceKey  contextKey = "token_audience"
+	actorKey          contextKey = "actor"
 )
 
 // AuthType indicates how the request was authenticated.
@@ -98,3 +104,67 @@ func SetServiceAccountInContext(ctx context.Context, saID uuid.UUID) context.Con
 	ctx = context.WithValue(ctx, authTypeKey, AuthTypeServiceAccount)
 	return ctx
 }
+
+// OAuthClient identifies the OAuth client a user token was issued to.
+type OAuthClient struct {
+	ID string
+}
+
+// SetOAuthClientInContext marks a user request as made by an OAuth client with the
+// given scopes. It must be called on a context already carrying the user.
+func SetOAuthClientInContext(ctx context.Context, client OAuthClient, scopes []string) context.Context {
+	ctx = context.WithValue(ctx, oauthClientKey, client)
+	ctx = context.WithValue(ctx, apiScopesKey, scopes)
+	return ctx
+}
+
+// OAuthClientFromContext returns the OAuth client the request's token was issued to, if
+// any.
+func OAuthClientFromContext(ctx context.Context) (OAuthClient, bool) {
+	client, ok := ctx.Value(oauthClientKey).(OAuthClient)
+	return client, ok
+}
+
+// SetAuthenticationInContext records when and how the request's user signed in.
+func SetAuthenticationInContext(ctx context.Context, authn auth.Authentication) context.Context {
+	return context.WithValue(ctx, authenticationKey, authn)
+}
+
+// AuthenticationFromContext returns when and how the request's user signed in; it is zero
+// for requests not authenticated with a user token.
+func AuthenticationFromContext(ctx context.Context) auth.Authentication {
+	authn, _ := ctx.Value(authenticationKey).(auth.Authentication)
+	return authn
+}
+
+// SetTokenAudienceInContext records the aud claim of the request's access token.
+func SetTokenAudienceInContext(ctx context.Context, audience []string) context.Context {
+	return context.WithValue(ctx, tokenAudienceKey, audience)
+}
+
+// TokenAudienceFromContext returns the aud claim of the request's access token; it is empty
+// for tokens addressed to no particular audience and for other credentials.
+func TokenAudienceFromContext(ctx context.Context) []string {
+	audience, _ := ctx.Value(tokenAudienceKey).([]string)
+	return audience
+}
+
+// SetActorInContext records who acts on the user's behalf, for tokens obtained by token
+// exchange.
+func SetActorInContext(ctx context.Context, actor auth.Actor) context.Context {
+	return context.WithValue(ctx, actorKey, actor)
+}
+
+// ActorFromContext returns who acts on the user's behalf, if the request's token was
+// obtained by token exchange.
+func ActorFromContext(ctx context.Context) (auth.Actor, bool) {
+	actor, ok := ctx.Value(actorKey).(auth.Actor)
+	return actor, ok
+}
+
+// APIScopesFromContext returns the scopes of the API key, OAuth token or service account
+// token the request was authenticated with.
+func APIScopesFromContext(ctx context.Context) []string {
+	scopes, _ := ctx.Value(apiScopesKey).([]string)
+	return scopes
+}
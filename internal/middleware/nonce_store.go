@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const nonceKeyPrefix = "authlayer:nonce:"
+
+// NonceStore remembers the nonces of signed requests and the jtis of service account
+// assertions for as long as they could be replayed.
+type NonceStore interface {
+	// Claim records the nonce for ttl and reports whether it was not already recorded.
+	Claim(ctx context.Context, nonce string, ttl time.Duration) (bool, error)
+}
+
+// MemoryNonceStore is a per-instance NonceStore. Behind a load balancer, a request replayed
+// to another instance goes undetected; use RedisNonceStore there.
+type MemoryNonceStore struct {
+	mu        sync.Mutex
+	nonces    map[string]time.Time
+	lastSweep time.Time
+}
+
+// NewMemoryNonceStore creates an empty in-memory nonce store.
+func NewMemoryNonceStore() *MemoryNonceStore {
+	return &MemoryNonceStore{nonces: make(map[string]time.Time), lastSweep: timeNow()}
+}
+
+func (s *MemoryNonceStore) Claim(ctx context.Context, nonce string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := timeNow()
+	if now.Sub(s.lastSweep) > time.Minute {
+		for n, expiresAt := range s.nonces {
+			if now.After(expiresAt) {
+				delete(s.nonces, n)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	if expiresAt, ok := s.nonces[nonce]; ok && now.Before(expiresAt) {
+		return false, nil
+	}
+	s.nonces[nonce] = now.Add(ttl)
+	return true, nil
+}
+
+// RedisNonceStore is a NonceStore shared by every instance through Redis.
+type RedisNonceStore struct {
+	client *redis.Client
+}
+
+// NewRedisNonceStore creates a nonce store backed by the given Redis client.
+func NewRedisNonceStore(client *redis.Client) *RedisNonceStore {
+	return &RedisNonceStore{client: client}
+}
+
+func (s *RedisNonceStore) Claim(ctx context.Context, nonce string, ttl time.Duration) (bool, error) {
+	return s.client.SetNX(ctx, nonceKeyPrefix+nonce, 1, ttl).Result()
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"slices"
 
 	"github.com/bernardoforcillo/authlayer/internal/rbac"
 
@@ -46,41 +47,90 @@ func (i *RBACInterceptor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 			return handler(ctx, req)
 		}
 
-		authType := AuthTypeFromContext(ctx)
-
-		switch authType {
-		case AuthTypeUser, AuthTypeAPIKey:
-			userID, err := UserIDFromContext(ctx)
-			if err != nil {
-				return nil, status.Errorf(codes.Unauthenticated, "no user in context")
-			}
-
-			allowed, _, err := i.checker.CheckPermission(ctx, userID, requirement.Permission, nil)
-			if err != nil {
-				return nil, status.Errorf(codes.Internal, "permission check failed: %v", err)
-			}
-			if !allowed {
-				return nil, status.Errorf(codes.PermissionDenied, "permission %q denied", requirement.Permission)
-			}
-
-		case AuthTypeServiceAccount:
-			saID, err := ServiceAccountIDFromContext(ctx)
-			if err != nil {
-				return nil, status.Errorf(codes.Unauthenticated, "no service account in context")
-			}
-
-			allowed, err := i.checker.CheckServiceAccountPermission(ctx, saID, requirement.Permission, nil)
-			if err != nil {
-				return nil, status.Errorf(codes.Internal, "permission check failed: %v", err)
-			}
-			if !allowed {
-				return nil, status.Errorf(codes.PermissionDenied, "permission %q denied", requirement.Permission)
-			}
-
-		default:
-			return nil, status.Errorf(codes.Unauthenticated, "unknown auth type")
+		if err := i.authorize(ctx, requirement); err != nil {
+			return nil, err
 		}
 
 		return handler(ctx, req)
 	}
 }
+
+// StreamServerInterceptor returns a gRPC stream interceptor for authorization.
+func (i *RBACInterceptor) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		requirement, exists := i.methodPermissions[info.FullMethod]
+		if !exists {
+			return handler(srv, ss)
+		}
+
+		if err := i.authorize(ss.Context(), requirement); err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+func (i *RBACInterceptor) authorize(ctx context.Context, requirement PermissionRequirement) error {
+	if err := CheckScopes(ctx, requirement.Permission); err != nil {
+		return err
+	}
+	authType := AuthTypeFromContext(ctx)
+
+	switch authType {
+	case AuthTypeUser, AuthTypeAPIKey:
+		userID, err := UserIDFromContext(ctx)
+		if err != nil {
+			return status.Errorf(codes.Unauthenticated, "no user in context")
+		}
+
+		allowed, _, err := i.checker.CheckPermission(ctx, userID, requirement.Permission, nil)
+		if err != nil {
+			return status.Errorf(codes.Internal, "permission check failed: %v", err)
+		}
+		if !allowed {
+			return status.Errorf(codes.PermissionDenied, "permission %q denied", requirement.Permission)
+		}
+
+	case AuthTypeServiceAccount:
+		saID, err := ServiceAccountIDFromContext(ctx)
+		if err != nil {
+			return status.Errorf(codes.Unauthenticated, "no service account in context")
+		}
+
+		allowed, err := i.checker.CheckServiceAccountPermission(ctx, saID, requirement.Permission, nil)
+		if err != nil {
+			return status.Errorf(codes.Internal, "permission check failed: %v", err)
+		}
+		if !allowed {
+			return status.Errorf(codes.PermissionDenied, "permission %q denied", requirement.Permission)
+		}
+
+	default:
+		return status.Errorf(codes.Unauthenticated, "unknown auth type")
+	}
+
+	return nil
+}
+
+// CheckScopes fails with PermissionDenied unless the request's credential may be used for
+// permission. API keys and service account tokens issued with scopes are limited to them.
+// Tokens issued to OAuth clients or obtained by token exchange act for the user only within
+// their scopes, so without any they grant no permission at all.
+func CheckScopes(ctx context.Context, permission string) error {
+	scopes := APIScopesFromContext(ctx)
+	_, delegated := ActorFromContext(ctx)
+	_, client := OAuthClientFromContext(ctx)
+	if len(scopes) == 0 && !delegated && !client {
+		return nil
+	}
+	if !slices.Contains(scopes, permission) {
+		return status.Errorf(codes.PermissionDenied, "credential scopes do not include %q", permission)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bernardoforcillo/authlayer/internal/auth"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCheckScopes(t *testing.T) {
+	user := SetUserInContext(context.Background(), uuid.New(), "alice@example.com")
+	delegated := func(scopes ...string) context.Context {
+		ctx := SetActorInContext(user, auth.Actor{Subject: uuid.NewString()})
+		return context.WithValue(ctx, apiScopesKey, scopes)
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		allowed bool
+	}{
+		{"session token", user, true},
+		{"unscoped API key", SetAPIKeyInContext(context.Background(), uuid.New(), nil), true},
+		{"API key with the scope", SetAPIKeyInContext(context.Background(), uuid.New(), []string{"org:read"}), true},
+		{"API key without the scope", SetAPIKeyInContext(context.Background(), uuid.New(), []string{"team:read"}), false},
+		{"OAuth client token without scopes", SetOAuthClientInContext(user, OAuthClient{ID: "app"}, nil), false},
+		{"OAuth client token with the scope", SetOAuthClientInContext(user, OAuthClient{ID: "app"}, []string{"org:read"}), true},
+		{"delegated token without scopes", delegated(), false},
+		{"delegated token without the scope", delegated("team:read"), false},
+		{"delegated token with the scope", delegated("org:read"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckScopes(tt.ctx, "org:read")
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, codes.PermissionDenied, status.Code(err))
+			}
+		})
+	}
+}
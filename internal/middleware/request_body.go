@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/proto"
+	"google.golang.org/grpc/mem"
+	"google.golang.org/grpc/stats"
+)
+
+// RequestBodyOptions record the encoded request of each unary call as it arrived on the
+// wire, which signed requests are verified against: re-encoding the decoded request would
+// not reproduce the bytes another client or protobuf version signed.
+//
+// The codec keeps the bytes of each message it decodes until the stats handler, which
+// gRPC calls right after decoding, moves them into the call's context.
+func RequestBodyOptions() []grpc.ServerOption {
+	bodies := &sync.Map{}
+	return []grpc.ServerOption{
+		grpc.ForceServerCodecV2(recordingCodec{CodecV2: encoding.GetCodecV2(proto.Name), bodies: bodies}),
+		grpc.StatsHandler(&requestBodyStats{bodies: bodies}),
+	}
+}
+
+// recordingCodec is the protobuf codec, keeping the bytes of every decoded message.
+type recordingCodec struct {
+	encoding.CodecV2
+	bodies *sync.Map
+}
+
+func (c recordingCodec) Unmarshal(data mem.BufferSlice, v any) error {
+	if err := c.CodecV2.Unmarshal(data, v); err != nil {
+		return err
+	}
+	c.bodies.Store(v, data.Materialize())
+	return nil
+}
+
+type requestBodyKey struct{}
+
+// requestBody holds the bytes of the first message of a call.
+type requestBody struct {
+	body     []byte
+	recorded bool
+}
+
+// requestBodyStats hands each decoded message's bytes to its call.
+type requestBodyStats struct {
+	bodies *sync.Map
+}
+
+func (h *requestBodyStats) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return context.WithValue(ctx, requestBodyKey{}, &requestBody{})
+}
+
+func (h *requestBodyStats) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	in, ok := s.(*stats.InPayload)
+	if !ok || in.Client {
+		return
+	}
+	body, _ := h.bodies.LoadAndDelete(in.Payload)
+	if rb, ok := ctx.Value(requestBodyKey{}).(*requestBody); ok && !rb.recorded {
+		rb.body, _ = body.([]byte)
+		rb.recorded = true
+	}
+}
+
+func (h *requestBodyStats) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (h *requestBodyStats) HandleConn(context.Context, stats.ConnStats) {}
+
+// requestBodyFromContext returns the bytes of the call's request, and false when
+// RequestBodyOptions is not installed. Streams have no body yet when they are
+// authenticated, so theirs is empty.
+func requestBodyFromContext(ctx context.Context) ([]byte, bool) {
+	rb, ok := ctx.Value(requestBodyKey{}).(*requestBody)
+	if !ok {
+		return nil, false
+	}
+	return rb.body, true
+}
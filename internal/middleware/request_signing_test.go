@@ -0,0 +1,173 @@
+package middleware
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bernardoforcillo/authlayer/internal/auth"
+	"github.com/bernardoforcillo/authlayer/internal/model"
+	"github.com/bernardoforcillo/authlayer/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding"
+	protocodec "google.golang.org/grpc/encoding/proto"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/mem"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// stubKeyRepo serves a single service account key.
+type stubKeyRepo struct {
+	repository.ServiceAccountKeyRepository
+	key *model.ServiceAccountKey
+}
+
+func (r *stubKeyRepo) GetByID(_ context.Context, id uuid.UUID) (*model.ServiceAccountKey, error) {
+	if id != r.key.ID {
+		return nil, errors.New("not found")
+	}
+	return r.key, nil
+}
+
+func (r *stubKeyRepo) UpdateLastUsed(context.Context, uuid.UUID) error { return nil }
+
+type signer struct {
+	keyID  string
+	secret []byte
+}
+
+func newSigningInterceptor(t *testing.T) (*AuthInterceptor, *model.ServiceAccountKey, signer) {
+	t.Helper()
+	boxKey := make([]byte, 32)
+	_, _ = rand.Read(boxKey)
+	box, err := auth.NewSecretBox(base64.StdEncoding.EncodeToString(boxKey))
+	require.NoError(t, err)
+
+	secret := []byte(rand.Text())
+	sealed, err := box.Seal(secret)
+	require.NoError(t, err)
+	key := &model.ServiceAccountKey{
+		Base:             model.Base{ID: uuid.New()},
+		ServiceAccountID: uuid.New(),
+		SigningSecret:    sealed,
+		ServiceAccount:   model.ServiceAccount{Status: model.ServiceAccountStatusActive},
+	}
+	i := NewAuthInterceptor(nil, nil, &stubKeyRepo{key: key}, box, NewMemoryNonceStore(), time.Minute, nil)
+	return i, key, signer{keyID: key.ID.String(), secret: secret}
+}
+
+func (s signer) sign(method, path string, body []byte) string {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	nonce := rand.Text()
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(auth.StringToSign(method, path, timestamp, nonce, body)))
+	signature := base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+	return "ServiceSignature keyId=" + s.keyID + ",timestamp=" + timestamp + ",nonce=" + nonce + ",signature=" + signature
+}
+
+// rawCodec sends a fixed encoding of the request.
+type rawCodec struct {
+	encoding.CodecV2
+	body []byte
+}
+
+func (c rawCodec) Marshal(any) (mem.BufferSlice, error) {
+	return mem.BufferSlice{mem.SliceBuffer(c.body)}, nil
+}
+
+// The signature covers the request as sent, even when re-encoding it gives other bytes.
+func TestSignedGRPCRequestCoversWireBytes(t *testing.T) {
+	interceptor, _, signer := newSigningInterceptor(t)
+
+	srv := grpc.NewServer(append(RequestBodyOptions(), grpc.ChainUnaryInterceptor(interceptor.UnaryServerInterceptor()))...)
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	// The service field twice: decoding keeps the last, so re-encoding drops the first
+	var body []byte
+	body = protowire.AppendTag(body, 1, protowire.BytesType)
+	body = protowire.AppendString(body, "ignored")
+	body = protowire.AppendTag(body, 1, protowire.BytesType)
+	body = protowire.AppendString(body, "")
+	reencoded, err := proto.Marshal(&healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.NotEqual(t, body, reencoded)
+
+	const method = "/grpc.health.v1.Health/Check"
+	call := func(signedBody []byte) error {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", signer.sign("POST", method, signedBody))
+		codec := rawCodec{CodecV2: encoding.GetCodecV2(protocodec.Name), body: body}
+		return conn.Invoke(ctx, method, &healthpb.HealthCheckRequest{}, &healthpb.HealthCheckResponse{}, grpc.ForceCodecV2(codec))
+	}
+
+	require.NoError(t, call(body))
+	assert.Equal(t, codes.Unauthenticated, status.Code(call(reencoded)))
+}
+
+func TestSignedGatewayRequest(t *testing.T) {
+	interceptor, key, signer := newSigningInterceptor(t)
+
+	// Stands in for the gateway, which forwards the authorization header as metadata
+	var forwarded string
+	handler := interceptor.GatewayHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwarded = r.Header.Get("Authorization")
+		ctx := metadata.NewIncomingContext(r.Context(), metadata.Pairs("authorization", forwarded))
+		ctx, err := interceptor.authenticate(ctx, "/authlayer.v1.UserService/UpdateUser")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		saID, err := ServiceAccountIDFromContext(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, key.ServiceAccountID, saID)
+	}))
+
+	const body = `{ "display_name": "Deploy bot" }`
+	serve := func(authorization, sentBody string) int {
+		r := httptest.NewRequest(http.MethodPatch, "/v1/users/me?update_mask=display_name", strings.NewReader(sentBody))
+		r.Header.Set("Authorization", authorization)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	authorization := signer.sign(http.MethodPatch, "/v1/users/me?update_mask=display_name", []byte(body))
+	assert.Equal(t, http.StatusOK, serve(authorization, body))
+	assert.True(t, strings.HasPrefix(forwarded, "GatewaySignature "))
+
+	// Handoffs are single use and end with the request
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", forwarded))
+	_, err := interceptor.authenticate(ctx, "/authlayer.v1.UserService/UpdateUser")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	assert.Equal(t, http.StatusUnauthorized, serve(authorization, body), "replayed nonce")
+	tampered := signer.sign(http.MethodPatch, "/v1/users/me?update_mask=display_name", []byte(body))
+	assert.Equal(t, http.StatusUnauthorized, serve(tampered, `{"display_name": "Admin"}`))
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ScopeType identifies where a materialized permission applies.
+type ScopeType string
+
+const (
+	// ScopeTypeGlobal rows have a nil ScopeID and answer checks made without an org.
+	ScopeTypeGlobal  ScopeType = "global"
+	ScopeTypeOrg     ScopeType = "org"
+	ScopeTypeProject ScopeType = "project"
+)
+
+// EffectivePermission is a denormalized row of the permissions a principal holds in a
+// scope, with the role that carries the permission. The table is derived from memberships,
+// bindings and the role tree, and can be rebuilt from them at any time.
+// The primary key doubles as the lookup index used by permission checks.
+type EffectivePermission struct {
+	PrincipalType  PrincipalType `gorm:"size:20;primaryKey" json:"principal_type"`
+	PrincipalID    uuid.UUID     `gorm:"type:uuid;primaryKey" json:"principal_id"`
+	ScopeType      ScopeType     `gorm:"size:20;primaryKey" json:"scope_type"`
+	ScopeID        uuid.UUID     `gorm:"type:uuid;primaryKey" json:"scope_id"`
+	PermissionName string        `gorm:"size:100;primaryKey" json:"permission_name"`
+	SourceRoleID   uuid.UUID     `gorm:"type:uuid;primaryKey;index" json:"source_role_id"`
+	PermissionID   uuid.UUID     `gorm:"type:uuid;not null" json:"permission_id"`
+	CreatedAt      time.Time     `gorm:"autoCreateTime" json:"created_at"`
+}
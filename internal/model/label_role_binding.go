@@ -0,0 +1,17 @@
+package model
+
+import "github.com/google/uuid"
+
+// LabelRoleBinding grants a role in an org to every principal of PrincipalType whose
+// labels match Selector (e.g. every service account labelled env=staging gets "deployer").
+// For teams, the role applies to the members of every matching team.
+type LabelRoleBinding struct {
+	Base
+	OrgID         uuid.UUID     `gorm:"type:uuid;not null;index" json:"org_id"`
+	RoleID        uuid.UUID     `gorm:"type:uuid;not null;index" json:"role_id"`
+	PrincipalType PrincipalType `gorm:"size:20;not null" json:"principal_type"`
+	Selector      string        `gorm:"size:1024;not null" json:"selector"`
+
+	Organization Organization `gorm:"foreignKey:OrgID" json:"organization,omitempty"`
+	Role         Role         `gorm:"foreignKey:RoleID" json:"role,omitempty"`
+}
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Labels are key/value tags attached to users, service accounts, teams and roles.
+// They are stored as a JSONB object so they can be filtered in SQL.
+type Labels map[string]string
+
+// Value implements driver.Valuer.
+func (l Labels) Value() (driver.Value, error) {
+	if l == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner.
+func (l *Labels) Scan(src interface{}) error {
+	var raw []byte
+	switch v := src.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return fmt.Errorf("unsupported labels type %T", src)
+	}
+	return json.Unmarshal(raw, l)
+}
@@ -0,0 +1,105 @@
+package model
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OAuthClient is an application that signs users in through authlayer's OAuth 2.0
+// authorization server.
+type OAuthClient struct {
+	Base
+	ClientID     string     `gorm:"size:64;not null;uniqueIndex" json:"client_id"`
+	SecretHash   *string    `gorm:"size:255" json:"-"` // nil for public clients
+	Name         string     `gorm:"size:255;not null" json:"name"`
+	RedirectURIs StringList `gorm:"type:jsonb;default:'[]';not null" json:"redirect_uris"`
+	Scopes       StringList `gorm:"type:jsonb;default:'[]';not null" json:"scopes"` // scopes the client may request
+	// Audience is the aud claim of the client's access tokens; the client ID when empty.
+	Audience *string `gorm:"size:512" json:"audience,omitempty"`
+	// Public clients (browser and mobile apps) cannot keep a secret and must use PKCE.
+	Public bool `gorm:"default:false;not null" json:"public"`
+	// Trusted first-party clients skip the consent screen.
+	Trusted   bool      `gorm:"default:false;not null" json:"trusted"`
+	CreatedBy uuid.UUID `gorm:"type:uuid;not null" json:"created_by"`
+	// OrgID is the organization that registered the client and manages it.
+	OrgID uuid.UUID `gorm:"type:uuid;not null;index" json:"org_id"`
+
+	Creator      User         `gorm:"foreignKey:CreatedBy" json:"creator,omitempty"`
+	Organization Organization `gorm:"foreignKey:OrgID" json:"organization,omitempty"`
+}
+
+// AccessTokenAudience returns the aud claim of the client's access tokens.
+func (c *OAuthClient) AccessTokenAudience() string {
+	if c.Audience != nil && *c.Audience != "" {
+		return *c.Audience
+	}
+	return c.ClientID
+}
+
+// OAuthAuthorizationCode is a single-use code issued by the authorize endpoint and
+// exchanged at the token endpoint.
+type OAuthAuthorizationCode struct {
+	Base
+	CodeHash      string     `gorm:"size:255;not null;uniqueIndex" json:"-"`
+	ClientID      string     `gorm:"size:64;not null" json:"client_id"`
+	UserID        uuid.UUID  `gorm:"type:uuid;not null" json:"user_id"`
+	RedirectURI   string     `gorm:"size:2048;not null" json:"redirect_uri"`
+	Scopes        StringList `gorm:"type:jsonb;default:'[]';not null" json:"scopes"`
+	CodeChallenge string     `gorm:"size:128" json:"-"` // S256 PKCE challenge, empty when not used
+	ExpiresAt     time.Time  `gorm:"not null;index" json:"expires_at"`
+
+	// OpenID Connect: echoed in the ID token
+	Nonce    string     `gorm:"size:512" json:"-"`
+	AuthTime *time.Time `json:"auth_time,omitempty"`
+	AMR      StringList `gorm:"type:jsonb;default:'[]';not null" json:"amr"`
+}
+
+// OAuthConsent records the scopes a user has approved for a client.
+type OAuthConsent struct {
+	Base
+	UserID   uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_oauth_consent_user_client" json:"user_id"`
+	ClientID string     `gorm:"size:64;not null;uniqueIndex:idx_oauth_consent_user_client" json:"client_id"`
+	Scopes   StringList `gorm:"type:jsonb;default:'[]';not null" json:"scopes"`
+}
+
+// Covers reports whether every scope in scopes has been approved.
+func (c *OAuthConsent) Covers(scopes []string) bool {
+	for _, s := range scopes {
+		if !slices.Contains(c.Scopes, s) {
+			return false
+		}
+	}
+	return true
+}
+
+// OAuthDeviceCodeStatus is the user's decision on a device authorization.
+type OAuthDeviceCodeStatus string
+
+const (
+	OAuthDeviceCodeStatusPending  OAuthDeviceCodeStatus = "pending"
+	OAuthDeviceCodeStatusApproved OAuthDeviceCodeStatus = "approved"
+	OAuthDeviceCodeStatusDenied   OAuthDeviceCodeStatus = "denied"
+)
+
+// OAuthDeviceCode is an RFC 8628 device authorization. The device polls the token endpoint
+// with the device code while the user approves the user code from another device.
+type OAuthDeviceCode struct {
+	Base
+	DeviceCodeHash string                `gorm:"size:255;not null;uniqueIndex" json:"-"`
+	UserCode       string                `gorm:"size:16;not null;uniqueIndex" json:"user_code"` // normalized, without the dash
+	ClientID       string                `gorm:"size:64;not null" json:"client_id"`
+	Scopes         StringList            `gorm:"type:jsonb;default:'[]';not null" json:"scopes"`
+	Status         OAuthDeviceCodeStatus `gorm:"size:20;default:'pending';not null" json:"status"`
+	ExpiresAt      time.Time             `gorm:"not null;index" json:"expires_at"`
+
+	// Minimum seconds between polls, raised each time the device polls too fast
+	PollInterval int        `gorm:"not null" json:"poll_interval"`
+	LastPolledAt *time.Time `json:"last_polled_at,omitempty"`
+
+	// Set on approval
+	UserID   *uuid.UUID `gorm:"type:uuid" json:"user_id,omitempty"`
+	AuthTime *time.Time `json:"auth_time,omitempty"`
+	AMR      StringList `gorm:"type:jsonb;default:'[]';not null" json:"amr"`
+}
@@ -3,11 +3,15 @@ package model
 import "github.com/google/uuid"
 
 // Organization represents a tenant/workspace in the multi-tenant system.
+// Organizations can be arranged in a tree (e.g. an enterprise and its subsidiaries);
+// if InheritParentRoles is set, roles held in the parent also apply here.
 type Organization struct {
 	Base
-	Name    string    `gorm:"size:255;not null" json:"name"`
-	Slug    string    `gorm:"size:255;uniqueIndex;not null" json:"slug"`
-	OwnerID uuid.UUID `gorm:"type:uuid;not null;index" json:"owner_id"`
+	Name               string     `gorm:"size:255;not null" json:"name"`
+	Slug               string     `gorm:"size:255;uniqueIndex;not null" json:"slug"`
+	OwnerID            uuid.UUID  `gorm:"type:uuid;not null;index" json:"owner_id"`
+	ParentOrgID        *uuid.UUID `gorm:"type:uuid;index" json:"parent_org_id,omitempty"`
+	InheritParentRoles bool       `gorm:"default:false;not null" json:"inherit_parent_roles"`
 
 	Owner   User                 `gorm:"foreignKey:OwnerID" json:"owner,omitempty"`
 	Members []OrganizationMember `gorm:"foreignKey:OrgID" json:"members,omitempty"`
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PermissionChange records the permissions a principal gained or lost in a scope when its
+// materialized rows were refreshed. Revision orders changes across all principals.
+type PermissionChange struct {
+	Revision      int64         `gorm:"primaryKey;autoIncrement" json:"revision"`
+	PrincipalType PrincipalType `gorm:"size:20;not null;index:idx_permission_changes_principal" json:"principal_type"`
+	PrincipalID   uuid.UUID     `gorm:"type:uuid;not null;index:idx_permission_changes_principal" json:"principal_id"`
+	ScopeType     ScopeType     `gorm:"size:20;not null" json:"scope_type"`
+	ScopeID       uuid.UUID     `gorm:"type:uuid;not null" json:"scope_id"`
+	Added         StringList    `gorm:"type:jsonb;default:'[]';not null" json:"added"`
+	Removed       StringList    `gorm:"type:jsonb;default:'[]';not null" json:"removed"`
+	CreatedAt     time.Time     `gorm:"autoCreateTime;index" json:"created_at"`
+}
+
+// StringList is a list of strings stored as a JSONB array.
+type StringList []string
+
+// Value implements driver.Valuer.
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner.
+func (l *StringList) Scan(src interface{}) error {
+	var raw []byte
+	switch v := src.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return fmt.Errorf("unsupported string list type %T", src)
+	}
+	return json.Unmarshal(raw, l)
+}
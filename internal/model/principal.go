@@ -0,0 +1,10 @@
+package model
+
+// PrincipalType identifies the kind of identity that holds a role.
+type PrincipalType string
+
+const (
+	PrincipalTypeUser           PrincipalType = "user"
+	PrincipalTypeServiceAccount PrincipalType = "service_account"
+	PrincipalTypeTeam           PrincipalType = "team"
+)
@@ -0,0 +1,27 @@
+package model
+
+import "github.com/google/uuid"
+
+// Project groups work inside an organization that several teams can share.
+type Project struct {
+	Base
+	Name        string    `gorm:"size:255;not null;uniqueIndex:idx_project_org_name" json:"name"`
+	Description *string   `gorm:"size:1024" json:"description,omitempty"`
+	OrgID       uuid.UUID `gorm:"type:uuid;not null;index;uniqueIndex:idx_project_org_name" json:"org_id"`
+
+	Organization Organization    `gorm:"foreignKey:OrgID" json:"organization,omitempty"`
+	Members      []ProjectMember `gorm:"foreignKey:ProjectID" json:"members,omitempty"`
+}
+
+// ProjectMember binds a user, team or service account to a role within a project.
+// Project roles are granted in addition to the roles the principal holds in the org.
+type ProjectMember struct {
+	Base
+	ProjectID     uuid.UUID     `gorm:"type:uuid;not null;uniqueIndex:idx_project_principal" json:"project_id"`
+	PrincipalType PrincipalType `gorm:"size:20;not null;uniqueIndex:idx_project_principal;index:idx_project_member_principal" json:"principal_type"`
+	PrincipalID   uuid.UUID     `gorm:"type:uuid;not null;uniqueIndex:idx_project_principal;index:idx_project_member_principal" json:"principal_id"`
+	RoleID        uuid.UUID     `gorm:"type:uuid;not null" json:"role_id"`
+
+	Project Project `gorm:"foreignKey:ProjectID" json:"project,omitempty"`
+	Role    Role    `gorm:"foreignKey:RoleID" json:"role,omitempty"`
+}
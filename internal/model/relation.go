@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RelationNamespace holds the relation schema for one ReBAC namespace (e.g. "document").
+type RelationNamespace struct {
+	Base
+	Name      string `gorm:"size:100;uniqueIndex;not null" json:"name"`
+	Relations string `gorm:"type:text;not null" json:"relations"` // JSON array of relation definitions
+}
+
+// RelationTuple states that a subject holds a relation on an object:
+// Namespace:ObjectID#Relation@SubjectNamespace:SubjectObjectID[#SubjectRelation].
+// An empty SubjectRelation means the subject is a concrete identity rather than a userset.
+// Tuples are hard-deleted, so they do not embed Base.
+type RelationTuple struct {
+	ID               uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Namespace        string    `gorm:"size:100;not null;uniqueIndex:idx_relation_tuple;index:idx_relation_tuple_object" json:"namespace"`
+	ObjectID         string    `gorm:"size:255;not null;uniqueIndex:idx_relation_tuple;index:idx_relation_tuple_object" json:"object_id"`
+	Relation         string    `gorm:"size:100;not null;uniqueIndex:idx_relation_tuple;index:idx_relation_tuple_object" json:"relation"`
+	SubjectNamespace string    `gorm:"size:100;not null;uniqueIndex:idx_relation_tuple;index:idx_relation_tuple_subject" json:"subject_namespace"`
+	SubjectObjectID  string    `gorm:"size:255;not null;uniqueIndex:idx_relation_tuple;index:idx_relation_tuple_subject" json:"subject_object_id"`
+	SubjectRelation  string    `gorm:"size:100;not null;default:'';uniqueIndex:idx_relation_tuple" json:"subject_relation"`
+	CreatedAt        time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -11,6 +11,7 @@ type Role struct {
 	Description  *string    `gorm:"size:512" json:"description,omitempty"`
 	OrgID        *uuid.UUID `gorm:"type:uuid;index;uniqueIndex:idx_role_org_name" json:"org_id,omitempty"`
 	ParentRoleID *uuid.UUID `gorm:"type:uuid;index" json:"parent_role_id,omitempty"`
+	Labels       Labels     `gorm:"type:jsonb;default:'{}';not null" json:"labels,omitempty"`
 
 	Organization *Organization `gorm:"foreignKey:OrgID" json:"organization,omitempty"`
 	ParentRole   *Role         `gorm:"foreignKey:ParentRoleID" json:"parent_role,omitempty"`
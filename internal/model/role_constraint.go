@@ -0,0 +1,25 @@
+package model
+
+import "github.com/google/uuid"
+
+// RoleConstraint is a separation-of-duties rule: a set of mutually exclusive roles
+// of which a single principal may hold at most one within an organization.
+// If OrgID is nil, the constraint applies to every organization.
+type RoleConstraint struct {
+	Base
+	Name        string     `gorm:"size:100;not null" json:"name"`
+	Description *string    `gorm:"size:512" json:"description,omitempty"`
+	OrgID       *uuid.UUID `gorm:"type:uuid;index" json:"org_id,omitempty"`
+
+	Organization *Organization `gorm:"foreignKey:OrgID" json:"organization,omitempty"`
+	Roles        []Role        `gorm:"many2many:role_constraint_roles" json:"roles,omitempty"`
+}
+
+// RoleConstraintRole is the join table between RoleConstraint and Role.
+type RoleConstraintRole struct {
+	RoleConstraintID uuid.UUID `gorm:"type:uuid;primaryKey" json:"role_constraint_id"`
+	RoleID           uuid.UUID `gorm:"type:uuid;primaryKey" json:"role_id"`
+
+	RoleConstraint RoleConstraint `gorm:"foreignKey:RoleConstraintID" json:"role_constraint,omitempty"`
+	Role           Role           `gorm:"foreignKey:RoleID" json:"role,omitempty"`
+}
@@ -24,6 +24,9 @@ type ServiceAccount struct {
 	CreatedBy         uuid.UUID            `gorm:"type:uuid;not null" json:"created_by"`
 	Status            ServiceAccountStatus `gorm:"size:20;default:'active';not null" json:"status"`
 	LastAuthenticatedAt *time.Time         `json:"last_authenticated_at,omitempty"`
+	Labels            Labels               `gorm:"type:jsonb;default:'{}';not null" json:"labels,omitempty"`
+	// Scopes the service account may request for its access tokens
+	Scopes            StringList           `gorm:"type:jsonb;default:'[]';not null" json:"scopes"`
 
 	Organization Organization        `gorm:"foreignKey:OrgID" json:"organization,omitempty"`
 	Creator      User                `gorm:"foreignKey:CreatedBy" json:"creator,omitempty"`
@@ -31,13 +34,31 @@ type ServiceAccount struct {
 	Roles        []ServiceAccountRole `gorm:"foreignKey:ServiceAccountID" json:"roles,omitempty"`
 }
 
+// ServiceAccountKeyType tells how a service account key authenticates.
+type ServiceAccountKeyType string
+
+const (
+	// Secret keys are random bearer secrets, of which only the hash is stored.
+	ServiceAccountKeyTypeSecret ServiceAccountKeyType = "secret"
+	// Public keys verify JWT assertions the service account signs with the private key,
+	// which authlayer never stores.
+	ServiceAccountKeyTypePublicKey ServiceAccountKeyType = "public_key"
+)
+
 // ServiceAccountKey represents an API key for a service account.
 type ServiceAccountKey struct {
 	Base
 	ServiceAccountID uuid.UUID  `gorm:"type:uuid;not null;index" json:"service_account_id"`
 	Name             string     `gorm:"size:255;not null" json:"name"`
+	KeyType          ServiceAccountKeyType `gorm:"size:20;default:'secret';not null" json:"key_type"`
 	KeyPrefix        string     `gorm:"size:8;not null" json:"key_prefix"`
+	// Hash of the secret, or "jwk:" and the JWK thumbprint of a public key
 	KeyHash          string     `gorm:"size:255;not null;uniqueIndex" json:"-"`
+	// PEM encoded public key and the JWT algorithm it verifies, for public keys
+	PublicKey        string     `gorm:"type:text" json:"public_key,omitempty"`
+	Algorithm        string     `gorm:"size:10" json:"algorithm,omitempty"`
+	// Secret sealed with the service key encryption key, for secrets that sign requests
+	SigningSecret    string     `gorm:"type:text" json:"-"`
 	ExpiresAt        *time.Time `json:"expires_at,omitempty"`
 	LastUsedAt       *time.Time `json:"last_used_at,omitempty"`
 	Revoked          bool       `gorm:"default:false;not null" json:"revoked"`
@@ -56,3 +77,20 @@ type ServiceAccountRole struct {
 	Role           Role           `gorm:"foreignKey:RoleID" json:"role,omitempty"`
 	Organization   Organization   `gorm:"foreignKey:OrgID" json:"organization,omitempty"`
 }
+
+// WorkloadIdentityTrust lets workloads holding tokens from an external OIDC issuer, such as
+// CI pipelines or Kubernetes pods, authenticate as the service account without a key.
+type WorkloadIdentityTrust struct {
+	Base
+	ServiceAccountID uuid.UUID `gorm:"type:uuid;not null;index" json:"service_account_id"`
+	Name             string    `gorm:"size:255;not null" json:"name"`
+	IssuerURL        string    `gorm:"size:512;not null" json:"issuer_url"`
+	Audience         string    `gorm:"size:512;not null" json:"audience"`
+	// Claims the external token must carry: each names a claim and the value it must have,
+	// or start with when the value ends in *
+	Conditions Labels     `gorm:"type:jsonb;default:'{}';not null" json:"conditions"`
+	CreatedBy  uuid.UUID  `gorm:"type:uuid;not null" json:"created_by"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+
+	ServiceAccount ServiceAccount `gorm:"foreignKey:ServiceAccountID" json:"service_account,omitempty"`
+}
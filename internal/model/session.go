@@ -16,6 +16,8 @@ type Session struct {
 	IPAddress   *string   `gorm:"size:45" json:"ip_address,omitempty"`
 	UserAgent   *string   `gorm:"size:512" json:"user_agent,omitempty"`
 	Revoked     bool      `gorm:"default:false;not null" json:"revoked"`
+	// ClientID is set for sessions issued to an OAuth client.
+	ClientID *string `gorm:"size:64;index" json:"client_id,omitempty"`
 
 	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
 }
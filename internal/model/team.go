@@ -5,8 +5,9 @@ import "github.com/google/uuid"
 // Team represents a sub-group within an organization.
 type Team struct {
 	Base
-	Name  string    `gorm:"size:255;not null;uniqueIndex:idx_team_org_name" json:"name"`
-	OrgID uuid.UUID `gorm:"type:uuid;not null;index;uniqueIndex:idx_team_org_name" json:"org_id"`
+	Name   string    `gorm:"size:255;not null;uniqueIndex:idx_team_org_name" json:"name"`
+	OrgID  uuid.UUID `gorm:"type:uuid;not null;index;uniqueIndex:idx_team_org_name" json:"org_id"`
+	Labels Labels    `gorm:"type:jsonb;default:'{}';not null" json:"labels,omitempty"`
 
 	Organization Organization `gorm:"foreignKey:OrgID" json:"organization,omitempty"`
 	Members      []TeamMember `gorm:"foreignKey:TeamID" json:"members,omitempty"`
@@ -18,6 +18,7 @@ type User struct {
 	Avatar        *string    `gorm:"size:512" json:"avatar,omitempty"`
 	EmailVerified bool       `gorm:"default:false;not null" json:"email_verified"`
 	Status        UserStatus `gorm:"size:20;default:'active';not null" json:"status"`
+	Labels        Labels     `gorm:"type:jsonb;default:'{}';not null" json:"labels,omitempty"`
 
 	// Associations
 	Accounts            []Account            `gorm:"foreignKey:UserID" json:"accounts,omitempty"`
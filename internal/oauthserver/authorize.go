@@ -0,0 +1,190 @@
+package oauthserver
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bernardoforcillo/authlayer/internal/auth"
+	"github.com/bernardoforcillo/authlayer/internal/middleware"
+	"github.com/bernardoforcillo/authlayer/internal/model"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Authorize serves the authorization endpoint (RFC 6749 section 4.1.1). It identifies the
+// signed-in user from the Authorization header or the session cookie, sends signed-out
+// users to the login page and users who have not yet approved the client to the consent
+// page, and otherwise redirects back to the client with an authorization code. OpenID
+// Connect requests may pass a nonce, and prompt=none to fail instead of showing a page.
+func (s *AuthorizationServer) Authorize(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	params := r.Form
+
+	// Until the client and redirect URI are known to be valid, errors are shown to the
+	// user rather than redirected, so the endpoint cannot be used as an open redirector.
+	client, err := s.clientRepo.GetByClientID(r.Context(), params.Get("client_id"))
+	if err != nil {
+		http.Error(w, "unknown client_id", http.StatusBadRequest)
+		return
+	}
+	redirectURI := params.Get("redirect_uri")
+	if redirectURI == "" && len(client.RedirectURIs) == 1 {
+		redirectURI = client.RedirectURIs[0]
+	}
+	if !slices.Contains(client.RedirectURIs, redirectURI) {
+		http.Error(w, "redirect_uri is not registered for this client", http.StatusBadRequest)
+		return
+	}
+
+	state := params.Get("state")
+	if params.Get("response_type") != "code" {
+		redirectError(w, r, redirectURI, state, newError(errUnsupportedResponseType, "only response_type=code is supported"))
+		return
+	}
+	scopes, oerr := s.requestedScopes(client, params.Get("scope"))
+	if oerr != nil {
+		redirectError(w, r, redirectURI, state, oerr)
+		return
+	}
+	challenge := params.Get("code_challenge")
+	if challenge == "" && client.Public {
+		redirectError(w, r, redirectURI, state, newError(errInvalidRequest, "public clients must use PKCE"))
+		return
+	}
+	if challenge != "" && (params.Get("code_challenge_method") != "S256" || len(challenge) != 43) {
+		redirectError(w, r, redirectURI, state, newError(errInvalidRequest, "code_challenge must be an S256 challenge"))
+		return
+	}
+
+	promptNone := slices.Contains(strings.Fields(params.Get("prompt")), "none")
+
+	// The URL to come back to once the user has signed in or consented
+	returnTo := s.jwtManager.Issuer() + "/oauth/authorize?" + params.Encode()
+
+	userID, authn, ok := s.authenticateUser(r)
+	if !ok {
+		if promptNone {
+			redirectError(w, r, redirectURI, state, newError(errLoginRequired, "the user is not signed in"))
+			return
+		}
+		if s.loginURL == "" {
+			redirectError(w, r, redirectURI, state, newError(errAccessDenied, "the user is not signed in"))
+			return
+		}
+		http.Redirect(w, r, appendQuery(s.loginURL, url.Values{"return_to": {returnTo}}), http.StatusFound)
+		return
+	}
+
+	if !client.Trusted {
+		consented, err := s.hasConsent(r, userID, client.ClientID, scopes)
+		if err != nil {
+			s.logger.Error("failed to load OAuth consent", zap.String("client_id", client.ClientID), zap.Error(err))
+			redirectError(w, r, redirectURI, state, newError(errServerError, ""))
+			return
+		}
+		if !consented {
+			if promptNone {
+				redirectError(w, r, redirectURI, state, newError(errConsentRequired, "the user has not approved this client"))
+				return
+			}
+			if s.consentURL == "" {
+				redirectError(w, r, redirectURI, state, newError(errAccessDenied, "the user has not approved this client"))
+				return
+			}
+			http.Redirect(w, r, appendQuery(s.consentURL, url.Values{
+				"client_id": {client.ClientID},
+				"scope":     {strings.Join(scopes, " ")},
+				"return_to": {returnTo},
+			}), http.StatusFound)
+			return
+		}
+	}
+
+	code, err := auth.GenerateRandomToken(32)
+	if err != nil {
+		redirectError(w, r, redirectURI, state, newError(errServerError, ""))
+		return
+	}
+	stored := &model.OAuthAuthorizationCode{
+		CodeHash:      auth.HashToken(code),
+		ClientID:      client.ClientID,
+		UserID:        userID,
+		RedirectURI:   redirectURI,
+		Scopes:        model.StringList(scopes),
+		CodeChallenge: challenge,
+		ExpiresAt:     time.Now().Add(s.codeTTL),
+		Nonce:         params.Get("nonce"),
+		AMR:           model.StringList(authn.Methods),
+	}
+	if !authn.Time.IsZero() {
+		stored.AuthTime = &authn.Time
+	}
+	if err := s.codeRepo.Create(r.Context(), stored); err != nil {
+		s.logger.Error("failed to store authorization code", zap.String("client_id", client.ClientID), zap.Error(err))
+		redirectError(w, r, redirectURI, state, newError(errServerError, ""))
+		return
+	}
+
+	resp := url.Values{"code": {code}}
+	if state != "" {
+		resp.Set("state", state)
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, appendQuery(redirectURI, resp), http.StatusFound)
+}
+
+// authenticateUser returns the signed-in user and how they signed in. Only user sessions
+// count: API keys, service accounts, tokens already issued to an OAuth client and tokens
+// obtained by token exchange cannot authorize a client.
+func (s *AuthorizationServer) authenticateUser(r *http.Request) (uuid.UUID, auth.Authentication, bool) {
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		if cookie, err := r.Cookie(s.sessionCookie); err == nil && cookie.Value != "" {
+			header = "Bearer " + cookie.Value
+		}
+	}
+	if header == "" {
+		return uuid.Nil, auth.Authentication{}, false
+	}
+
+	ctx, err := s.authenticator.Authenticate(r.Context(), header, s.authenticator.Audience())
+	if err != nil || middleware.AuthTypeFromContext(ctx) != middleware.AuthTypeUser {
+		return uuid.Nil, auth.Authentication{}, false
+	}
+	if _, ok := middleware.OAuthClientFromContext(ctx); ok {
+		return uuid.Nil, auth.Authentication{}, false
+	}
+	if _, ok := middleware.ActorFromContext(ctx); ok {
+		return uuid.Nil, auth.Authentication{}, false
+	}
+	userID, err := middleware.UserIDFromContext(ctx)
+	if err != nil {
+		return uuid.Nil, auth.Authentication{}, false
+	}
+	return userID, middleware.AuthenticationFromContext(ctx), true
+}
+
+func (s *AuthorizationServer) hasConsent(r *http.Request, userID uuid.UUID, clientID string, scopes []string) (bool, error) {
+	consent, err := s.consentRepo.Get(r.Context(), userID, clientID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return consent.Covers(scopes), nil
+}
@@ -0,0 +1,295 @@
+package oauthserver
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/bernardoforcillo/authlayer/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRedirectURI = "https://app.example.com/callback"
+
+// code runs the authorization request to completion and returns the issued code.
+func (ts *testServer) code(t *testing.T, session string, params url.Values) string {
+	t.Helper()
+	status, location := ts.authorize(t, session, params)
+	require.Equal(t, http.StatusFound, status)
+	code := location.Query().Get("code")
+	require.NotEmpty(t, code, location.String())
+	return code
+}
+
+func authorizeParams(client *model.OAuthClient, extra url.Values) url.Values {
+	params := url.Values{
+		"client_id":     {client.ClientID},
+		"redirect_uri":  {testRedirectURI},
+		"response_type": {"code"},
+		"state":         {"xyz"},
+	}
+	for k, v := range extra {
+		params[k] = v
+	}
+	return params
+}
+
+func redeemForm(client *model.OAuthClient, code string, extra url.Values) url.Values {
+	form := url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {testRedirectURI},
+		"client_id":    {client.ClientID},
+	}
+	if !client.Public {
+		form.Set("client_secret", "secret")
+	}
+	for k, v := range extra {
+		form[k] = v
+	}
+	return form
+}
+
+func TestAuthorizationCodeFlow(t *testing.T) {
+	ts := newTestServer(t)
+	_, session := ts.addUser(t)
+	client := ts.addClient(&model.OAuthClient{
+		ClientID:     "web",
+		RedirectURIs: model.StringList{testRedirectURI},
+		Scopes:       model.StringList{"profile", "email"},
+		Trusted:      true,
+	})
+
+	status, location := ts.authorize(t, session, authorizeParams(client, url.Values{"scope": {"profile"}}))
+	require.Equal(t, http.StatusFound, status)
+	assert.Equal(t, "xyz", location.Query().Get("state"))
+	code := location.Query().Get("code")
+
+	status, body := ts.token(redeemForm(client, code, nil))
+	require.Equal(t, http.StatusOK, status, body)
+	assert.Equal(t, []string{"profile"}, scopeOf(body))
+	claims, err := ts.jwtManager.ValidateAccessToken(body["access_token"].(string))
+	require.NoError(t, err)
+	assert.Equal(t, "web", claims.ClientID)
+	assert.Equal(t, []string{"web"}, []string(claims.Audience))
+
+	// Codes are single use
+	status, body = ts.token(redeemForm(client, code, nil))
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, errInvalidGrant, body["error"])
+
+	// Codes are bound to their client
+	other := ts.addClient(&model.OAuthClient{ClientID: "other", RedirectURIs: model.StringList{testRedirectURI}, Trusted: true})
+	code = ts.code(t, session, authorizeParams(client, nil))
+	status, body = ts.token(redeemForm(other, code, nil))
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, errInvalidGrant, body["error"])
+
+	status, body = ts.token(redeemForm(client, "not-a-code", url.Values{"client_secret": {"wrong"}}))
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, errInvalidClient, body["error"])
+}
+
+func TestAuthorizeRedirectURI(t *testing.T) {
+	ts := newTestServer(t)
+	_, session := ts.addUser(t)
+	client := ts.addClient(&model.OAuthClient{
+		ClientID:     "web",
+		RedirectURIs: model.StringList{testRedirectURI, "https://app.example.com/other"},
+		Trusted:      true,
+	})
+
+	// Anything but an exact match is refused without redirecting
+	for _, uri := range []string{
+		"",
+		"https://app.example.com/callback/",
+		"https://app.example.com/callback?next=/admin",
+		"https://app.example.com/callback/../evil",
+		"https://APP.example.com/callback",
+		"http://app.example.com/callback",
+		"https://evil.example.com/callback",
+	} {
+		status, location := ts.authorize(t, session, authorizeParams(client, url.Values{"redirect_uri": {uri}}))
+		assert.Equal(t, http.StatusBadRequest, status, uri)
+		assert.Empty(t, location.String(), uri)
+	}
+
+	status, _ := ts.authorize(t, session, authorizeParams(client, url.Values{"client_id": {"unknown"}}))
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	// The token request must repeat the redirect URI of the authorization request
+	code := ts.code(t, session, authorizeParams(client, nil))
+	status, body := ts.token(redeemForm(client, code, url.Values{"redirect_uri": {"https://app.example.com/other"}}))
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, errInvalidGrant, body["error"])
+
+	code = ts.code(t, session, authorizeParams(client, nil))
+	status, body = ts.token(redeemForm(client, code, url.Values{"redirect_uri": {""}}))
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, errInvalidGrant, body["error"])
+
+	// Errors after the redirect URI is validated go back to the client
+	status, location := ts.authorize(t, session, authorizeParams(client, url.Values{"response_type": {"token"}}))
+	assert.Equal(t, http.StatusFound, status)
+	assert.Equal(t, errUnsupportedResponseType, location.Query().Get("error"))
+	assert.Equal(t, "xyz", location.Query().Get("state"))
+}
+
+func TestAuthorizePKCE(t *testing.T) {
+	ts := newTestServer(t)
+	_, session := ts.addUser(t)
+	client := ts.addClient(&model.OAuthClient{
+		ClientID:     "spa",
+		RedirectURIs: model.StringList{testRedirectURI},
+		Public:       true,
+		Trusted:      true,
+	})
+	verifier, challenge := pkcePair()
+	withChallenge := url.Values{"code_challenge": {challenge}, "code_challenge_method": {"S256"}}
+
+	for name, params := range map[string]url.Values{
+		"no challenge":    nil,
+		"plain method":    {"code_challenge": {verifier[:43]}, "code_challenge_method": {"plain"}},
+		"no method":       {"code_challenge": {challenge}},
+		"short challenge": {"code_challenge": {challenge[:42]}, "code_challenge_method": {"S256"}},
+	} {
+		status, location := ts.authorize(t, session, authorizeParams(client, params))
+		assert.Equal(t, http.StatusFound, status, name)
+		assert.Equal(t, errInvalidRequest, location.Query().Get("error"), name)
+	}
+
+	otherVerifier, _ := pkcePair()
+	for name, form := range map[string]url.Values{
+		"no verifier":    nil,
+		"wrong verifier": {"code_verifier": {otherVerifier}},
+		"the challenge":  {"code_verifier": {challenge}},
+	} {
+		code := ts.code(t, session, authorizeParams(client, withChallenge))
+		status, body := ts.token(redeemForm(client, code, form))
+		assert.Equal(t, http.StatusBadRequest, status, name)
+		assert.Equal(t, errInvalidGrant, body["error"], name)
+
+		// A failed attempt burns the code
+		status, _ = ts.token(redeemForm(client, code, url.Values{"code_verifier": {verifier}}))
+		assert.Equal(t, http.StatusBadRequest, status, name)
+	}
+
+	code := ts.code(t, session, authorizeParams(client, withChallenge))
+	status, body := ts.token(redeemForm(client, code, url.Values{"code_verifier": {verifier}}))
+	assert.Equal(t, http.StatusOK, status, body)
+}
+
+func TestAuthorizeConsent(t *testing.T) {
+	ts := newTestServer(t)
+	user, session := ts.addUser(t)
+	client := ts.addClient(&model.OAuthClient{
+		ClientID:     "partner",
+		RedirectURIs: model.StringList{testRedirectURI},
+		Scopes:       model.StringList{"profile", "email"},
+	})
+
+	// Signed-out users are sent to the login page, or get an error with prompt=none
+	status, location := ts.authorize(t, "", authorizeParams(client, nil))
+	assert.Equal(t, http.StatusFound, status)
+	assert.Equal(t, "app.example.com", location.Host)
+	assert.Equal(t, "/login", location.Path)
+	returnTo, err := url.Parse(location.Query().Get("return_to"))
+	require.NoError(t, err)
+	assert.Equal(t, testIssuerURL+"/oauth/authorize", returnTo.Scheme+"://"+returnTo.Host+returnTo.Path)
+
+	_, location = ts.authorize(t, "", authorizeParams(client, url.Values{"prompt": {"none"}}))
+	assert.Equal(t, errLoginRequired, location.Query().Get("error"))
+
+	// Access tokens issued to a client cannot authorize another one
+	code := ts.code(t, session, authorizeParams(ts.addClient(&model.OAuthClient{
+		ClientID: "web", RedirectURIs: model.StringList{testRedirectURI}, Trusted: true,
+	}), nil))
+	_, body := ts.token(redeemForm(ts.clients.clients["web"], code, nil))
+	_, location = ts.authorize(t, body["access_token"].(string), authorizeParams(client, nil))
+	assert.Equal(t, "/login", location.Path)
+
+	// Without consent, the user is asked for it
+	status, location = ts.authorize(t, session, authorizeParams(client, url.Values{"scope": {"profile"}}))
+	assert.Equal(t, http.StatusFound, status)
+	assert.Equal(t, "/consent", location.Path)
+	assert.Equal(t, "partner", location.Query().Get("client_id"))
+	assert.Equal(t, "profile", location.Query().Get("scope"))
+
+	_, location = ts.authorize(t, session, authorizeParams(client, url.Values{"prompt": {"none"}}))
+	assert.Equal(t, errConsentRequired, location.Query().Get("error"))
+
+	// Consent covers the scopes approved, and no more
+	ts.consents.consents[user.ID.String()+"/partner"] = &model.OAuthConsent{Scopes: model.StringList{"profile"}}
+	ts.code(t, session, authorizeParams(client, url.Values{"scope": {"profile"}}))
+	_, location = ts.authorize(t, session, authorizeParams(client, url.Values{"scope": {"profile email"}}))
+	assert.Equal(t, "/consent", location.Path)
+
+	// Scopes the client is not registered for are refused outright
+	_, location = ts.authorize(t, session, authorizeParams(client, url.Values{"scope": {"profile admin"}}))
+	assert.Equal(t, errInvalidScope, location.Query().Get("error"))
+
+	// Trusted clients skip consent
+	client.Trusted = true
+	ts.code(t, session, authorizeParams(client, url.Values{"scope": {"profile email"}}))
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	ts := newTestServer(t)
+	_, session := ts.addUser(t)
+	client := ts.addClient(&model.OAuthClient{
+		ClientID:     "web",
+		RedirectURIs: model.StringList{testRedirectURI},
+		Scopes:       model.StringList{"profile", "email"},
+		Trusted:      true,
+	})
+	refresh := func(c *model.OAuthClient, token string, extra url.Values) (int, map[string]interface{}) {
+		form := url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {token},
+			"client_id":     {c.ClientID},
+			"client_secret": {"secret"},
+		}
+		for k, v := range extra {
+			form[k] = v
+		}
+		return ts.token(form)
+	}
+
+	code := ts.code(t, session, authorizeParams(client, url.Values{"scope": {"profile email"}}))
+	_, body := ts.token(redeemForm(client, code, nil))
+	first := body["refresh_token"].(string)
+
+	// Rotation issues a new refresh token of the same family; scopes can only narrow
+	status, body := refresh(client, first, url.Values{"scope": {"profile"}})
+	require.Equal(t, http.StatusOK, status, body)
+	second := body["refresh_token"].(string)
+	assert.NotEqual(t, first, second)
+	assert.Equal(t, []string{"profile"}, scopeOf(body))
+
+	status, body = refresh(client, second, url.Values{"scope": {"email"}})
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, errInvalidScope, body["error"])
+
+	// The failed attempt rotated second away too; start over
+	code = ts.code(t, session, authorizeParams(client, nil))
+	_, body = ts.token(redeemForm(client, code, nil))
+	first = body["refresh_token"].(string)
+	_, body = refresh(client, first, nil)
+	second = body["refresh_token"].(string)
+
+	// Refresh tokens are bound to their client
+	other := ts.addClient(&model.OAuthClient{ClientID: "other", RedirectURIs: model.StringList{testRedirectURI}})
+	status, body = refresh(other, second, nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, errInvalidGrant, body["error"])
+
+	// Presenting a rotated token revokes the whole family, including its successor
+	status, body = refresh(client, first, nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, errInvalidGrant, body["error"])
+	status, body = refresh(client, second, nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, errInvalidGrant, body["error"])
+}
@@ -0,0 +1,157 @@
+package oauthserver
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bernardoforcillo/authlayer/internal/auth"
+	"github.com/bernardoforcillo/authlayer/internal/model"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	grantTypeClientCredentials = "client_credentials"
+	grantTypeJWTBearer         = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+	clientAssertionTypeJWT     = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+)
+
+// clientCredentialsGrant issues a service account a short-lived access token (RFC 6749
+// section 4.4). The service account is the client: it authenticates with one of its keys,
+// as client_id and client_secret, or with a signed client assertion.
+func (s *AuthorizationServer) clientCredentialsGrant(r *http.Request) (*TokenResponse, *oauthError) {
+	sa, oerr := s.authenticateServiceAccount(r)
+	if oerr != nil {
+		return nil, oerr
+	}
+	return s.issueServiceAccountToken(r, sa)
+}
+
+// jwtBearerGrant issues a service account a short-lived access token for an assertion it
+// signed with one of its keys, presented as authorization grant (RFC 7523 section 2.1).
+func (s *AuthorizationServer) jwtBearerGrant(r *http.Request) (*TokenResponse, *oauthError) {
+	assertion := r.PostForm.Get("assertion")
+	if assertion == "" {
+		return nil, newError(errInvalidRequest, "assertion is required")
+	}
+	key, oerr := s.verifyServiceAccountAssertion(r.Context(), assertion)
+	if oerr != nil && oerr.Code == errServerError {
+		return nil, oerr
+	}
+	if oerr != nil {
+		return nil, newError(errInvalidGrant, oerr.Description)
+	}
+	if key.ExpiresAt != nil && key.ExpiresAt.Before(time.Now()) {
+		return nil, newError(errInvalidGrant, "service account key expired")
+	}
+
+	go func() { _ = s.saKeyRepo.UpdateLastUsed(context.Background(), key.ID) }()
+	return s.issueServiceAccountToken(r, &key.ServiceAccount)
+}
+
+// issueServiceAccountToken issues an authenticated service account its access token.
+func (s *AuthorizationServer) issueServiceAccountToken(r *http.Request, sa *model.ServiceAccount) (*TokenResponse, *oauthError) {
+	if sa.Status != model.ServiceAccountStatusActive {
+		return nil, newError(errInvalidClient, "service account is disabled")
+	}
+
+	// Scopes only narrow what the token is used for; the service account's permissions
+	// still come from its roles.
+	scopes := dedupe(strings.Fields(r.PostForm.Get("scope")))
+	for _, scope := range scopes {
+		if !slices.Contains(sa.Scopes, scope) {
+			return nil, newError(errInvalidScope, "scope "+scope+" is not allowed for this service account")
+		}
+	}
+	token, expiresAt, err := s.jwtManager.GenerateServiceAccountToken(auth.ServiceAccountGrant{
+		ServiceAccountID: sa.ID.String(),
+		OrgID:            sa.OrgID.String(),
+		Scopes:           scopes,
+	})
+	if err != nil {
+		return nil, newError(errServerError, "")
+	}
+
+	if err := s.saRepo.UpdateLastAuthenticated(r.Context(), sa.ID); err != nil {
+		s.logger.Warn("failed to record service account authentication", zap.String("service_account_id", sa.ID.String()), zap.Error(err))
+	}
+
+	return &TokenResponse{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(time.Until(expiresAt).Round(time.Second).Seconds()),
+		Scope:       strings.Join(scopes, " "),
+	}, nil
+}
+
+// authenticateServiceAccount identifies the service account of a client_credentials
+// request, with client_id set to the service account ID.
+func (s *AuthorizationServer) authenticateServiceAccount(r *http.Request) (*model.ServiceAccount, *oauthError) {
+	var (
+		key  *model.ServiceAccountKey
+		oerr *oauthError
+	)
+	if assertionType := r.PostForm.Get("client_assertion_type"); assertionType != "" {
+		if assertionType != clientAssertionTypeJWT {
+			return nil, newError(errInvalidClient, "unsupported client_assertion_type")
+		}
+		key, oerr = s.verifyServiceAccountAssertion(r.Context(), r.PostForm.Get("client_assertion"))
+	} else {
+		key, oerr = s.serviceAccountKey(r)
+	}
+	if oerr != nil {
+		return nil, oerr
+	}
+	if key.ExpiresAt != nil && key.ExpiresAt.Before(time.Now()) {
+		return nil, newError(errInvalidClient, "service account key expired")
+	}
+	if id := r.PostForm.Get("client_id"); id != "" && id != key.ServiceAccountID.String() {
+		return nil, newError(errInvalidClient, "client_id does not match the service account key")
+	}
+
+	go func() { _ = s.saKeyRepo.UpdateLastUsed(context.Background(), key.ID) }()
+	return &key.ServiceAccount, nil
+}
+
+// serviceAccountKey looks up the key presented as client secret, with HTTP Basic or form
+// fields.
+func (s *AuthorizationServer) serviceAccountKey(r *http.Request) (*model.ServiceAccountKey, *oauthError) {
+	clientID, secret, basic := r.BasicAuth()
+	if !basic {
+		clientID, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID == "" || secret == "" {
+		return nil, newError(errInvalidClient, "client authentication is required")
+	}
+
+	key, err := s.saKeyRepo.GetByKeyHash(r.Context(), auth.HashToken(secret))
+	if err != nil || key.ServiceAccountID.String() != clientID {
+		return nil, newError(errInvalidClient, "invalid client credentials")
+	}
+	return key, nil
+}
+
+// verifyServiceAccountAssertion checks an RFC 7523 assertion signed with the service account
+// key named by its kid header, addressed to authlayer's issuer or token endpoint. Public
+// keys verify assertions signed with their private key, and secret keys HS256 assertions
+// keyed by the secret when it is sealed for request signing.
+func (s *AuthorizationServer) verifyServiceAccountAssertion(ctx context.Context, assertion string) (*model.ServiceAccountKey, *oauthError) {
+	if assertion == "" {
+		return nil, newError(errInvalidClient, "client_assertion is required")
+	}
+
+	issuer := s.jwtManager.Issuer()
+	key, err := s.authenticator.VerifyServiceAccountAssertion(ctx, assertion, issuer, issuer+"/oauth/token")
+	if status.Code(err) == codes.Unavailable {
+		return nil, newError(errServerError, "")
+	}
+	if err != nil {
+		return nil, newError(errInvalidClient, "invalid assertion: "+err.Error())
+	}
+	return key, nil
+}
@@ -0,0 +1,153 @@
+package oauthserver
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bernardoforcillo/authlayer/internal/model"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func postToken(s *AuthorizationServer, form url.Values) (*httptest.ResponseRecorder, map[string]interface{}) {
+	r := httptest.NewRequest(http.MethodPost, "/oauth/token", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	s.Token(w, r)
+	var body map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &body)
+	return w, body
+}
+
+func TestClientCredentialsScopes(t *testing.T) {
+	ts := newTestServer(t)
+	sa := ts.addServiceAccount("reports:read", "reports:write")
+	secret := ts.addSecretKey(sa)
+
+	token := func(scope string) (int, map[string]interface{}) {
+		return ts.token(url.Values{
+			"grant_type":    {grantTypeClientCredentials},
+			"client_id":     {sa.ID.String()},
+			"client_secret": {secret},
+			"scope":         {scope},
+		})
+	}
+
+	status, body := token("reports:read")
+	require.Equal(t, http.StatusOK, status, body)
+	assert.Equal(t, []string{"reports:read"}, scopeOf(body))
+	claims, err := ts.jwtManager.ValidateAccessToken(body["access_token"].(string))
+	require.NoError(t, err)
+	assert.Equal(t, "reports:read", claims.Scope)
+
+	status, body = token("reports:read billing:write")
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, errInvalidScope, body["error"])
+}
+
+// assertion signs RFC 7523 claims with the service account key's private key.
+func assertion(t *testing.T, key *model.ServiceAccountKey, privateKey string, claims jwt.RegisteredClaims) string {
+	t.Helper()
+	signer, err := jwt.ParseECPrivateKeyFromPEM([]byte(privateKey))
+	require.NoError(t, err)
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header["kid"] = key.ID.String()
+	signed, err := token.SignedString(signer)
+	require.NoError(t, err)
+	return signed
+}
+
+func assertionClaims(sa *model.ServiceAccount) jwt.RegisteredClaims {
+	now := time.Now()
+	return jwt.RegisteredClaims{
+		Issuer:    sa.ID.String(),
+		Subject:   sa.ID.String(),
+		Audience:  jwt.ClaimStrings{testIssuerURL + "/oauth/token"},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+		ID:        rand.Text(),
+	}
+}
+
+func TestJWTBearerGrant(t *testing.T) {
+	ts := newTestServer(t)
+	sa := ts.addServiceAccount("reports:read")
+	key, privateKey := ts.addKeyPair(t, sa)
+	grant := func(assertion, scope string) (int, map[string]interface{}) {
+		return ts.token(url.Values{"grant_type": {grantTypeJWTBearer}, "assertion": {assertion}, "scope": {scope}})
+	}
+
+	signed := assertion(t, key, privateKey, assertionClaims(sa))
+	status, body := grant(signed, "reports:read")
+	require.Equal(t, http.StatusOK, status, body)
+	assert.Equal(t, "Bearer", body["token_type"])
+	claims, err := ts.jwtManager.ValidateAccessToken(body["access_token"].(string))
+	require.NoError(t, err)
+	assert.Equal(t, sa.ID.String(), claims.ServiceAccountID)
+	assert.Equal(t, "reports:read", claims.Scope)
+
+	// Each assertion is accepted once
+	status, body = grant(signed, "")
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, errInvalidGrant, body["error"])
+
+	status, body = grant("", "")
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, errInvalidRequest, body["error"])
+
+	status, body = grant(assertion(t, key, privateKey, assertionClaims(sa)), "billing:write")
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, errInvalidScope, body["error"])
+
+	other := ts.addServiceAccount()
+	_, otherPrivateKey := ts.addKeyPair(t, other)
+	for name, claims := range map[string]func(*jwt.RegisteredClaims){
+		"other audience":    func(c *jwt.RegisteredClaims) { c.Audience = jwt.ClaimStrings{"https://api.example.com"} },
+		"other issuer":      func(c *jwt.RegisteredClaims) { c.Issuer = other.ID.String() },
+		"other subject":     func(c *jwt.RegisteredClaims) { c.Subject = other.ID.String() },
+		"expired":           func(c *jwt.RegisteredClaims) { c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute)) },
+		"no expiry":         func(c *jwt.RegisteredClaims) { c.ExpiresAt = nil },
+		"too long lifetime": func(c *jwt.RegisteredClaims) { c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(time.Hour)) },
+		"no jti":            func(c *jwt.RegisteredClaims) { c.ID = "" },
+	} {
+		c := assertionClaims(sa)
+		claims(&c)
+		status, body := grant(assertion(t, key, privateKey, c), "")
+		assert.Equal(t, http.StatusBadRequest, status, name)
+		assert.Equal(t, errInvalidGrant, body["error"], name)
+	}
+
+	// Signed with another service account's private key
+	status, body = grant(assertion(t, key, otherPrivateKey, assertionClaims(sa)), "")
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, errInvalidGrant, body["error"])
+
+	// HMAC assertions need a key that keeps its secret
+	hmacAssertion := jwt.NewWithClaims(jwt.SigningMethodHS256, assertionClaims(sa))
+	hmacAssertion.Header["kid"] = key.ID.String()
+	forged, err := hmacAssertion.SignedString([]byte(key.PublicKey))
+	require.NoError(t, err)
+	status, body = grant(forged, "")
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, errInvalidGrant, body["error"])
+
+	expired := time.Now().Add(-time.Hour)
+	ts.saKeys.keys[key.ID].ExpiresAt = &expired
+	status, body = grant(assertion(t, key, privateKey, assertionClaims(sa)), "")
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, errInvalidGrant, body["error"])
+	ts.saKeys.keys[key.ID].ExpiresAt = nil
+
+	ts.saKeys.keys[key.ID].ServiceAccount.Status = model.ServiceAccountStatusDisabled
+	status, body = grant(assertion(t, key, privateKey, assertionClaims(sa)), "")
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, errInvalidClient, body["error"])
+}
@@ -0,0 +1,149 @@
+package oauthserver
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/bernardoforcillo/authlayer/internal/auth"
+	"github.com/bernardoforcillo/authlayer/internal/model"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	grantTypeDeviceCode = "urn:ietf:params:oauth:grant-type:device_code"
+
+	// slowDownStep is how much the poll interval grows each time a device polls too fast
+	// (RFC 8628 section 3.5).
+	slowDownStep = 5
+)
+
+// DeviceAuthorizationResponse is the RFC 8628 section 3.2 device authorization response.
+type DeviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int64  `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// DeviceAuthorization serves the device authorization endpoint (RFC 8628 section 3.1), for
+// devices without a browser. The device shows the user code and verification URI, and
+// polls the token endpoint with the device code until the user has approved it.
+func (s *AuthorizationServer) DeviceAuthorization(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		writeTokenError(w, newError(errInvalidRequest, "invalid form body"))
+		return
+	}
+
+	client, oerr := s.authenticateClient(r)
+	if oerr != nil {
+		writeTokenError(w, oerr)
+		return
+	}
+	scopes, oerr := s.requestedScopes(client, r.PostForm.Get("scope"))
+	if oerr != nil {
+		writeTokenError(w, oerr)
+		return
+	}
+
+	deviceCode, err := auth.GenerateRandomToken(32)
+	if err != nil {
+		writeTokenError(w, newError(errServerError, ""))
+		return
+	}
+	interval := int(s.devicePollInterval / time.Second)
+	code := &model.OAuthDeviceCode{
+		DeviceCodeHash: auth.HashToken(deviceCode),
+		ClientID:       client.ClientID,
+		Scopes:         model.StringList(scopes),
+		Status:         model.OAuthDeviceCodeStatusPending,
+		ExpiresAt:      time.Now().Add(s.deviceCodeTTL),
+		PollInterval:   interval,
+	}
+	// User codes are short, so retry on the rare collision with a live one
+	for attempt := 0; ; attempt++ {
+		if code.UserCode, err = auth.GenerateUserCode(); err == nil {
+			err = s.deviceCodeRepo.Create(r.Context(), code)
+		}
+		if err == nil || attempt == 2 {
+			break
+		}
+	}
+	if err != nil {
+		s.logger.Error("failed to store device code", zap.String("client_id", client.ClientID), zap.Error(err))
+		writeTokenError(w, newError(errServerError, ""))
+		return
+	}
+
+	userCode := auth.FormatUserCode(code.UserCode)
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, DeviceAuthorizationResponse{
+		DeviceCode:              deviceCode,
+		UserCode:                userCode,
+		VerificationURI:         s.deviceVerificationURL,
+		VerificationURIComplete: appendQuery(s.deviceVerificationURL, url.Values{"user_code": {userCode}}),
+		ExpiresIn:               int64(s.deviceCodeTTL / time.Second),
+		Interval:                interval,
+	})
+}
+
+// deviceCodeGrant redeems an approved device code (RFC 8628 section 3.4). Until the user
+// decides, the device is told to keep polling, and to slow down when it polls faster than
+// its interval.
+func (s *AuthorizationServer) deviceCodeGrant(r *http.Request, client *model.OAuthClient) (*TokenResponse, *oauthError) {
+	deviceCode := r.PostForm.Get("device_code")
+	if deviceCode == "" {
+		return nil, newError(errInvalidRequest, "device_code is required")
+	}
+
+	codeHash := auth.HashToken(deviceCode)
+	code, err := s.deviceCodeRepo.Poll(r.Context(), codeHash)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, newError(errInvalidGrant, "invalid device_code")
+	}
+	if err != nil {
+		return nil, newError(errServerError, "")
+	}
+	if code.ClientID != client.ClientID {
+		return nil, newError(errInvalidGrant, "device_code was issued to another client")
+	}
+
+	now := time.Now()
+	if now.After(code.ExpiresAt) {
+		return nil, newError(errExpiredToken, "the device code has expired")
+	}
+	if code.LastPolledAt != nil && now.Sub(*code.LastPolledAt) < time.Duration(code.PollInterval)*time.Second {
+		if err := s.deviceCodeRepo.IncreasePollInterval(r.Context(), code.ID, slowDownStep); err != nil {
+			return nil, newError(errServerError, "")
+		}
+		return nil, newError(errSlowDown, "")
+	}
+
+	switch code.Status {
+	case model.OAuthDeviceCodeStatusPending:
+		return nil, newError(errAuthorizationPending, "")
+	case model.OAuthDeviceCodeStatusDenied:
+		return nil, newError(errAccessDenied, "the user denied the request")
+	}
+
+	// Only one poll can redeem the approval
+	code, err = s.deviceCodeRepo.Consume(r.Context(), codeHash)
+	if err != nil || code.UserID == nil {
+		return nil, newError(errInvalidGrant, "invalid device_code")
+	}
+	authn := auth.Authentication{Methods: code.AMR}
+	if code.AuthTime != nil {
+		authn.Time = *code.AuthTime
+	}
+	return s.issueTokens(r, client, *code.UserID, "", code.Scopes, authn, "")
+}
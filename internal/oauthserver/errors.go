@@ -0,0 +1,94 @@
+package oauthserver
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// OAuth error codes (RFC 6749 unless noted).
+const (
+	errInvalidRequest          = "invalid_request"
+	errInvalidClient           = "invalid_client"
+	errInvalidGrant            = "invalid_grant"
+	errUnauthorizedClient      = "unauthorized_client"
+	errUnsupportedGrantType    = "unsupported_grant_type"
+	errUnsupportedResponseType = "unsupported_response_type"
+	errInvalidScope            = "invalid_scope"
+	errAccessDenied            = "access_denied"
+	errServerError             = "server_error"
+
+	// OpenID Connect Core section 3.1.2.6
+	errLoginRequired   = "login_required"
+	errConsentRequired = "consent_required"
+
+	// RFC 8628 section 3.5
+	errAuthorizationPending = "authorization_pending"
+	errSlowDown             = "slow_down"
+	errExpiredToken         = "expired_token"
+
+	// RFC 8693 section 2.2.2
+	errInvalidTarget = "invalid_target"
+)
+
+// oauthError is an error returned to the client, either in a token endpoint response or
+// appended to the redirect URI.
+type oauthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+func newError(code, description string) *oauthError {
+	return &oauthError{Code: code, Description: description}
+}
+
+// status returns the HTTP status of a token endpoint error response.
+func (e *oauthError) status() int {
+	switch e.Code {
+	case errInvalidClient:
+		return http.StatusUnauthorized
+	case errServerError:
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// writeTokenError writes an RFC 6749 section 5.2 error response.
+func writeTokenError(w http.ResponseWriter, e *oauthError) {
+	if e.Code == errInvalidClient {
+		w.Header().Set("WWW-Authenticate", `Basic realm="authlayer"`)
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	w.WriteHeader(e.status())
+	writeJSON(w, e)
+}
+
+// redirectError sends the user agent back to the client with an RFC 6749 section 4.1.2.1
+// error.
+func redirectError(w http.ResponseWriter, r *http.Request, redirectURI, state string, e *oauthError) {
+	params := url.Values{"error": {e.Code}}
+	if e.Description != "" {
+		params.Set("error_description", e.Description)
+	}
+	if state != "" {
+		params.Set("state", state)
+	}
+	http.Redirect(w, r, appendQuery(redirectURI, params), http.StatusFound)
+}
+
+// appendQuery adds params to the URI's query string, keeping any it already has.
+func appendQuery(uri string, params url.Values) string {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+	q := u.Query()
+	for k, vs := range params {
+		for _, v := range vs {
+			q.Add(k, v)
+		}
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
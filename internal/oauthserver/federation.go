@@ -0,0 +1,205 @@
+package oauthserver
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bernardoforcillo/authlayer/internal/model"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	tokenTypeIDToken = "urn:ietf:params:oauth:token-type:id_token"
+
+	// discoveryTimeout bounds the OIDC discovery of a trusted issuer.
+	discoveryTimeout = 10 * time.Second
+	// discoveryRetryAfter is how long a failed discovery is remembered before the issuer
+	// is contacted again.
+	discoveryRetryAfter = 30 * time.Second
+)
+
+// federatedIssuers caches the OIDC discovery of the issuers named by workload identity
+// trusts. go-oidc caches their signing keys and refetches them on rotation. Each issuer is
+// discovered once at a time, without holding up exchanges for other issuers, and failures
+// are remembered for discoveryRetryAfter so an unreachable issuer is not hammered.
+type federatedIssuers struct {
+	mu      sync.Mutex
+	issuers map[string]*federatedIssuer
+}
+
+type federatedIssuer struct {
+	// done is closed once discovery finishes and the fields below are set.
+	done     chan struct{}
+	provider *oidc.Provider
+	err      error
+	failedAt time.Time
+}
+
+func (f *federatedIssuers) provider(ctx context.Context, issuer string) (*oidc.Provider, error) {
+	f.mu.Lock()
+	entry, ok := f.issuers[issuer]
+	if ok {
+		select {
+		case <-entry.done:
+			if entry.err != nil && time.Since(entry.failedAt) >= discoveryRetryAfter {
+				ok = false
+			}
+		default:
+		}
+	}
+	if !ok {
+		entry = &federatedIssuer{done: make(chan struct{})}
+		if f.issuers == nil {
+			f.issuers = make(map[string]*federatedIssuer)
+		}
+		f.issuers[issuer] = entry
+		// Discovery is shared by every exchange waiting on the issuer, so it must not end
+		// when the exchange that started it gives up.
+		go entry.discover(issuer)
+	}
+	f.mu.Unlock()
+
+	select {
+	case <-entry.done:
+		return entry.provider, entry.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (e *federatedIssuer) discover(issuer string) {
+	ctx, cancel := context.WithTimeout(context.Background(), discoveryTimeout)
+	defer cancel()
+	e.provider, e.err = oidc.NewProvider(ctx, issuer)
+	if e.err != nil {
+		e.failedAt = time.Now()
+	}
+	close(e.done)
+}
+
+// federatedExchange trades a token from an external OIDC issuer, such as a CI OIDC token
+// or a Kubernetes projected service account token, for an access token of the service
+// account named by client_id. The token must satisfy one of the service account's workload
+// identity trusts: come from its issuer, be addressed to its audience and carry the claims
+// its conditions require.
+func (s *AuthorizationServer) federatedExchange(r *http.Request, subjectToken, issuer string) (*TokenResponse, *oauthError) {
+	if t := r.PostForm.Get("subject_token_type"); t != tokenTypeJWT && t != tokenTypeIDToken {
+		return nil, newError(errInvalidRequest, "subject_token_type must be a JWT or ID token")
+	}
+	if t := r.PostForm.Get("requested_token_type"); t != "" && t != tokenTypeAccessToken {
+		return nil, newError(errInvalidRequest, "only access tokens can be requested")
+	}
+	saID, err := uuid.Parse(r.PostForm.Get("client_id"))
+	if err != nil {
+		return nil, newError(errInvalidRequest, "client_id must be the service account ID")
+	}
+	sa, err := s.saRepo.GetByID(r.Context(), saID)
+	if err != nil {
+		return nil, newError(errInvalidClient, "unknown service account")
+	}
+
+	trusts, err := s.trustRepo.ListByServiceAccountID(r.Context(), saID)
+	if err != nil {
+		return nil, newError(errServerError, "")
+	}
+	// Only issuers the service account trusts are ever contacted
+	var provider *oidc.Provider
+	for i := range trusts {
+		trust := &trusts[i]
+		if trust.IssuerURL != issuer {
+			continue
+		}
+		if provider == nil {
+			if provider, err = s.federation.provider(r.Context(), issuer); err != nil {
+				s.logger.Warn("failed to discover federated issuer", zap.String("issuer", issuer), zap.Error(err))
+				return nil, newError(errInvalidGrant, "the subject token issuer cannot be reached")
+			}
+		}
+
+		idToken, err := provider.Verifier(&oidc.Config{ClientID: trust.Audience}).Verify(r.Context(), subjectToken)
+		if err != nil {
+			continue
+		}
+		var claims map[string]interface{}
+		if err := idToken.Claims(&claims); err != nil || !conditionsMet(trust.Conditions, claims) {
+			continue
+		}
+
+		if err := s.trustRepo.UpdateLastUsed(r.Context(), trust.ID); err != nil {
+			s.logger.Warn("failed to record workload identity trust use", zap.String("trust_id", trust.ID.String()), zap.Error(err))
+		}
+		s.logger.Info("federated workload identity",
+			zap.String("service_account_id", saID.String()),
+			zap.String("trust_id", trust.ID.String()),
+			zap.String("issuer", issuer),
+			zap.String("subject", idToken.Subject),
+		)
+		return s.issueServiceAccountToken(r, sa)
+	}
+	return nil, newError(errInvalidGrant, "the subject token satisfies no workload identity trust of the service account")
+}
+
+// conditionsMet reports whether the claims have every value the conditions require. A
+// value ending in * matches claims starting with the rest.
+func conditionsMet(conditions model.Labels, claims map[string]interface{}) bool {
+	for name, want := range conditions {
+		got, ok := claimValue(claims, name)
+		if !ok {
+			return false
+		}
+		if prefix, wildcard := strings.CutSuffix(want, "*"); wildcard {
+			if !strings.HasPrefix(got, prefix) {
+				return false
+			}
+		} else if got != want {
+			return false
+		}
+	}
+	return true
+}
+
+// claimValue returns the scalar claim with the given name. Nested claims are named by their
+// path joined with dots, as in kubernetes.io.namespace; claim names may contain dots
+// themselves.
+func claimValue(claims map[string]interface{}, name string) (string, bool) {
+	if v, ok := claims[name]; ok {
+		switch v := v.(type) {
+		case string:
+			return v, true
+		case bool:
+			return strconv.FormatBool(v), true
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), true
+		}
+		return "", false
+	}
+	for i := range len(name) {
+		if name[i] != '.' {
+			continue
+		}
+		if nested, ok := claims[name[:i]].(map[string]interface{}); ok {
+			if v, ok := claimValue(nested, name[i+1:]); ok {
+				return v, true
+			}
+		}
+	}
+	return "", false
+}
+
+// unverifiedIssuer returns the iss claim of a JWT without verifying it, or "" when the
+// token is not a JWT.
+func unverifiedIssuer(token string) string {
+	claims := &jwt.RegisteredClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil {
+		return ""
+	}
+	return claims.Issuer
+}
@@ -0,0 +1,196 @@
+package oauthserver
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bernardoforcillo/authlayer/internal/model"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testIssuer is a stand-in OIDC issuer serving discovery and the JWKS of one RSA key.
+type testIssuer struct {
+	*httptest.Server
+	key         *rsa.PrivateKey
+	discoveries atomic.Int32
+	// release, when set, holds discovery requests until it is closed.
+	release chan struct{}
+	fail    atomic.Bool
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	iss := &testIssuer{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		iss.discoveries.Add(1)
+		if iss.release != nil {
+			<-iss.release
+		}
+		if iss.fail.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                                iss.URL,
+			"jwks_uri":                              iss.URL + "/jwks",
+			"authorization_endpoint":                iss.URL + "/authorize",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"alg": "RS256",
+				"use": "sig",
+				"kid": "test",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	iss.Server = httptest.NewServer(mux)
+	t.Cleanup(func() {
+		if iss.release != nil {
+			select {
+			case <-iss.release:
+			default:
+				close(iss.release)
+			}
+		}
+		iss.Close()
+	})
+	return iss
+}
+
+func (iss *testIssuer) token(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	claims["iss"] = iss.URL
+	claims["iat"] = time.Now().Unix()
+	claims["exp"] = time.Now().Add(time.Minute).Unix()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "test"
+	signed, err := token.SignedString(iss.key)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestFederatedIssuerVerifiesTokens(t *testing.T) {
+	iss := newTestIssuer(t)
+	f := &federatedIssuers{}
+	ctx := context.Background()
+
+	provider, err := f.provider(ctx, iss.URL)
+	require.NoError(t, err)
+
+	token := iss.token(t, jwt.MapClaims{
+		"sub": "system:serviceaccount:ci:deployer",
+		"aud": "authlayer",
+		"kubernetes.io": map[string]interface{}{
+			"namespace": "ci",
+		},
+	})
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: "authlayer"}).Verify(ctx, token)
+	require.NoError(t, err)
+	var claims map[string]interface{}
+	require.NoError(t, idToken.Claims(&claims))
+	assert.True(t, conditionsMet(model.Labels{"kubernetes.io.namespace": "ci", "sub": "system:serviceaccount:ci:*"}, claims))
+	assert.False(t, conditionsMet(model.Labels{"kubernetes.io.namespace": "prod"}, claims))
+
+	_, err = provider.Verifier(&oidc.Config{ClientID: "other"}).Verify(ctx, token)
+	assert.Error(t, err)
+	assert.Equal(t, iss.URL, unverifiedIssuer(token))
+}
+
+func TestFederatedIssuerDiscoveredOnce(t *testing.T) {
+	iss := newTestIssuer(t)
+	iss.release = make(chan struct{})
+	f := &federatedIssuers{}
+
+	var wg sync.WaitGroup
+	providers := make([]*oidc.Provider, 8)
+	for i := range providers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			providers[i], _ = f.provider(context.Background(), iss.URL)
+		}()
+	}
+	require.Eventually(t, func() bool { return iss.discoveries.Load() == 1 }, time.Second, 10*time.Millisecond)
+	close(iss.release)
+	wg.Wait()
+
+	assert.EqualValues(t, 1, iss.discoveries.Load())
+	for _, p := range providers {
+		assert.NotNil(t, p)
+		assert.Same(t, providers[0], p)
+	}
+}
+
+func TestSlowFederatedIssuerDoesNotBlockOthers(t *testing.T) {
+	slow := newTestIssuer(t)
+	slow.release = make(chan struct{})
+	fast := newTestIssuer(t)
+	f := &federatedIssuers{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := f.provider(ctx, slow.URL)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := f.provider(context.Background(), fast.URL)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("discovery of one issuer waited on another")
+	}
+
+	// The abandoned discovery carries on for the next exchange
+	close(slow.release)
+	provider, err := f.provider(context.Background(), slow.URL)
+	require.NoError(t, err)
+	assert.NotNil(t, provider)
+	assert.EqualValues(t, 1, slow.discoveries.Load())
+}
+
+func TestFailedFederatedDiscoveryIsCached(t *testing.T) {
+	iss := newTestIssuer(t)
+	iss.fail.Store(true)
+	f := &federatedIssuers{}
+
+	for range 3 {
+		_, err := f.provider(context.Background(), iss.URL)
+		require.Error(t, err)
+	}
+	assert.EqualValues(t, 1, iss.discoveries.Load())
+
+	// Once the failure is old enough the issuer is contacted again
+	f.issuers[iss.URL].failedAt = time.Now().Add(-discoveryRetryAfter)
+	iss.fail.Store(false)
+	_, err := f.provider(context.Background(), iss.URL)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, iss.discoveries.Load())
+}
@@ -0,0 +1,142 @@
+// Package oauthserver implements the HTTP endpoints through which other services consume
+// authlayer as an authorization server.
+package oauthserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/bernardoforcillo/authlayer/internal/auth"
+	"github.com/bernardoforcillo/authlayer/internal/middleware"
+	"github.com/bernardoforcillo/authlayer/internal/model"
+)
+
+// IntrospectionResponse is the RFC 7662 introspection response. TokenType is the
+// authorization scheme the token is presented with: Bearer, ApiKey, ServiceKey or
+// ServiceAssertion.
+type IntrospectionResponse struct {
+	Active        bool        `json:"active"`
+	TokenType     string      `json:"token_type,omitempty"`
+	Subject       string      `json:"sub,omitempty"`
+	PrincipalType string      `json:"principal_type,omitempty"`
+	Email         string      `json:"email,omitempty"`
+	Scope         string      `json:"scope,omitempty"`
+	ClientID      string      `json:"client_id,omitempty"`
+	OrgID         string      `json:"org_id,omitempty"`
+	Actor         *auth.Actor `json:"act,omitempty"`
+	Audience      []string    `json:"aud,omitempty"`
+	Issuer        string      `json:"iss,omitempty"`
+	ExpiresAt     int64       `json:"exp,omitempty"`
+	IssuedAt      int64       `json:"iat,omitempty"`
+}
+
+// IntrospectionHandler serves RFC 7662 token introspection for access tokens, API keys,
+// and service account keys and assertions, so services can validate credentials they
+// cannot verify locally.
+type IntrospectionHandler struct {
+	authenticator *middleware.AuthInterceptor
+	jwtManager    *auth.JWTManager
+}
+
+// NewIntrospectionHandler creates an introspection handler. Callers must authenticate with
+// any credential the API accepts.
+func NewIntrospectionHandler(authenticator *middleware.AuthInterceptor, jwtManager *auth.JWTManager) *IntrospectionHandler {
+	return &IntrospectionHandler{authenticator: authenticator, jwtManager: jwtManager}
+}
+
+func (h *IntrospectionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if _, err := h.authenticator.Authenticate(r.Context(), r.Header.Get("Authorization"), h.authenticator.Audience()); err != nil {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="authlayer"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+
+	token := r.PostForm.Get("token")
+	if token == "" {
+		http.Error(w, "token is required", http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, h.introspect(r, token, r.PostForm.Get("token_type_hint")))
+}
+
+func (h *IntrospectionHandler) introspect(r *http.Request, token, hint string) IntrospectionResponse {
+	switch strings.ToLower(hint) {
+	case "", "bearer", "access_token":
+		claims, err := h.jwtManager.ValidateAccessToken(token)
+		if err != nil {
+			return IntrospectionResponse{}
+		}
+		resp := IntrospectionResponse{
+			Active:        true,
+			TokenType:     "Bearer",
+			Subject:       claims.UserID,
+			PrincipalType: string(model.PrincipalTypeUser),
+			Email:         claims.Email,
+			Scope:         claims.Scope,
+			ClientID:      claims.ClientID,
+			Audience:      claims.Audience,
+			Issuer:        claims.Issuer,
+			Actor:         claims.Actor,
+		}
+		if claims.ServiceAccountID != "" {
+			resp.Subject = claims.ServiceAccountID
+			resp.PrincipalType = string(model.PrincipalTypeServiceAccount)
+			resp.OrgID = claims.OrgID
+		}
+		if claims.ExpiresAt != nil {
+			resp.ExpiresAt = claims.ExpiresAt.Unix()
+		}
+		if claims.IssuedAt != nil {
+			resp.IssuedAt = claims.IssuedAt.Unix()
+		}
+		return resp
+
+	case "apikey", "api_key":
+		ctx, err := h.authenticator.Authenticate(r.Context(), "ApiKey "+token, h.authenticator.Audience())
+		if err != nil {
+			return IntrospectionResponse{}
+		}
+		userID, _ := middleware.UserIDFromContext(ctx)
+		return IntrospectionResponse{
+			Active:        true,
+			TokenType:     "ApiKey",
+			Subject:       userID.String(),
+			PrincipalType: string(model.PrincipalTypeUser),
+			Scope:         strings.Join(middleware.APIScopesFromContext(ctx), " "),
+		}
+
+	case "servicekey", "service_key", "serviceassertion", "service_assertion":
+		scheme := "ServiceKey"
+		if strings.Contains(strings.ToLower(hint), "assertion") {
+			scheme = "ServiceAssertion"
+		}
+		ctx, err := h.authenticator.Authenticate(r.Context(), scheme+" "+token, h.authenticator.Audience())
+		if err != nil {
+			return IntrospectionResponse{}
+		}
+		saID, _ := middleware.ServiceAccountIDFromContext(ctx)
+		return IntrospectionResponse{
+			Active:        true,
+			TokenType:     scheme,
+			Subject:       saID.String(),
+			PrincipalType: string(model.PrincipalTypeServiceAccount),
+		}
+	}
+	return IntrospectionResponse{}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,22 @@
+package oauthserver
+
+import (
+	"net/http"
+
+	"github.com/bernardoforcillo/authlayer/internal/auth"
+)
+
+// JWKSHandler publishes the keys access tokens are signed with.
+type JWKSHandler struct {
+	jwtManager *auth.JWTManager
+}
+
+// NewJWKSHandler creates a JWKS handler.
+func NewJWKSHandler(jwtManager *auth.JWTManager) *JWKSHandler {
+	return &JWKSHandler{jwtManager: jwtManager}
+}
+
+func (h *JWKSHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	writeJSON(w, h.jwtManager.JWKS())
+}
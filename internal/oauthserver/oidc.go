@@ -0,0 +1,186 @@
+package oauthserver
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/bernardoforcillo/authlayer/internal/auth"
+	"github.com/bernardoforcillo/authlayer/internal/model"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// OpenID Connect scopes. Clients must be registered with a scope to request it.
+const (
+	scopeOpenID  = "openid"
+	scopeProfile = "profile"
+	scopeEmail   = "email"
+	scopeOrgs    = "orgs"
+	scopeRoles   = "roles"
+)
+
+// DiscoveryDocument is the OpenID Provider metadata (OpenID Connect Discovery 1.0), which
+// also serves as RFC 8414 authorization server metadata.
+type DiscoveryDocument struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserInfoEndpoint                  string   `json:"userinfo_endpoint,omitempty"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	IntrospectionEndpoint             string   `json:"introspection_endpoint"`
+	DeviceAuthorizationEndpoint       string   `json:"device_authorization_endpoint,omitempty"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported,omitempty"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported,omitempty"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported,omitempty"`
+}
+
+// Discovery serves /.well-known/openid-configuration and
+// /.well-known/oauth-authorization-server. Without OpenID Connect only the RFC 8414
+// metadata is described.
+func (s *AuthorizationServer) Discovery(w http.ResponseWriter, r *http.Request) {
+	issuer := s.jwtManager.Issuer()
+	doc := DiscoveryDocument{
+		Issuer:                            issuer,
+		AuthorizationEndpoint:             issuer + "/oauth/authorize",
+		TokenEndpoint:                     issuer + "/oauth/token",
+		JWKSURI:                           issuer + "/.well-known/jwks.json",
+		IntrospectionEndpoint:             issuer + "/oauth/introspect",
+		ScopesSupported:                   []string{scopeProfile, scopeEmail, scopeOrgs, scopeRoles},
+		ResponseTypesSupported:            []string{"code"},
+		GrantTypesSupported:               []string{"authorization_code", "refresh_token", grantTypeClientCredentials, grantTypeJWTBearer, grantTypeTokenExchange},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{"S256"},
+	}
+	if s.OIDCEnabled() {
+		doc.UserInfoEndpoint = issuer + "/userinfo"
+		doc.ScopesSupported = append([]string{scopeOpenID}, doc.ScopesSupported...)
+		doc.SubjectTypesSupported = []string{"public"}
+		doc.IDTokenSigningAlgValuesSupported = []string{s.jwtManager.IDTokenSigningAlg()}
+		doc.ClaimsSupported = []string{
+			"iss", "sub", "aud", "exp", "iat", "auth_time", "nonce", "amr",
+			"email", "email_verified", "name", "picture", "orgs", "roles",
+		}
+	}
+	if s.DeviceFlowEnabled() {
+		doc.DeviceAuthorizationEndpoint = issuer + "/oauth/device/code"
+		doc.GrantTypesSupported = append(doc.GrantTypesSupported, grantTypeDeviceCode)
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	writeJSON(w, doc)
+}
+
+// UserInfoResponse is the OpenID Connect UserInfo response.
+type UserInfoResponse struct {
+	Subject string `json:"sub"`
+	auth.UserClaims
+}
+
+// UserInfo serves the OpenID Connect UserInfo endpoint. It takes an access token issued
+// to a client with the openid scope and returns the claims its scopes release.
+func (s *AuthorizationServer) UserInfo(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		writeBearerError(w, http.StatusUnauthorized, "", "")
+		return
+	}
+	claims, err := s.jwtManager.ValidateAccessToken(token)
+	if err != nil {
+		writeBearerError(w, http.StatusUnauthorized, "invalid_token", "the access token is invalid or expired")
+		return
+	}
+	scopes := claims.Scopes()
+	if !slices.Contains(scopes, scopeOpenID) {
+		writeBearerError(w, http.StatusForbidden, "insufficient_scope", "the access token lacks the openid scope")
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		writeBearerError(w, http.StatusUnauthorized, "invalid_token", "invalid user ID in token")
+		return
+	}
+	user, err := s.userRepo.GetByID(r.Context(), userID)
+	if err != nil || user.Status == model.UserStatusBanned {
+		writeBearerError(w, http.StatusUnauthorized, "invalid_token", "the user is no longer active")
+		return
+	}
+	userClaims, err := s.userClaims(r.Context(), user, scopes)
+	if err != nil {
+		s.logger.Error("failed to build userinfo claims", zap.String("user_id", claims.UserID), zap.Error(err))
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, UserInfoResponse{Subject: claims.UserID, UserClaims: userClaims})
+}
+
+// userClaims returns the claims about the user released by the granted scopes.
+func (s *AuthorizationServer) userClaims(ctx context.Context, user *model.User, scopes []string) (auth.UserClaims, error) {
+	var claims auth.UserClaims
+	if slices.Contains(scopes, scopeProfile) {
+		claims.Name = user.Name
+		if user.Avatar != nil {
+			claims.Picture = *user.Avatar
+		}
+	}
+	if slices.Contains(scopes, scopeEmail) {
+		verified := user.EmailVerified
+		claims.Email = user.Email
+		claims.EmailVerified = &verified
+	}
+
+	wantOrgs, wantRoles := slices.Contains(scopes, scopeOrgs), slices.Contains(scopes, scopeRoles)
+	if !wantOrgs && !wantRoles {
+		return claims, nil
+	}
+	memberships, err := s.orgMemberRepo.ListAllByUserID(ctx, user.ID)
+	if err != nil {
+		return claims, err
+	}
+	if wantOrgs {
+		claims.Orgs = make([]auth.OrgClaim, 0, len(memberships))
+		for _, m := range memberships {
+			claims.Orgs = append(claims.Orgs, auth.OrgClaim{
+				ID:   m.OrgID.String(),
+				Slug: m.Organization.Slug,
+				Name: m.Organization.Name,
+			})
+		}
+	}
+	if wantRoles {
+		claims.Roles = make(map[string]string, len(memberships))
+		for _, m := range memberships {
+			claims.Roles[m.OrgID.String()] = m.Role.Name
+		}
+	}
+	return claims, nil
+}
+
+// writeBearerError writes an RFC 6750 section 3 error for a protected resource request.
+func writeBearerError(w http.ResponseWriter, status int, code, description string) {
+	challenge := `Bearer realm="authlayer"`
+	if code != "" {
+		challenge += `, error="` + code + `"`
+	}
+	if description != "" {
+		challenge += `, error_description="` + description + `"`
+	}
+	w.Header().Set("WWW-Authenticate", challenge)
+	w.WriteHeader(status)
+}
@@ -0,0 +1,208 @@
+package oauthserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bernardoforcillo/authlayer/internal/auth"
+	"github.com/bernardoforcillo/authlayer/internal/config"
+	"github.com/bernardoforcillo/authlayer/internal/middleware"
+	"github.com/bernardoforcillo/authlayer/internal/model"
+	"github.com/bernardoforcillo/authlayer/internal/repository"
+
+	"go.uber.org/zap"
+)
+
+const codePruneEvery = 5 * time.Minute
+
+// AuthorizationServer implements the OAuth 2.0 and OpenID Connect endpoints through which
+// first-party and partner apps sign users in.
+type AuthorizationServer struct {
+	authenticator  *middleware.AuthInterceptor
+	jwtManager     *auth.JWTManager
+	clientRepo     repository.OAuthClientRepository
+	codeRepo       repository.OAuthAuthorizationCodeRepository
+	deviceCodeRepo repository.OAuthDeviceCodeRepository
+	consentRepo    repository.OAuthConsentRepository
+	sessionRepo    repository.SessionRepository
+	userRepo       repository.UserRepository
+	orgMemberRepo  repository.OrganizationMemberRepository
+	saRepo         repository.ServiceAccountRepository
+	saKeyRepo      repository.ServiceAccountKeyRepository
+	trustRepo      repository.WorkloadIdentityTrustRepository
+
+	loginURL      string
+	consentURL    string
+	sessionCookie string
+	codeTTL       time.Duration
+	logger        *zap.Logger
+
+	deviceVerificationURL string
+	deviceCodeTTL         time.Duration
+	devicePollInterval    time.Duration
+
+	exchangePolicies []config.TokenExchangePolicy
+	federation       federatedIssuers
+}
+
+// NewAuthorizationServer creates the authorization server.
+func NewAuthorizationServer(
+	cfg *config.Config,
+	authenticator *middleware.AuthInterceptor,
+	jwtManager *auth.JWTManager,
+	clientRepo repository.OAuthClientRepository,
+	codeRepo repository.OAuthAuthorizationCodeRepository,
+	deviceCodeRepo repository.OAuthDeviceCodeRepository,
+	consentRepo repository.OAuthConsentRepository,
+	sessionRepo repository.SessionRepository,
+	userRepo repository.UserRepository,
+	orgMemberRepo repository.OrganizationMemberRepository,
+	saRepo repository.ServiceAccountRepository,
+	saKeyRepo repository.ServiceAccountKeyRepository,
+	trustRepo repository.WorkloadIdentityTrustRepository,
+	logger *zap.Logger,
+) *AuthorizationServer {
+	return &AuthorizationServer{
+		authenticator:  authenticator,
+		jwtManager:     jwtManager,
+		clientRepo:     clientRepo,
+		codeRepo:       codeRepo,
+		deviceCodeRepo: deviceCodeRepo,
+		consentRepo:    consentRepo,
+		sessionRepo:    sessionRepo,
+		userRepo:       userRepo,
+		orgMemberRepo:  orgMemberRepo,
+		saRepo:         saRepo,
+		saKeyRepo:      saKeyRepo,
+		trustRepo:      trustRepo,
+		loginURL:       cfg.OAuthLoginURL,
+		consentURL:     cfg.OAuthConsentURL,
+		sessionCookie:  cfg.OAuthSessionCookie,
+		codeTTL:        cfg.OAuthCodeTTL,
+		logger:         logger,
+
+		deviceVerificationURL: cfg.OAuthDeviceVerificationURL,
+		deviceCodeTTL:         cfg.OAuthDeviceCodeTTL,
+		devicePollInterval:    cfg.OAuthDevicePollInterval,
+
+		exchangePolicies: cfg.TokenExchangePolicies,
+	}
+}
+
+// OIDCEnabled reports whether the OpenID Connect endpoints and the openid scope are
+// available, which requires an asymmetric JWT signing key for ID tokens.
+func (s *AuthorizationServer) OIDCEnabled() bool {
+	return s.jwtManager.CanSignIDTokens()
+}
+
+// DeviceFlowEnabled reports whether the device authorization grant is configured.
+func (s *AuthorizationServer) DeviceFlowEnabled() bool {
+	return s.deviceVerificationURL != ""
+}
+
+// RunPruner deletes expired authorization and device codes until ctx is cancelled.
+func (s *AuthorizationServer) RunPruner(ctx context.Context) {
+	ticker := time.NewTicker(codePruneEvery)
+	defer ticker.Stop()
+	for {
+		if err := s.codeRepo.DeleteExpired(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Error("failed to prune authorization codes", zap.Error(err))
+		}
+		if err := s.deviceCodeRepo.DeleteExpired(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Error("failed to prune device codes", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// authenticateClient identifies the client of a token request. Confidential clients
+// authenticate with HTTP Basic (client_secret_basic) or form fields (client_secret_post);
+// public clients only name themselves.
+func (s *AuthorizationServer) authenticateClient(r *http.Request) (*model.OAuthClient, *oauthError) {
+	clientID, secret, basic := r.BasicAuth()
+	if basic {
+		// RFC 6749 section 2.3.1: credentials are form-encoded before Basic encoding
+		var err1, err2 error
+		clientID, err1 = url.QueryUnescape(clientID)
+		secret, err2 = url.QueryUnescape(secret)
+		if err1 != nil || err2 != nil {
+			return nil, newError(errInvalidClient, "malformed client credentials")
+		}
+		if id := r.PostForm.Get("client_id"); id != "" && id != clientID {
+			return nil, newError(errInvalidRequest, "client_id does not match the authenticated client")
+		}
+	} else {
+		clientID = r.PostForm.Get("client_id")
+		secret = r.PostForm.Get("client_secret")
+	}
+	if clientID == "" {
+		return nil, newError(errInvalidClient, "client authentication is required")
+	}
+
+	client, err := s.clientRepo.GetByClientID(r.Context(), clientID)
+	if err != nil {
+		return nil, newError(errInvalidClient, "unknown client")
+	}
+	if client.Public {
+		return client, nil
+	}
+	if secret == "" || client.SecretHash == nil ||
+		subtle.ConstantTimeCompare([]byte(auth.HashToken(secret)), []byte(*client.SecretHash)) != 1 {
+		return nil, newError(errInvalidClient, "invalid client credentials")
+	}
+	return client, nil
+}
+
+// requestedScopes resolves the scope parameter against the client's allowed scopes. An
+// empty parameter requests all of them.
+func (s *AuthorizationServer) requestedScopes(client *model.OAuthClient, scope string) ([]string, *oauthError) {
+	requested := strings.Fields(scope)
+	if len(requested) == 0 {
+		scopes := slices.Clone([]string(client.Scopes))
+		if !s.OIDCEnabled() {
+			scopes = slices.DeleteFunc(scopes, func(sc string) bool { return sc == scopeOpenID })
+		}
+		return scopes, nil
+	}
+	for _, sc := range requested {
+		if !slices.Contains(client.Scopes, sc) {
+			return nil, newError(errInvalidScope, "scope "+sc+" is not allowed for this client")
+		}
+		if sc == scopeOpenID && !s.OIDCEnabled() {
+			return nil, newError(errInvalidScope, "OpenID Connect is not enabled")
+		}
+	}
+	return dedupe(requested), nil
+}
+
+func dedupe(values []string) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if !slices.Contains(out, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// verifyPKCE checks an RFC 7636 code verifier against its S256 challenge.
+func verifyPKCE(verifier, challenge string) bool {
+	if len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	computed := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(challenge)) == 1
+}
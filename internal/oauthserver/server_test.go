@@ -0,0 +1,327 @@
+package oauthserver
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bernardoforcillo/authlayer/internal/auth"
+	"github.com/bernardoforcillo/authlayer/internal/config"
+	"github.com/bernardoforcillo/authlayer/internal/middleware"
+	"github.com/bernardoforcillo/authlayer/internal/model"
+	"github.com/bernardoforcillo/authlayer/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const testIssuerURL = "https://auth.example.com"
+
+type memoryClients struct {
+	repository.OAuthClientRepository
+	clients map[string]*model.OAuthClient
+}
+
+func (r *memoryClients) GetByClientID(_ context.Context, clientID string) (*model.OAuthClient, error) {
+	if c, ok := r.clients[clientID]; ok {
+		return c, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+type memoryCodes struct {
+	repository.OAuthAuthorizationCodeRepository
+	mu    sync.Mutex
+	codes map[string]*model.OAuthAuthorizationCode
+}
+
+func (r *memoryCodes) Create(_ context.Context, code *model.OAuthAuthorizationCode) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.codes[code.CodeHash] = code
+	return nil
+}
+
+func (r *memoryCodes) Consume(_ context.Context, codeHash string) (*model.OAuthAuthorizationCode, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	code, ok := r.codes[codeHash]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	delete(r.codes, codeHash)
+	return code, nil
+}
+
+type memoryConsents struct {
+	repository.OAuthConsentRepository
+	consents map[string]*model.OAuthConsent
+}
+
+func (r *memoryConsents) Get(_ context.Context, userID uuid.UUID, clientID string) (*model.OAuthConsent, error) {
+	if c, ok := r.consents[userID.String()+"/"+clientID]; ok {
+		return c, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+type memorySessions struct {
+	repository.SessionRepository
+	mu       sync.Mutex
+	sessions map[string]*model.Session
+}
+
+func (r *memorySessions) Create(_ context.Context, session *model.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sessions[session.TokenHash] = session
+	return nil
+}
+
+func (r *memorySessions) GetByTokenHash(_ context.Context, tokenHash string) (*model.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s, ok := r.sessions[tokenHash]; ok {
+		copied := *s
+		return &copied, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memorySessions) RevokeByTokenHash(_ context.Context, tokenHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s, ok := r.sessions[tokenHash]; ok {
+		s.Revoked = true
+	}
+	return nil
+}
+
+func (r *memorySessions) RevokeByFamily(_ context.Context, family string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, s := range r.sessions {
+		if s.TokenFamily == family {
+			s.Revoked = true
+		}
+	}
+	return nil
+}
+
+type memoryUsers struct {
+	repository.UserRepository
+	users map[uuid.UUID]*model.User
+}
+
+func (r *memoryUsers) GetByID(_ context.Context, id uuid.UUID) (*model.User, error) {
+	if u, ok := r.users[id]; ok {
+		return u, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+type memoryServiceAccounts struct {
+	repository.ServiceAccountRepository
+	accounts map[uuid.UUID]*model.ServiceAccount
+}
+
+func (r *memoryServiceAccounts) GetByID(_ context.Context, id uuid.UUID) (*model.ServiceAccount, error) {
+	if sa, ok := r.accounts[id]; ok {
+		return sa, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryServiceAccounts) UpdateLastAuthenticated(context.Context, uuid.UUID) error { return nil }
+
+type memoryServiceAccountKeys struct {
+	repository.ServiceAccountKeyRepository
+	mu   sync.Mutex
+	keys map[uuid.UUID]*model.ServiceAccountKey
+}
+
+func (r *memoryServiceAccountKeys) GetByID(_ context.Context, id uuid.UUID) (*model.ServiceAccountKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if key, ok := r.keys[id]; ok {
+		copied := *key
+		return &copied, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryServiceAccountKeys) GetByKeyHash(_ context.Context, hash string) (*model.ServiceAccountKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, key := range r.keys {
+		if key.KeyHash == hash {
+			copied := *key
+			return &copied, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryServiceAccountKeys) UpdateLastUsed(context.Context, uuid.UUID) error { return nil }
+
+// testServer is an AuthorizationServer over in-memory repositories.
+type testServer struct {
+	*AuthorizationServer
+	jwtManager      *auth.JWTManager
+	clients         *memoryClients
+	codes           *memoryCodes
+	consents        *memoryConsents
+	sessions        *memorySessions
+	users           *memoryUsers
+	serviceAccounts *memoryServiceAccounts
+	saKeys          *memoryServiceAccountKeys
+}
+
+func newTestServer(t *testing.T, configure ...func(*config.Config)) *testServer {
+	t.Helper()
+	cfg := &config.Config{
+		OAuthIssuer:            testIssuerURL,
+		JWTAccessSecret:        "access",
+		JWTRefreshSecret:       "refresh",
+		JWTAccessExpiration:    time.Minute,
+		JWTRefreshExpiration:   time.Hour,
+		ServiceAccountTokenTTL: time.Minute,
+		OAuthCodeTTL:           time.Minute,
+		OAuthLoginURL:          "https://app.example.com/login",
+		OAuthConsentURL:        "https://app.example.com/consent",
+		OAuthSessionCookie:     "session",
+	}
+	for _, c := range configure {
+		c(cfg)
+	}
+	jwtManager, err := auth.NewJWTManager(cfg)
+	require.NoError(t, err)
+
+	ts := &testServer{
+		jwtManager: jwtManager,
+		clients:    &memoryClients{clients: make(map[string]*model.OAuthClient)},
+		codes:      &memoryCodes{codes: make(map[string]*model.OAuthAuthorizationCode)},
+		consents:   &memoryConsents{consents: make(map[string]*model.OAuthConsent)},
+		sessions:   &memorySessions{sessions: make(map[string]*model.Session)},
+		users:      &memoryUsers{users: make(map[uuid.UUID]*model.User)},
+
+		serviceAccounts: &memoryServiceAccounts{accounts: make(map[uuid.UUID]*model.ServiceAccount)},
+		saKeys:          &memoryServiceAccountKeys{keys: make(map[uuid.UUID]*model.ServiceAccountKey)},
+	}
+	authenticator := middleware.NewAuthInterceptor(jwtManager, nil, ts.saKeys, nil, middleware.NewMemoryNonceStore(), time.Minute, nil)
+	ts.AuthorizationServer = NewAuthorizationServer(
+		cfg, authenticator, jwtManager,
+		ts.clients, ts.codes, nil, ts.consents, ts.sessions, ts.users,
+		nil, ts.serviceAccounts, ts.saKeys, nil, zap.NewNop(),
+	)
+	return ts
+}
+
+// addUser registers an active user and returns a session access token for them.
+func (ts *testServer) addUser(t *testing.T) (*model.User, string) {
+	t.Helper()
+	user := &model.User{Base: model.Base{ID: uuid.New()}, Email: "alice@example.com", Status: model.UserStatusActive}
+	ts.users.users[user.ID] = user
+	tokens, err := ts.jwtManager.GenerateTokenPair(user.ID.String(), user.Email, "", auth.Authentication{})
+	require.NoError(t, err)
+	return user, tokens.AccessToken
+}
+
+// addClient registers a confidential client with the secret "secret".
+func (ts *testServer) addClient(client *model.OAuthClient) *model.OAuthClient {
+	if !client.Public {
+		hash := auth.HashToken("secret")
+		client.SecretHash = &hash
+	}
+	ts.clients.clients[client.ClientID] = client
+	return client
+}
+
+// addServiceAccount registers an active service account allowed the given scopes.
+func (ts *testServer) addServiceAccount(scopes ...string) *model.ServiceAccount {
+	sa := &model.ServiceAccount{
+		Base:   model.Base{ID: uuid.New()},
+		OrgID:  uuid.New(),
+		Status: model.ServiceAccountStatusActive,
+		Scopes: model.StringList(scopes),
+	}
+	ts.serviceAccounts.accounts[sa.ID] = sa
+	return sa
+}
+
+// addSecretKey gives the service account a secret key and returns the secret.
+func (ts *testServer) addSecretKey(sa *model.ServiceAccount) string {
+	secret := rand.Text()
+	key := &model.ServiceAccountKey{
+		Base:             model.Base{ID: uuid.New()},
+		ServiceAccountID: sa.ID,
+		KeyType:          model.ServiceAccountKeyTypeSecret,
+		KeyHash:          auth.HashToken(secret),
+		ServiceAccount:   *sa,
+	}
+	ts.saKeys.keys[key.ID] = key
+	return secret
+}
+
+// addKeyPair gives the service account a public key and returns the key with the PEM
+// encoded private key.
+func (ts *testServer) addKeyPair(t *testing.T, sa *model.ServiceAccount) (*model.ServiceAccountKey, string) {
+	t.Helper()
+	private, public, err := auth.GenerateKeyPair()
+	require.NoError(t, err)
+	encoded, err := auth.EncodePublicKey(public)
+	require.NoError(t, err)
+	key := &model.ServiceAccountKey{
+		Base:             model.Base{ID: uuid.New()},
+		ServiceAccountID: sa.ID,
+		KeyType:          model.ServiceAccountKeyTypePublicKey,
+		KeyHash:          "jwk:" + rand.Text(),
+		PublicKey:        encoded,
+		Algorithm:        "ES256",
+		ServiceAccount:   *sa,
+	}
+	ts.saKeys.keys[key.ID] = key
+	return key, private
+}
+
+// authorize calls the authorization endpoint as the holder of session and returns the
+// redirect location.
+func (ts *testServer) authorize(t *testing.T, session string, params url.Values) (int, *url.URL) {
+	t.Helper()
+	r := httptest.NewRequest(http.MethodGet, "/oauth/authorize?"+params.Encode(), nil)
+	if session != "" {
+		r.Header.Set("Authorization", "Bearer "+session)
+	}
+	w := httptest.NewRecorder()
+	ts.Authorize(w, r)
+	location, err := url.Parse(w.Header().Get("Location"))
+	require.NoError(t, err)
+	return w.Code, location
+}
+
+func (ts *testServer) token(form url.Values) (int, map[string]interface{}) {
+	w, body := postToken(ts.AuthorizationServer, form)
+	return w.Code, body
+}
+
+// pkcePair returns an RFC 7636 code verifier and its S256 challenge.
+func pkcePair() (verifier, challenge string) {
+	verifier = rand.Text() + rand.Text()
+	sum := sha256.Sum256([]byte(verifier))
+	return verifier, base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func scopeOf(body map[string]interface{}) []string {
+	scope, _ := body["scope"].(string)
+	return strings.Fields(scope)
+}
@@ -0,0 +1,224 @@
+package oauthserver
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bernardoforcillo/authlayer/internal/auth"
+	"github.com/bernardoforcillo/authlayer/internal/model"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// TokenResponse is the RFC 6749 section 5.1 access token response.
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+	IDToken      string `json:"id_token,omitempty"`
+	// Set by token exchange (RFC 8693 section 2.2.1)
+	IssuedTokenType string `json:"issued_token_type,omitempty"`
+}
+
+// Token serves the token endpoint (RFC 6749 section 3.2).
+func (s *AuthorizationServer) Token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		writeTokenError(w, newError(errInvalidRequest, "invalid form body"))
+		return
+	}
+
+	var (
+		resp *TokenResponse
+		oerr *oauthError
+	)
+	// Service accounts are not registered OAuth clients and authenticate on their own
+	switch grantType := r.PostForm.Get("grant_type"); grantType {
+	case grantTypeClientCredentials:
+		resp, oerr = s.clientCredentialsGrant(r)
+	case grantTypeJWTBearer:
+		resp, oerr = s.jwtBearerGrant(r)
+	case grantTypeTokenExchange:
+		resp, oerr = s.tokenExchangeGrant(r)
+	default:
+		resp, oerr = s.clientGrant(r, grantType)
+	}
+	if oerr != nil {
+		writeTokenError(w, oerr)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	writeJSON(w, resp)
+}
+
+// clientGrant serves the grants of registered OAuth clients.
+func (s *AuthorizationServer) clientGrant(r *http.Request, grantType string) (*TokenResponse, *oauthError) {
+	client, oerr := s.authenticateClient(r)
+	if oerr != nil {
+		return nil, oerr
+	}
+
+	switch grantType {
+	case "authorization_code":
+		return s.authorizationCodeGrant(r, client)
+	case "refresh_token":
+		return s.refreshTokenGrant(r, client)
+	case grantTypeDeviceCode:
+		if !s.DeviceFlowEnabled() {
+			return nil, newError(errUnsupportedGrantType, "the device authorization grant is not enabled")
+		}
+		return s.deviceCodeGrant(r, client)
+	case "":
+		return nil, newError(errInvalidRequest, "grant_type is required")
+	default:
+		return nil, newError(errUnsupportedGrantType, "unsupported grant_type "+grantType)
+	}
+}
+
+// authorizationCodeGrant redeems an authorization code (RFC 6749 section 4.1.3), checking
+// the PKCE verifier when the code was requested with a challenge.
+func (s *AuthorizationServer) authorizationCodeGrant(r *http.Request, client *model.OAuthClient) (*TokenResponse, *oauthError) {
+	code := r.PostForm.Get("code")
+	if code == "" {
+		return nil, newError(errInvalidRequest, "code is required")
+	}
+
+	// The code is deleted before it is checked, so a failed attempt also burns it
+	stored, err := s.codeRepo.Consume(r.Context(), auth.HashToken(code))
+	if err != nil {
+		return nil, newError(errInvalidGrant, "invalid or already used authorization code")
+	}
+	if stored.ClientID != client.ClientID {
+		return nil, newError(errInvalidGrant, "authorization code was issued to another client")
+	}
+	if time.Now().After(stored.ExpiresAt) {
+		return nil, newError(errInvalidGrant, "authorization code expired")
+	}
+	if uri := r.PostForm.Get("redirect_uri"); uri != stored.RedirectURI && (uri != "" || len(client.RedirectURIs) != 1) {
+		return nil, newError(errInvalidGrant, "redirect_uri does not match the authorization request")
+	}
+	if stored.CodeChallenge != "" && !verifyPKCE(r.PostForm.Get("code_verifier"), stored.CodeChallenge) {
+		return nil, newError(errInvalidGrant, "invalid code_verifier")
+	}
+
+	authn := auth.Authentication{Methods: stored.AMR}
+	if stored.AuthTime != nil {
+		authn.Time = *stored.AuthTime
+	}
+	return s.issueTokens(r, client, stored.UserID, "", stored.Scopes, authn, stored.Nonce)
+}
+
+// refreshTokenGrant rotates a refresh token (RFC 6749 section 6). As for first-party
+// sessions, presenting an already rotated token revokes the whole token family.
+func (s *AuthorizationServer) refreshTokenGrant(r *http.Request, client *model.OAuthClient) (*TokenResponse, *oauthError) {
+	refreshToken := r.PostForm.Get("refresh_token")
+	if refreshToken == "" {
+		return nil, newError(errInvalidRequest, "refresh_token is required")
+	}
+
+	claims, err := s.jwtManager.ValidateRefreshToken(refreshToken)
+	if err != nil {
+		return nil, newError(errInvalidGrant, "invalid refresh token")
+	}
+	if claims.ClientID != client.ClientID {
+		return nil, newError(errInvalidGrant, "refresh token was issued to another client")
+	}
+
+	tokenHash := auth.HashToken(refreshToken)
+	session, err := s.sessionRepo.GetByTokenHash(r.Context(), tokenHash)
+	if err != nil {
+		return nil, newError(errInvalidGrant, "invalid refresh token")
+	}
+	if session.Revoked {
+		s.logger.Warn("OAuth refresh token reuse detected, revoking family",
+			zap.String("family", session.TokenFamily),
+			zap.String("client_id", client.ClientID),
+			zap.String("user_id", session.UserID.String()),
+		)
+		_ = s.sessionRepo.RevokeByFamily(r.Context(), session.TokenFamily)
+		return nil, newError(errInvalidGrant, "refresh token reuse detected")
+	}
+	if err := s.sessionRepo.RevokeByTokenHash(r.Context(), tokenHash); err != nil {
+		return nil, newError(errServerError, "")
+	}
+
+	// Scopes can only narrow, and never exceed what the client is allowed today
+	scopes := claims.Scopes()
+	if requested := strings.Fields(r.PostForm.Get("scope")); len(requested) > 0 {
+		for _, scope := range requested {
+			if !slices.Contains(scopes, scope) {
+				return nil, newError(errInvalidScope, "scope "+scope+" was not granted")
+			}
+		}
+		scopes = dedupe(requested)
+	}
+	scopes = slices.DeleteFunc(scopes, func(scope string) bool { return !slices.Contains(client.Scopes, scope) })
+
+	return s.issueTokens(r, client, session.UserID, claims.TokenFamily, scopes, claims.Authentication(), "")
+}
+
+// issueTokens creates a token pair for the client acting on behalf of the user, and
+// records the refresh token as a session of the client. With the openid scope the response
+// also carries an ID token.
+func (s *AuthorizationServer) issueTokens(r *http.Request, client *model.OAuthClient, userID uuid.UUID, family string, scopes []string, authn auth.Authentication, nonce string) (*TokenResponse, *oauthError) {
+	user, err := s.userRepo.GetByID(r.Context(), userID)
+	if err != nil {
+		return nil, newError(errInvalidGrant, "user not found")
+	}
+	if user.Status == model.UserStatusBanned {
+		return nil, newError(errInvalidGrant, "account is banned")
+	}
+
+	tokens, err := s.jwtManager.GenerateClientTokenPair(user.ID.String(), user.Email, family, authn, auth.ClientGrant{
+		ClientID: client.ClientID,
+		Audience: client.AccessTokenAudience(),
+		Scopes:   scopes,
+	})
+	if err != nil {
+		return nil, newError(errServerError, "")
+	}
+
+	clientID := client.ClientID
+	err = s.sessionRepo.Create(r.Context(), &model.Session{
+		UserID:      user.ID,
+		TokenHash:   auth.HashToken(tokens.RefreshToken),
+		TokenFamily: tokens.TokenFamily,
+		ExpiresAt:   tokens.RefreshTokenExpiresAt,
+		ClientID:    &clientID,
+	})
+	if err != nil {
+		s.logger.Error("failed to store OAuth session", zap.String("client_id", client.ClientID), zap.Error(err))
+		return nil, newError(errServerError, "")
+	}
+
+	resp := &TokenResponse{
+		AccessToken:  tokens.AccessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(time.Until(tokens.AccessTokenExpiresAt).Round(time.Second).Seconds()),
+		RefreshToken: tokens.RefreshToken,
+		Scope:        strings.Join(scopes, " "),
+	}
+	if slices.Contains(scopes, scopeOpenID) && s.OIDCEnabled() {
+		userClaims, err := s.userClaims(r.Context(), user, scopes)
+		if err != nil {
+			s.logger.Error("failed to build ID token claims", zap.String("user_id", user.ID.String()), zap.Error(err))
+			return nil, newError(errServerError, "")
+		}
+		resp.IDToken, err = s.jwtManager.GenerateIDToken(user.ID.String(), client.ClientID, nonce, authn, userClaims)
+		if err != nil {
+			return nil, newError(errServerError, "")
+		}
+	}
+	return resp, nil
+}
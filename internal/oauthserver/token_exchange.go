@@ -0,0 +1,145 @@
+package oauthserver
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bernardoforcillo/authlayer/internal/config"
+	"github.com/bernardoforcillo/authlayer/internal/model"
+
+	"github.com/google/uuid"
+)
+
+const (
+	grantTypeTokenExchange = "urn:ietf:params:oauth:grant-type:token-exchange"
+
+	tokenTypeAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+	tokenTypeJWT         = "urn:ietf:params:oauth:token-type:jwt"
+)
+
+// tokenExchangeGrant lets a service account trade the access token of the user it serves
+// for a narrower one addressed to another service (RFC 8693). The service account
+// authenticates as for the client_credentials grant, or presents its own access token as
+// actor_token. The issued token keeps the user as subject and names the service account in
+// its act claim. Subject tokens of other issuers are handled by federatedExchange.
+func (s *AuthorizationServer) tokenExchangeGrant(r *http.Request) (*TokenResponse, *oauthError) {
+	subjectToken := r.PostForm.Get("subject_token")
+	if subjectToken == "" {
+		return nil, newError(errInvalidRequest, "subject_token is required")
+	}
+	// Tokens of other issuers are external workload identities
+	if issuer := unverifiedIssuer(subjectToken); issuer != "" && issuer != s.jwtManager.Issuer() {
+		return s.federatedExchange(r, subjectToken, issuer)
+	}
+
+	sa, oerr := s.exchangeActor(r)
+	if oerr != nil {
+		return nil, oerr
+	}
+	if sa.Status != model.ServiceAccountStatusActive {
+		return nil, newError(errInvalidClient, "service account is disabled")
+	}
+
+	if !isAccessTokenType(r.PostForm.Get("subject_token_type")) {
+		return nil, newError(errInvalidRequest, "subject_token_type must be an access token")
+	}
+	if t := r.PostForm.Get("requested_token_type"); t != "" && t != tokenTypeAccessToken {
+		return nil, newError(errInvalidRequest, "only access tokens can be requested")
+	}
+	subject, err := s.jwtManager.ValidateAccessToken(subjectToken)
+	if err != nil || subject.UserID == "" {
+		return nil, newError(errInvalidGrant, "subject_token must be a valid user access token")
+	}
+
+	audience := r.PostForm.Get("audience")
+	if audience == "" {
+		return nil, newError(errInvalidRequest, "audience is required")
+	}
+	policy := s.exchangePolicy(sa.ID, audience)
+	if policy == nil {
+		return nil, newError(errInvalidTarget, "the service account may not exchange tokens for this audience")
+	}
+
+	// The token can only narrow: to the policy's scopes, and to the user token's scopes
+	// when it has any
+	available := policy.Scopes
+	if subjectScopes := subject.Scopes(); len(subjectScopes) > 0 {
+		available = slices.DeleteFunc(slices.Clone(available), func(scope string) bool {
+			return !slices.Contains(subjectScopes, scope)
+		})
+	}
+	scopes := slices.Clone(available)
+	if requested := strings.Fields(r.PostForm.Get("scope")); len(requested) > 0 {
+		for _, scope := range requested {
+			if !slices.Contains(available, scope) {
+				return nil, newError(errInvalidScope, "scope "+scope+" cannot be delegated")
+			}
+		}
+		scopes = dedupe(requested)
+	}
+
+	userID, err := uuid.Parse(subject.UserID)
+	if err != nil {
+		return nil, newError(errInvalidGrant, "subject_token must be a valid user access token")
+	}
+	user, err := s.userRepo.GetByID(r.Context(), userID)
+	if err != nil || user.Status == model.UserStatusBanned {
+		return nil, newError(errInvalidGrant, "the user is no longer active")
+	}
+
+	token, expiresAt, err := s.jwtManager.GenerateDelegatedToken(subject, sa.ID.String(), audience, scopes)
+	if err != nil {
+		return nil, newError(errServerError, "")
+	}
+	return &TokenResponse{
+		AccessToken:     token,
+		IssuedTokenType: tokenTypeAccessToken,
+		TokenType:       "Bearer",
+		ExpiresIn:       int64(time.Until(expiresAt).Round(time.Second).Seconds()),
+		Scope:           strings.Join(scopes, " "),
+	}, nil
+}
+
+// exchangeActor identifies the service account requesting a token exchange.
+func (s *AuthorizationServer) exchangeActor(r *http.Request) (*model.ServiceAccount, *oauthError) {
+	actorToken := r.PostForm.Get("actor_token")
+	if actorToken == "" {
+		return s.authenticateServiceAccount(r)
+	}
+
+	if !isAccessTokenType(r.PostForm.Get("actor_token_type")) {
+		return nil, newError(errInvalidRequest, "actor_token_type must be an access token")
+	}
+	claims, err := s.jwtManager.ValidateAccessToken(actorToken)
+	if err != nil || claims.ServiceAccountID == "" {
+		return nil, newError(errInvalidClient, "actor_token must be a valid service account token")
+	}
+	saID, err := uuid.Parse(claims.ServiceAccountID)
+	if err != nil {
+		return nil, newError(errInvalidClient, "actor_token must be a valid service account token")
+	}
+	// Unlike the API, the exchange checks that the service account is still enabled
+	sa, err := s.saRepo.GetByID(r.Context(), saID)
+	if err != nil {
+		return nil, newError(errInvalidClient, "unknown service account")
+	}
+	return sa, nil
+}
+
+// exchangePolicy returns the policy allowing the service account to exchange tokens for
+// the audience, if any.
+func (s *AuthorizationServer) exchangePolicy(saID uuid.UUID, audience string) *config.TokenExchangePolicy {
+	for i := range s.exchangePolicies {
+		p := &s.exchangePolicies[i]
+		if p.ServiceAccountID == saID && slices.Contains(p.Audiences, audience) {
+			return p
+		}
+	}
+	return nil
+}
+
+func isAccessTokenType(tokenType string) bool {
+	return tokenType == tokenTypeAccessToken || tokenType == tokenTypeJWT
+}
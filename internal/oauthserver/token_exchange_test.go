@@ -0,0 +1,156 @@
+package oauthserver
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/bernardoforcillo/authlayer/internal/auth"
+	"github.com/bernardoforcillo/authlayer/internal/config"
+	"github.com/bernardoforcillo/authlayer/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const exchangeAudience = "https://orders.example.com"
+
+func TestTokenExchange(t *testing.T) {
+	ts := newTestServer(t)
+	sa := ts.addServiceAccount()
+	secret := ts.addSecretKey(sa)
+	ts.exchangePolicies = []config.TokenExchangePolicy{{
+		ServiceAccountID: sa.ID,
+		Audiences:        []string{exchangeAudience},
+		Scopes:           []string{"orders:read", "orders:write", "profile"},
+	}}
+
+	user, session := ts.addUser(t)
+	// A client token limits what can be delegated to its own scopes
+	clientTokens, err := ts.jwtManager.GenerateClientTokenPair(user.ID.String(), user.Email, "", auth.Authentication{}, auth.ClientGrant{
+		ClientID: "app",
+		Audience: testIssuerURL,
+		Scopes:   []string{"orders:read", "profile", "billing:read"},
+	})
+	require.NoError(t, err)
+
+	exchange := func(subjectToken string, extra url.Values) (int, map[string]interface{}) {
+		form := url.Values{
+			"grant_type":         {grantTypeTokenExchange},
+			"client_id":          {sa.ID.String()},
+			"client_secret":      {secret},
+			"subject_token":      {subjectToken},
+			"subject_token_type": {tokenTypeAccessToken},
+			"audience":           {exchangeAudience},
+		}
+		for k, v := range extra {
+			form[k] = v
+		}
+		return ts.token(form)
+	}
+
+	t.Run("session token", func(t *testing.T) {
+		status, body := exchange(session, nil)
+		require.Equal(t, http.StatusOK, status, body)
+		assert.Equal(t, tokenTypeAccessToken, body["issued_token_type"])
+		assert.ElementsMatch(t, []string{"orders:read", "orders:write", "profile"}, scopeOf(body))
+
+		claims, err := ts.jwtManager.ValidateAccessToken(body["access_token"].(string))
+		require.NoError(t, err)
+		assert.Equal(t, user.ID.String(), claims.UserID)
+		assert.Equal(t, []string{exchangeAudience}, []string(claims.Audience))
+		require.NotNil(t, claims.Actor)
+		assert.Equal(t, sa.ID.String(), claims.Actor.Subject)
+	})
+
+	t.Run("narrowed to the subject token's scopes", func(t *testing.T) {
+		status, body := exchange(clientTokens.AccessToken, nil)
+		require.Equal(t, http.StatusOK, status, body)
+		assert.ElementsMatch(t, []string{"orders:read", "profile"}, scopeOf(body))
+
+		status, body = exchange(clientTokens.AccessToken, url.Values{"scope": {"orders:read"}})
+		require.Equal(t, http.StatusOK, status, body)
+		assert.Equal(t, []string{"orders:read"}, scopeOf(body))
+
+		// Within the policy, but not granted to the client
+		status, body = exchange(clientTokens.AccessToken, url.Values{"scope": {"orders:write"}})
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, errInvalidScope, body["error"])
+
+		// Granted to the client, but outside the policy
+		status, body = exchange(clientTokens.AccessToken, url.Values{"scope": {"billing:read"}})
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, errInvalidScope, body["error"])
+	})
+
+	t.Run("actor token", func(t *testing.T) {
+		actorToken, _, err := ts.jwtManager.GenerateServiceAccountToken(auth.ServiceAccountGrant{ServiceAccountID: sa.ID.String()})
+		require.NoError(t, err)
+		status, body := ts.token(url.Values{
+			"grant_type":         {grantTypeTokenExchange},
+			"subject_token":      {session},
+			"subject_token_type": {tokenTypeAccessToken},
+			"actor_token":        {actorToken},
+			"actor_token_type":   {tokenTypeJWT},
+			"audience":           {exchangeAudience},
+		})
+		require.Equal(t, http.StatusOK, status, body)
+
+		status, body = ts.token(url.Values{
+			"grant_type":         {grantTypeTokenExchange},
+			"subject_token":      {session},
+			"subject_token_type": {tokenTypeAccessToken},
+			"actor_token":        {session},
+			"actor_token_type":   {tokenTypeAccessToken},
+			"audience":           {exchangeAudience},
+		})
+		assert.Equal(t, http.StatusUnauthorized, status)
+		assert.Equal(t, errInvalidClient, body["error"])
+	})
+
+	saToken, _, err := ts.jwtManager.GenerateServiceAccountToken(auth.ServiceAccountGrant{ServiceAccountID: sa.ID.String()})
+	require.NoError(t, err)
+	other := ts.addServiceAccount()
+	for _, tt := range []struct {
+		name         string
+		subjectToken string
+		extra        url.Values
+		status       int
+		error        string
+	}{
+		{"no subject token", "", nil, http.StatusBadRequest, errInvalidRequest},
+		{"refresh token type", session, url.Values{"subject_token_type": {"urn:ietf:params:oauth:token-type:refresh_token"}}, http.StatusBadRequest, errInvalidRequest},
+		{"ID token requested", session, url.Values{"requested_token_type": {"urn:ietf:params:oauth:token-type:id_token"}}, http.StatusBadRequest, errInvalidRequest},
+		{"invalid subject token", "not-a-token", nil, http.StatusBadRequest, errInvalidGrant},
+		{"service account subject token", saToken, nil, http.StatusBadRequest, errInvalidGrant},
+		{"no audience", session, url.Values{"audience": {""}}, http.StatusBadRequest, errInvalidRequest},
+		{"audience outside the policy", session, url.Values{"audience": {"https://billing.example.com"}}, http.StatusBadRequest, errInvalidTarget},
+		{"wrong secret", session, url.Values{"client_secret": {"wrong"}}, http.StatusUnauthorized, errInvalidClient},
+		{"service account without a policy", session, url.Values{"client_id": {other.ID.String()}, "client_secret": {ts.addSecretKey(other)}}, http.StatusBadRequest, errInvalidTarget},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := exchange(tt.subjectToken, tt.extra)
+			assert.Equal(t, tt.status, status, body)
+			assert.Equal(t, tt.error, body["error"])
+		})
+	}
+
+	t.Run("banned user", func(t *testing.T) {
+		user.Status = model.UserStatusBanned
+		defer func() { user.Status = model.UserStatusActive }()
+		status, body := exchange(session, nil)
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, errInvalidGrant, body["error"])
+	})
+
+	t.Run("disabled service account", func(t *testing.T) {
+		for _, key := range ts.saKeys.keys {
+			if key.ServiceAccountID == sa.ID {
+				key.ServiceAccount.Status = model.ServiceAccountStatusDisabled
+			}
+		}
+		status, body := exchange(session, nil)
+		assert.Equal(t, http.StatusUnauthorized, status)
+		assert.Equal(t, errInvalidClient, body["error"])
+	})
+}
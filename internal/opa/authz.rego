@@ -0,0 +1,111 @@
+# Package authlayer.authz mirrors rbac.Checker over the model exported in the bundle, so
+# OPA reaches the same decisions as CheckPermission without calling authlayer.
+#
+# Query data.authlayer.authz.allow with an input such as:
+#
+#   {
+#     "principal_type": "user",        # or "service_account"
+#     "principal_id": "<uuid>",
+#     "permission": "project:update",
+#     "org_id": "<uuid>",              # optional
+#     "project_id": "<uuid>"           # optional, takes precedence over org_id
+#   }
+#
+# As in Checker, users hold no permissions outside an org, while a service account checked
+# without an org holds the roles granted to it in every org.
+package authlayer.authz
+
+import rego.v1
+
+model := data.authlayer.model
+
+default allow := false
+
+allow if {
+	input.principal_type == "user"
+	input.project_id
+	input.permission in user_project_permissions(input.principal_id, input.project_id)
+}
+
+allow if {
+	input.principal_type == "user"
+	not input.project_id
+	input.permission in user_permissions(input.principal_id, input.org_id)
+}
+
+allow if {
+	input.principal_type == "service_account"
+	input.project_id
+	input.permission in service_account_project_permissions(input.principal_id, input.project_id)
+}
+
+allow if {
+	input.principal_type == "service_account"
+	not input.project_id
+	input.org_id
+	input.permission in service_account_permissions(input.principal_id, input.org_id)
+}
+
+allow if {
+	input.principal_type == "service_account"
+	not input.project_id
+	not input.org_id
+	input.permission in service_account_global_permissions(input.principal_id)
+}
+
+# permissions_of expands roles through their ancestors, as ResolveGrants does.
+permissions_of(roles) := {perm |
+	some role in roles
+	some ancestor in model.roles[role].inherits
+	some perm in model.roles[ancestor].permissions
+}
+
+# user_roles returns the user's memberships and label binding roles in the org and every
+# ancestor whose roles cascade down to it.
+user_roles(user_id, org_id) := {role |
+	some scope in model.orgs[org_id].scope
+	some role in model.users[user_id].org_roles[scope]
+} | {role |
+	some scope in model.orgs[org_id].scope
+	some role in model.users[user_id].label_roles[scope]
+}
+
+user_permissions(user_id, org_id) := permissions_of(user_roles(user_id, org_id))
+
+user_project_permissions(user_id, project_id) := permissions_of(roles) if {
+	project := model.projects[project_id]
+	direct := {role | some role in project.users[user_id]}
+	via_teams := {role |
+		some team in model.users[user_id].teams[project.org_id]
+		some role in project.teams[team]
+	}
+	roles := (user_roles(user_id, project.org_id) | direct) | via_teams
+}
+
+# service_account_roles returns the service account's grants in the org and the ancestors it
+# inherits from, plus its label binding roles when its own org is in that scope.
+service_account_roles(sa_id, org_id) := {role |
+	some scope in model.orgs[org_id].scope
+	some role in model.service_accounts[sa_id].org_roles[scope]
+} | {role |
+	sa := model.service_accounts[sa_id]
+	sa.org_id in model.orgs[org_id].scope
+	some role in sa.label_roles
+}
+
+service_account_permissions(sa_id, org_id) := permissions_of(service_account_roles(sa_id, org_id))
+
+service_account_global_permissions(sa_id) := permissions_of(roles) if {
+	sa := model.service_accounts[sa_id]
+	granted := {role |
+		some org_roles in sa.org_roles
+		some role in org_roles
+	}
+	roles := granted | {role | some role in sa.label_roles}
+}
+
+service_account_project_permissions(sa_id, project_id) := permissions_of(roles) if {
+	project := model.projects[project_id]
+	direct := {role | some role in project.service_accounts[sa_id]}
+	roles := service_account_roles(sa_id, project.org_id) | direct
+}
@@ -0,0 +1,294 @@
+package opa
+
+import (
+	"context"
+	"sort"
+
+	"github.com/bernardoforcillo/authlayer/internal/labels"
+	"github.com/bernardoforcillo/authlayer/internal/model"
+	"github.com/bernardoforcillo/authlayer/internal/rbac"
+	"github.com/bernardoforcillo/authlayer/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// bundleModel is exported as data.authlayer.model. Org scopes, role ancestry and label
+// selector matches are resolved here, so the Rego library only has to join the sets.
+type bundleModel struct {
+	Orgs            map[string]orgData             `json:"orgs"`
+	Roles           map[string]roleData            `json:"roles"`
+	Users           map[string]*userData           `json:"users"`
+	ServiceAccounts map[string]*serviceAccountData `json:"service_accounts"`
+	Projects        map[string]*projectData        `json:"projects"`
+}
+
+type orgData struct {
+	// Scope lists the org and the ancestors whose roles cascade down to it.
+	Scope []string `json:"scope"`
+}
+
+type roleData struct {
+	Name         string   `json:"name"`
+	OrgID        *string  `json:"org_id,omitempty"`
+	ParentRoleID *string  `json:"parent_role_id,omitempty"`
+	Permissions  []string `json:"permissions"`
+	// Inherits lists the role and the ancestors whose permissions it carries.
+	Inherits []string `json:"inherits"`
+}
+
+type userData struct {
+	OrgRoles   map[string][]string `json:"org_roles"`
+	LabelRoles map[string][]string `json:"label_roles"`
+	Teams      map[string][]string `json:"teams"`
+}
+
+type serviceAccountData struct {
+	OrgID      string              `json:"org_id"`
+	OrgRoles   map[string][]string `json:"org_roles"`
+	LabelRoles []string            `json:"label_roles"`
+}
+
+type projectData struct {
+	OrgID           string              `json:"org_id"`
+	Users           map[string][]string `json:"users"`
+	Teams           map[string][]string `json:"teams"`
+	ServiceAccounts map[string][]string `json:"service_accounts"`
+}
+
+// buildModel loads a consistent snapshot and derives the bundle model from it, mirroring
+// how rbac.Resolver seeds and expands roles.
+func buildModel(ctx context.Context, repo repository.RBACSnapshotRepository) (*bundleModel, error) {
+	snap, err := repo.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &bundleModel{
+		Orgs:            make(map[string]orgData, len(snap.Organizations)),
+		Roles:           make(map[string]roleData, len(snap.Roles)),
+		Users:           make(map[string]*userData),
+		ServiceAccounts: make(map[string]*serviceAccountData, len(snap.ServiceAccounts)),
+		Projects:        make(map[string]*projectData, len(snap.Projects)),
+	}
+
+	orgs := make(map[uuid.UUID]model.Organization, len(snap.Organizations))
+	for _, o := range snap.Organizations {
+		orgs[o.ID] = o
+	}
+	for _, o := range snap.Organizations {
+		m.Orgs[o.ID.String()] = orgData{Scope: orgScope(o, orgs)}
+	}
+
+	roles := make(map[uuid.UUID]model.Role, len(snap.Roles))
+	for _, r := range snap.Roles {
+		roles[r.ID] = r
+	}
+	rolePerms := make(map[uuid.UUID][]string)
+	for _, rp := range snap.RolePermissions {
+		rolePerms[rp.RoleID] = append(rolePerms[rp.RoleID], rp.PermissionName)
+	}
+	for _, r := range snap.Roles {
+		m.Roles[r.ID.String()] = roleData{
+			Name:         r.Name,
+			OrgID:        uuidString(r.OrgID),
+			ParentRoleID: uuidString(r.ParentRoleID),
+			Permissions:  sortedUnique(rolePerms[r.ID]),
+			Inherits:     roleAncestry(r, roles),
+		}
+	}
+
+	user := func(id uuid.UUID) *userData {
+		u, ok := m.Users[id.String()]
+		if !ok {
+			u = &userData{
+				OrgRoles:   make(map[string][]string),
+				LabelRoles: make(map[string][]string),
+				Teams:      make(map[string][]string),
+			}
+			m.Users[id.String()] = u
+		}
+		return u
+	}
+
+	userLabels := make(map[uuid.UUID]model.Labels, len(snap.Users))
+	for _, u := range snap.Users {
+		userLabels[u.ID] = u.Labels
+	}
+	membersByOrg := make(map[uuid.UUID][]uuid.UUID)
+	for _, om := range snap.OrgMembers {
+		membersByOrg[om.OrgID] = append(membersByOrg[om.OrgID], om.UserID)
+		u := user(om.UserID)
+		u.OrgRoles[om.OrgID.String()] = append(u.OrgRoles[om.OrgID.String()], om.RoleID.String())
+	}
+
+	teams := make(map[uuid.UUID]model.Team, len(snap.Teams))
+	teamsByOrg := make(map[uuid.UUID][]model.Team)
+	for _, t := range snap.Teams {
+		teams[t.ID] = t
+		teamsByOrg[t.OrgID] = append(teamsByOrg[t.OrgID], t)
+	}
+	teamMembers := make(map[uuid.UUID][]uuid.UUID)
+	for _, tm := range snap.TeamMembers {
+		t, ok := teams[tm.TeamID]
+		if !ok {
+			continue
+		}
+		teamMembers[t.ID] = append(teamMembers[t.ID], tm.UserID)
+		u := user(tm.UserID)
+		u.Teams[t.OrgID.String()] = append(u.Teams[t.OrgID.String()], t.ID.String())
+	}
+
+	saByID := make(map[uuid.UUID]*serviceAccountData, len(snap.ServiceAccounts))
+	saLabels := make(map[uuid.UUID]model.Labels, len(snap.ServiceAccounts))
+	saByOrg := make(map[uuid.UUID][]uuid.UUID)
+	for _, sa := range snap.ServiceAccounts {
+		data := &serviceAccountData{OrgID: sa.OrgID.String(), OrgRoles: make(map[string][]string)}
+		m.ServiceAccounts[sa.ID.String()] = data
+		saByID[sa.ID] = data
+		saLabels[sa.ID] = sa.Labels
+		saByOrg[sa.OrgID] = append(saByOrg[sa.OrgID], sa.ID)
+	}
+	for _, sar := range snap.ServiceAccountRoles {
+		if sa, ok := saByID[sar.ServiceAccountID]; ok {
+			sa.OrgRoles[sar.OrgID.String()] = append(sa.OrgRoles[sar.OrgID.String()], sar.RoleID.String())
+		}
+	}
+
+	for _, b := range snap.LabelBindings {
+		sel, err := labels.Parse(b.Selector)
+		if err != nil {
+			// Selectors are validated on create; one that no longer parses matches nothing.
+			continue
+		}
+		org, role := b.OrgID.String(), b.RoleID.String()
+		switch b.PrincipalType {
+		case model.PrincipalTypeUser:
+			for _, userID := range membersByOrg[b.OrgID] {
+				if sel.Matches(userLabels[userID]) {
+					u := user(userID)
+					u.LabelRoles[org] = append(u.LabelRoles[org], role)
+				}
+			}
+		case model.PrincipalTypeTeam:
+			for _, t := range teamsByOrg[b.OrgID] {
+				if !sel.Matches(t.Labels) {
+					continue
+				}
+				for _, userID := range teamMembers[t.ID] {
+					u := user(userID)
+					u.LabelRoles[org] = append(u.LabelRoles[org], role)
+				}
+			}
+		case model.PrincipalTypeServiceAccount:
+			for _, saID := range saByOrg[b.OrgID] {
+				if sel.Matches(saLabels[saID]) {
+					saByID[saID].LabelRoles = append(saByID[saID].LabelRoles, role)
+				}
+			}
+		}
+	}
+
+	projects := make(map[uuid.UUID]*projectData, len(snap.Projects))
+	for _, p := range snap.Projects {
+		data := &projectData{
+			OrgID:           p.OrgID.String(),
+			Users:           make(map[string][]string),
+			Teams:           make(map[string][]string),
+			ServiceAccounts: make(map[string][]string),
+		}
+		m.Projects[p.ID.String()] = data
+		projects[p.ID] = data
+	}
+	for _, pm := range snap.ProjectMembers {
+		p, ok := projects[pm.ProjectID]
+		if !ok {
+			continue
+		}
+		principal, role := pm.PrincipalID.String(), pm.RoleID.String()
+		switch pm.PrincipalType {
+		case model.PrincipalTypeUser:
+			p.Users[principal] = append(p.Users[principal], role)
+		case model.PrincipalTypeTeam:
+			p.Teams[principal] = append(p.Teams[principal], role)
+		case model.PrincipalTypeServiceAccount:
+			p.ServiceAccounts[principal] = append(p.ServiceAccounts[principal], role)
+		}
+	}
+
+	// Sort every list so the same model always serializes to the same bytes and revision.
+	for _, u := range m.Users {
+		sortValues(u.OrgRoles)
+		sortValues(u.LabelRoles)
+		sortValues(u.Teams)
+	}
+	for _, sa := range m.ServiceAccounts {
+		sortValues(sa.OrgRoles)
+		sa.LabelRoles = sortedUnique(sa.LabelRoles)
+	}
+	for _, p := range m.Projects {
+		sortValues(p.Users)
+		sortValues(p.Teams)
+		sortValues(p.ServiceAccounts)
+	}
+
+	return m, nil
+}
+
+// orgScope mirrors the org_scope walk of ResolveGrants: the org, then each ancestor for as
+// long as the org below it inherits parent roles.
+func orgScope(org model.Organization, orgs map[uuid.UUID]model.Organization) []string {
+	scope := []string{org.ID.String()}
+	current := org
+	for depth := 1; depth < rbac.MaxHierarchyDepth && current.InheritParentRoles && current.ParentOrgID != nil; depth++ {
+		parent, ok := orgs[*current.ParentOrgID]
+		if !ok {
+			break
+		}
+		scope = append(scope, parent.ID.String())
+		current = parent
+	}
+	return sortedUnique(scope)
+}
+
+// roleAncestry mirrors the role_tree walk of ResolveGrants: the role and its parents, up to
+// the hierarchy depth limit, stopping at a deleted parent.
+func roleAncestry(role model.Role, roles map[uuid.UUID]model.Role) []string {
+	ancestry := []string{role.ID.String()}
+	current := role
+	for depth := 1; depth < rbac.MaxHierarchyDepth && current.ParentRoleID != nil; depth++ {
+		parent, ok := roles[*current.ParentRoleID]
+		if !ok {
+			break
+		}
+		ancestry = append(ancestry, parent.ID.String())
+		current = parent
+	}
+	return sortedUnique(ancestry)
+}
+
+func uuidString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+func sortValues(m map[string][]string) {
+	for k, v := range m {
+		m[k] = sortedUnique(v)
+	}
+}
+
+func sortedUnique(values []string) []string {
+	out := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
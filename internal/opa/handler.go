@@ -0,0 +1,165 @@
+// Package opa serves the RBAC model as an Open Policy Agent bundle, with a Rego library
+// that mirrors rbac.Checker.
+package opa
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bernardoforcillo/authlayer/internal/repository"
+
+	"go.uber.org/zap"
+)
+
+//go:embed authz.rego
+var authzPolicy []byte
+
+// buildTimeout bounds how long a single bundle build may take.
+const buildTimeout = 30 * time.Second
+
+type bundle struct {
+	revision string
+	archive  []byte
+	builtAt  time.Time
+}
+
+// BundleHandler serves GET requests for the bundle archive. OPA polls it with the
+// If-None-Match header set to the last ETag and gets 304 until the model changes.
+type BundleHandler struct {
+	repo     repository.RBACSnapshotRepository
+	token    string
+	cacheTTL time.Duration
+	logger   *zap.Logger
+
+	mu      sync.Mutex
+	current *bundle
+}
+
+// NewBundleHandler creates a bundle handler. Requests must carry token as a bearer
+// credential; built bundles are reused for cacheTTL so many polling OPA instances share one
+// build.
+func NewBundleHandler(repo repository.RBACSnapshotRepository, token string, cacheTTL time.Duration, logger *zap.Logger) *BundleHandler {
+	return &BundleHandler{repo: repo, token: token, cacheTTL: cacheTTL, logger: logger}
+}
+
+func (h *BundleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if h.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="authlayer"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	b, err := h.bundle(r.Context())
+	if err != nil {
+		h.logger.Error("failed to build OPA bundle", zap.Error(err))
+		http.Error(w, "failed to build bundle", http.StatusInternalServerError)
+		return
+	}
+
+	etag := `"` + b.revision + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+	if match := r.Header.Get("If-None-Match"); match != "" && strings.Contains(match, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/gzip")
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodGet {
+		_, _ = w.Write(b.archive)
+	}
+}
+
+// bundle returns the cached bundle while it is fresh, building a new one otherwise.
+// Holding the lock while building collapses concurrent rebuilds into one.
+func (h *BundleHandler) bundle(ctx context.Context) (*bundle, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.current != nil && time.Since(h.current.builtAt) < h.cacheTTL {
+		return h.current, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), buildTimeout)
+	defer cancel()
+	m, err := buildModel(ctx, h.repo)
+	if err != nil {
+		return nil, err
+	}
+	b, err := packBundle(m)
+	if err != nil {
+		return nil, err
+	}
+	h.current = b
+	return b, nil
+}
+
+// packBundle writes the model and policy into a bundle archive whose revision is the hash
+// of its data, so an unchanged model keeps its ETag across builds and instances.
+func packBundle(m *bundleModel) (*bundle, error) {
+	data, err := json.Marshal(map[string]interface{}{
+		"authlayer": map[string]interface{}{"model": m},
+	})
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.New()
+	hash.Write(data)
+	hash.Write(authzPolicy)
+	revision := hex.EncodeToString(hash.Sum(nil)[:16])
+
+	manifest, err := json.Marshal(map[string]interface{}{
+		"revision": revision,
+		"roots":    []string{"authlayer"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	files := []struct {
+		name string
+		body []byte
+	}{
+		{"/.manifest", manifest},
+		{"/data.json", data},
+		{"/authlayer/authz.rego", authzPolicy},
+	}
+	for _, f := range files {
+		hdr := &tar.Header{Name: f.name, Mode: 0o644, Size: int64(len(f.body)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(f.body); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+
+	return &bundle{revision: revision, archive: buf.Bytes(), builtAt: time.Now()}, nil
+}
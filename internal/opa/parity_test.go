@@ -0,0 +1,289 @@
+package opa
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/bernardoforcillo/authlayer/internal/database"
+	"github.com/bernardoforcillo/authlayer/internal/model"
+	"github.com/bernardoforcillo/authlayer/internal/rbac"
+	"github.com/bernardoforcillo/authlayer/internal/repository"
+
+	"github.com/google/uuid"
+	opabundle "github.com/open-policy-agent/opa/v1/bundle"
+	"github.com/open-policy-agent/opa/v1/rego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type staticSnapshotRepo struct {
+	snap *repository.RBACSnapshot
+}
+
+func (r staticSnapshotRepo) Load(context.Context) (*repository.RBACSnapshot, error) {
+	return r.snap, nil
+}
+
+// parityFixture is a small tenant tree exercising org inheritance, the role hierarchy and
+// label bindings:
+//
+//   - parent, with child inheriting its roles and sibling not inheriting them
+//   - viewer (doc:read) <- editor (doc:write) <- admin (doc:delete), and auditor (audit:read)
+//   - alice is an editor of parent; bob a viewer of child labelled team=infra, which binds
+//     admin in child; carol a viewer of sibling and member of child's ops team, labelled
+//     tier=gold, which binds auditor in child
+//   - the deployer service account of child is labelled env=prod, which binds editor in
+//     child, and is granted auditor in sibling
+//   - child's site project grants carol viewer and the ops team editor
+type parityFixture struct {
+	snap                                  *repository.RBACSnapshot
+	parent, child, sibling                uuid.UUID
+	alice, bob, carol, deployer, site     uuid.UUID
+	viewer, editor, admin, auditor, opsID uuid.UUID
+}
+
+func newParityFixture() *parityFixture {
+	f := &parityFixture{
+		parent: uuid.New(), child: uuid.New(), sibling: uuid.New(),
+		alice: uuid.New(), bob: uuid.New(), carol: uuid.New(), deployer: uuid.New(), site: uuid.New(),
+		viewer: uuid.New(), editor: uuid.New(), admin: uuid.New(), auditor: uuid.New(), opsID: uuid.New(),
+	}
+	suffix := uuid.NewString()
+	user := func(id uuid.UUID, name string, labels model.Labels) model.User {
+		u := model.User{Email: name + "-" + suffix + "@parity.test", Name: name, Labels: labels}
+		u.ID = id
+		return u
+	}
+	org := func(id uuid.UUID, name string, parent *uuid.UUID, inherit bool) model.Organization {
+		o := model.Organization{Name: name, Slug: name + "-" + suffix, OwnerID: f.alice, ParentOrgID: parent, InheritParentRoles: inherit}
+		o.ID = id
+		return o
+	}
+	role := func(id uuid.UUID, name string, parent *uuid.UUID) model.Role {
+		r := model.Role{Name: name, OrgID: &f.parent, ParentRoleID: parent}
+		r.ID = id
+		return r
+	}
+	base := func() model.Base { return model.Base{ID: uuid.New()} }
+
+	team := model.Team{Name: "ops", OrgID: f.child, Labels: model.Labels{"tier": "gold"}}
+	team.ID = f.opsID
+	sa := model.ServiceAccount{DisplayName: "deployer", OrgID: f.child, CreatedBy: f.alice, Status: model.ServiceAccountStatusActive, Labels: model.Labels{"env": "prod"}}
+	sa.ID = f.deployer
+	project := model.Project{Name: "site", OrgID: f.child}
+	project.ID = f.site
+
+	f.snap = &repository.RBACSnapshot{
+		Users: []model.User{
+			user(f.alice, "alice", nil),
+			user(f.bob, "bob", model.Labels{"team": "infra"}),
+			user(f.carol, "carol", nil),
+		},
+		Organizations: []model.Organization{
+			org(f.parent, "parent", nil, false),
+			org(f.child, "child", &f.parent, true),
+			org(f.sibling, "sibling", &f.parent, false),
+		},
+		Roles: []model.Role{
+			role(f.viewer, "viewer", nil),
+			role(f.editor, "editor", &f.viewer),
+			role(f.admin, "admin", &f.editor),
+			role(f.auditor, "auditor", nil),
+		},
+		RolePermissions: []repository.RolePermissionName{
+			{RoleID: f.viewer, PermissionName: "doc:read"},
+			{RoleID: f.editor, PermissionName: "doc:write"},
+			{RoleID: f.admin, PermissionName: "doc:delete"},
+			{RoleID: f.auditor, PermissionName: "audit:read"},
+		},
+		OrgMembers: []model.OrganizationMember{
+			{Base: base(), OrgID: f.parent, UserID: f.alice, RoleID: f.editor},
+			{Base: base(), OrgID: f.child, UserID: f.bob, RoleID: f.viewer},
+			{Base: base(), OrgID: f.sibling, UserID: f.carol, RoleID: f.viewer},
+		},
+		Teams:           []model.Team{team},
+		TeamMembers:     []model.TeamMember{{Base: base(), TeamID: f.opsID, UserID: f.carol, RoleID: f.viewer}},
+		ServiceAccounts: []model.ServiceAccount{sa},
+		ServiceAccountRoles: []model.ServiceAccountRole{
+			{Base: base(), ServiceAccountID: f.deployer, RoleID: f.auditor, OrgID: f.sibling},
+		},
+		Projects: []model.Project{project},
+		ProjectMembers: []model.ProjectMember{
+			{Base: base(), ProjectID: f.site, PrincipalType: model.PrincipalTypeUser, PrincipalID: f.carol, RoleID: f.viewer},
+			{Base: base(), ProjectID: f.site, PrincipalType: model.PrincipalTypeTeam, PrincipalID: f.opsID, RoleID: f.editor},
+		},
+		LabelBindings: []model.LabelRoleBinding{
+			{Base: base(), OrgID: f.child, RoleID: f.admin, PrincipalType: model.PrincipalTypeUser, Selector: "team=infra"},
+			{Base: base(), OrgID: f.child, RoleID: f.auditor, PrincipalType: model.PrincipalTypeTeam, Selector: "tier=gold"},
+			{Base: base(), OrgID: f.child, RoleID: f.editor, PrincipalType: model.PrincipalTypeServiceAccount, Selector: "env=prod"},
+		},
+	}
+	return f
+}
+
+// seed writes the fixture rows into db.
+func (f *parityFixture) seed(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	s := f.snap
+	for _, rows := range []interface{}{&s.Users, &s.Organizations, &s.Roles} {
+		require.NoError(t, db.Create(rows).Error)
+	}
+	for _, rp := range s.RolePermissions {
+		perm := model.Permission{Name: rp.PermissionName}
+		require.NoError(t, db.Where(model.Permission{Name: rp.PermissionName}).FirstOrCreate(&perm).Error)
+		require.NoError(t, db.Create(&model.RolePermission{RoleID: rp.RoleID, PermissionID: perm.ID}).Error)
+	}
+	for _, rows := range []interface{}{
+		&s.OrgMembers, &s.Teams, &s.TeamMembers, &s.ServiceAccounts, &s.ServiceAccountRoles,
+		&s.Projects, &s.ProjectMembers, &s.LabelBindings,
+	} {
+		require.NoError(t, db.Create(rows).Error)
+	}
+}
+
+type parityCase struct {
+	name       string
+	sa         bool
+	principal  uuid.UUID
+	permission string
+	org        *uuid.UUID
+	project    *uuid.UUID
+	want       bool
+}
+
+func (f *parityFixture) cases() []parityCase {
+	return []parityCase{
+		// Org inheritance
+		{name: "member of the org", principal: f.alice, permission: "doc:write", org: &f.parent, want: true},
+		{name: "inherited by a child org", principal: f.alice, permission: "doc:write", org: &f.child, want: true},
+		{name: "not inherited by a non-inheriting org", principal: f.alice, permission: "doc:read", org: &f.sibling, want: false},
+		{name: "not inherited upwards", principal: f.bob, permission: "doc:read", org: &f.parent, want: false},
+		{name: "no permissions outside an org", principal: f.alice, permission: "doc:read", want: false},
+		// Role hierarchy
+		{name: "parent role permission", principal: f.alice, permission: "doc:read", org: &f.parent, want: true},
+		{name: "child role permission not granted to parent", principal: f.alice, permission: "doc:delete", org: &f.parent, want: false},
+		// Label bindings
+		{name: "user label binding", principal: f.bob, permission: "doc:delete", org: &f.child, want: true},
+		{name: "user label binding expands ancestors", principal: f.bob, permission: "doc:write", org: &f.child, want: true},
+		{name: "team label binding", principal: f.carol, permission: "audit:read", org: &f.child, want: true},
+		{name: "team label binding stays in its org", principal: f.carol, permission: "audit:read", org: &f.sibling, want: false},
+		{name: "service account label binding", sa: true, principal: f.deployer, permission: "doc:write", org: &f.child, want: true},
+		{name: "service account label binding stays in its org", sa: true, principal: f.deployer, permission: "doc:read", org: &f.sibling, want: false},
+		{name: "service account grant", sa: true, principal: f.deployer, permission: "audit:read", org: &f.sibling, want: true},
+		{name: "service account grant stays in its org", sa: true, principal: f.deployer, permission: "audit:read", org: &f.child, want: false},
+		{name: "service account without org holds every grant", sa: true, principal: f.deployer, permission: "audit:read", want: true},
+		{name: "service account without org holds label bindings", sa: true, principal: f.deployer, permission: "doc:write", want: true},
+		{name: "service account without org", sa: true, principal: f.deployer, permission: "doc:delete", want: false},
+		// Projects
+		{name: "project grant", principal: f.carol, permission: "doc:read", project: &f.site, want: true},
+		{name: "project team grant", principal: f.carol, permission: "doc:write", project: &f.site, want: true},
+		{name: "project inherits org roles", principal: f.alice, permission: "doc:write", project: &f.site, want: true},
+		{name: "project grant limit", principal: f.carol, permission: "doc:delete", project: &f.site, want: false},
+		{name: "service account in project", sa: true, principal: f.deployer, permission: "doc:write", project: &f.site, want: true},
+	}
+}
+
+// bundleQuery loads the bundle the handler serves into OPA.
+func bundleQuery(t *testing.T, repo repository.RBACSnapshotRepository) rego.PreparedEvalQuery {
+	t.Helper()
+	handler := NewBundleHandler(repo, "token", time.Minute, zap.NewNop())
+	req := httptest.NewRequest(http.MethodGet, "/opa/bundles/authlayer.tar.gz", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	b, err := opabundle.NewReader(w.Body).Read()
+	require.NoError(t, err)
+	query, err := rego.New(
+		rego.Query("data.authlayer.authz.allow"),
+		rego.ParsedBundle("authlayer", &b),
+	).PrepareForEval(context.Background())
+	require.NoError(t, err)
+	return query
+}
+
+func evalAllow(t *testing.T, query rego.PreparedEvalQuery, c parityCase) bool {
+	t.Helper()
+	input := map[string]interface{}{
+		"principal_type": "user",
+		"principal_id":   c.principal.String(),
+		"permission":     c.permission,
+	}
+	if c.sa {
+		input["principal_type"] = "service_account"
+	}
+	if c.org != nil {
+		input["org_id"] = c.org.String()
+	}
+	if c.project != nil {
+		input["project_id"] = c.project.String()
+	}
+	rs, err := query.Eval(context.Background(), rego.EvalInput(input))
+	require.NoError(t, err)
+	return rs.Allowed()
+}
+
+func checkAllow(t *testing.T, checker *rbac.Checker, c parityCase) bool {
+	t.Helper()
+	ctx := context.Background()
+	var (
+		allowed bool
+		err     error
+	)
+	switch {
+	case c.sa && c.project != nil:
+		allowed, err = checker.CheckServiceAccountProjectPermission(ctx, c.principal, c.permission, *c.project)
+	case c.sa:
+		allowed, err = checker.CheckServiceAccountPermission(ctx, c.principal, c.permission, c.org)
+	case c.project != nil:
+		allowed, err = checker.CheckProjectPermission(ctx, c.principal, c.permission, *c.project)
+	default:
+		allowed, _, err = checker.CheckPermission(ctx, c.principal, c.permission, c.org)
+	}
+	require.NoError(t, err)
+	return allowed
+}
+
+// The bundle's Rego reaches the expected decisions on the fixture. With the Postgres named by
+// AUTHLAYER_TEST_DATABASE_URL, the fixture is also stored there, the bundle is built from
+// it and every decision is compared with rbac.Checker.
+func TestBundlePolicyMatchesChecker(t *testing.T) {
+	f := newParityFixture()
+
+	var (
+		repo    repository.RBACSnapshotRepository = staticSnapshotRepo{snap: f.snap}
+		checker *rbac.Checker
+	)
+	if dsn := os.Getenv("AUTHLAYER_TEST_DATABASE_URL"); dsn != "" {
+		db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+		require.NoError(t, err)
+		require.NoError(t, database.Migrate(db))
+		f.seed(t, db)
+		repo = repository.NewRBACSnapshotRepository(db)
+		resolver := rbac.NewResolver(
+			repository.NewRolePermissionRepository(db),
+			repository.NewUserRepository(db),
+			repository.NewServiceAccountRepository(db),
+			rbac.NewMemoryCache(time.Minute, 0),
+		)
+		checker = rbac.NewChecker(resolver, nil, nil, zap.NewNop())
+	}
+
+	query := bundleQuery(t, repo)
+	for _, c := range f.cases() {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.want, evalAllow(t, query, c), "rego")
+			if checker != nil {
+				assert.Equal(t, c.want, checkAllow(t, checker, c), "checker")
+			}
+		})
+	}
+}
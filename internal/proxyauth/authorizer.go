@@ -0,0 +1,145 @@
+// Package proxyauth makes authorization decisions for requests that reach services through
+// a proxy (Envoy ext_authz, nginx auth_request, Traefik ForwardAuth) instead of calling
+// authlayer directly.
+package proxyauth
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/bernardoforcillo/authlayer/internal/middleware"
+	"github.com/bernardoforcillo/authlayer/internal/model"
+	"github.com/bernardoforcillo/authlayer/internal/rbac"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Headers set on allowed requests so upstream services can trust the identity without
+// re-authenticating.
+const (
+	HeaderUser          = "X-Auth-User"
+	HeaderPrincipalType = "X-Auth-Principal-Type"
+	HeaderEmail         = "X-Auth-Email"
+	HeaderOrg           = "X-Auth-Org"
+	HeaderPermissions   = "X-Auth-Permissions"
+)
+
+// IdentityHeaders lists every header Decision.Headers sets.
+var IdentityHeaders = []string{HeaderUser, HeaderPrincipalType, HeaderEmail, HeaderOrg, HeaderPermissions}
+
+// Decision describes an allowed request.
+type Decision struct {
+	PrincipalType model.PrincipalType
+	PrincipalID   uuid.UUID
+	Email         string
+	OrgID         *uuid.UUID
+	// Permissions are the principal's effective permissions in the org.
+	Permissions []string
+}
+
+// Headers returns the identity headers to inject upstream. Every header is present, empty
+// when unknown, so that overwriting them also discards any client-supplied values.
+func (d *Decision) Headers() map[string]string {
+	user, org := "", ""
+	if d.PrincipalID != uuid.Nil {
+		user = d.PrincipalID.String()
+	}
+	if d.OrgID != nil {
+		org = d.OrgID.String()
+	}
+	return map[string]string{
+		HeaderUser:          user,
+		HeaderPrincipalType: string(d.PrincipalType),
+		HeaderEmail:         d.Email,
+		HeaderOrg:           org,
+		HeaderPermissions:   strings.Join(d.Permissions, ","),
+	}
+}
+
+// Authorizer authenticates credentials with the same logic as the gRPC API and checks
+// permissions with the same Checker.
+type Authorizer struct {
+	authenticator *middleware.AuthInterceptor
+	checker       *rbac.Checker
+	resolver      *rbac.Resolver
+	audience      string
+}
+
+// NewAuthorizer creates an authorizer. Access tokens naming an audience must include
+// audience, the audience of the proxied services.
+func NewAuthorizer(authenticator *middleware.AuthInterceptor, checker *rbac.Checker, resolver *rbac.Resolver, audience string) *Authorizer {
+	return &Authorizer{authenticator: authenticator, checker: checker, resolver: resolver, audience: audience}
+}
+
+// Authorize authenticates the authorization header value and, when permission is set, checks
+// that the principal holds it in the org. Errors are gRPC status errors: Unauthenticated for
+// bad credentials and PermissionDenied when the permission is missing.
+func (a *Authorizer) Authorize(ctx context.Context, credential string, orgID *uuid.UUID, permission string) (*Decision, error) {
+	if credential == "" {
+		return nil, status.Errorf(codes.Unauthenticated, "missing credentials")
+	}
+	ctx, err := a.authenticator.Authenticate(ctx, credential, a.audience)
+	if err != nil {
+		return nil, err
+	}
+
+	decision := &Decision{OrgID: orgID}
+	var perms []model.Permission
+	switch middleware.AuthTypeFromContext(ctx) {
+	case middleware.AuthTypeUser, middleware.AuthTypeAPIKey:
+		userID, err := middleware.UserIDFromContext(ctx)
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, "no user in context")
+		}
+		decision.PrincipalType = model.PrincipalTypeUser
+		decision.PrincipalID = userID
+		decision.Email = middleware.UserEmailFromContext(ctx)
+
+		if permission != "" {
+			allowed, _, err := a.checker.CheckPermission(ctx, userID, permission, orgID)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "permission check failed")
+			}
+			if !allowed {
+				return nil, status.Errorf(codes.PermissionDenied, "permission %q denied", permission)
+			}
+		}
+		if perms, err = a.resolver.ResolveUserPermissions(ctx, userID, orgID); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to resolve permissions")
+		}
+
+	case middleware.AuthTypeServiceAccount:
+		saID, err := middleware.ServiceAccountIDFromContext(ctx)
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, "no service account in context")
+		}
+		decision.PrincipalType = model.PrincipalTypeServiceAccount
+		decision.PrincipalID = saID
+
+		if permission != "" {
+			allowed, err := a.checker.CheckServiceAccountPermission(ctx, saID, permission, orgID)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "permission check failed")
+			}
+			if !allowed {
+				return nil, status.Errorf(codes.PermissionDenied, "permission %q denied", permission)
+			}
+		}
+		if perms, err = a.resolver.ResolveServiceAccountPermissions(ctx, saID, orgID); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to resolve permissions")
+		}
+
+	default:
+		return nil, status.Errorf(codes.Unauthenticated, "unknown auth type")
+	}
+
+	decision.Permissions = make([]string, len(perms))
+	for i, p := range perms {
+		decision.Permissions[i] = p.Name
+	}
+	sort.Strings(decision.Permissions)
+	return decision, nil
+}
@@ -0,0 +1,183 @@
+package proxyauth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bernardoforcillo/authlayer/internal/config"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const forwardAuthCacheSweep = 10000
+
+// Headers proxies use to describe the original request: Traefik sends X-Forwarded-*, and
+// nginx configurations conventionally pass X-Original-*.
+var (
+	forwardedMethodHeaders = []string{"X-Forwarded-Method", "X-Original-Method"}
+	forwardedURIHeaders    = []string{"X-Forwarded-Uri", "X-Original-URI"}
+)
+
+type cachedDecision struct {
+	decision  *Decision
+	expiresAt time.Time
+}
+
+// ForwardAuthHandler implements the forward-auth protocol of nginx auth_request and Traefik
+// ForwardAuth: it answers 200 with identity headers, 401 or 403. The permission comes from
+// the "permission" query parameter of the auth URL the proxy is configured with or, failing
+// that, from route rules matched against the original request. Headers are never trusted
+// for it, since proxies pass the client's headers through.
+type ForwardAuthHandler struct {
+	authorizer *Authorizer
+	rules      []config.RouteRule
+	orgHeader  string
+	cookieName string
+	cacheTTL   time.Duration
+	logger     *zap.Logger
+
+	mu    sync.Mutex
+	cache map[string]cachedDecision
+}
+
+// NewForwardAuthHandler creates a forward-auth handler. Credentials are read from the
+// Authorization header or, for browsers, from an access token in cookieName. Allowed
+// decisions are cached for cacheTTL; denials are never cached.
+func NewForwardAuthHandler(authorizer *Authorizer, rules []config.RouteRule, orgHeader, cookieName string, cacheTTL time.Duration, logger *zap.Logger) *ForwardAuthHandler {
+	return &ForwardAuthHandler{
+		authorizer: authorizer,
+		rules:      rules,
+		orgHeader:  orgHeader,
+		cookieName: cookieName,
+		cacheTTL:   cacheTTL,
+		logger:     logger,
+		cache:      make(map[string]cachedDecision),
+	}
+}
+
+func (h *ForwardAuthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	permission := r.URL.Query().Get("permission")
+	if permission == "" && len(h.rules) > 0 {
+		rule, ok := MatchRoute(h.rules, firstHeader(r, forwardedMethodHeaders), firstHeader(r, forwardedURIHeaders))
+		if !ok {
+			http.Error(w, "no route rule matches the request", http.StatusForbidden)
+			return
+		}
+		if rule.Public {
+			// Blank the identity headers so the client cannot supply its own upstream.
+			for name, value := range (&Decision{}).Headers() {
+				w.Header().Set(name, value)
+			}
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		permission = rule.Permission
+	}
+
+	var orgID *uuid.UUID
+	org := r.URL.Query().Get("org")
+	if org == "" {
+		org = r.Header.Get(h.orgHeader)
+	}
+	if org != "" {
+		id, err := uuid.Parse(org)
+		if err != nil {
+			http.Error(w, "invalid org", http.StatusBadRequest)
+			return
+		}
+		orgID = &id
+	}
+
+	credential := r.Header.Get("Authorization")
+	if credential == "" && h.cookieName != "" {
+		if c, err := r.Cookie(h.cookieName); err == nil && c.Value != "" {
+			credential = "Bearer " + c.Value
+		}
+	}
+	if credential == "" {
+		unauthorized(w, "missing credentials")
+		return
+	}
+
+	key := cacheKey(credential, org, permission)
+	decision, ok := h.cached(key)
+	if !ok {
+		var err error
+		decision, err = h.authorizer.Authorize(r.Context(), credential, orgID, permission)
+		if err != nil {
+			switch status.Code(err) {
+			case codes.Unauthenticated:
+				unauthorized(w, status.Convert(err).Message())
+			case codes.PermissionDenied:
+				http.Error(w, status.Convert(err).Message(), http.StatusForbidden)
+			default:
+				h.logger.Error("forward auth check failed", zap.Error(err))
+				http.Error(w, "authorization check failed", http.StatusInternalServerError)
+			}
+			return
+		}
+		h.store(key, decision)
+	}
+
+	for name, value := range decision.Headers() {
+		w.Header().Set(name, value)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *ForwardAuthHandler) cached(key string) (*Decision, bool) {
+	if h.cacheTTL <= 0 {
+		return nil, false
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	entry, ok := h.cache[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.decision, true
+}
+
+func (h *ForwardAuthHandler) store(key string, decision *Decision) {
+	if h.cacheTTL <= 0 {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	if len(h.cache) >= forwardAuthCacheSweep {
+		for k, entry := range h.cache {
+			if now.After(entry.expiresAt) {
+				delete(h.cache, k)
+			}
+		}
+	}
+	h.cache[key] = cachedDecision{decision: decision, expiresAt: now.Add(h.cacheTTL)}
+}
+
+// cacheKey hashes the credential so raw secrets are not kept as map keys.
+func cacheKey(credential, org, permission string) string {
+	sum := sha256.Sum256([]byte(credential + "\x00" + org + "\x00" + permission))
+	return hex.EncodeToString(sum[:])
+}
+
+func firstHeader(r *http.Request, names []string) string {
+	for _, name := range names {
+		if v := r.Header.Get(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func unauthorized(w http.ResponseWriter, message string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="authlayer"`)
+	http.Error(w, message, http.StatusUnauthorized)
+}
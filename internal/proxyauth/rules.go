@@ -0,0 +1,51 @@
+package proxyauth
+
+import (
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/bernardoforcillo/authlayer/internal/config"
+)
+
+// MatchRoute returns the first rule whose methods and path prefix match the request.
+// The query string is ignored. The path is decoded and cleaned of dot segments and repeated
+// slashes first, so it matches the rules as the backend will resolve it, and prefixes only
+// match whole segments: /admin matches /admin/users but not /administrator. Paths that
+// cannot be decoded match no rule.
+func MatchRoute(rules []config.RouteRule, method, rawPath string) (config.RouteRule, bool) {
+	p, ok := cleanPath(rawPath)
+	if !ok {
+		return config.RouteRule{}, false
+	}
+	for _, rule := range rules {
+		if !hasPathPrefix(p, rule.PathPrefix) {
+			continue
+		}
+		if len(rule.Methods) == 0 {
+			return rule, true
+		}
+		for _, m := range rule.Methods {
+			if strings.EqualFold(m, method) {
+				return rule, true
+			}
+		}
+	}
+	return config.RouteRule{}, false
+}
+
+func cleanPath(rawPath string) (string, bool) {
+	if i := strings.IndexAny(rawPath, "?#"); i >= 0 {
+		rawPath = rawPath[:i]
+	}
+	decoded, err := url.PathUnescape(rawPath)
+	if err != nil {
+		return "", false
+	}
+	return path.Clean("/" + decoded), true
+}
+
+func hasPathPrefix(p, prefix string) bool {
+	trimmed := strings.TrimSuffix(prefix, "/")
+	return p == prefix || p == trimmed || strings.HasPrefix(p, trimmed+"/")
+}
@@ -0,0 +1,46 @@
+package proxyauth
+
+import (
+	"testing"
+
+	"github.com/bernardoforcillo/authlayer/internal/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchRoute(t *testing.T) {
+	rules := []config.RouteRule{
+		{PathPrefix: "/public", Public: true},
+		{PathPrefix: "/admin", Permission: "admin:access"},
+		{PathPrefix: "/docs/", Methods: []string{"GET"}, Permission: "docs:read"},
+		{PathPrefix: "/", Permission: "app:use"},
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		want   string
+	}{
+		{"exact prefix", "GET", "/public", "/public"},
+		{"below prefix", "GET", "/public/logo.png?v=2", "/public"},
+		{"dot segments", "GET", "/public/../admin/users", "/admin"},
+		{"encoded dot segments", "GET", "/public/%2e%2e/admin", "/admin"},
+		{"encoded slash", "GET", "/public%2F..%2Fadmin", "/admin"},
+		{"repeated slashes", "GET", "//admin", "/admin"},
+		{"sibling prefix", "GET", "/administrator", "/"},
+		{"sibling of public", "GET", "/publicity", "/"},
+		{"trailing slash prefix", "GET", "/docs", "/docs/"},
+		{"method mismatch", "POST", "/docs/guide", "/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, ok := MatchRoute(rules, tt.method, tt.path)
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, rule.PathPrefix)
+		})
+	}
+
+	_, ok := MatchRoute(rules, "GET", "/public/%zz")
+	assert.False(t, ok, "undecodable paths match no rule")
+}
@@ -1,6 +1,8 @@
 package rbac
 
 import (
+	"container/list"
+	"context"
 	"strings"
 	"sync"
 	"time"
@@ -8,63 +10,166 @@ import (
 	"github.com/google/uuid"
 )
 
-type cacheEntry struct {
-	permissions []string
-	expiresAt   time.Time
+// Cache stores resolved permission sets. Entries record the roles they were derived
+// from, including inherited ancestors, so that a change to one role can find every
+// dependent entry. Implementations treat backend failures as cache misses.
+type Cache interface {
+	// Get returns cached permissions if they exist and are not expired.
+	Get(ctx context.Context, key string) ([]string, bool)
+	// Set stores permissions along with the roles they were derived from.
+	Set(ctx context.Context, key string, permissions []string, roleIDs []uuid.UUID)
+	// InvalidateUser removes all entries for a user.
+	InvalidateUser(ctx context.Context, userID uuid.UUID)
+	// InvalidateServiceAccount removes all entries for a service account.
+	InvalidateServiceAccount(ctx context.Context, saID uuid.UUID)
+	// InvalidateRole removes every entry derived from the role, which includes the
+	// entries of principals holding any descendant of the role.
+	InvalidateRole(ctx context.Context, roleID uuid.UUID)
+	// Clear removes all entries.
+	Clear(ctx context.Context)
 }
 
-// Cache is an in-memory permission cache with TTL.
-type Cache struct {
-	store sync.Map
-	ttl   time.Duration
+func cacheKey(userID uuid.UUID, orgID *uuid.UUID) string {
+	if orgID != nil {
+		return userPrefix(userID) + "org:" + orgID.String()
+	}
+	return userPrefix(userID) + "global"
 }
 
-// NewCache creates a new cache with the given TTL.
-func NewCache(ttl time.Duration) *Cache {
-	return &Cache{ttl: ttl}
+func projectCacheKey(userID, projectID uuid.UUID) string {
+	return userPrefix(userID) + "project:" + projectID.String()
 }
 
-func cacheKey(userID uuid.UUID, orgID *uuid.UUID) string {
+func serviceAccountCacheKey(saID uuid.UUID, orgID *uuid.UUID) string {
 	if orgID != nil {
-		return "user:" + userID.String() + ":org:" + orgID.String()
+		return serviceAccountPrefix(saID) + "org:" + orgID.String()
+	}
+	return serviceAccountPrefix(saID) + "global"
+}
+
+func serviceAccountProjectCacheKey(saID, projectID uuid.UUID) string {
+	return serviceAccountPrefix(saID) + "project:" + projectID.String()
+}
+
+func userPrefix(userID uuid.UUID) string {
+	return "user:" + userID.String() + ":"
+}
+
+func serviceAccountPrefix(saID uuid.UUID) string {
+	return "sa:" + saID.String() + ":"
+}
+
+type cacheEntry struct {
+	key         string
+	permissions []string
+	roleIDs     map[uuid.UUID]bool
+	expiresAt   time.Time
+}
+
+// MemoryCache is an in-process Cache with TTL expiry and a bounded LRU size.
+type MemoryCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	order      *list.List // front = most recently used
+	entries    map[string]*list.Element
+}
+
+// NewMemoryCache creates an in-memory cache. When it holds maxEntries entries, the least
+// recently used one is evicted to make room; maxEntries <= 0 means unbounded.
+func NewMemoryCache(ttl time.Duration, maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
 	}
-	return "user:" + userID.String() + ":global"
 }
 
-// Get returns cached permissions if they exist and are not expired.
-func (c *Cache) Get(key string) ([]string, bool) {
-	val, ok := c.store.Load(key)
+func (c *MemoryCache) Get(_ context.Context, key string) ([]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
 	if !ok {
 		return nil, false
 	}
-	entry := val.(*cacheEntry)
+	entry := el.Value.(*cacheEntry)
 	if time.Now().After(entry.expiresAt) {
-		c.store.Delete(key)
+		c.remove(el)
 		return nil, false
 	}
+	c.order.MoveToFront(el)
 	return entry.permissions, true
 }
 
-// Set stores permissions in the cache.
-func (c *Cache) Set(key string, permissions []string) {
-	c.store.Store(key, &cacheEntry{
+func (c *MemoryCache) Set(_ context.Context, key string, permissions []string, roleIDs []uuid.UUID) {
+	roles := make(map[uuid.UUID]bool, len(roleIDs))
+	for _, id := range roleIDs {
+		roles[id] = true
+	}
+	entry := &cacheEntry{
+		key:         key,
 		permissions: permissions,
+		roleIDs:     roles,
 		expiresAt:   time.Now().Add(c.ttl),
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		el.Value = entry
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	if c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *MemoryCache) InvalidateUser(_ context.Context, userID uuid.UUID) {
+	c.removeWhere(func(e *cacheEntry) bool {
+		return strings.HasPrefix(e.key, userPrefix(userID))
+	})
+}
+
+func (c *MemoryCache) InvalidateServiceAccount(_ context.Context, saID uuid.UUID) {
+	c.removeWhere(func(e *cacheEntry) bool {
+		return strings.HasPrefix(e.key, serviceAccountPrefix(saID))
+	})
+}
+
+func (c *MemoryCache) InvalidateRole(_ context.Context, roleID uuid.UUID) {
+	c.removeWhere(func(e *cacheEntry) bool {
+		return e.roleIDs[roleID]
 	})
 }
 
-// Invalidate removes a specific cache entry.
-func (c *Cache) Invalidate(key string) {
-	c.store.Delete(key)
+func (c *MemoryCache) Clear(_ context.Context) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	c.entries = make(map[string]*list.Element)
 }
 
-// InvalidateUser removes all cache entries for a user.
-func (c *Cache) InvalidateUser(userID uuid.UUID) {
-	prefix := "user:" + userID.String()
-	c.store.Range(func(key, value interface{}) bool {
-		if k, ok := key.(string); ok && strings.HasPrefix(k, prefix) {
-			c.store.Delete(key)
+func (c *MemoryCache) removeWhere(match func(*cacheEntry) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for el := c.order.Front(); el != nil; {
+		next := el.Next()
+		if match(el.Value.(*cacheEntry)) {
+			c.remove(el)
 		}
-		return true
-	})
+		el = next
+	}
+}
+
+// remove must be called with mu held.
+func (c *MemoryCache) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.entries, el.Value.(*cacheEntry).key)
 }
@@ -0,0 +1,124 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/bernardoforcillo/authlayer/internal/model"
+	"github.com/bernardoforcillo/authlayer/internal/repository"
+
+	"go.uber.org/zap"
+)
+
+const (
+	changeFeedBatchSize    = 500
+	changeFeedPollInterval = 5 * time.Second
+	changeFeedPruneEvery   = time.Hour
+)
+
+// ErrRevisionCompacted is returned when a watch resumes from a revision whose successors
+// have already been pruned. The watcher must resynchronize and start from the head.
+var ErrRevisionCompacted = errors.New("revision has been compacted")
+
+// ChangeFeed streams the permission changes recorded by the Materializer. Watchers are
+// woken when this instance records changes or hears of a change on another instance, and
+// poll as a fallback.
+type ChangeFeed struct {
+	repo   repository.PermissionChangeRepository
+	logger *zap.Logger
+
+	mu   sync.Mutex
+	wake chan struct{}
+}
+
+// NewChangeFeed creates a feed over the recorded permission changes.
+func NewChangeFeed(repo repository.PermissionChangeRepository, logger *zap.Logger) *ChangeFeed {
+	return &ChangeFeed{repo: repo, logger: logger, wake: make(chan struct{})}
+}
+
+// Notify wakes every watcher to look for new changes.
+func (f *ChangeFeed) Notify() {
+	f.mu.Lock()
+	close(f.wake)
+	f.wake = make(chan struct{})
+	f.mu.Unlock()
+}
+
+func (f *ChangeFeed) waiter() <-chan struct{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.wake
+}
+
+// Watch calls send for every change matching filter after the given revision, in revision
+// order, until ctx is cancelled or send fails. A nil from starts at the current head.
+func (f *ChangeFeed) Watch(ctx context.Context, from *int64, filter repository.PermissionChangeFilter, send func(model.PermissionChange) error) error {
+	var revision int64
+	if from == nil {
+		head, err := f.repo.LatestRevision(ctx)
+		if err != nil {
+			return err
+		}
+		revision = head
+	} else {
+		oldest, err := f.repo.OldestRevision(ctx)
+		if err != nil {
+			return err
+		}
+		if oldest > *from+1 {
+			return ErrRevisionCompacted
+		}
+		revision = *from
+	}
+
+	ticker := time.NewTicker(changeFeedPollInterval)
+	defer ticker.Stop()
+	for {
+		// Take the wake channel before reading so a change recorded meanwhile is not missed.
+		wake := f.waiter()
+		for {
+			changes, err := f.repo.ListAfter(ctx, revision, filter, changeFeedBatchSize)
+			if err != nil {
+				return err
+			}
+			for _, change := range changes {
+				if err := send(change); err != nil {
+					return err
+				}
+				revision = change.Revision
+			}
+			if len(changes) < changeFeedBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunPruner deletes changes older than retention every hour until ctx is cancelled.
+func (f *ChangeFeed) RunPruner(ctx context.Context, retention time.Duration) {
+	ticker := time.NewTicker(changeFeedPruneEvery)
+	defer ticker.Stop()
+	for {
+		pruned, err := f.repo.DeleteBefore(ctx, time.Now().Add(-retention))
+		if err != nil && ctx.Err() == nil {
+			f.logger.Error("failed to prune permission changes", zap.Error(err))
+		} else if pruned > 0 {
+			f.logger.Info("pruned permission changes", zap.Int64("deleted", pruned))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
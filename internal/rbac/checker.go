@@ -2,23 +2,64 @@ package rbac
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/bernardoforcillo/authlayer/internal/model"
+	"github.com/bernardoforcillo/authlayer/internal/repository"
 
 	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	publishTimeout = 5 * time.Second
+	rebuildTimeout = 30 * time.Second
 )
 
 // Checker provides high-level permission checking.
 type Checker struct {
-	resolver *Resolver
+	resolver     *Resolver
+	materializer *Materializer
+	bus          InvalidationBus
+	instanceID   string
+	logger       *zap.Logger
+
+	// rebuildMu guards the background rebuild: flushes arriving while one runs are
+	// coalesced into a single follow-up rebuild.
+	rebuildMu      sync.Mutex
+	rebuilding     bool
+	rebuildPending bool
 }
 
-// NewChecker creates a new permission checker.
-func NewChecker(resolver *Resolver) *Checker {
-	return &Checker{resolver: resolver}
+// NewChecker creates a new permission checker. Cache invalidations are applied locally
+// and, when bus is non-nil, published to the other server instances.
+// When materializer is non-nil, checks read the effective_permissions table instead of
+// resolving live, and every Update refreshes the affected rows.
+func NewChecker(resolver *Resolver, materializer *Materializer, bus InvalidationBus, logger *zap.Logger) *Checker {
+	return &Checker{
+		resolver:     resolver,
+		materializer: materializer,
+		bus:          bus,
+		instanceID:   uuid.NewString(),
+		logger:       logger,
+	}
 }
 
 // CheckPermission returns true if the user has the specified permission in the given scope.
 // It also returns the name of the role that granted the permission.
 func (c *Checker) CheckPermission(ctx context.Context, userID uuid.UUID, permissionName string, orgID *uuid.UUID) (bool, string, error) {
+	if c.materializer != nil {
+		// Users hold no permissions outside an org.
+		if orgID == nil {
+			return false, "", nil
+		}
+		role, ok, err := c.materializer.repo.FindSourceRole(ctx, userPrincipal(userID), model.ScopeTypeOrg, *orgID, permissionName)
+		return ok, role, err
+	}
+
 	perms, err := c.resolver.ResolveUserPermissions(ctx, userID, orgID)
 	if err != nil {
 		return false, "", err
@@ -35,6 +76,14 @@ func (c *Checker) CheckPermission(ctx context.Context, userID uuid.UUID, permiss
 
 // CheckServiceAccountPermission checks if a service account has the given permission.
 func (c *Checker) CheckServiceAccountPermission(ctx context.Context, saID uuid.UUID, permissionName string, orgID *uuid.UUID) (bool, error) {
+	if c.materializer != nil {
+		scopeType, scopeID := model.ScopeTypeGlobal, uuid.Nil
+		if orgID != nil {
+			scopeType, scopeID = model.ScopeTypeOrg, *orgID
+		}
+		return c.materializer.repo.Exists(ctx, serviceAccountPrincipal(saID), scopeType, scopeID, permissionName)
+	}
+
 	perms, err := c.resolver.ResolveServiceAccountPermissions(ctx, saID, orgID)
 	if err != nil {
 		return false, err
@@ -49,7 +98,199 @@ func (c *Checker) CheckServiceAccountPermission(ctx context.Context, saID uuid.U
 	return false, nil
 }
 
-// InvalidateUserCache clears the permission cache for a user.
-func (c *Checker) InvalidateUserCache(userID uuid.UUID) {
-	c.resolver.cache.InvalidateUser(userID)
+// CheckProjectPermission returns true if the user has the permission in the project,
+// either through its org roles or through a project grant.
+func (c *Checker) CheckProjectPermission(ctx context.Context, userID uuid.UUID, permissionName string, projectID uuid.UUID) (bool, error) {
+	if c.materializer != nil {
+		return c.materializer.repo.ExistsInProject(ctx, userPrincipal(userID), projectID, permissionName)
+	}
+
+	perms, err := c.resolver.ResolveUserProjectPermissions(ctx, userID, projectID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, p := range perms {
+		if p.Name == permissionName {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// CheckServiceAccountProjectPermission checks if a service account has the permission in the project.
+func (c *Checker) CheckServiceAccountProjectPermission(ctx context.Context, saID uuid.UUID, permissionName string, projectID uuid.UUID) (bool, error) {
+	if c.materializer != nil {
+		return c.materializer.repo.ExistsInProject(ctx, serviceAccountPrincipal(saID), projectID, permissionName)
+	}
+
+	perms, err := c.resolver.ResolveServiceAccountProjectPermissions(ctx, saID, projectID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, p := range perms {
+		if p.Name == permissionName {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Changes records the users, service accounts and roles whose permissions a write affects.
+type Changes struct {
+	invalidations []Invalidation
+}
+
+// User records a change to the permissions of a user.
+func (c *Changes) User(userID uuid.UUID) {
+	c.add(Invalidation{Kind: InvalidationUser, ID: userID})
+}
+
+// ServiceAccount records a change to the permissions of a service account.
+func (c *Changes) ServiceAccount(saID uuid.UUID) {
+	c.add(Invalidation{Kind: InvalidationServiceAccount, ID: saID})
+}
+
+// Role records a change to the permissions or position in the hierarchy of a role, which
+// affects every principal holding it or one of its descendants.
+func (c *Changes) Role(roleID uuid.UUID) {
+	c.add(Invalidation{Kind: InvalidationRole, ID: roleID})
+}
+
+func (c *Changes) add(inv Invalidation) {
+	if !slices.Contains(c.invalidations, inv) {
+		c.invalidations = append(c.invalidations, inv)
+	}
+}
+
+// Update runs fn, a write recording what it affects in changes, and then clears the
+// affected cached permissions on every instance. When checks read the materialized table,
+// fn runs in a transaction that also refreshes the affected rows, so the rows commit or
+// roll back with the write and a failed refresh fails it. Update must not be called
+// inside another transaction, as the caches would be cleared before it commits.
+func (c *Checker) Update(ctx context.Context, fn func(ctx context.Context, changes *Changes) error) error {
+	changes := &Changes{}
+	if c.materializer == nil {
+		if err := fn(ctx, changes); err != nil {
+			return err
+		}
+	} else {
+		changed := false
+		err := c.materializer.tx.InTx(ctx, func(ctx context.Context) error {
+			if err := fn(ctx, changes); err != nil {
+				return err
+			}
+			for _, inv := range changes.invalidations {
+				ch, err := c.materializer.apply(ctx, inv)
+				if err != nil {
+					return fmt.Errorf("refresh effective permissions of %s %s: %w", inv.Kind, inv.ID, err)
+				}
+				changed = changed || ch
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if changed {
+			c.materializer.notify()
+		}
+	}
+
+	for _, inv := range changes.invalidations {
+		c.invalidate(inv)
+	}
+	return nil
+}
+
+// InvalidateAllCaches clears every cached permission set on every instance. It is meant
+// for rare changes whose dependents are too expensive to enumerate; materialized rows are
+// rebuilt in the background.
+func (c *Checker) InvalidateAllCaches() {
+	c.invalidate(Invalidation{Kind: InvalidationAll})
+}
+
+// ListenForInvalidations applies invalidations published by other instances until ctx is
+// cancelled. It returns immediately when no bus is configured.
+func (c *Checker) ListenForInvalidations(ctx context.Context) error {
+	if c.bus == nil {
+		return nil
+	}
+	return c.bus.Subscribe(ctx, func(inv Invalidation) {
+		if inv.Origin == c.instanceID {
+			return
+		}
+		applyInvalidation(ctx, c.resolver.cache, inv)
+		// The origin has already refreshed the shared table; only local watchers need waking.
+		if c.materializer != nil {
+			c.materializer.notify()
+		}
+	})
+}
+
+func (c *Checker) invalidate(inv Invalidation) {
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+
+	applyInvalidation(ctx, c.resolver.cache, inv)
+	if inv.Kind == InvalidationAll {
+		c.rebuild()
+	}
+	if c.bus == nil {
+		return
+	}
+
+	inv.Origin = c.instanceID
+	if err := c.bus.Publish(ctx, inv); err != nil {
+		// Other instances fall back to the cache TTL.
+		c.logger.Error("failed to publish cache invalidation",
+			zap.String("kind", string(inv.Kind)), zap.Stringer("id", inv.ID), zap.Error(err))
+	}
+}
+
+// rebuild recomputes every materialized row in the background, coalescing rebuilds
+// requested while one runs into a single follow-up. The table is shared, so only the
+// instance that made the change rebuilds it.
+func (c *Checker) rebuild() {
+	if c.materializer == nil {
+		return
+	}
+
+	c.rebuildMu.Lock()
+	defer c.rebuildMu.Unlock()
+	if c.rebuilding {
+		c.rebuildPending = true
+		return
+	}
+	c.rebuilding = true
+	go func() {
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), rebuildTimeout)
+			if _, err := c.materializer.Rebuild(ctx); err != nil {
+				// Rows stay stale until the next rebuild.
+				c.logger.Error("failed to rebuild effective permissions", zap.Error(err))
+			}
+			cancel()
+
+			c.rebuildMu.Lock()
+			if !c.rebuildPending {
+				c.rebuilding = false
+				c.rebuildMu.Unlock()
+				return
+			}
+			c.rebuildPending = false
+			c.rebuildMu.Unlock()
+		}
+	}()
+}
+
+func userPrincipal(userID uuid.UUID) repository.PrincipalRef {
+	return repository.PrincipalRef{Type: model.PrincipalTypeUser, ID: userID}
+}
+
+func serviceAccountPrincipal(saID uuid.UUID) repository.PrincipalRef {
+	return repository.PrincipalRef{Type: model.PrincipalTypeServiceAccount, ID: saID}
 }
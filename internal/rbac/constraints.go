@@ -0,0 +1,418 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bernardoforcillo/authlayer/internal/model"
+	"github.com/bernardoforcillo/authlayer/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Violation describes a principal holding more than one role of a separation-of-duties constraint.
+// RoleIDs lists the conflicting roles, including roles held only through inheritance.
+type Violation struct {
+	Constraint    model.RoleConstraint
+	PrincipalType model.PrincipalType
+	PrincipalID   uuid.UUID
+	RoleIDs       []uuid.UUID
+}
+
+// ConstraintViolationError is returned when a role assignment would break one or more constraints.
+type ConstraintViolationError struct {
+	Violations []Violation
+}
+
+func (e *ConstraintViolationError) Error() string {
+	names := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		names[i] = v.Constraint.Name
+	}
+	return fmt.Sprintf("role assignment violates separation-of-duties constraint(s): %s", strings.Join(names, ", "))
+}
+
+// ConstraintEnforcer validates role assignments against separation-of-duties constraints.
+// A principal holds a role in an org through its org membership, its team memberships
+// in that org, its project bindings (directly or through a team), or (for service accounts)
+// its role bindings, plus every ancestor of those roles.
+type ConstraintEnforcer struct {
+	constraintRepo    repository.RoleConstraintRepository
+	roleRepo          repository.RoleRepository
+	orgMemberRepo     repository.OrganizationMemberRepository
+	teamMemberRepo    repository.TeamMemberRepository
+	saRoleRepo        repository.ServiceAccountRoleRepository
+	projectMemberRepo repository.ProjectMemberRepository
+	tx                repository.Transactor
+	maxDepth          int
+}
+
+// NewConstraintEnforcer creates a new separation-of-duties enforcer.
+func NewConstraintEnforcer(
+	constraintRepo repository.RoleConstraintRepository,
+	roleRepo repository.RoleRepository,
+	orgMemberRepo repository.OrganizationMemberRepository,
+	teamMemberRepo repository.TeamMemberRepository,
+	saRoleRepo repository.ServiceAccountRoleRepository,
+	projectMemberRepo repository.ProjectMemberRepository,
+	tx repository.Transactor,
+) *ConstraintEnforcer {
+	return &ConstraintEnforcer{
+		constraintRepo:    constraintRepo,
+		roleRepo:          roleRepo,
+		orgMemberRepo:     orgMemberRepo,
+		teamMemberRepo:    teamMemberRepo,
+		saRoleRepo:        saRoleRepo,
+		projectMemberRepo: projectMemberRepo,
+		tx:                tx,
+		maxDepth:          MaxHierarchyDepth,
+	}
+}
+
+// Assign runs fn, which validates a role assignment in the org and stores it, in one
+// transaction holding the org's assignment lock. Validation reads every role the principal
+// holds, and a team binding reaches every team member, so assignments in the org are
+// serialized: two concurrent ones cannot each pass and together break a constraint.
+func (e *ConstraintEnforcer) Assign(ctx context.Context, orgID uuid.UUID, fn func(ctx context.Context) error) error {
+	return e.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := e.tx.Lock(ctx, "role_assignments:"+orgID.String()); err != nil {
+			return err
+		}
+		return fn(ctx)
+	})
+}
+
+// ValidateUserOrgRole checks that making roleID the user's org role keeps the user within all constraints.
+// The user's current org role is replaced, so it does not count towards a conflict.
+func (e *ConstraintEnforcer) ValidateUserOrgRole(ctx context.Context, orgID, userID, roleID uuid.UUID) error {
+	teamMembers, err := e.teamMemberRepo.ListByUserIDAndOrgID(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+
+	roleIDs := []uuid.UUID{roleID}
+	for _, tm := range teamMembers {
+		roleIDs = append(roleIDs, tm.RoleID)
+	}
+
+	projectRoleIDs, err := e.userProjectRoles(ctx, orgID, userID, teamMembers, nil)
+	if err != nil {
+		return err
+	}
+	roleIDs = append(roleIDs, projectRoleIDs...)
+
+	return e.validate(ctx, orgID, model.PrincipalTypeUser, userID, roleIDs)
+}
+
+// ValidateUserTeamRole checks that giving the user roleID in the team keeps the user within all constraints.
+// Any existing membership in the same team is replaced.
+func (e *ConstraintEnforcer) ValidateUserTeamRole(ctx context.Context, orgID, teamID, userID, roleID uuid.UUID) error {
+	roleIDs := []uuid.UUID{roleID}
+
+	membership, err := e.orgMemberRepo.GetMembership(ctx, orgID, userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if membership != nil {
+		roleIDs = append(roleIDs, membership.RoleID)
+	}
+
+	teamMembers, err := e.teamMemberRepo.ListByUserIDAndOrgID(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	for _, tm := range teamMembers {
+		if tm.TeamID == teamID {
+			continue
+		}
+		roleIDs = append(roleIDs, tm.RoleID)
+	}
+
+	// Joining the team also grants the team's project roles.
+	teamMembers = append(teamMembers, model.TeamMember{TeamID: teamID, UserID: userID})
+	projectRoleIDs, err := e.userProjectRoles(ctx, orgID, userID, teamMembers, nil)
+	if err != nil {
+		return err
+	}
+	roleIDs = append(roleIDs, projectRoleIDs...)
+
+	return e.validate(ctx, orgID, model.PrincipalTypeUser, userID, roleIDs)
+}
+
+// ValidateServiceAccountRole checks that binding roleID to the service account keeps it within all constraints.
+func (e *ConstraintEnforcer) ValidateServiceAccountRole(ctx context.Context, orgID, saID, roleID uuid.UUID) error {
+	saRoles, err := e.saRoleRepo.ListByServiceAccountID(ctx, saID)
+	if err != nil {
+		return err
+	}
+
+	roleIDs := []uuid.UUID{roleID}
+	for _, sar := range saRoles {
+		if sar.OrgID == orgID {
+			roleIDs = append(roleIDs, sar.RoleID)
+		}
+	}
+
+	bindings, err := e.projectMemberRepo.ListByOrgIDAndPrincipals(ctx, orgID, model.PrincipalTypeServiceAccount, []uuid.UUID{saID})
+	if err != nil {
+		return err
+	}
+	for _, pm := range bindings {
+		roleIDs = append(roleIDs, pm.RoleID)
+	}
+
+	return e.validate(ctx, orgID, model.PrincipalTypeServiceAccount, saID, roleIDs)
+}
+
+// ValidateProjectMember checks that binding the principal to roleID in the project keeps it within all constraints.
+// Any existing binding of the principal in the same project is replaced. For a team, every current
+// member of the team is checked, since they all receive the project role.
+func (e *ConstraintEnforcer) ValidateProjectMember(ctx context.Context, orgID, projectID uuid.UUID, principalType model.PrincipalType, principalID, roleID uuid.UUID) error {
+	replaced := func(pm model.ProjectMember) bool {
+		return pm.ProjectID == projectID && pm.PrincipalType == principalType && pm.PrincipalID == principalID
+	}
+
+	switch principalType {
+	case model.PrincipalTypeUser:
+		return e.validateUserWithProjectRole(ctx, orgID, principalID, roleID, replaced)
+
+	case model.PrincipalTypeTeam:
+		members, err := e.teamMemberRepo.ListAllByTeamID(ctx, principalID)
+		if err != nil {
+			return err
+		}
+		for _, m := range members {
+			if err := e.validateUserWithProjectRole(ctx, orgID, m.UserID, roleID, replaced); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case model.PrincipalTypeServiceAccount:
+		saRoles, err := e.saRoleRepo.ListByServiceAccountID(ctx, principalID)
+		if err != nil {
+			return err
+		}
+		roleIDs := []uuid.UUID{roleID}
+		for _, sar := range saRoles {
+			if sar.OrgID == orgID {
+				roleIDs = append(roleIDs, sar.RoleID)
+			}
+		}
+		bindings, err := e.projectMemberRepo.ListByOrgIDAndPrincipals(ctx, orgID, model.PrincipalTypeServiceAccount, []uuid.UUID{principalID})
+		if err != nil {
+			return err
+		}
+		for _, pm := range bindings {
+			if !replaced(pm) {
+				roleIDs = append(roleIDs, pm.RoleID)
+			}
+		}
+		return e.validate(ctx, orgID, model.PrincipalTypeServiceAccount, principalID, roleIDs)
+
+	default:
+		return fmt.Errorf("unsupported principal type %q", principalType)
+	}
+}
+
+// FindViolations reports every principal in the org that currently breaks a constraint.
+func (e *ConstraintEnforcer) FindViolations(ctx context.Context, orgID uuid.UUID) ([]Violation, error) {
+	constraints, err := e.constraintRepo.ListApplicable(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if len(constraints) == 0 {
+		return nil, nil
+	}
+
+	userRoles := make(map[uuid.UUID][]uuid.UUID)
+	members, err := e.orgMemberRepo.ListAllByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range members {
+		userRoles[m.UserID] = append(userRoles[m.UserID], m.RoleID)
+	}
+	teamMembers, err := e.teamMemberRepo.ListByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	teamUsers := make(map[uuid.UUID][]uuid.UUID)
+	for _, tm := range teamMembers {
+		userRoles[tm.UserID] = append(userRoles[tm.UserID], tm.RoleID)
+		teamUsers[tm.TeamID] = append(teamUsers[tm.TeamID], tm.UserID)
+	}
+
+	saRoles := make(map[uuid.UUID][]uuid.UUID)
+	bindings, err := e.saRoleRepo.ListByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, sar := range bindings {
+		saRoles[sar.ServiceAccountID] = append(saRoles[sar.ServiceAccountID], sar.RoleID)
+	}
+
+	projectBindings, err := e.projectMemberRepo.ListByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, pm := range projectBindings {
+		switch pm.PrincipalType {
+		case model.PrincipalTypeUser:
+			userRoles[pm.PrincipalID] = append(userRoles[pm.PrincipalID], pm.RoleID)
+		case model.PrincipalTypeTeam:
+			for _, userID := range teamUsers[pm.PrincipalID] {
+				userRoles[userID] = append(userRoles[userID], pm.RoleID)
+			}
+		case model.PrincipalTypeServiceAccount:
+			saRoles[pm.PrincipalID] = append(saRoles[pm.PrincipalID], pm.RoleID)
+		}
+	}
+
+	// Ancestor lookups are shared across principals since most hold the same few roles.
+	ancestry := make(map[uuid.UUID][]uuid.UUID)
+
+	var violations []Violation
+	for userID, roleIDs := range userRoles {
+		found, err := e.evaluate(ctx, constraints, ancestry, model.PrincipalTypeUser, userID, roleIDs)
+		if err != nil {
+			return nil, err
+		}
+		violations = append(violations, found...)
+	}
+	for saID, roleIDs := range saRoles {
+		found, err := e.evaluate(ctx, constraints, ancestry, model.PrincipalTypeServiceAccount, saID, roleIDs)
+		if err != nil {
+			return nil, err
+		}
+		violations = append(violations, found...)
+	}
+
+	return violations, nil
+}
+
+// validateUserWithProjectRole checks a user holding roleID in a project on top of every role
+// it already holds in the org, except the project bindings matched by skip.
+func (e *ConstraintEnforcer) validateUserWithProjectRole(ctx context.Context, orgID, userID, roleID uuid.UUID, skip func(model.ProjectMember) bool) error {
+	roleIDs := []uuid.UUID{roleID}
+
+	membership, err := e.orgMemberRepo.GetMembership(ctx, orgID, userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if membership != nil {
+		roleIDs = append(roleIDs, membership.RoleID)
+	}
+
+	teamMembers, err := e.teamMemberRepo.ListByUserIDAndOrgID(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	for _, tm := range teamMembers {
+		roleIDs = append(roleIDs, tm.RoleID)
+	}
+
+	projectRoleIDs, err := e.userProjectRoles(ctx, orgID, userID, teamMembers, skip)
+	if err != nil {
+		return err
+	}
+	roleIDs = append(roleIDs, projectRoleIDs...)
+
+	return e.validate(ctx, orgID, model.PrincipalTypeUser, userID, roleIDs)
+}
+
+// userProjectRoles returns the roles a user holds through project bindings in the org,
+// bound either to the user directly or to one of the given team memberships.
+func (e *ConstraintEnforcer) userProjectRoles(ctx context.Context, orgID, userID uuid.UUID, teamMembers []model.TeamMember, skip func(model.ProjectMember) bool) ([]uuid.UUID, error) {
+	bindings, err := e.projectMemberRepo.ListByOrgIDAndPrincipals(ctx, orgID, model.PrincipalTypeUser, []uuid.UUID{userID})
+	if err != nil {
+		return nil, err
+	}
+
+	teamIDs := make([]uuid.UUID, len(teamMembers))
+	for i, tm := range teamMembers {
+		teamIDs[i] = tm.TeamID
+	}
+	teamBindings, err := e.projectMemberRepo.ListByOrgIDAndPrincipals(ctx, orgID, model.PrincipalTypeTeam, teamIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var roleIDs []uuid.UUID
+	for _, pm := range append(bindings, teamBindings...) {
+		if skip != nil && skip(pm) {
+			continue
+		}
+		roleIDs = append(roleIDs, pm.RoleID)
+	}
+	return roleIDs, nil
+}
+
+func (e *ConstraintEnforcer) validate(ctx context.Context, orgID uuid.UUID, principalType model.PrincipalType, principalID uuid.UUID, roleIDs []uuid.UUID) error {
+	constraints, err := e.constraintRepo.ListApplicable(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if len(constraints) == 0 {
+		return nil
+	}
+
+	violations, err := e.evaluate(ctx, constraints, make(map[uuid.UUID][]uuid.UUID), principalType, principalID, roleIDs)
+	if err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return &ConstraintViolationError{Violations: violations}
+	}
+	return nil
+}
+
+func (e *ConstraintEnforcer) evaluate(
+	ctx context.Context,
+	constraints []model.RoleConstraint,
+	ancestry map[uuid.UUID][]uuid.UUID,
+	principalType model.PrincipalType,
+	principalID uuid.UUID,
+	roleIDs []uuid.UUID,
+) ([]Violation, error) {
+	held := make(map[uuid.UUID]bool)
+	for _, roleID := range roleIDs {
+		ancestorIDs, ok := ancestry[roleID]
+		if !ok {
+			ancestors, err := e.roleRepo.GetAncestors(ctx, roleID, e.maxDepth)
+			if err != nil {
+				return nil, err
+			}
+			ancestorIDs = make([]uuid.UUID, len(ancestors))
+			for i, a := range ancestors {
+				ancestorIDs[i] = a.ID
+			}
+			ancestry[roleID] = ancestorIDs
+		}
+		for _, id := range ancestorIDs {
+			held[id] = true
+		}
+	}
+
+	var violations []Violation
+	for _, c := range constraints {
+		var conflicting []uuid.UUID
+		for _, role := range c.Roles {
+			if held[role.ID] {
+				conflicting = append(conflicting, role.ID)
+			}
+		}
+		if len(conflicting) > 1 {
+			violations = append(violations, Violation{
+				Constraint:    c,
+				PrincipalType: principalType,
+				PrincipalID:   principalID,
+				RoleIDs:       conflicting,
+			})
+		}
+	}
+	return violations, nil
+}
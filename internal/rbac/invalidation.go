@@ -0,0 +1,51 @@
+package rbac
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// InvalidationKind identifies what an Invalidation evicts from the permission cache.
+type InvalidationKind string
+
+const (
+	// InvalidationUser evicts every cached entry of a single user.
+	InvalidationUser InvalidationKind = "user"
+	// InvalidationServiceAccount evicts every cached entry of a single service account.
+	InvalidationServiceAccount InvalidationKind = "service_account"
+	// InvalidationRole evicts every entry derived from a role or from one of its descendants.
+	InvalidationRole InvalidationKind = "role"
+	// InvalidationAll flushes the whole cache.
+	InvalidationAll InvalidationKind = "all"
+)
+
+// Invalidation is a cache eviction shared between server instances.
+type Invalidation struct {
+	Kind InvalidationKind `json:"kind"`
+	ID   uuid.UUID        `json:"id"`
+	// Origin identifies the publishing instance so it can skip its own events.
+	Origin string `json:"origin"`
+}
+
+// InvalidationBus fans cache invalidations out to every server instance.
+type InvalidationBus interface {
+	// Publish sends the invalidation to all subscribers, including other instances.
+	Publish(ctx context.Context, inv Invalidation) error
+	// Subscribe calls handler for every invalidation received until ctx is cancelled.
+	Subscribe(ctx context.Context, handler func(Invalidation)) error
+}
+
+// applyInvalidation evicts the cache entries targeted by the invalidation.
+func applyInvalidation(ctx context.Context, cache Cache, inv Invalidation) {
+	switch inv.Kind {
+	case InvalidationUser:
+		cache.InvalidateUser(ctx, inv.ID)
+	case InvalidationServiceAccount:
+		cache.InvalidateServiceAccount(ctx, inv.ID)
+	case InvalidationRole:
+		cache.InvalidateRole(ctx, inv.ID)
+	case InvalidationAll:
+		cache.Clear(ctx)
+	}
+}
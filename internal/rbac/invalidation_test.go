@@ -0,0 +1,122 @@
+package rbac
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryBus delivers every published invalidation synchronously to all subscribers.
+type memoryBus struct {
+	mu       sync.Mutex
+	handlers []func(Invalidation)
+}
+
+func (b *memoryBus) Publish(_ context.Context, inv Invalidation) error {
+	b.mu.Lock()
+	handlers := append([]func(Invalidation){}, b.handlers...)
+	b.mu.Unlock()
+	for _, h := range handlers {
+		h(inv)
+	}
+	return nil
+}
+
+func (b *memoryBus) Subscribe(ctx context.Context, handler func(Invalidation)) error {
+	b.mu.Lock()
+	b.handlers = append(b.handlers, handler)
+	b.mu.Unlock()
+	<-ctx.Done()
+	return nil
+}
+
+func (b *memoryBus) subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.handlers)
+}
+
+// countingCache records the invalidations applied to a MemoryCache.
+type countingCache struct {
+	*MemoryCache
+	mu    sync.Mutex
+	users int
+}
+
+func (c *countingCache) InvalidateUser(ctx context.Context, userID uuid.UUID) {
+	c.mu.Lock()
+	c.users++
+	c.mu.Unlock()
+	c.MemoryCache.InvalidateUser(ctx, userID)
+}
+
+func (c *countingCache) userInvalidations() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.users
+}
+
+func newTestChecker(cache Cache, bus InvalidationBus) *Checker {
+	resolver := NewResolver(nil, nil, nil, cache)
+	return NewChecker(resolver, nil, bus, zap.NewNop())
+}
+
+func TestCheckerInvalidationReachesOtherInstances(t *testing.T) {
+	bus := &memoryBus{}
+	local := &countingCache{MemoryCache: NewMemoryCache(time.Minute, 0)}
+	remote := &countingCache{MemoryCache: NewMemoryCache(time.Minute, 0)}
+	a, b := newTestChecker(local, bus), newTestChecker(remote, bus)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go a.ListenForInvalidations(ctx)
+	go b.ListenForInvalidations(ctx)
+	require.Eventually(t, func() bool { return bus.subscribers() == 2 }, time.Second, time.Millisecond)
+
+	userID, otherID := uuid.New(), uuid.New()
+	for _, c := range []Cache{local, remote} {
+		c.Set(ctx, cacheKey(userID, nil), []string{"a"}, nil)
+		c.Set(ctx, cacheKey(otherID, nil), []string{"b"}, nil)
+	}
+
+	require.NoError(t, a.Update(ctx, func(ctx context.Context, changes *Changes) error {
+		changes.User(userID)
+		return nil
+	}))
+
+	for _, c := range []Cache{local, remote} {
+		_, ok := c.Get(ctx, cacheKey(userID, nil))
+		assert.False(t, ok)
+		_, ok = c.Get(ctx, cacheKey(otherID, nil))
+		assert.True(t, ok)
+	}
+	// The publisher applies its own invalidation once and skips the echo from the bus.
+	assert.Equal(t, 1, local.userInvalidations())
+	assert.Equal(t, 1, remote.userInvalidations())
+}
+
+func TestApplyInvalidation(t *testing.T) {
+	ctx := context.Background()
+	role, other := uuid.New(), uuid.New()
+	userID, saID := uuid.New(), uuid.New()
+
+	cache := NewMemoryCache(time.Minute, 0)
+	cache.Set(ctx, cacheKey(userID, nil), []string{"a"}, []uuid.UUID{role})
+	cache.Set(ctx, serviceAccountCacheKey(saID, nil), []string{"b"}, []uuid.UUID{other})
+
+	applyInvalidation(ctx, cache, Invalidation{Kind: InvalidationRole, ID: role})
+	_, ok := cache.Get(ctx, cacheKey(userID, nil))
+	assert.False(t, ok)
+	_, ok = cache.Get(ctx, serviceAccountCacheKey(saID, nil))
+	assert.True(t, ok)
+
+	applyInvalidation(ctx, cache, Invalidation{Kind: InvalidationAll})
+	_, ok = cache.Get(ctx, serviceAccountCacheKey(saID, nil))
+	assert.False(t, ok)
+}
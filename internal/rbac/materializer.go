@@ -0,0 +1,270 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bernardoforcillo/authlayer/internal/model"
+	"github.com/bernardoforcillo/authlayer/internal/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Materializer keeps the effective_permissions table in step with live resolution. Each
+// refresh recomputes every row of a principal and swaps them in a single transaction, so
+// checks never observe a half-updated principal.
+type Materializer struct {
+	resolver *Resolver
+	repo     repository.EffectivePermissionRepository
+	tx       repository.Transactor
+	feed     *ChangeFeed
+	logger   *zap.Logger
+}
+
+// NewMaterializer creates a materializer that derives rows from the resolver's sources.
+// Writes that change permissions refresh their rows in their own transaction through tx.
+// When feed is non-nil, its watchers are woken whenever a refresh records changes.
+func NewMaterializer(resolver *Resolver, repo repository.EffectivePermissionRepository, tx repository.Transactor, feed *ChangeFeed, logger *zap.Logger) *Materializer {
+	return &Materializer{resolver: resolver, repo: repo, tx: tx, feed: feed, logger: logger}
+}
+
+// Discrepancy is a difference between the table and live resolution.
+type Discrepancy struct {
+	Principal    repository.PrincipalRef
+	ScopeType    model.ScopeType
+	ScopeID      uuid.UUID
+	Permission   string
+	SourceRoleID uuid.UUID
+	// Missing is true when live resolution grants the permission but the table lacks the
+	// row, and false when the table holds a row live resolution no longer produces.
+	Missing bool
+}
+
+func (d Discrepancy) String() string {
+	state := "stale"
+	if d.Missing {
+		state = "missing"
+	}
+	return fmt.Sprintf("%s %s:%s %s:%s %s (role %s)",
+		state, d.Principal.Type, d.Principal.ID, d.ScopeType, d.ScopeID, d.Permission, d.SourceRoleID)
+}
+
+// RefreshPrincipal recomputes all rows of a user or service account.
+func (m *Materializer) RefreshPrincipal(ctx context.Context, principal repository.PrincipalRef) error {
+	changed, err := m.refreshPrincipal(ctx, principal)
+	if err != nil {
+		return err
+	}
+	if changed {
+		m.notify()
+	}
+	return nil
+}
+
+// refreshPrincipal recomputes all rows of a principal and reports whether any changed,
+// leaving watchers asleep so callers inside a transaction can wake them after it commits.
+func (m *Materializer) refreshPrincipal(ctx context.Context, principal repository.PrincipalRef) (bool, error) {
+	rows, err := m.compute(ctx, principal)
+	if err != nil {
+		return false, err
+	}
+	changes, err := m.repo.ReplaceForPrincipal(ctx, principal, rows)
+	if err != nil {
+		return false, err
+	}
+	return len(changes) > 0, nil
+}
+
+// refreshRole recomputes the rows of every principal whose permissions may depend on the
+// role, which includes the holders of its descendants.
+func (m *Materializer) refreshRole(ctx context.Context, roleID uuid.UUID) (bool, error) {
+	principals, err := m.repo.ListPrincipalsForRole(ctx, roleID)
+	if err != nil {
+		return false, err
+	}
+	changed := false
+	for _, p := range principals {
+		c, err := m.refreshPrincipal(ctx, p)
+		if err != nil {
+			return false, err
+		}
+		changed = changed || c
+	}
+	return changed, nil
+}
+
+// Rebuild recomputes the rows of every user and service account and drops the rows of
+// deleted ones. It returns the number of principals refreshed.
+func (m *Materializer) Rebuild(ctx context.Context) (int, error) {
+	principals, err := m.repo.ListPrincipals(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for i, p := range principals {
+		if err := m.RefreshPrincipal(ctx, p); err != nil {
+			return i, fmt.Errorf("refresh %s %s: %w", p.Type, p.ID, err)
+		}
+	}
+
+	pruned, err := m.repo.DeleteOrphans(ctx)
+	if err != nil {
+		return len(principals), err
+	}
+	m.logger.Info("rebuilt effective permissions",
+		zap.Int("principals", len(principals)), zap.Int64("orphaned_rows_deleted", pruned))
+	return len(principals), nil
+}
+
+// Verify compares the table against live resolution for every principal.
+func (m *Materializer) Verify(ctx context.Context) ([]Discrepancy, error) {
+	principals, err := m.repo.ListPrincipals(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var discrepancies []Discrepancy
+	for _, p := range principals {
+		live, err := m.compute(ctx, p)
+		if err != nil {
+			return nil, fmt.Errorf("resolve %s %s: %w", p.Type, p.ID, err)
+		}
+		stored, err := m.repo.ListByPrincipal(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+
+		storedKeys := make(map[string]bool, len(stored))
+		for _, row := range stored {
+			storedKeys[rowKey(row)] = true
+		}
+		liveKeys := make(map[string]bool, len(live))
+		for _, row := range live {
+			liveKeys[rowKey(row)] = true
+			if !storedKeys[rowKey(row)] {
+				discrepancies = append(discrepancies, discrepancyFor(p, row, true))
+			}
+		}
+		for _, row := range stored {
+			if !liveKeys[rowKey(row)] {
+				discrepancies = append(discrepancies, discrepancyFor(p, row, false))
+			}
+		}
+	}
+
+	return discrepancies, nil
+}
+
+// apply refreshes the rows affected by a user, service account or role invalidation and
+// reports whether any changed.
+func (m *Materializer) apply(ctx context.Context, inv Invalidation) (bool, error) {
+	switch inv.Kind {
+	case InvalidationUser:
+		return m.refreshPrincipal(ctx, userPrincipal(inv.ID))
+	case InvalidationServiceAccount:
+		return m.refreshPrincipal(ctx, serviceAccountPrincipal(inv.ID))
+	case InvalidationRole:
+		return m.refreshRole(ctx, inv.ID)
+	}
+	return false, nil
+}
+
+// compute resolves the principal in every org and project it may hold permissions in, plus
+// the org-less scope for service accounts.
+func (m *Materializer) compute(ctx context.Context, principal repository.PrincipalRef) ([]model.EffectivePermission, error) {
+	// Deleted principals keep no rows.
+	var err error
+	switch principal.Type {
+	case model.PrincipalTypeUser:
+		_, err = m.resolver.userRepo.GetByID(ctx, principal.ID)
+	case model.PrincipalTypeServiceAccount:
+		_, err = m.resolver.saRepo.GetByID(ctx, principal.ID)
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	orgIDs, projectIDs, err := m.repo.ListCandidateScopes(ctx, principal)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []model.EffectivePermission
+	add := func(scopeType model.ScopeType, scopeID uuid.UUID, seed repository.RoleSeed) error {
+		grants, err := m.resolver.grants(ctx, seed)
+		if err != nil {
+			return err
+		}
+		for _, g := range grants {
+			if g.Permission == nil {
+				continue
+			}
+			rows = append(rows, model.EffectivePermission{
+				PrincipalType:  principal.Type,
+				PrincipalID:    principal.ID,
+				ScopeType:      scopeType,
+				ScopeID:        scopeID,
+				PermissionName: g.Permission.Name,
+				SourceRoleID:   g.RoleID,
+				PermissionID:   g.Permission.ID,
+			})
+		}
+		return nil
+	}
+
+	switch principal.Type {
+	case model.PrincipalTypeUser:
+		for _, orgID := range orgIDs {
+			if err := add(model.ScopeTypeOrg, orgID, userSeed(principal.ID, &orgID)); err != nil {
+				return nil, err
+			}
+		}
+		for _, projectID := range projectIDs {
+			if err := add(model.ScopeTypeProject, projectID, userProjectSeed(principal.ID, projectID)); err != nil {
+				return nil, err
+			}
+		}
+	case model.PrincipalTypeServiceAccount:
+		if err := add(model.ScopeTypeGlobal, uuid.Nil, serviceAccountSeed(principal.ID, nil)); err != nil {
+			return nil, err
+		}
+		for _, orgID := range orgIDs {
+			if err := add(model.ScopeTypeOrg, orgID, serviceAccountSeed(principal.ID, &orgID)); err != nil {
+				return nil, err
+			}
+		}
+		for _, projectID := range projectIDs {
+			if err := add(model.ScopeTypeProject, projectID, serviceAccountProjectSeed(principal.ID, projectID)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return rows, nil
+}
+
+// notify wakes the feed's watchers, if any.
+func (m *Materializer) notify() {
+	if m.feed != nil {
+		m.feed.Notify()
+	}
+}
+
+func rowKey(row model.EffectivePermission) string {
+	return string(row.ScopeType) + ":" + row.ScopeID.String() + ":" + row.PermissionName + ":" + row.SourceRoleID.String()
+}
+
+func discrepancyFor(principal repository.PrincipalRef, row model.EffectivePermission, missing bool) Discrepancy {
+	return Discrepancy{
+		Principal:    principal,
+		ScopeType:    row.ScopeType,
+		ScopeID:      row.ScopeID,
+		Permission:   row.PermissionName,
+		SourceRoleID: row.SourceRoleID,
+		Missing:      missing,
+	}
+}
@@ -0,0 +1,82 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bernardoforcillo/authlayer/internal/model"
+	"github.com/bernardoforcillo/authlayer/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type inTxKey struct{}
+
+// fakeTransactor marks the context it runs work in, standing in for a database transaction.
+type fakeTransactor struct{}
+
+func (fakeTransactor) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(context.WithValue(ctx, inTxKey{}, true))
+}
+
+func (fakeTransactor) Lock(context.Context, string) error { return nil }
+
+// stubEffectiveRepo answers checks from a fixed role and fails to list the holders of roles.
+type stubEffectiveRepo struct {
+	repository.EffectivePermissionRepository
+	sourceRole string
+	listErr    error
+	listedInTx bool
+}
+
+func (r *stubEffectiveRepo) FindSourceRole(context.Context, repository.PrincipalRef, model.ScopeType, uuid.UUID, string) (string, bool, error) {
+	return r.sourceRole, r.sourceRole != "", nil
+}
+
+func (r *stubEffectiveRepo) ListPrincipalsForRole(ctx context.Context, _ uuid.UUID) ([]repository.PrincipalRef, error) {
+	r.listedInTx, _ = ctx.Value(inTxKey{}).(bool)
+	return nil, r.listErr
+}
+
+func newMaterializedChecker(repo repository.EffectivePermissionRepository, cache Cache) *Checker {
+	resolver := NewResolver(nil, nil, nil, cache)
+	materializer := NewMaterializer(resolver, repo, fakeTransactor{}, nil, zap.NewNop())
+	return NewChecker(resolver, materializer, nil, zap.NewNop())
+}
+
+// A write must fail, and leave the caches alone, when its rows cannot be refreshed with it.
+func TestCheckerUpdateFailsWhenRefreshFails(t *testing.T) {
+	ctx := context.Background()
+	repo := &stubEffectiveRepo{listErr: errors.New("connection reset")}
+	cache := &countingCache{MemoryCache: NewMemoryCache(time.Minute, 0)}
+	checker := newMaterializedChecker(repo, cache)
+
+	userID := uuid.New()
+	wroteInTx := false
+	err := checker.Update(ctx, func(ctx context.Context, changes *Changes) error {
+		wroteInTx, _ = ctx.Value(inTxKey{}).(bool)
+		changes.Role(uuid.New())
+		changes.User(userID)
+		return nil
+	})
+
+	require.ErrorIs(t, err, repo.listErr)
+	assert.True(t, wroteInTx)
+	assert.True(t, repo.listedInTx)
+	assert.Zero(t, cache.userInvalidations())
+}
+
+func TestMaterializedCheckPermissionReturnsSourceRole(t *testing.T) {
+	checker := newMaterializedChecker(&stubEffectiveRepo{sourceRole: "editor"}, NewMemoryCache(time.Minute, 0))
+	orgID := uuid.New()
+
+	allowed, role, err := checker.CheckPermission(context.Background(), uuid.New(), "doc:write", &orgID)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	assert.Equal(t, "editor", role)
+}
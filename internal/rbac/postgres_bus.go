@@ -0,0 +1,96 @@
+package rbac
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	invalidationChannel = "authlayer_rbac_invalidation"
+	maxReconnectBackoff = 30 * time.Second
+)
+
+// PostgresBus distributes invalidations with Postgres LISTEN/NOTIFY, so every
+// instance sharing the database sees them without extra infrastructure.
+type PostgresBus struct {
+	db     *gorm.DB
+	dsn    string
+	logger *zap.Logger
+}
+
+// NewPostgresBus creates a bus that publishes through db and listens on a
+// dedicated connection opened from dsn.
+func NewPostgresBus(db *gorm.DB, dsn string, logger *zap.Logger) *PostgresBus {
+	return &PostgresBus{db: db, dsn: dsn, logger: logger}
+}
+
+// Publish sends the invalidation with pg_notify.
+func (b *PostgresBus) Publish(ctx context.Context, inv Invalidation) error {
+	payload, err := json.Marshal(inv)
+	if err != nil {
+		return err
+	}
+	return b.db.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", invalidationChannel, string(payload)).Error
+}
+
+// Subscribe listens for invalidations until ctx is cancelled, reconnecting with
+// backoff when the connection drops. Notifications sent while disconnected are
+// lost, so the handler receives an InvalidationAll after every reconnect.
+func (b *PostgresBus) Subscribe(ctx context.Context, handler func(Invalidation)) error {
+	backoff := time.Second
+	connected := false
+	for {
+		err := b.listen(ctx, handler, func() {
+			if connected {
+				handler(Invalidation{Kind: InvalidationAll})
+			}
+			connected = true
+			backoff = time.Second
+		})
+		if ctx.Err() != nil {
+			return nil
+		}
+		b.logger.Warn("invalidation listener disconnected", zap.Error(err), zap.Duration("retry_in", backoff))
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > maxReconnectBackoff {
+			backoff = maxReconnectBackoff
+		}
+	}
+}
+
+func (b *PostgresBus) listen(ctx context.Context, handler func(Invalidation), onListening func()) error {
+	conn, err := pgx.Connect(ctx, b.dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+invalidationChannel); err != nil {
+		return err
+	}
+	onListening()
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var inv Invalidation
+		if err := json.Unmarshal([]byte(n.Payload), &inv); err != nil {
+			b.logger.Warn("ignoring malformed invalidation", zap.String("payload", n.Payload), zap.Error(err))
+			continue
+		}
+		handler(inv)
+	}
+}
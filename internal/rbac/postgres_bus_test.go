@@ -0,0 +1,216 @@
+package rbac
+
+import (
+	"context"
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgproto3"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// fakePostgres speaks just enough of the Postgres wire protocol for PostgresBus: simple
+// queries, LISTEN and pg_notify. Clients must use the simple query protocol.
+type fakePostgres struct {
+	listener net.Listener
+
+	mu        sync.Mutex
+	listening map[string][]*fakePostgresConn
+	conns     map[*fakePostgresConn]bool
+}
+
+type fakePostgresConn struct {
+	net.Conn
+	mu      sync.Mutex
+	backend *pgproto3.Backend
+}
+
+func (c *fakePostgresConn) send(msgs ...pgproto3.BackendMessage) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, msg := range msgs {
+		c.backend.Send(msg)
+	}
+	return c.backend.Flush()
+}
+
+var pgNotifyCall = regexp.MustCompile(`pg_notify\(\s*'((?:[^']|'')*)'\s*,\s*'((?:[^']|'')*)'\s*\)`)
+
+func newFakePostgres(t *testing.T) *fakePostgres {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	f := &fakePostgres{
+		listener:  listener,
+		listening: make(map[string][]*fakePostgresConn),
+		conns:     make(map[*fakePostgresConn]bool),
+	}
+	t.Cleanup(func() {
+		listener.Close()
+		f.dropConnections()
+	})
+
+	go func() {
+		for {
+			nc, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn := &fakePostgresConn{Conn: nc, backend: pgproto3.NewBackend(nc, nc)}
+			f.mu.Lock()
+			f.conns[conn] = true
+			f.mu.Unlock()
+			go f.serve(conn)
+		}
+	}()
+	return f
+}
+
+func (f *fakePostgres) dsn() string {
+	return "postgres://authlayer@" + f.listener.Addr().String() + "/authlayer?sslmode=disable"
+}
+
+// dropConnections closes every client connection, as a server restart would.
+func (f *fakePostgres) dropConnections() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for conn := range f.conns {
+		conn.Close()
+	}
+	f.conns = make(map[*fakePostgresConn]bool)
+	f.listening = make(map[string][]*fakePostgresConn)
+}
+
+// listeners returns the number of connections listening on channel.
+func (f *fakePostgres) listeners(channel string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.listening[channel])
+}
+
+func (f *fakePostgres) serve(conn *fakePostgresConn) {
+	defer conn.Close()
+
+	if _, err := conn.backend.ReceiveStartupMessage(); err != nil {
+		return
+	}
+	err := conn.send(
+		&pgproto3.AuthenticationOk{},
+		&pgproto3.ParameterStatus{Name: "server_version", Value: "16.0"},
+		&pgproto3.ParameterStatus{Name: "client_encoding", Value: "UTF8"},
+		&pgproto3.ParameterStatus{Name: "standard_conforming_strings", Value: "on"},
+		&pgproto3.BackendKeyData{ProcessID: 1, SecretKey: 1},
+		&pgproto3.ReadyForQuery{TxStatus: 'I'},
+	)
+	if err != nil {
+		return
+	}
+
+	for {
+		msg, err := conn.backend.Receive()
+		if err != nil {
+			return
+		}
+		switch msg := msg.(type) {
+		case *pgproto3.Query:
+			if err := f.query(conn, msg.String); err != nil {
+				return
+			}
+		case *pgproto3.Terminate:
+			return
+		default:
+			conn.send(
+				&pgproto3.ErrorResponse{Severity: "ERROR", Code: "0A000", Message: "fake postgres only supports simple queries"},
+				&pgproto3.ReadyForQuery{TxStatus: 'I'},
+			)
+		}
+	}
+}
+
+func (f *fakePostgres) query(conn *fakePostgresConn, sql string) error {
+	ready := &pgproto3.ReadyForQuery{TxStatus: 'I'}
+
+	if channel, ok := strings.CutPrefix(sql, "LISTEN "); ok {
+		f.mu.Lock()
+		f.listening[channel] = append(f.listening[channel], conn)
+		f.mu.Unlock()
+		return conn.send(&pgproto3.CommandComplete{CommandTag: []byte("LISTEN")}, ready)
+	}
+
+	if m := pgNotifyCall.FindStringSubmatch(sql); m != nil {
+		channel, payload := strings.ReplaceAll(m[1], "''", "'"), strings.ReplaceAll(m[2], "''", "'")
+		f.mu.Lock()
+		listeners := append([]*fakePostgresConn{}, f.listening[channel]...)
+		f.mu.Unlock()
+		for _, l := range listeners {
+			l.send(&pgproto3.NotificationResponse{PID: 1, Channel: channel, Payload: payload})
+		}
+		return conn.send(
+			&pgproto3.RowDescription{Fields: []pgproto3.FieldDescription{{Name: []byte("pg_notify"), DataTypeOID: 2278, DataTypeSize: 4, TypeModifier: -1}}},
+			&pgproto3.DataRow{Values: [][]byte{{}}},
+			&pgproto3.CommandComplete{CommandTag: []byte("SELECT 1")},
+			ready,
+		)
+	}
+
+	if strings.TrimSpace(sql) == "" || strings.HasPrefix(sql, "--") {
+		return conn.send(&pgproto3.EmptyQueryResponse{}, ready)
+	}
+	return conn.send(&pgproto3.CommandComplete{CommandTag: []byte("SELECT 0")}, ready)
+}
+
+func newTestPostgresBus(t *testing.T) (*PostgresBus, *fakePostgres) {
+	t.Helper()
+	server := newFakePostgres(t)
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: server.dsn(), PreferSimpleProtocol: true}), &gorm.Config{})
+	require.NoError(t, err)
+	return NewPostgresBus(db, server.dsn(), zap.NewNop()), server
+}
+
+func TestPostgresBusDelivers(t *testing.T) {
+	bus, server := newTestPostgresBus(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	received := make(chan Invalidation, 16)
+	go bus.Subscribe(ctx, func(inv Invalidation) { received <- inv })
+	require.Eventually(t, func() bool { return server.listeners(invalidationChannel) == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	want := Invalidation{Kind: InvalidationRole, ID: uuid.New(), Origin: "it's a test"}
+	require.NoError(t, bus.Publish(ctx, want))
+	select {
+	case got := <-received:
+		require.Equal(t, want, got)
+	case <-time.After(5 * time.Second):
+		t.Fatal("invalidation not delivered")
+	}
+}
+
+// Notifications sent while the listener is disconnected are lost, so a reconnect must be
+// followed by a full invalidation.
+func TestPostgresBusInvalidatesAllAfterReconnect(t *testing.T) {
+	bus, server := newTestPostgresBus(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	received := make(chan Invalidation, 16)
+	go bus.Subscribe(ctx, func(inv Invalidation) { received <- inv })
+	require.Eventually(t, func() bool { return server.listeners(invalidationChannel) == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	server.dropConnections()
+	select {
+	case got := <-received:
+		require.Equal(t, Invalidation{Kind: InvalidationAll}, got)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no invalidation after reconnect")
+	}
+	require.Eventually(t, func() bool { return server.listeners(invalidationChannel) == 1 }, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,136 @@
+package rbac
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	redisKeyPrefix = "authlayer:rbac:"
+	redisScanCount = 500
+)
+
+// invalidateRoleScript deletes every entry listed in the role set KEYS[1], then the set.
+// ARGV[1] is the prefix entry keys are stored under.
+var invalidateRoleScript = redis.NewScript(`
+local members = redis.call("SMEMBERS", KEYS[1])
+for i = 1, #members, 500 do
+	local batch = {}
+	for j = i, math.min(i + 499, #members) do
+		batch[#batch + 1] = ARGV[1] .. members[j]
+	end
+	redis.call("DEL", unpack(batch))
+end
+redis.call("DEL", KEYS[1])
+return #members
+`)
+
+type redisEntry struct {
+	Permissions []string `json:"permissions"`
+}
+
+// RedisCache is a Cache shared by every instance through Redis. Each entry is stored
+// under its own key with the TTL; a set per role tracks the entries derived from it.
+type RedisCache struct {
+	client *redis.Client
+	ttl    time.Duration
+	logger *zap.Logger
+}
+
+// NewRedisCache creates a cache backed by the given Redis client.
+func NewRedisCache(client *redis.Client, ttl time.Duration, logger *zap.Logger) *RedisCache {
+	return &RedisCache{client: client, ttl: ttl, logger: logger}
+}
+
+func (c *RedisCache) Get(ctx context.Context, key string) ([]string, bool) {
+	data, err := c.client.Get(ctx, redisKeyPrefix+key).Bytes()
+	if err != nil {
+		if err != redis.Nil {
+			c.logger.Warn("permission cache read failed", zap.String("key", key), zap.Error(err))
+		}
+		return nil, false
+	}
+
+	var entry redisEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		c.logger.Warn("discarding malformed permission cache entry", zap.String("key", key), zap.Error(err))
+		return nil, false
+	}
+	return entry.Permissions, true
+}
+
+func (c *RedisCache) Set(ctx context.Context, key string, permissions []string, roleIDs []uuid.UUID) {
+	data, err := json.Marshal(redisEntry{Permissions: permissions})
+	if err != nil {
+		return
+	}
+
+	// Role sets may outlive the entries they list; stale members are harmless to delete.
+	_, err = c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, redisKeyPrefix+key, data, c.ttl)
+		for _, roleID := range roleIDs {
+			pipe.SAdd(ctx, roleSetKey(roleID), key)
+			pipe.Expire(ctx, roleSetKey(roleID), c.ttl)
+		}
+		return nil
+	})
+	if err != nil {
+		c.logger.Warn("permission cache write failed", zap.String("key", key), zap.Error(err))
+	}
+}
+
+func (c *RedisCache) InvalidateUser(ctx context.Context, userID uuid.UUID) {
+	c.deleteMatching(ctx, redisKeyPrefix+userPrefix(userID)+"*")
+}
+
+func (c *RedisCache) InvalidateServiceAccount(ctx context.Context, saID uuid.UUID) {
+	c.deleteMatching(ctx, redisKeyPrefix+serviceAccountPrefix(saID)+"*")
+}
+
+func (c *RedisCache) InvalidateRole(ctx context.Context, roleID uuid.UUID) {
+	// The script runs atomically, so an entry Set while the role is being invalidated is
+	// either deleted with it or keeps its membership in a fresh role set.
+	err := invalidateRoleScript.Run(ctx, c.client, []string{roleSetKey(roleID)}, redisKeyPrefix).Err()
+	if err != nil {
+		c.logger.Warn("permission cache invalidation failed", zap.String("role_id", roleID.String()), zap.Error(err))
+	}
+}
+
+func (c *RedisCache) Clear(ctx context.Context) {
+	c.deleteMatching(ctx, redisKeyPrefix+"*")
+}
+
+// deleteMatching removes every key matching pattern, scanning in batches so large
+// caches don't block Redis.
+func (c *RedisCache) deleteMatching(ctx context.Context, pattern string) {
+	iter := c.client.Scan(ctx, 0, pattern, redisScanCount).Iterator()
+	var batch []string
+	for iter.Next(ctx) {
+		batch = append(batch, iter.Val())
+		if len(batch) == redisScanCount {
+			if err := c.client.Del(ctx, batch...).Err(); err != nil {
+				c.logger.Warn("permission cache invalidation failed", zap.String("pattern", pattern), zap.Error(err))
+				return
+			}
+			batch = batch[:0]
+		}
+	}
+	if err := iter.Err(); err != nil {
+		c.logger.Warn("permission cache invalidation failed", zap.String("pattern", pattern), zap.Error(err))
+		return
+	}
+	if len(batch) > 0 {
+		if err := c.client.Del(ctx, batch...).Err(); err != nil {
+			c.logger.Warn("permission cache invalidation failed", zap.String("pattern", pattern), zap.Error(err))
+		}
+	}
+}
+
+func roleSetKey(roleID uuid.UUID) string {
+	return redisKeyPrefix + "role:" + roleID.String()
+}
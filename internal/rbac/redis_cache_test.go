@@ -0,0 +1,147 @@
+package rbac
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// newTestRedisCache returns a cache backed by an in-process Redis, along with a client of
+// that Redis and the server itself for controlling its clock.
+func newTestRedisCache(t *testing.T) (*RedisCache, *redis.Client, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewRedisCache(client, time.Minute, zap.NewNop()), client, server
+}
+
+func TestRedisCacheSetGet(t *testing.T) {
+	cache, _, _ := newTestRedisCache(t)
+	ctx := context.Background()
+	key := cacheKey(uuid.New(), nil)
+
+	_, ok := cache.Get(ctx, key)
+	assert.False(t, ok)
+
+	cache.Set(ctx, key, []string{"org:read", "org:update"}, []uuid.UUID{uuid.New()})
+	perms, ok := cache.Get(ctx, key)
+	require.True(t, ok)
+	assert.Equal(t, []string{"org:read", "org:update"}, perms)
+}
+
+func TestRedisCacheExpires(t *testing.T) {
+	cache, _, server := newTestRedisCache(t)
+	ctx := context.Background()
+	key := cacheKey(uuid.New(), nil)
+
+	cache.Set(ctx, key, []string{"org:read"}, nil)
+	server.FastForward(time.Minute + time.Second)
+	_, ok := cache.Get(ctx, key)
+	assert.False(t, ok)
+}
+
+func TestRedisCacheInvalidatePrincipal(t *testing.T) {
+	cache, _, _ := newTestRedisCache(t)
+	ctx := context.Background()
+	userID, saID, orgID := uuid.New(), uuid.New(), uuid.New()
+
+	cache.Set(ctx, cacheKey(userID, nil), []string{"a"}, nil)
+	cache.Set(ctx, cacheKey(userID, &orgID), []string{"b"}, nil)
+	cache.Set(ctx, serviceAccountCacheKey(saID, &orgID), []string{"c"}, nil)
+
+	cache.InvalidateUser(ctx, userID)
+	_, ok := cache.Get(ctx, cacheKey(userID, nil))
+	assert.False(t, ok)
+	_, ok = cache.Get(ctx, cacheKey(userID, &orgID))
+	assert.False(t, ok)
+	_, ok = cache.Get(ctx, serviceAccountCacheKey(saID, &orgID))
+	assert.True(t, ok)
+
+	cache.InvalidateServiceAccount(ctx, saID)
+	_, ok = cache.Get(ctx, serviceAccountCacheKey(saID, &orgID))
+	assert.False(t, ok)
+}
+
+func TestRedisCacheInvalidateRole(t *testing.T) {
+	cache, client, _ := newTestRedisCache(t)
+	ctx := context.Background()
+	role, other := uuid.New(), uuid.New()
+	derived, unrelated := cacheKey(uuid.New(), nil), cacheKey(uuid.New(), nil)
+
+	cache.Set(ctx, derived, []string{"a"}, []uuid.UUID{role, other})
+	cache.Set(ctx, unrelated, []string{"b"}, []uuid.UUID{other})
+
+	cache.InvalidateRole(ctx, role)
+	_, ok := cache.Get(ctx, derived)
+	assert.False(t, ok)
+	_, ok = cache.Get(ctx, unrelated)
+	assert.True(t, ok)
+
+	n, err := client.Exists(ctx, roleSetKey(role)).Result()
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
+
+// An entry written while its role is being invalidated must either be deleted or remain
+// listed in the role set, so that the next invalidation still finds it.
+func TestRedisCacheInvalidateRoleConcurrentSet(t *testing.T) {
+	cache, client, _ := newTestRedisCache(t)
+	ctx := context.Background()
+	role := uuid.New()
+
+	keys := make([]string, 200)
+	for i := range keys {
+		keys[i] = cacheKey(uuid.New(), nil)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for _, key := range keys {
+			cache.Set(ctx, key, []string{"p"}, []uuid.UUID{role})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 50 {
+			cache.InvalidateRole(ctx, role)
+		}
+	}()
+	wg.Wait()
+
+	for _, key := range keys {
+		if _, ok := cache.Get(ctx, key); !ok {
+			continue
+		}
+		member, err := client.SIsMember(ctx, roleSetKey(role), key).Result()
+		require.NoError(t, err)
+		assert.True(t, member, "cached entry %s is not tracked by its role", key)
+	}
+
+	cache.InvalidateRole(ctx, role)
+	for _, key := range keys {
+		_, ok := cache.Get(ctx, key)
+		assert.False(t, ok)
+	}
+}
+
+func TestRedisCacheClear(t *testing.T) {
+	cache, _, _ := newTestRedisCache(t)
+	ctx := context.Background()
+	key := cacheKey(uuid.New(), nil)
+
+	cache.Set(ctx, key, []string{"a"}, []uuid.UUID{uuid.New()})
+	cache.Clear(ctx)
+	_, ok := cache.Get(ctx, key)
+	assert.False(t, ok)
+}
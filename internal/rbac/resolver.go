@@ -2,131 +2,182 @@ package rbac
 
 import (
 	"context"
+	"time"
 
+	"github.com/bernardoforcillo/authlayer/internal/labels"
 	"github.com/bernardoforcillo/authlayer/internal/model"
 	"github.com/bernardoforcillo/authlayer/internal/repository"
 
 	"github.com/google/uuid"
+	"golang.org/x/sync/singleflight"
 )
 
-const defaultMaxHierarchyDepth = 10
+// MaxHierarchyDepth bounds how many levels of the role and org hierarchies are walked.
+const MaxHierarchyDepth = 10
+
+// resolveTimeout bounds a resolution shared by concurrent cache misses.
+const resolveTimeout = 10 * time.Second
 
 // Resolver computes effective permissions for a user by traversing the role hierarchy.
 type Resolver struct {
-	roleRepo      repository.RoleRepository
-	rolePermRepo  repository.RolePermissionRepository
-	orgMemberRepo repository.OrganizationMemberRepository
-	teamMemberRepo repository.TeamMemberRepository
-	saRoleRepo    repository.ServiceAccountRoleRepository
-	cache         *Cache
-	maxDepth      int
+	rolePermRepo repository.RolePermissionRepository
+	userRepo     repository.UserRepository
+	saRepo       repository.ServiceAccountRepository
+	cache        Cache
+	inflight     singleflight.Group
+	maxDepth     int
 }
 
 // NewResolver creates a new permission resolver.
 func NewResolver(
-	roleRepo repository.RoleRepository,
 	rolePermRepo repository.RolePermissionRepository,
-	orgMemberRepo repository.OrganizationMemberRepository,
-	teamMemberRepo repository.TeamMemberRepository,
-	saRoleRepo repository.ServiceAccountRoleRepository,
-	cache *Cache,
+	userRepo repository.UserRepository,
+	saRepo repository.ServiceAccountRepository,
+	cache Cache,
 ) *Resolver {
 	return &Resolver{
-		roleRepo:       roleRepo,
-		rolePermRepo:   rolePermRepo,
-		orgMemberRepo:  orgMemberRepo,
-		teamMemberRepo: teamMemberRepo,
-		saRoleRepo:     saRoleRepo,
-		cache:          cache,
-		maxDepth:       defaultMaxHierarchyDepth,
+		rolePermRepo: rolePermRepo,
+		userRepo:     userRepo,
+		saRepo:       saRepo,
+		cache:        cache,
+		maxDepth:     MaxHierarchyDepth,
 	}
 }
 
 // ResolveUserPermissions returns all effective permissions for a user in the given org context.
 func (r *Resolver) ResolveUserPermissions(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID) ([]model.Permission, error) {
-	key := cacheKey(userID, orgID)
-	if cached, ok := r.cache.Get(key); ok {
+	return r.resolve(ctx, cacheKey(userID, orgID), userSeed(userID, orgID))
+}
+
+// ResolveUserProjectPermissions returns the user's effective permissions in a project:
+// the roles held in the project's org plus the project bindings of the user and of its teams.
+func (r *Resolver) ResolveUserProjectPermissions(ctx context.Context, userID, projectID uuid.UUID) ([]model.Permission, error) {
+	return r.resolve(ctx, projectCacheKey(userID, projectID), userProjectSeed(userID, projectID))
+}
+
+// ResolveServiceAccountPermissions returns all effective permissions for a service account.
+func (r *Resolver) ResolveServiceAccountPermissions(ctx context.Context, saID uuid.UUID, orgID *uuid.UUID) ([]model.Permission, error) {
+	return r.resolve(ctx, serviceAccountCacheKey(saID, orgID), serviceAccountSeed(saID, orgID))
+}
+
+// ResolveServiceAccountProjectPermissions returns the service account's roles in the
+// project's org plus its project bindings, expanded to permissions.
+func (r *Resolver) ResolveServiceAccountProjectPermissions(ctx context.Context, saID, projectID uuid.UUID) ([]model.Permission, error) {
+	return r.resolve(ctx, serviceAccountProjectCacheKey(saID, projectID), serviceAccountProjectSeed(saID, projectID))
+}
+
+// resolve returns the permissions cached under key, or expands the principal's role seed.
+// Concurrent misses for the same key share one resolution. It runs on its own context, not
+// the first caller's, so a caller giving up does not fail the others waiting on it, and
+// outside any transaction the caller holds, whose uncommitted state must not be shared.
+func (r *Resolver) resolve(ctx context.Context, key string, seed repository.RoleSeed) ([]model.Permission, error) {
+	if cached, ok := r.cache.Get(ctx, key); ok {
 		return r.permissionNamestoModels(cached), nil
 	}
 
-	roleIDs, err := r.collectRoleIDs(ctx, userID, orgID)
-	if err != nil {
-		return nil, err
-	}
+	ch := r.inflight.DoChan(key, func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), resolveTimeout)
+		defer cancel()
 
-	// Expand role hierarchy for each role
-	allRoleIDs := make(map[uuid.UUID]bool)
-	for _, roleID := range roleIDs {
-		ancestors, err := r.roleRepo.GetAncestors(ctx, roleID, r.maxDepth)
+		grants, err := r.grants(ctx, seed)
 		if err != nil {
 			return nil, err
 		}
-		for _, ancestor := range ancestors {
-			allRoleIDs[ancestor.ID] = true
+
+		var perms []model.Permission
+		var permNames []string
+		var roleIDs []uuid.UUID
+		seenRoles := make(map[uuid.UUID]bool)
+		seenPerms := make(map[uuid.UUID]bool)
+		for _, g := range grants {
+			if !seenRoles[g.RoleID] {
+				seenRoles[g.RoleID] = true
+				roleIDs = append(roleIDs, g.RoleID)
+			}
+			if g.Permission == nil || seenPerms[g.Permission.ID] {
+				continue
+			}
+			seenPerms[g.Permission.ID] = true
+			perms = append(perms, *g.Permission)
+			permNames = append(permNames, g.Permission.Name)
 		}
-	}
 
-	expandedIDs := make([]uuid.UUID, 0, len(allRoleIDs))
-	for id := range allRoleIDs {
-		expandedIDs = append(expandedIDs, id)
+		r.cache.Set(ctx, key, permNames, roleIDs)
+		return perms, nil
+	})
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.([]model.Permission), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
+}
 
-	perms, err := r.rolePermRepo.GetPermissionsByRoleIDs(ctx, expandedIDs)
+// grants expands seed in a single query. Label binding selectors are evaluated here, so a
+// second query is only made when a binding matches and grants a role not yet expanded.
+func (r *Resolver) grants(ctx context.Context, seed repository.RoleSeed) ([]repository.RoleGrant, error) {
+	grants, candidates, err := r.rolePermRepo.ResolveGrants(ctx, seed, r.maxDepth)
 	if err != nil {
 		return nil, err
 	}
 
-	// Cache the result
-	permNames := make([]string, len(perms))
-	for i, p := range perms {
-		permNames[i] = p.Name
+	expanded := make(map[uuid.UUID]bool, len(grants))
+	for _, g := range grants {
+		expanded[g.RoleID] = true
+	}
+	var labelRoleIDs []uuid.UUID
+	for _, c := range candidates {
+		if !expanded[c.RoleID] && selectorMatches(c.Selector, c.Labels) {
+			expanded[c.RoleID] = true
+			labelRoleIDs = append(labelRoleIDs, c.RoleID)
+		}
+	}
+	if len(labelRoleIDs) == 0 {
+		return grants, nil
 	}
-	r.cache.Set(key, permNames)
-
-	return perms, nil
-}
 
-// ResolveServiceAccountPermissions returns all effective permissions for a service account.
-func (r *Resolver) ResolveServiceAccountPermissions(ctx context.Context, saID uuid.UUID, orgID *uuid.UUID) ([]model.Permission, error) {
-	saRoles, err := r.saRoleRepo.ListByServiceAccountID(ctx, saID)
+	labelGrants, _, err := r.rolePermRepo.ResolveGrants(ctx, repository.RoleSeed{RoleIDs: labelRoleIDs}, r.maxDepth)
 	if err != nil {
 		return nil, err
 	}
+	return append(grants, labelGrants...), nil
+}
 
-	allRoleIDs := make(map[uuid.UUID]bool)
-	for _, sar := range saRoles {
-		if orgID != nil && sar.OrgID != *orgID {
-			continue
-		}
-		ancestors, err := r.roleRepo.GetAncestors(ctx, sar.RoleID, r.maxDepth)
-		if err != nil {
-			return nil, err
-		}
-		for _, ancestor := range ancestors {
-			allRoleIDs[ancestor.ID] = true
-		}
-	}
+// userSeed selects the user's memberships in the org and in every ancestor whose roles
+// cascade down to it, plus the roles granted by matching label bindings.
+func userSeed(userID uuid.UUID, orgID *uuid.UUID) repository.RoleSeed {
+	return repository.RoleSeed{UserID: &userID, OrgID: orgID}
+}
 
-	expandedIDs := make([]uuid.UUID, 0, len(allRoleIDs))
-	for id := range allRoleIDs {
-		expandedIDs = append(expandedIDs, id)
-	}
+// userProjectSeed extends the seed of the project's org with the project bindings of the
+// user and of its teams.
+func userProjectSeed(userID, projectID uuid.UUID) repository.RoleSeed {
+	return repository.RoleSeed{UserID: &userID, ProjectID: &projectID}
+}
 
-	return r.rolePermRepo.GetPermissionsByRoleIDs(ctx, expandedIDs)
+// serviceAccountSeed selects the service account's grants in the org and the ancestors it
+// inherits from, or in every org when orgID is nil, plus its label binding roles.
+func serviceAccountSeed(saID uuid.UUID, orgID *uuid.UUID) repository.RoleSeed {
+	return repository.RoleSeed{ServiceAccountID: &saID, OrgID: orgID}
 }
 
-func (r *Resolver) collectRoleIDs(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID) ([]uuid.UUID, error) {
-	var roleIDs []uuid.UUID
+// serviceAccountProjectSeed extends the seed of the project's org with the service
+// account's project bindings.
+func serviceAccountProjectSeed(saID, projectID uuid.UUID) repository.RoleSeed {
+	return repository.RoleSeed{ServiceAccountID: &saID, ProjectID: &projectID}
+}
 
-	if orgID != nil {
-		membership, err := r.orgMemberRepo.GetMembership(ctx, *orgID, userID)
-		if err != nil {
-			return nil, err
-		}
-		roleIDs = append(roleIDs, membership.RoleID)
+// selectorMatches reports whether l satisfies the stored selector. Selectors are validated
+// when bindings are created, so one that no longer parses simply matches nothing.
+func selectorMatches(selector string, l model.Labels) bool {
+	sel, err := labels.Parse(selector)
+	if err != nil {
+		return false
 	}
-
-	return roleIDs, nil
+	return sel.Matches(l)
 }
 
 func (r *Resolver) permissionNamestoModels(names []string) []model.Permission {
@@ -0,0 +1,172 @@
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bernardoforcillo/authlayer/internal/database"
+	"github.com/bernardoforcillo/authlayer/internal/model"
+	"github.com/bernardoforcillo/authlayer/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// blockingGrantRepo counts ResolveGrants calls and holds each one until release is closed.
+type blockingGrantRepo struct {
+	repository.RolePermissionRepository
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (r *blockingGrantRepo) ResolveGrants(ctx context.Context, _ repository.RoleSeed, _ int) ([]repository.RoleGrant, []repository.LabelCandidate, error) {
+	r.calls.Add(1)
+	<-r.release
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	perm := &model.Permission{Name: "org:read"}
+	perm.ID = uuid.New()
+	return []repository.RoleGrant{{RoleID: uuid.New(), Permission: perm}}, nil, nil
+}
+
+func TestResolverCollapsesConcurrentMisses(t *testing.T) {
+	repo := &blockingGrantRepo{release: make(chan struct{})}
+	resolver := NewResolver(repo, nil, nil, NewMemoryCache(time.Minute, 0))
+	userID, orgID := uuid.New(), uuid.New()
+
+	const callers = 8
+	var wg sync.WaitGroup
+	results := make([][]model.Permission, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			perms, err := resolver.ResolveUserPermissions(context.Background(), userID, &orgID)
+			assert.NoError(t, err)
+			results[i] = perms
+		}()
+	}
+
+	// Let every caller reach the cache miss before the first resolution returns.
+	require.Eventually(t, func() bool { return repo.calls.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	close(repo.release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), repo.calls.Load())
+	for _, perms := range results {
+		require.Len(t, perms, 1)
+		assert.Equal(t, "org:read", perms[0].Name)
+	}
+}
+
+// A caller giving up must not fail the callers sharing its resolution.
+func TestResolverSharedResolutionOutlivesCancelledCaller(t *testing.T) {
+	repo := &blockingGrantRepo{release: make(chan struct{})}
+	resolver := NewResolver(repo, nil, nil, NewMemoryCache(time.Minute, 0))
+	userID, orgID := uuid.New(), uuid.New()
+
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := resolver.ResolveUserPermissions(firstCtx, userID, &orgID)
+		firstErr <- err
+	}()
+	require.Eventually(t, func() bool { return repo.calls.Load() == 1 }, time.Second, time.Millisecond)
+
+	secondPerms := make(chan []model.Permission, 1)
+	go func() {
+		perms, err := resolver.ResolveUserPermissions(context.Background(), userID, &orgID)
+		assert.NoError(t, err)
+		secondPerms <- perms
+	}()
+
+	cancelFirst()
+	assert.ErrorIs(t, <-firstErr, context.Canceled)
+
+	close(repo.release)
+	perms := <-secondPerms
+	require.Len(t, perms, 1)
+	assert.Equal(t, "org:read", perms[0].Name)
+	assert.Equal(t, int32(1), repo.calls.Load())
+}
+
+// seedRoleChain creates a user holding the last of depth chained roles in a new org, each role
+// granting one permission, and returns the user, org and held role.
+func seedRoleChain(tb testing.TB, db *gorm.DB, depth int) (uuid.UUID, uuid.UUID, uuid.UUID) {
+	suffix := uuid.NewString()
+	user := &model.User{Email: suffix + "@bench.test", Name: "bench"}
+	require.NoError(tb, db.Create(user).Error)
+	org := &model.Organization{Name: "bench", Slug: "bench-" + suffix, OwnerID: user.ID}
+	require.NoError(tb, db.Create(org).Error)
+
+	var parentID *uuid.UUID
+	var role *model.Role
+	for i := range depth {
+		role = &model.Role{Name: fmt.Sprintf("level-%d", i), OrgID: &org.ID, ParentRoleID: parentID}
+		require.NoError(tb, db.Create(role).Error)
+		perm := &model.Permission{Name: fmt.Sprintf("bench:%s:%d", suffix, i)}
+		require.NoError(tb, db.Create(perm).Error)
+		require.NoError(tb, db.Create(&model.RolePermission{RoleID: role.ID, PermissionID: perm.ID}).Error)
+		parentID = &role.ID
+	}
+
+	require.NoError(tb, db.Create(&model.OrganizationMember{OrgID: org.ID, UserID: user.ID, RoleID: role.ID}).Error)
+	return user.ID, org.ID, role.ID
+}
+
+// BenchmarkResolveUserPermissions compares the single recursive query with walking the
+// role chain one parent at a time, on uncached resolutions against the Postgres named by
+// AUTHLAYER_TEST_DATABASE_URL.
+func BenchmarkResolveUserPermissions(b *testing.B) {
+	dsn := os.Getenv("AUTHLAYER_TEST_DATABASE_URL")
+	if dsn == "" {
+		b.Skip("AUTHLAYER_TEST_DATABASE_URL not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	require.NoError(b, err)
+	require.NoError(b, database.Migrate(db))
+
+	rolePermRepo := repository.NewRolePermissionRepository(db)
+	roleRepo := repository.NewRoleRepository(db)
+	cache := NewMemoryCache(time.Minute, 0)
+	resolver := NewResolver(rolePermRepo, repository.NewUserRepository(db), repository.NewServiceAccountRepository(db), cache)
+	ctx := context.Background()
+
+	for _, depth := range []int{1, 5, 10} {
+		userID, orgID, roleID := seedRoleChain(b, db, depth)
+
+		b.Run(fmt.Sprintf("single_query/depth=%d", depth), func(b *testing.B) {
+			for b.Loop() {
+				cache.Clear(ctx)
+				perms, err := resolver.ResolveUserPermissions(ctx, userID, &orgID)
+				require.NoError(b, err)
+				require.Len(b, perms, depth)
+			}
+		})
+
+		b.Run(fmt.Sprintf("per_level/depth=%d", depth), func(b *testing.B) {
+			for b.Loop() {
+				var roleIDs []uuid.UUID
+				for id := &roleID; id != nil; {
+					role, err := roleRepo.GetByID(ctx, *id)
+					require.NoError(b, err)
+					roleIDs = append(roleIDs, role.ID)
+					id = role.ParentRoleID
+				}
+				perms, err := rolePermRepo.GetPermissionsByRoleIDs(ctx, roleIDs)
+				require.NoError(b, err)
+				require.Len(b, perms, depth)
+			}
+		})
+	}
+}
@@ -0,0 +1,548 @@
+package rebac
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bernardoforcillo/authlayer/internal/model"
+	"github.com/bernardoforcillo/authlayer/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultMaxDepth = 25
+
+	// maxListObjectsChecks bounds the candidates one ListObjects page evaluates, so a
+	// namespace with many objects the subject cannot reach still answers promptly.
+	maxListObjectsChecks = 1000
+	candidateBatchSize   = 100
+)
+
+// ObjectRef identifies an object as namespace:object_id.
+type ObjectRef struct {
+	Namespace string
+	ObjectID  string
+}
+
+func (o ObjectRef) String() string {
+	return o.Namespace + ":" + o.ObjectID
+}
+
+// SubjectRef is a concrete subject (namespace:object_id) or, if Relation is set,
+// a userset (namespace:object_id#relation).
+type SubjectRef struct {
+	Namespace string
+	ObjectID  string
+	Relation  string
+}
+
+func (s SubjectRef) String() string {
+	if s.Relation == "" {
+		return s.Namespace + ":" + s.ObjectID
+	}
+	return s.Namespace + ":" + s.ObjectID + "#" + s.Relation
+}
+
+// Tuple states that Subject holds Relation on Object.
+type Tuple struct {
+	Object   ObjectRef
+	Relation string
+	Subject  SubjectRef
+}
+
+// ToModel converts the tuple to its storage form.
+func (t Tuple) ToModel() model.RelationTuple {
+	return model.RelationTuple{
+		Namespace:        t.Object.Namespace,
+		ObjectID:         t.Object.ObjectID,
+		Relation:         t.Relation,
+		SubjectNamespace: t.Subject.Namespace,
+		SubjectObjectID:  t.Subject.ObjectID,
+		SubjectRelation:  t.Subject.Relation,
+	}
+}
+
+// TupleFromModel converts a stored tuple.
+func TupleFromModel(m *model.RelationTuple) Tuple {
+	return Tuple{
+		Object:   ObjectRef{Namespace: m.Namespace, ObjectID: m.ObjectID},
+		Relation: m.Relation,
+		Subject:  SubjectRef{Namespace: m.SubjectNamespace, ObjectID: m.SubjectObjectID, Relation: m.SubjectRelation},
+	}
+}
+
+// UsersetTree is the expansion of Object#Relation. Subjects holds the direct subjects,
+// Children the expansions of the relation's rewrite rules.
+type UsersetTree struct {
+	Object   ObjectRef
+	Relation string
+	Subjects []SubjectRef
+	Children []*UsersetTree
+}
+
+// Engine evaluates relation tuples against namespace schemas.
+type Engine struct {
+	nsRepo         repository.RelationNamespaceRepository
+	tupleRepo      repository.RelationTupleRepository
+	orgMemberRepo  repository.OrganizationMemberRepository
+	teamMemberRepo repository.TeamMemberRepository
+	maxDepth       int
+}
+
+// NewEngine creates a new ReBAC engine.
+func NewEngine(
+	nsRepo repository.RelationNamespaceRepository,
+	tupleRepo repository.RelationTupleRepository,
+	orgMemberRepo repository.OrganizationMemberRepository,
+	teamMemberRepo repository.TeamMemberRepository,
+) *Engine {
+	return &Engine{
+		nsRepo:         nsRepo,
+		tupleRepo:      tupleRepo,
+		orgMemberRepo:  orgMemberRepo,
+		teamMemberRepo: teamMemberRepo,
+		maxDepth:       defaultMaxDepth,
+	}
+}
+
+// WriteNamespace validates and stores a namespace schema.
+func (e *Engine) WriteNamespace(ctx context.Context, ns *Namespace) error {
+	if err := ns.Validate(); err != nil {
+		return err
+	}
+	m, err := ns.ToModel()
+	if err != nil {
+		return err
+	}
+	return e.nsRepo.Upsert(ctx, m)
+}
+
+// Namespace returns the schema of a built-in or stored namespace.
+func (e *Engine) Namespace(ctx context.Context, name string) (*Namespace, error) {
+	if ns, ok := builtinNamespaces[name]; ok {
+		return ns, nil
+	}
+	m, err := e.nsRepo.GetByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownNamespace, name)
+		}
+		return nil, err
+	}
+	return NamespaceFromModel(m)
+}
+
+// WriteTuples validates the tuples against their schemas and applies writes and deletes atomically.
+// Tuples cannot be written on built-in namespaces; their relations come from memberships.
+func (e *Engine) WriteTuples(ctx context.Context, writes, deletes []Tuple) error {
+	r := e.newResolution(ctx)
+
+	toModels := func(tuples []Tuple, validate bool) ([]model.RelationTuple, error) {
+		models := make([]model.RelationTuple, len(tuples))
+		for i, t := range tuples {
+			if validate {
+				if err := r.validateTuple(t); err != nil {
+					return nil, err
+				}
+			}
+			models[i] = t.ToModel()
+		}
+		return models, nil
+	}
+
+	writeModels, err := toModels(writes, true)
+	if err != nil {
+		return err
+	}
+	// Deletes are not validated so that tuples outliving a schema change can still be removed.
+	deleteModels, _ := toModels(deletes, false)
+
+	return e.tupleRepo.Write(ctx, writeModels, deleteModels)
+}
+
+// ReadTuples returns the stored tuples of an object.
+func (e *Engine) ReadTuples(ctx context.Context, object ObjectRef, relation *string, pagination repository.Pagination) ([]Tuple, int64, error) {
+	models, total, err := e.tupleRepo.ListByObject(ctx, object.Namespace, object.ObjectID, relation, pagination)
+	if err != nil {
+		return nil, 0, err
+	}
+	tuples := make([]Tuple, len(models))
+	for i := range models {
+		tuples[i] = TupleFromModel(&models[i])
+	}
+	return tuples, total, nil
+}
+
+// Check reports whether subject holds relation on object.
+func (e *Engine) Check(ctx context.Context, object ObjectRef, relation string, subject SubjectRef) (bool, error) {
+	r := e.newResolution(ctx)
+	if _, err := r.relation(object.Namespace, relation); err != nil {
+		return false, err
+	}
+	return r.check(object, relation, subject, 0)
+}
+
+// Expand returns the userset tree of object#relation. Usersets appearing as direct
+// subjects are not expanded further; callers can Expand them separately.
+func (e *Engine) Expand(ctx context.Context, object ObjectRef, relation string) (*UsersetTree, error) {
+	r := e.newResolution(ctx)
+	return r.expand(object, relation, 0)
+}
+
+// ListObjects returns a page of the IDs of objects in namespace on which subject holds
+// relation. Candidates are the objects of the namespace that have tuples, in ID order,
+// each evaluated with Check. A page ends once it holds PageSize objects or has evaluated
+// maxListObjectsChecks candidates; nextToken is empty after the last page and may follow
+// a short or empty page.
+func (e *Engine) ListObjects(ctx context.Context, namespace, relation string, subject SubjectRef, pagination repository.Pagination) (objectIDs []string, nextToken string, err error) {
+	r := e.newResolution(ctx)
+	ns, err := r.namespace(namespace)
+	if err != nil {
+		return nil, "", err
+	}
+	if ns.Builtin() {
+		return nil, "", fmt.Errorf("%w: cannot list objects of built-in namespace %q", ErrUnknownNamespace, namespace)
+	}
+	if _, ok := ns.Relation(relation); !ok {
+		return nil, "", fmt.Errorf("%w: %s#%s", ErrUnknownRelation, namespace, relation)
+	}
+
+	pageSize := pagination.PageSize
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 50
+	}
+
+	after := pagination.PageToken
+	for checked := 0; checked < maxListObjectsChecks; {
+		candidates, err := e.tupleRepo.ListObjectIDs(ctx, namespace, after, min(candidateBatchSize, maxListObjectsChecks-checked))
+		if err != nil {
+			return nil, "", err
+		}
+		if len(candidates) == 0 {
+			return objectIDs, "", nil
+		}
+		for _, id := range candidates {
+			r.reset()
+			ok, err := r.check(ObjectRef{Namespace: namespace, ObjectID: id}, relation, subject, 0)
+			if err != nil {
+				return nil, "", err
+			}
+			checked++
+			after = id
+			if ok {
+				objectIDs = append(objectIDs, id)
+				if len(objectIDs) == pageSize {
+					return objectIDs, after, nil
+				}
+			}
+		}
+	}
+	return objectIDs, after, nil
+}
+
+// resolution carries per-request state: loaded schemas are reused across the traversal,
+// and visited records the nodes it has entered so that cycles end instead of exhausting
+// the depth budget.
+type resolution struct {
+	ctx        context.Context
+	engine     *Engine
+	namespaces map[string]*Namespace
+	visited    map[string]bool
+}
+
+func (e *Engine) newResolution(ctx context.Context) *resolution {
+	return &resolution{ctx: ctx, engine: e, namespaces: make(map[string]*Namespace), visited: make(map[string]bool)}
+}
+
+// reset forgets the visited nodes before an unrelated traversal.
+func (r *resolution) reset() {
+	clear(r.visited)
+}
+
+// visit marks a node as entered and reports whether it was entered before.
+func (r *resolution) visit(key string) bool {
+	if r.visited[key] {
+		return true
+	}
+	r.visited[key] = true
+	return false
+}
+
+func (r *resolution) namespace(name string) (*Namespace, error) {
+	if ns, ok := r.namespaces[name]; ok {
+		return ns, nil
+	}
+	ns, err := r.engine.Namespace(r.ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	r.namespaces[name] = ns
+	return ns, nil
+}
+
+func (r *resolution) relation(namespace, relation string) (*RelationDefinition, error) {
+	ns, err := r.namespace(namespace)
+	if err != nil {
+		return nil, err
+	}
+	def, ok := ns.Relation(relation)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s#%s", ErrUnknownRelation, namespace, relation)
+	}
+	return def, nil
+}
+
+func (r *resolution) validateTuple(t Tuple) error {
+	if t.Object.ObjectID == "" || t.Subject.ObjectID == "" {
+		return fmt.Errorf("%w: object and subject IDs are required", ErrInvalidTuple)
+	}
+	ns, err := r.namespace(t.Object.Namespace)
+	if err != nil {
+		return err
+	}
+	if ns.Builtin() {
+		return fmt.Errorf("%w: tuples cannot be written on built-in namespace %q", ErrInvalidTuple, ns.Name)
+	}
+	if _, ok := ns.Relation(t.Relation); !ok {
+		return fmt.Errorf("%w: %s#%s", ErrUnknownRelation, t.Object.Namespace, t.Relation)
+	}
+
+	subjectNS, err := r.namespace(t.Subject.Namespace)
+	if err != nil {
+		return err
+	}
+	if t.Subject.Relation != "" {
+		if _, ok := subjectNS.Relation(t.Subject.Relation); !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownRelation, t.Subject)
+		}
+	}
+	if subjectNS.Builtin() {
+		if _, err := uuid.Parse(t.Subject.ObjectID); err != nil {
+			return fmt.Errorf("%w: subject %s is not a valid %s ID", ErrInvalidTuple, t.Subject, subjectNS.Name)
+		}
+	}
+	return nil
+}
+
+func (r *resolution) check(object ObjectRef, relation string, subject SubjectRef, depth int) (bool, error) {
+	if depth > r.engine.maxDepth {
+		return false, ErrDepthExceeded
+	}
+
+	// A userset subject trivially holds itself.
+	if subject.Namespace == object.Namespace && subject.ObjectID == object.ObjectID && subject.Relation == relation {
+		return true, nil
+	}
+
+	// A node already entered is either on the current path, where revisiting it is a
+	// cycle that grants nothing, or was answered false: a true answer ends the check.
+	if r.visit(object.String() + "#" + relation + "@" + subject.String()) {
+		return false, nil
+	}
+
+	ns, err := r.namespace(object.Namespace)
+	if err != nil {
+		return false, err
+	}
+	if ns.Builtin() {
+		return r.checkBuiltin(object, relation, subject)
+	}
+
+	def, ok := ns.Relation(relation)
+	if !ok {
+		return false, fmt.Errorf("%w: %s#%s", ErrUnknownRelation, object.Namespace, relation)
+	}
+
+	// Direct tuples, following usersets.
+	tuples, err := r.engine.tupleRepo.ListByObjectRelation(r.ctx, object.Namespace, object.ObjectID, relation)
+	if err != nil {
+		return false, err
+	}
+	for _, t := range tuples {
+		ts := SubjectRef{Namespace: t.SubjectNamespace, ObjectID: t.SubjectObjectID, Relation: t.SubjectRelation}
+		if ts == subject {
+			return true, nil
+		}
+		if ts.Relation == "" {
+			continue
+		}
+		ok, err := r.check(ObjectRef{Namespace: ts.Namespace, ObjectID: ts.ObjectID}, ts.Relation, subject, depth+1)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+
+	// Computed usersets on the same object.
+	for _, cu := range def.ComputedUsersets {
+		ok, err := r.check(object, cu, subject, depth+1)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+
+	// Tuple-to-userset: follow the tupleset relation to other objects.
+	for _, ttu := range def.TupleToUsersets {
+		tuples, err := r.engine.tupleRepo.ListByObjectRelation(r.ctx, object.Namespace, object.ObjectID, ttu.Tupleset)
+		if err != nil {
+			return false, err
+		}
+		for _, t := range tuples {
+			target := ObjectRef{Namespace: t.SubjectNamespace, ObjectID: t.SubjectObjectID}
+			targetNS, err := r.namespace(target.Namespace)
+			if err != nil {
+				return false, err
+			}
+			if _, ok := targetNS.Relation(ttu.ComputedUserset); !ok {
+				// The referenced object type does not define the relation; it contributes nothing.
+				continue
+			}
+			ok, err := r.check(target, ttu.ComputedUserset, subject, depth+1)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// checkBuiltin resolves team#member and organization#member from memberships.
+// Only concrete users can be members; service accounts are referenced directly.
+func (r *resolution) checkBuiltin(object ObjectRef, relation string, subject SubjectRef) (bool, error) {
+	if relation != RelationMember || subject.Relation != "" {
+		return false, nil
+	}
+	objectID, err := uuid.Parse(object.ObjectID)
+	if err != nil {
+		return false, nil
+	}
+	subjectID, err := uuid.Parse(subject.ObjectID)
+	if err != nil {
+		return false, nil
+	}
+
+	var lookupErr error
+	switch {
+	case object.Namespace == NamespaceTeam && subject.Namespace == NamespaceUser:
+		_, lookupErr = r.engine.teamMemberRepo.GetMembership(r.ctx, objectID, subjectID)
+	case object.Namespace == NamespaceOrganization && subject.Namespace == NamespaceUser:
+		_, lookupErr = r.engine.orgMemberRepo.GetMembership(r.ctx, objectID, subjectID)
+	default:
+		return false, nil
+	}
+
+	if lookupErr != nil {
+		if errors.Is(lookupErr, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, lookupErr
+	}
+	return true, nil
+}
+
+func (r *resolution) expand(object ObjectRef, relation string, depth int) (*UsersetTree, error) {
+	if depth > r.engine.maxDepth {
+		return nil, ErrDepthExceeded
+	}
+
+	def, err := r.relation(object.Namespace, relation)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := &UsersetTree{Object: object, Relation: relation}
+	// A node already expanded elsewhere in the tree is left as a leaf
+	if r.visit(object.String() + "#" + relation) {
+		return tree, nil
+	}
+
+	ns, _ := r.namespace(object.Namespace)
+	if ns.Builtin() {
+		subjects, err := r.expandBuiltin(object)
+		if err != nil {
+			return nil, err
+		}
+		tree.Subjects = subjects
+		return tree, nil
+	}
+
+	tuples, err := r.engine.tupleRepo.ListByObjectRelation(r.ctx, object.Namespace, object.ObjectID, relation)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tuples {
+		tree.Subjects = append(tree.Subjects, SubjectRef{Namespace: t.SubjectNamespace, ObjectID: t.SubjectObjectID, Relation: t.SubjectRelation})
+	}
+
+	for _, cu := range def.ComputedUsersets {
+		child, err := r.expand(object, cu, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		tree.Children = append(tree.Children, child)
+	}
+
+	for _, ttu := range def.TupleToUsersets {
+		tuples, err := r.engine.tupleRepo.ListByObjectRelation(r.ctx, object.Namespace, object.ObjectID, ttu.Tupleset)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tuples {
+			target := ObjectRef{Namespace: t.SubjectNamespace, ObjectID: t.SubjectObjectID}
+			targetNS, err := r.namespace(target.Namespace)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := targetNS.Relation(ttu.ComputedUserset); !ok {
+				continue
+			}
+			child, err := r.expand(target, ttu.ComputedUserset, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			tree.Children = append(tree.Children, child)
+		}
+	}
+
+	return tree, nil
+}
+
+func (r *resolution) expandBuiltin(object ObjectRef) ([]SubjectRef, error) {
+	objectID, err := uuid.Parse(object.ObjectID)
+	if err != nil {
+		return nil, nil
+	}
+
+	var subjects []SubjectRef
+	switch object.Namespace {
+	case NamespaceTeam:
+		members, err := r.engine.teamMemberRepo.ListAllByTeamID(r.ctx, objectID)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range members {
+			subjects = append(subjects, SubjectRef{Namespace: NamespaceUser, ObjectID: m.UserID.String()})
+		}
+	case NamespaceOrganization:
+		members, err := r.engine.orgMemberRepo.ListAllByOrgID(r.ctx, objectID)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range members {
+			subjects = append(subjects, SubjectRef{Namespace: NamespaceUser, ObjectID: m.UserID.String()})
+		}
+	}
+	return subjects, nil
+}
@@ -0,0 +1,144 @@
+package rebac
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/bernardoforcillo/authlayer/internal/model"
+)
+
+// Built-in namespaces that refer to existing authlayer identities.
+const (
+	NamespaceUser           = "user"
+	NamespaceServiceAccount = "service_account"
+	NamespaceTeam           = "team"
+	NamespaceOrganization   = "organization"
+
+	// RelationMember is the built-in relation of teams and organizations,
+	// resolved from their membership tables.
+	RelationMember = "member"
+)
+
+var (
+	ErrUnknownNamespace = errors.New("rebac: unknown namespace")
+	ErrUnknownRelation  = errors.New("rebac: unknown relation")
+	ErrInvalidSchema    = errors.New("rebac: invalid schema")
+	ErrInvalidTuple     = errors.New("rebac: invalid tuple")
+	ErrDepthExceeded    = errors.New("rebac: maximum resolution depth exceeded")
+)
+
+var identifierPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,99}$`)
+
+// TupleToUserset grants a relation to the subjects of ComputedUserset on every
+// object referenced by the Tupleset relation (e.g. "viewer of the parent folder").
+type TupleToUserset struct {
+	Tupleset        string `json:"tupleset"`
+	ComputedUserset string `json:"computed_userset"`
+}
+
+// RelationDefinition declares a relation of a namespace. Direct tuples always grant
+// the relation; the rewrite rules union in further subjects.
+type RelationDefinition struct {
+	Name             string           `json:"name"`
+	ComputedUsersets []string         `json:"computed_usersets,omitempty"`
+	TupleToUsersets  []TupleToUserset `json:"tuple_to_usersets,omitempty"`
+}
+
+// Namespace is the relation schema of a namespace.
+type Namespace struct {
+	Name      string
+	Relations []RelationDefinition
+	builtin   bool
+}
+
+// Relation returns the named relation definition.
+func (n *Namespace) Relation(name string) (*RelationDefinition, bool) {
+	for i := range n.Relations {
+		if n.Relations[i].Name == name {
+			return &n.Relations[i], true
+		}
+	}
+	return nil, false
+}
+
+// Builtin reports whether the namespace is backed by authlayer identities rather than tuples.
+func (n *Namespace) Builtin() bool {
+	return n.builtin
+}
+
+var builtinNamespaces = map[string]*Namespace{
+	NamespaceUser:           {Name: NamespaceUser, builtin: true},
+	NamespaceServiceAccount: {Name: NamespaceServiceAccount, builtin: true},
+	NamespaceTeam:           {Name: NamespaceTeam, Relations: []RelationDefinition{{Name: RelationMember}}, builtin: true},
+	NamespaceOrganization:   {Name: NamespaceOrganization, Relations: []RelationDefinition{{Name: RelationMember}}, builtin: true},
+}
+
+// IsBuiltinNamespace reports whether name is reserved for authlayer identities.
+func IsBuiltinNamespace(name string) bool {
+	_, ok := builtinNamespaces[name]
+	return ok
+}
+
+// Validate checks relation names and that every rewrite references a relation of this namespace.
+// The computed userset of a tuple-to-userset rule lives in the referenced namespace and is checked at resolution time.
+func (n *Namespace) Validate() error {
+	if !identifierPattern.MatchString(n.Name) {
+		return fmt.Errorf("%w: invalid namespace name %q", ErrInvalidSchema, n.Name)
+	}
+	if IsBuiltinNamespace(n.Name) {
+		return fmt.Errorf("%w: namespace %q is reserved", ErrInvalidSchema, n.Name)
+	}
+
+	defined := make(map[string]bool)
+	for _, rel := range n.Relations {
+		if !identifierPattern.MatchString(rel.Name) {
+			return fmt.Errorf("%w: invalid relation name %q", ErrInvalidSchema, rel.Name)
+		}
+		if defined[rel.Name] {
+			return fmt.Errorf("%w: duplicate relation %q", ErrInvalidSchema, rel.Name)
+		}
+		defined[rel.Name] = true
+	}
+
+	for _, rel := range n.Relations {
+		for _, cu := range rel.ComputedUsersets {
+			if !defined[cu] {
+				return fmt.Errorf("%w: relation %q references undefined relation %q", ErrInvalidSchema, rel.Name, cu)
+			}
+		}
+		for _, ttu := range rel.TupleToUsersets {
+			if !defined[ttu.Tupleset] {
+				return fmt.Errorf("%w: relation %q references undefined tupleset %q", ErrInvalidSchema, rel.Name, ttu.Tupleset)
+			}
+			if !identifierPattern.MatchString(ttu.ComputedUserset) {
+				return fmt.Errorf("%w: invalid computed userset %q", ErrInvalidSchema, ttu.ComputedUserset)
+			}
+		}
+	}
+	return nil
+}
+
+// ToModel serializes the namespace for storage.
+func (n *Namespace) ToModel() (*model.RelationNamespace, error) {
+	relations, err := json.Marshal(n.Relations)
+	if err != nil {
+		return nil, err
+	}
+	return &model.RelationNamespace{
+		Name:      n.Name,
+		Relations: string(relations),
+	}, nil
+}
+
+// NamespaceFromModel parses a stored namespace.
+func NamespaceFromModel(m *model.RelationNamespace) (*Namespace, error) {
+	ns := &Namespace{Name: m.Name}
+	if m.Relations != "" {
+		if err := json.Unmarshal([]byte(m.Relations), &ns.Relations); err != nil {
+			return nil, err
+		}
+	}
+	return ns, nil
+}
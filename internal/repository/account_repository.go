@@ -18,12 +18,12 @@ func NewAccountRepository(db *gorm.DB) AccountRepository {
 }
 
 func (r *accountRepository) Create(ctx context.Context, account *model.Account) error {
-	return r.db.WithContext(ctx).Create(account).Error
+	return conn(ctx, r.db).Create(account).Error
 }
 
 func (r *accountRepository) GetByProviderAndID(ctx context.Context, provider, providerAccountID string) (*model.Account, error) {
 	var account model.Account
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("provider = ? AND provider_account_id = ?", provider, providerAccountID).
 		Preload("User").
 		First(&account).Error
@@ -35,7 +35,7 @@ func (r *accountRepository) GetByProviderAndID(ctx context.Context, provider, pr
 
 func (r *accountRepository) GetByUserIDAndProvider(ctx context.Context, userID uuid.UUID, provider string) (*model.Account, error) {
 	var account model.Account
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("user_id = ? AND provider = ?", userID, provider).
 		First(&account).Error
 	if err != nil {
@@ -45,7 +45,7 @@ func (r *accountRepository) GetByUserIDAndProvider(ctx context.Context, userID u
 }
 
 func (r *accountRepository) DeleteByUserIDAndProvider(ctx context.Context, userID uuid.UUID, provider string) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Where("user_id = ? AND provider = ?", userID, provider).
 		Delete(&model.Account{}).Error
 }
@@ -19,12 +19,12 @@ func NewAPIKeyRepository(db *gorm.DB) APIKeyRepository {
 }
 
 func (r *apiKeyRepository) Create(ctx context.Context, apiKey *model.APIKey) error {
-	return r.db.WithContext(ctx).Create(apiKey).Error
+	return conn(ctx, r.db).Create(apiKey).Error
 }
 
 func (r *apiKeyRepository) GetByKeyHash(ctx context.Context, keyHash string) (*model.APIKey, error) {
 	var key model.APIKey
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("key_hash = ? AND revoked = false", keyHash).
 		Preload("User").
 		First(&key).Error
@@ -36,7 +36,7 @@ func (r *apiKeyRepository) GetByKeyHash(ctx context.Context, keyHash string) (*m
 
 func (r *apiKeyRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.APIKey, error) {
 	var key model.APIKey
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&key).Error; err != nil {
+	if err := conn(ctx, r.db).Where("id = ?", id).First(&key).Error; err != nil {
 		return nil, err
 	}
 	return &key, nil
@@ -46,7 +46,7 @@ func (r *apiKeyRepository) ListByUserID(ctx context.Context, userID uuid.UUID, p
 	var keys []model.APIKey
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&model.APIKey{}).Where("user_id = ?", userID)
+	query := conn(ctx, r.db).Model(&model.APIKey{}).Where("user_id = ?", userID)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -65,7 +65,7 @@ func (r *apiKeyRepository) ListByUserID(ctx context.Context, userID uuid.UUID, p
 }
 
 func (r *apiKeyRepository) Revoke(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Model(&model.APIKey{}).
 		Where("id = ?", id).
 		Update("revoked", true).Error
@@ -73,7 +73,7 @@ func (r *apiKeyRepository) Revoke(ctx context.Context, id uuid.UUID) error {
 
 func (r *apiKeyRepository) UpdateLastUsed(ctx context.Context, id uuid.UUID) error {
 	now := time.Now()
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Model(&model.APIKey{}).
 		Where("id = ?", id).
 		Update("last_used_at", now).Error
@@ -0,0 +1,348 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"github.com/bernardoforcillo/authlayer/internal/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type effectivePermissionRepository struct {
+	db *gorm.DB
+}
+
+func NewEffectivePermissionRepository(db *gorm.DB) EffectivePermissionRepository {
+	return &effectivePermissionRepository{db: db}
+}
+
+// ReplaceForPrincipal atomically swaps all rows of the principal for the given ones and
+// records, in the same transaction, a change for every scope whose permissions differ.
+// Refreshes of one principal are serialized, and changes are committed in revision order so
+// readers following revisions never skip one.
+func (r *effectivePermissionRepository) ReplaceForPrincipal(ctx context.Context, principal PrincipalRef, rows []model.EffectivePermission) ([]model.PermissionChange, error) {
+	var changes []model.PermissionChange
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))",
+			string(principal.Type)+":"+principal.ID.String()).Error; err != nil {
+			return err
+		}
+
+		var previous []model.EffectivePermission
+		if err := tx.
+			Where("principal_type = ? AND principal_id = ?", principal.Type, principal.ID).
+			Find(&previous).Error; err != nil {
+			return err
+		}
+		if err := tx.
+			Where("principal_type = ? AND principal_id = ?", principal.Type, principal.ID).
+			Delete(&model.EffectivePermission{}).Error; err != nil {
+			return err
+		}
+		if len(rows) > 0 {
+			if err := tx.CreateInBatches(rows, 500).Error; err != nil {
+				return err
+			}
+		}
+
+		changes = diffPermissions(principal, previous, rows)
+		if len(changes) == 0 {
+			return nil
+		}
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext('authlayer_permission_changes'))").Error; err != nil {
+			return err
+		}
+		return tx.Create(&changes).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+type permissionScope struct {
+	scopeType model.ScopeType
+	scopeID   uuid.UUID
+}
+
+// diffPermissions compares the permission names held per scope, ignoring which role
+// carries them.
+func diffPermissions(principal PrincipalRef, previous, current []model.EffectivePermission) []model.PermissionChange {
+	group := func(rows []model.EffectivePermission) map[permissionScope]map[string]bool {
+		scopes := make(map[permissionScope]map[string]bool)
+		for _, row := range rows {
+			scope := permissionScope{row.ScopeType, row.ScopeID}
+			if scopes[scope] == nil {
+				scopes[scope] = make(map[string]bool)
+			}
+			scopes[scope][row.PermissionName] = true
+		}
+		return scopes
+	}
+	before, after := group(previous), group(current)
+
+	scopes := make(map[permissionScope]bool, len(before)+len(after))
+	for scope := range before {
+		scopes[scope] = true
+	}
+	for scope := range after {
+		scopes[scope] = true
+	}
+
+	var changes []model.PermissionChange
+	for scope := range scopes {
+		change := model.PermissionChange{
+			PrincipalType: principal.Type,
+			PrincipalID:   principal.ID,
+			ScopeType:     scope.scopeType,
+			ScopeID:       scope.scopeID,
+			Added:         model.StringList{},
+			Removed:       model.StringList{},
+		}
+		for name := range after[scope] {
+			if !before[scope][name] {
+				change.Added = append(change.Added, name)
+			}
+		}
+		for name := range before[scope] {
+			if !after[scope][name] {
+				change.Removed = append(change.Removed, name)
+			}
+		}
+		if len(change.Added) == 0 && len(change.Removed) == 0 {
+			continue
+		}
+		sort.Strings(change.Added)
+		sort.Strings(change.Removed)
+		changes = append(changes, change)
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].ScopeType != changes[j].ScopeType {
+			return changes[i].ScopeType < changes[j].ScopeType
+		}
+		return changes[i].ScopeID.String() < changes[j].ScopeID.String()
+	})
+	return changes
+}
+
+func (r *effectivePermissionRepository) ListByPrincipal(ctx context.Context, principal PrincipalRef) ([]model.EffectivePermission, error) {
+	var rows []model.EffectivePermission
+	err := conn(ctx, r.db).
+		Where("principal_type = ? AND principal_id = ?", principal.Type, principal.ID).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *effectivePermissionRepository) Exists(ctx context.Context, principal PrincipalRef, scopeType model.ScopeType, scopeID uuid.UUID, permission string) (bool, error) {
+	var exists bool
+	err := conn(ctx, r.db).Raw(`
+		SELECT EXISTS (
+			SELECT 1 FROM effective_permissions
+			WHERE principal_type = ? AND principal_id = ? AND scope_type = ? AND scope_id = ? AND permission_name = ?
+		)
+	`, principal.Type, principal.ID, scopeType, scopeID, permission).Scan(&exists).Error
+	return exists, err
+}
+
+// FindSourceRole reports whether the principal holds the permission in the scope and returns
+// the name of the granting role, the first in name order when several grant it.
+func (r *effectivePermissionRepository) FindSourceRole(ctx context.Context, principal PrincipalRef, scopeType model.ScopeType, scopeID uuid.UUID, permission string) (string, bool, error) {
+	var names []string
+	err := conn(ctx, r.db).Raw(`
+		SELECT r.name FROM effective_permissions ep
+		INNER JOIN roles r ON r.id = ep.source_role_id
+		WHERE ep.principal_type = ? AND ep.principal_id = ? AND ep.scope_type = ? AND ep.scope_id = ? AND ep.permission_name = ?
+		ORDER BY r.name
+		LIMIT 1
+	`, principal.Type, principal.ID, scopeType, scopeID, permission).Scan(&names).Error
+	if err != nil || len(names) == 0 {
+		return "", false, err
+	}
+	return names[0], true, nil
+}
+
+// ExistsInProject checks the principal's project rows and the rows of the project's org, since
+// only principals bound to the project have project rows of their own.
+func (r *effectivePermissionRepository) ExistsInProject(ctx context.Context, principal PrincipalRef, projectID uuid.UUID, permission string) (bool, error) {
+	var exists bool
+	err := conn(ctx, r.db).Raw(`
+		SELECT EXISTS (
+			SELECT 1 FROM effective_permissions
+			WHERE principal_type = ? AND principal_id = ? AND permission_name = ?
+			AND (
+				(scope_type = ? AND scope_id = ?)
+				OR (scope_type = ? AND scope_id = (SELECT org_id FROM projects WHERE id = ? AND deleted_at IS NULL))
+			)
+		)
+	`, principal.Type, principal.ID, permission,
+		model.ScopeTypeProject, projectID,
+		model.ScopeTypeOrg, projectID,
+	).Scan(&exists).Error
+	return exists, err
+}
+
+// ListCandidateScopes returns the orgs and projects in which the principal may hold
+// permissions: the orgs it has grants or teams in, their descendants (roles may cascade
+// down the org tree), and the projects it is bound to directly or through a team.
+func (r *effectivePermissionRepository) ListCandidateScopes(ctx context.Context, principal PrincipalRef) ([]uuid.UUID, []uuid.UUID, error) {
+	var directOrgs, directProjects string
+	var orgArgs, projectArgs []interface{}
+	switch principal.Type {
+	case model.PrincipalTypeUser:
+		directOrgs = `
+			SELECT org_id FROM organization_members WHERE user_id = ? AND deleted_at IS NULL
+			UNION
+			SELECT t.org_id FROM team_members tm
+			INNER JOIN teams t ON t.id = tm.team_id AND t.deleted_at IS NULL
+			WHERE tm.user_id = ? AND tm.deleted_at IS NULL`
+		orgArgs = []interface{}{principal.ID, principal.ID}
+		directProjects = `
+			SELECT project_id FROM project_members
+			WHERE deleted_at IS NULL AND (
+				(principal_type = ? AND principal_id = ?)
+				OR (principal_type = ? AND principal_id IN (
+					SELECT team_id FROM team_members WHERE user_id = ? AND deleted_at IS NULL
+				))
+			)`
+		projectArgs = []interface{}{model.PrincipalTypeUser, principal.ID, model.PrincipalTypeTeam, principal.ID}
+	case model.PrincipalTypeServiceAccount:
+		directOrgs = `
+			SELECT org_id FROM service_account_roles WHERE service_account_id = ? AND deleted_at IS NULL
+			UNION
+			SELECT org_id FROM service_accounts WHERE id = ? AND deleted_at IS NULL`
+		orgArgs = []interface{}{principal.ID, principal.ID}
+		directProjects = `
+			SELECT project_id FROM project_members
+			WHERE principal_type = ? AND principal_id = ? AND deleted_at IS NULL`
+		projectArgs = []interface{}{model.PrincipalTypeServiceAccount, principal.ID}
+	default:
+		return nil, nil, nil
+	}
+
+	var orgIDs []uuid.UUID
+	err := conn(ctx, r.db).Raw(`
+		WITH RECURSIVE direct(org_id) AS (`+directOrgs+`
+		),
+		org_tree AS (
+			SELECT id FROM organizations WHERE id IN (SELECT org_id FROM direct) AND deleted_at IS NULL
+			UNION
+			SELECT o.id FROM organizations o
+			INNER JOIN org_tree ot ON o.parent_org_id = ot.id
+			WHERE o.deleted_at IS NULL
+		)
+		SELECT id FROM org_tree
+	`, orgArgs...).Scan(&orgIDs).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var projectIDs []uuid.UUID
+	if err := conn(ctx, r.db).Raw(directProjects, projectArgs...).Scan(&projectIDs).Error; err != nil {
+		return nil, nil, err
+	}
+
+	return orgIDs, projectIDs, nil
+}
+
+// ListPrincipalsForRole returns every user and service account whose permissions may depend
+// on the role: holders of the role or of any descendant through memberships, grants, project
+// bindings or label bindings, and principals that still have rows sourced from those roles.
+// Label bindings are expanded to every principal they could match.
+func (r *effectivePermissionRepository) ListPrincipalsForRole(ctx context.Context, roleID uuid.UUID) ([]PrincipalRef, error) {
+	user, sa, team := model.PrincipalTypeUser, model.PrincipalTypeServiceAccount, model.PrincipalTypeTeam
+
+	var rows []struct {
+		PrincipalType model.PrincipalType
+		PrincipalID   uuid.UUID
+	}
+	err := conn(ctx, r.db).Raw(`
+		WITH RECURSIVE role_tree AS (
+			SELECT id FROM roles WHERE id = ?
+			UNION
+			SELECT r.id FROM roles r INNER JOIN role_tree rt ON r.parent_role_id = rt.id
+		)
+		SELECT CAST(? AS text) AS principal_type, user_id AS principal_id FROM organization_members
+		WHERE role_id IN (SELECT id FROM role_tree) AND deleted_at IS NULL
+		UNION
+		SELECT CAST(? AS text), service_account_id FROM service_account_roles
+		WHERE role_id IN (SELECT id FROM role_tree) AND deleted_at IS NULL
+		UNION
+		SELECT principal_type, principal_id FROM project_members
+		WHERE role_id IN (SELECT id FROM role_tree) AND principal_type IN (?, ?) AND deleted_at IS NULL
+		UNION
+		SELECT CAST(? AS text), tm.user_id FROM project_members pm
+		INNER JOIN team_members tm ON tm.team_id = pm.principal_id AND tm.deleted_at IS NULL
+		WHERE pm.role_id IN (SELECT id FROM role_tree) AND pm.principal_type = ? AND pm.deleted_at IS NULL
+		UNION
+		SELECT CAST(? AS text), om.user_id FROM label_role_bindings b
+		INNER JOIN organization_members om ON om.org_id = b.org_id AND om.deleted_at IS NULL
+		WHERE b.role_id IN (SELECT id FROM role_tree) AND b.principal_type = ? AND b.deleted_at IS NULL
+		UNION
+		SELECT CAST(? AS text), tm.user_id FROM label_role_bindings b
+		INNER JOIN teams t ON t.org_id = b.org_id AND t.deleted_at IS NULL
+		INNER JOIN team_members tm ON tm.team_id = t.id AND tm.deleted_at IS NULL
+		WHERE b.role_id IN (SELECT id FROM role_tree) AND b.principal_type = ? AND b.deleted_at IS NULL
+		UNION
+		SELECT CAST(? AS text), s.id FROM label_role_bindings b
+		INNER JOIN service_accounts s ON s.org_id = b.org_id AND s.deleted_at IS NULL
+		WHERE b.role_id IN (SELECT id FROM role_tree) AND b.principal_type = ? AND b.deleted_at IS NULL
+		UNION
+		SELECT principal_type, principal_id FROM effective_permissions
+		WHERE source_role_id IN (SELECT id FROM role_tree)
+	`, roleID,
+		user,
+		sa,
+		user, sa,
+		user, team,
+		user, user,
+		user, team,
+		sa, sa,
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	principals := make([]PrincipalRef, len(rows))
+	for i, row := range rows {
+		principals[i] = PrincipalRef{Type: row.PrincipalType, ID: row.PrincipalID}
+	}
+	return principals, nil
+}
+
+// ListPrincipals returns every user and service account that is not deleted.
+func (r *effectivePermissionRepository) ListPrincipals(ctx context.Context) ([]PrincipalRef, error) {
+	var userIDs, saIDs []uuid.UUID
+	if err := conn(ctx, r.db).Model(&model.User{}).Pluck("id", &userIDs).Error; err != nil {
+		return nil, err
+	}
+	if err := conn(ctx, r.db).Model(&model.ServiceAccount{}).Pluck("id", &saIDs).Error; err != nil {
+		return nil, err
+	}
+
+	principals := make([]PrincipalRef, 0, len(userIDs)+len(saIDs))
+	for _, id := range userIDs {
+		principals = append(principals, PrincipalRef{Type: model.PrincipalTypeUser, ID: id})
+	}
+	for _, id := range saIDs {
+		principals = append(principals, PrincipalRef{Type: model.PrincipalTypeServiceAccount, ID: id})
+	}
+	return principals, nil
+}
+
+// DeleteOrphans removes the rows of deleted users and service accounts. Principals are
+// refreshed when deleted, so any rows left here were never announced and no changes are
+// recorded for them.
+func (r *effectivePermissionRepository) DeleteOrphans(ctx context.Context) (int64, error) {
+	result := conn(ctx, r.db).Exec(`
+		DELETE FROM effective_permissions
+		WHERE (principal_type = ? AND principal_id NOT IN (SELECT id FROM users WHERE deleted_at IS NULL))
+		OR (principal_type = ? AND principal_id NOT IN (SELECT id FROM service_accounts WHERE deleted_at IS NULL))
+	`, model.PrincipalTypeUser, model.PrincipalTypeServiceAccount)
+	return result.RowsAffected, result.Error
+}
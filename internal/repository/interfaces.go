@@ -2,7 +2,9 @@ package repository
 
 import (
 	"context"
+	"time"
 
+	"github.com/bernardoforcillo/authlayer/internal/labels"
 	"github.com/bernardoforcillo/authlayer/internal/model"
 
 	"github.com/google/uuid"
@@ -18,6 +20,41 @@ type Pagination struct {
 type UserFilter struct {
 	Search *string
 	Status *model.UserStatus
+	Labels labels.Selector
+}
+
+// RoleSeed selects the roles a principal holds directly, before expanding the role hierarchy.
+type RoleSeed struct {
+	// UserID selects the user's org memberships, the project bindings of the user and of
+	// its teams, and the user and team label bindings in scope.
+	UserID *uuid.UUID
+	// ServiceAccountID selects the service account's role grants, project bindings and
+	// label bindings in scope.
+	ServiceAccountID *uuid.UUID
+	// OrgID scopes the seed to the org and the ancestors it inherits roles from. When
+	// neither OrgID nor ProjectID is set, a service account's grants in every org are
+	// selected and a user holds nothing.
+	OrgID *uuid.UUID
+	// ProjectID adds the project bindings and scopes the seed to the project's org.
+	ProjectID *uuid.UUID
+	// RoleIDs are granted in addition.
+	RoleIDs []uuid.UUID
+}
+
+// RoleGrant pairs a role reached while expanding a RoleSeed with one of its permissions.
+// Permission is nil for roles that carry no permissions of their own.
+type RoleGrant struct {
+	RoleID     uuid.UUID
+	Permission *model.Permission
+}
+
+// LabelCandidate is a label binding in a RoleSeed's scope with the labels of the principal,
+// or of one of the user's teams, it is matched against. Its role applies when Selector
+// matches Labels.
+type LabelCandidate struct {
+	RoleID   uuid.UUID
+	Selector string
+	Labels   model.Labels
 }
 
 type UserRepository interface {
@@ -42,6 +79,9 @@ type SessionRepository interface {
 	RevokeByTokenHash(ctx context.Context, tokenHash string) error
 	RevokeAllByUserID(ctx context.Context, userID uuid.UUID) error
 	RevokeByFamily(ctx context.Context, family string) error
+	// RevokeByClient revokes the sessions issued to an OAuth client, limited to one user
+	// when userID is not nil.
+	RevokeByClient(ctx context.Context, clientID string, userID *uuid.UUID) error
 	DeleteExpired(ctx context.Context) error
 }
 
@@ -61,14 +101,21 @@ type OrganizationRepository interface {
 	Update(ctx context.Context, org *model.Organization) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	ListByUserID(ctx context.Context, userID uuid.UUID, pagination Pagination) ([]model.Organization, int64, error)
+	SetParent(ctx context.Context, orgID uuid.UUID, parentID *uuid.UUID, inheritParentRoles bool) error
+	GetAncestors(ctx context.Context, orgID uuid.UUID, maxDepth int) ([]model.Organization, error)
+	GetDescendants(ctx context.Context, orgID uuid.UUID, maxDepth int) ([]model.Organization, error)
 }
 
 type OrganizationMemberRepository interface {
 	Add(ctx context.Context, member *model.OrganizationMember) error
 	Remove(ctx context.Context, orgID, userID uuid.UUID) error
 	GetMembership(ctx context.Context, orgID, userID uuid.UUID) (*model.OrganizationMember, error)
+	ListAllByUserID(ctx context.Context, userID uuid.UUID) ([]model.OrganizationMember, error)
 	UpdateRole(ctx context.Context, orgID, userID, roleID uuid.UUID) error
 	ListByOrgID(ctx context.Context, orgID uuid.UUID, pagination Pagination) ([]model.OrganizationMember, int64, error)
+	ListAllByOrgID(ctx context.Context, orgID uuid.UUID) ([]model.OrganizationMember, error)
+	ListByOrgIDs(ctx context.Context, orgIDs []uuid.UUID, pagination Pagination) ([]model.OrganizationMember, int64, error)
+	CountDistinctUsers(ctx context.Context, orgIDs []uuid.UUID) (int64, error)
 }
 
 type TeamRepository interface {
@@ -76,13 +123,36 @@ type TeamRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*model.Team, error)
 	Update(ctx context.Context, team *model.Team) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	ListByOrgID(ctx context.Context, orgID uuid.UUID, pagination Pagination) ([]model.Team, int64, error)
+	ListByOrgID(ctx context.Context, orgID uuid.UUID, selector labels.Selector, pagination Pagination) ([]model.Team, int64, error)
+	ListAllByOrgID(ctx context.Context, orgID uuid.UUID) ([]model.Team, error)
 }
 
 type TeamMemberRepository interface {
 	Add(ctx context.Context, member *model.TeamMember) error
 	Remove(ctx context.Context, teamID, userID uuid.UUID) error
+	GetMembership(ctx context.Context, teamID, userID uuid.UUID) (*model.TeamMember, error)
 	ListByTeamID(ctx context.Context, teamID uuid.UUID, pagination Pagination) ([]model.TeamMember, int64, error)
+	ListAllByTeamID(ctx context.Context, teamID uuid.UUID) ([]model.TeamMember, error)
+	ListByOrgID(ctx context.Context, orgID uuid.UUID) ([]model.TeamMember, error)
+	ListByUserIDAndOrgID(ctx context.Context, userID, orgID uuid.UUID) ([]model.TeamMember, error)
+}
+
+type ProjectRepository interface {
+	Create(ctx context.Context, project *model.Project) error
+	GetByID(ctx context.Context, id uuid.UUID) (*model.Project, error)
+	Update(ctx context.Context, project *model.Project) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListByOrgID(ctx context.Context, orgID uuid.UUID, pagination Pagination) ([]model.Project, int64, error)
+}
+
+type ProjectMemberRepository interface {
+	Upsert(ctx context.Context, member *model.ProjectMember) error
+	Remove(ctx context.Context, projectID uuid.UUID, principalType model.PrincipalType, principalID uuid.UUID) error
+	ListByProjectID(ctx context.Context, projectID uuid.UUID, pagination Pagination) ([]model.ProjectMember, int64, error)
+	ListAllByProjectID(ctx context.Context, projectID uuid.UUID) ([]model.ProjectMember, error)
+	ListByProjectIDAndPrincipals(ctx context.Context, projectID uuid.UUID, principalType model.PrincipalType, principalIDs []uuid.UUID) ([]model.ProjectMember, error)
+	ListByOrgID(ctx context.Context, orgID uuid.UUID) ([]model.ProjectMember, error)
+	ListByOrgIDAndPrincipals(ctx context.Context, orgID uuid.UUID, principalType model.PrincipalType, principalIDs []uuid.UUID) ([]model.ProjectMember, error)
 }
 
 type RoleRepository interface {
@@ -91,7 +161,7 @@ type RoleRepository interface {
 	GetByNameAndOrg(ctx context.Context, name string, orgID *uuid.UUID) (*model.Role, error)
 	Update(ctx context.Context, role *model.Role) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	ListByOrgID(ctx context.Context, orgID *uuid.UUID, pagination Pagination) ([]model.Role, int64, error)
+	ListByOrgID(ctx context.Context, orgID *uuid.UUID, selector labels.Selector, pagination Pagination) ([]model.Role, int64, error)
 	GetAncestors(ctx context.Context, roleID uuid.UUID, maxDepth int) ([]model.Role, error)
 }
 
@@ -107,6 +177,7 @@ type RolePermissionRepository interface {
 	Assign(ctx context.Context, roleID, permissionID uuid.UUID) error
 	Revoke(ctx context.Context, roleID, permissionID uuid.UUID) error
 	GetPermissionsByRoleIDs(ctx context.Context, roleIDs []uuid.UUID) ([]model.Permission, error)
+	ResolveGrants(ctx context.Context, seed RoleSeed, maxDepth int) ([]RoleGrant, []LabelCandidate, error)
 }
 
 type InvitationRepository interface {
@@ -121,19 +192,165 @@ type ServiceAccountRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*model.ServiceAccount, error)
 	Update(ctx context.Context, sa *model.ServiceAccount) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	ListByOrgID(ctx context.Context, orgID uuid.UUID, pagination Pagination) ([]model.ServiceAccount, int64, error)
+	ListByOrgID(ctx context.Context, orgID uuid.UUID, selector labels.Selector, pagination Pagination) ([]model.ServiceAccount, int64, error)
+	ListAllByOrgID(ctx context.Context, orgID uuid.UUID) ([]model.ServiceAccount, error)
+	UpdateLastAuthenticated(ctx context.Context, id uuid.UUID) error
 }
 
 type ServiceAccountKeyRepository interface {
 	Create(ctx context.Context, key *model.ServiceAccountKey) error
+	GetByID(ctx context.Context, id uuid.UUID) (*model.ServiceAccountKey, error)
 	GetByKeyHash(ctx context.Context, keyHash string) (*model.ServiceAccountKey, error)
 	Revoke(ctx context.Context, id uuid.UUID) error
 	ListByServiceAccountID(ctx context.Context, saID uuid.UUID, pagination Pagination) ([]model.ServiceAccountKey, int64, error)
 	UpdateLastUsed(ctx context.Context, id uuid.UUID) error
 }
 
+// WorkloadIdentityTrustRepository stores the external OIDC issuers whose tokens
+// authenticate as service accounts.
+type WorkloadIdentityTrustRepository interface {
+	Create(ctx context.Context, trust *model.WorkloadIdentityTrust) error
+	// GetByID returns the trust with its service account.
+	GetByID(ctx context.Context, id uuid.UUID) (*model.WorkloadIdentityTrust, error)
+	// ListByServiceAccountID returns the service account's trusts, oldest first.
+	ListByServiceAccountID(ctx context.Context, saID uuid.UUID) ([]model.WorkloadIdentityTrust, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	UpdateLastUsed(ctx context.Context, id uuid.UUID) error
+}
+
 type ServiceAccountRoleRepository interface {
 	Assign(ctx context.Context, sar *model.ServiceAccountRole) error
 	Revoke(ctx context.Context, saID, roleID, orgID uuid.UUID) error
 	ListByServiceAccountID(ctx context.Context, saID uuid.UUID) ([]model.ServiceAccountRole, error)
+	ListByOrgID(ctx context.Context, orgID uuid.UUID) ([]model.ServiceAccountRole, error)
+}
+
+type RoleConstraintRepository interface {
+	Create(ctx context.Context, constraint *model.RoleConstraint, roleIDs []uuid.UUID) error
+	GetByID(ctx context.Context, id uuid.UUID) (*model.RoleConstraint, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListByOrgID(ctx context.Context, orgID *uuid.UUID, pagination Pagination) ([]model.RoleConstraint, int64, error)
+	ListApplicable(ctx context.Context, orgID uuid.UUID) ([]model.RoleConstraint, error)
+}
+
+type LabelRoleBindingRepository interface {
+	Create(ctx context.Context, binding *model.LabelRoleBinding) error
+	GetByID(ctx context.Context, id uuid.UUID) (*model.LabelRoleBinding, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListByOrgID(ctx context.Context, orgID uuid.UUID, pagination Pagination) ([]model.LabelRoleBinding, int64, error)
+	ListByOrgIDs(ctx context.Context, orgIDs []uuid.UUID, principalType model.PrincipalType) ([]model.LabelRoleBinding, error)
+}
+
+type RelationNamespaceRepository interface {
+	Upsert(ctx context.Context, ns *model.RelationNamespace) error
+	GetByName(ctx context.Context, name string) (*model.RelationNamespace, error)
+	List(ctx context.Context, pagination Pagination) ([]model.RelationNamespace, int64, error)
+}
+
+type RelationTupleRepository interface {
+	Write(ctx context.Context, writes, deletes []model.RelationTuple) error
+	ListByObjectRelation(ctx context.Context, namespace, objectID, relation string) ([]model.RelationTuple, error)
+	ListByObject(ctx context.Context, namespace, objectID string, relation *string, pagination Pagination) ([]model.RelationTuple, int64, error)
+	ListObjectIDs(ctx context.Context, namespace, after string, limit int) ([]string, error)
+}
+
+// PrincipalRef identifies a user or service account.
+type PrincipalRef struct {
+	Type model.PrincipalType
+	ID   uuid.UUID
+}
+
+type EffectivePermissionRepository interface {
+	ReplaceForPrincipal(ctx context.Context, principal PrincipalRef, rows []model.EffectivePermission) ([]model.PermissionChange, error)
+	ListByPrincipal(ctx context.Context, principal PrincipalRef) ([]model.EffectivePermission, error)
+	Exists(ctx context.Context, principal PrincipalRef, scopeType model.ScopeType, scopeID uuid.UUID, permission string) (bool, error)
+	ExistsInProject(ctx context.Context, principal PrincipalRef, projectID uuid.UUID, permission string) (bool, error)
+	FindSourceRole(ctx context.Context, principal PrincipalRef, scopeType model.ScopeType, scopeID uuid.UUID, permission string) (string, bool, error)
+	ListCandidateScopes(ctx context.Context, principal PrincipalRef) (orgIDs, projectIDs []uuid.UUID, err error)
+	ListPrincipalsForRole(ctx context.Context, roleID uuid.UUID) ([]PrincipalRef, error)
+	ListPrincipals(ctx context.Context) ([]PrincipalRef, error)
+	DeleteOrphans(ctx context.Context) (int64, error)
+}
+
+// PermissionChangeFilter narrows a change feed; zero fields match everything.
+type PermissionChangeFilter struct {
+	PrincipalType model.PrincipalType
+	PrincipalID   *uuid.UUID
+	ScopeType     model.ScopeType
+	ScopeID       *uuid.UUID
+}
+
+type PermissionChangeRepository interface {
+	ListAfter(ctx context.Context, revision int64, filter PermissionChangeFilter, limit int) ([]model.PermissionChange, error)
+	LatestRevision(ctx context.Context) (int64, error)
+	OldestRevision(ctx context.Context) (int64, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// RolePermissionName is a role's direct permission, by name.
+type RolePermissionName struct {
+	RoleID         uuid.UUID
+	PermissionName string
+}
+
+// RBACSnapshot is every non-deleted row the resolver consults, read at one point in time.
+type RBACSnapshot struct {
+	Organizations       []model.Organization
+	Roles               []model.Role
+	RolePermissions     []RolePermissionName
+	Users               []model.User
+	OrgMembers          []model.OrganizationMember
+	Teams               []model.Team
+	TeamMembers         []model.TeamMember
+	ServiceAccounts     []model.ServiceAccount
+	ServiceAccountRoles []model.ServiceAccountRole
+	Projects            []model.Project
+	ProjectMembers      []model.ProjectMember
+	LabelBindings       []model.LabelRoleBinding
+}
+
+type RBACSnapshotRepository interface {
+	Load(ctx context.Context) (*RBACSnapshot, error)
+}
+
+type OAuthClientRepository interface {
+	Create(ctx context.Context, client *model.OAuthClient) error
+	GetByClientID(ctx context.Context, clientID string) (*model.OAuthClient, error)
+	Update(ctx context.Context, client *model.OAuthClient) error
+	Delete(ctx context.Context, clientID string) error
+	ListByOrgID(ctx context.Context, orgID uuid.UUID, pagination Pagination) ([]model.OAuthClient, int64, error)
+}
+
+type OAuthAuthorizationCodeRepository interface {
+	Create(ctx context.Context, code *model.OAuthAuthorizationCode) error
+	// Consume deletes the code and returns it, so that it can be redeemed only once. It
+	// returns gorm.ErrRecordNotFound for unknown or already redeemed codes.
+	Consume(ctx context.Context, codeHash string) (*model.OAuthAuthorizationCode, error)
+	DeleteExpired(ctx context.Context) error
+}
+
+type OAuthDeviceCodeRepository interface {
+	Create(ctx context.Context, code *model.OAuthDeviceCode) error
+	// GetPending returns an unexpired device code still awaiting the user's decision.
+	GetPending(ctx context.Context, userCode string) (*model.OAuthDeviceCode, error)
+	// Decide records the user's decision on a pending device code. It returns
+	// gorm.ErrRecordNotFound when the code is unknown, expired or already decided.
+	Decide(ctx context.Context, userCode string, status model.OAuthDeviceCodeStatus, userID uuid.UUID, authTime *time.Time, amr []string) error
+	// Poll records a poll of the device code and returns it as it was before, so that
+	// LastPolledAt is the previous poll. Concurrent polls are serialized.
+	Poll(ctx context.Context, deviceCodeHash string) (*model.OAuthDeviceCode, error)
+	IncreasePollInterval(ctx context.Context, id uuid.UUID, seconds int) error
+	// Consume deletes an approved device code and returns it, so that it can be redeemed
+	// only once.
+	Consume(ctx context.Context, deviceCodeHash string) (*model.OAuthDeviceCode, error)
+	DeleteExpired(ctx context.Context) error
+}
+
+type OAuthConsentRepository interface {
+	Get(ctx context.Context, userID uuid.UUID, clientID string) (*model.OAuthConsent, error)
+	// Grant adds scopes to the user's consent for the client, creating it if needed.
+	Grant(ctx context.Context, userID uuid.UUID, clientID string, scopes []string) (*model.OAuthConsent, error)
+	Revoke(ctx context.Context, userID uuid.UUID, clientID string) error
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]model.OAuthConsent, error)
+	DeleteByClientID(ctx context.Context, clientID string) error
 }
@@ -18,12 +18,12 @@ func NewInvitationRepository(db *gorm.DB) InvitationRepository {
 }
 
 func (r *invitationRepository) Create(ctx context.Context, invitation *model.Invitation) error {
-	return r.db.WithContext(ctx).Create(invitation).Error
+	return conn(ctx, r.db).Create(invitation).Error
 }
 
 func (r *invitationRepository) GetByToken(ctx context.Context, token string) (*model.Invitation, error) {
 	var inv model.Invitation
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("token = ?", token).
 		Preload("Organization").
 		Preload("Role").
@@ -35,7 +35,7 @@ func (r *invitationRepository) GetByToken(ctx context.Context, token string) (*m
 }
 
 func (r *invitationRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status model.InvitationStatus) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Model(&model.Invitation{}).
 		Where("id = ?", id).
 		Update("status", status).Error
@@ -45,7 +45,7 @@ func (r *invitationRepository) ListByOrgID(ctx context.Context, orgID uuid.UUID,
 	var invitations []model.Invitation
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&model.Invitation{}).Where("org_id = ?", orgID)
+	query := conn(ctx, r.db).Model(&model.Invitation{}).Where("org_id = ?", orgID)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/bernardoforcillo/authlayer/internal/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type labelRoleBindingRepository struct {
+	db *gorm.DB
+}
+
+func NewLabelRoleBindingRepository(db *gorm.DB) LabelRoleBindingRepository {
+	return &labelRoleBindingRepository{db: db}
+}
+
+func (r *labelRoleBindingRepository) Create(ctx context.Context, binding *model.LabelRoleBinding) error {
+	return conn(ctx, r.db).Create(binding).Error
+}
+
+func (r *labelRoleBindingRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.LabelRoleBinding, error) {
+	var binding model.LabelRoleBinding
+	if err := conn(ctx, r.db).Where("id = ?", id).First(&binding).Error; err != nil {
+		return nil, err
+	}
+	return &binding, nil
+}
+
+func (r *labelRoleBindingRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conn(ctx, r.db).Where("id = ?", id).Delete(&model.LabelRoleBinding{}).Error
+}
+
+func (r *labelRoleBindingRepository) ListByOrgID(ctx context.Context, orgID uuid.UUID, pagination Pagination) ([]model.LabelRoleBinding, int64, error) {
+	var bindings []model.LabelRoleBinding
+	var total int64
+
+	query := conn(ctx, r.db).Model(&model.LabelRoleBinding{}).Where("org_id = ?", orgID)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	pageSize := pagination.PageSize
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	if err := query.Order("created_at DESC").Limit(pageSize).Find(&bindings).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return bindings, total, nil
+}
+
+// ListByOrgIDs returns the bindings for principalType in any of the orgs.
+func (r *labelRoleBindingRepository) ListByOrgIDs(ctx context.Context, orgIDs []uuid.UUID, principalType model.PrincipalType) ([]model.LabelRoleBinding, error) {
+	if len(orgIDs) == 0 {
+		return nil, nil
+	}
+
+	var bindings []model.LabelRoleBinding
+	err := conn(ctx, r.db).
+		Where("org_id IN ? AND principal_type = ?", orgIDs, principalType).
+		Find(&bindings).Error
+	if err != nil {
+		return nil, err
+	}
+	return bindings, nil
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"github.com/bernardoforcillo/authlayer/internal/labels"
+
+	"gorm.io/gorm"
+)
+
+// applyLabelSelector restricts query to rows whose JSONB labels column satisfies sel.
+// Absent keys behave as in labels.Requirement.Matches.
+func applyLabelSelector(query *gorm.DB, column string, sel labels.Selector) *gorm.DB {
+	for _, req := range sel {
+		switch req.Operator {
+		case labels.Equals:
+			query = query.Where(column+" ->> ? = ?", req.Key, req.Values[0])
+		case labels.NotEquals:
+			query = query.Where("("+column+" ->> ?) IS DISTINCT FROM ?", req.Key, req.Values[0])
+		case labels.In:
+			query = query.Where(column+" ->> ? IN ?", req.Key, req.Values)
+		case labels.NotIn:
+			query = query.Where("("+column+" ->> ? IS NULL OR "+column+" ->> ? NOT IN ?)", req.Key, req.Key, req.Values)
+		case labels.Exists:
+			query = query.Where(column+" ->> ? IS NOT NULL", req.Key)
+		case labels.DoesNotExist:
+			query = query.Where(column+" ->> ? IS NULL", req.Key)
+		}
+	}
+	return query
+}
@@ -0,0 +1,282 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/bernardoforcillo/authlayer/internal/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type oauthClientRepository struct {
+	db *gorm.DB
+}
+
+func NewOAuthClientRepository(db *gorm.DB) OAuthClientRepository {
+	return &oauthClientRepository{db: db}
+}
+
+func (r *oauthClientRepository) Create(ctx context.Context, client *model.OAuthClient) error {
+	return conn(ctx, r.db).Create(client).Error
+}
+
+func (r *oauthClientRepository) GetByClientID(ctx context.Context, clientID string) (*model.OAuthClient, error) {
+	var client model.OAuthClient
+	if err := conn(ctx, r.db).Where("client_id = ?", clientID).First(&client).Error; err != nil {
+		return nil, err
+	}
+	return &client, nil
+}
+
+func (r *oauthClientRepository) Update(ctx context.Context, client *model.OAuthClient) error {
+	return conn(ctx, r.db).Save(client).Error
+}
+
+// Delete soft-deletes the client. The row keeps its client ID reserved, so a new
+// registration can never inherit tokens or consents issued to the old one.
+func (r *oauthClientRepository) Delete(ctx context.Context, clientID string) error {
+	result := conn(ctx, r.db).Where("client_id = ?", clientID).Delete(&model.OAuthClient{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *oauthClientRepository) ListByOrgID(ctx context.Context, orgID uuid.UUID, pagination Pagination) ([]model.OAuthClient, int64, error) {
+	var clients []model.OAuthClient
+	var total int64
+
+	query := conn(ctx, r.db).Model(&model.OAuthClient{}).Where("org_id = ?", orgID)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	pageSize := pagination.PageSize
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	if err := query.Order("created_at DESC").Limit(pageSize).Find(&clients).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return clients, total, nil
+}
+
+type oauthAuthorizationCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewOAuthAuthorizationCodeRepository(db *gorm.DB) OAuthAuthorizationCodeRepository {
+	return &oauthAuthorizationCodeRepository{db: db}
+}
+
+func (r *oauthAuthorizationCodeRepository) Create(ctx context.Context, code *model.OAuthAuthorizationCode) error {
+	return conn(ctx, r.db).Create(code).Error
+}
+
+func (r *oauthAuthorizationCodeRepository) Consume(ctx context.Context, codeHash string) (*model.OAuthAuthorizationCode, error) {
+	var code model.OAuthAuthorizationCode
+	result := conn(ctx, r.db).
+		Unscoped().
+		Clauses(clause.Returning{}).
+		Where("code_hash = ?", codeHash).
+		Delete(&code)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &code, nil
+}
+
+func (r *oauthAuthorizationCodeRepository) DeleteExpired(ctx context.Context) error {
+	return conn(ctx, r.db).
+		Unscoped().
+		Where("expires_at < ?", time.Now()).
+		Delete(&model.OAuthAuthorizationCode{}).Error
+}
+
+type oauthConsentRepository struct {
+	db *gorm.DB
+}
+
+func NewOAuthConsentRepository(db *gorm.DB) OAuthConsentRepository {
+	return &oauthConsentRepository{db: db}
+}
+
+func (r *oauthConsentRepository) Get(ctx context.Context, userID uuid.UUID, clientID string) (*model.OAuthConsent, error) {
+	var consent model.OAuthConsent
+	err := conn(ctx, r.db).
+		Where("user_id = ? AND client_id = ?", userID, clientID).
+		First(&consent).Error
+	if err != nil {
+		return nil, err
+	}
+	return &consent, nil
+}
+
+func (r *oauthConsentRepository) Grant(ctx context.Context, userID uuid.UUID, clientID string, scopes []string) (*model.OAuthConsent, error) {
+	var consent model.OAuthConsent
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND client_id = ?", userID, clientID).
+			First(&consent).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			consent = model.OAuthConsent{UserID: userID, ClientID: clientID, Scopes: model.StringList(scopes)}
+			return tx.Create(&consent).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		// A revoked consent starts over rather than resurrecting its old scopes
+		if consent.DeletedAt.Valid {
+			consent.Scopes = nil
+			consent.DeletedAt = gorm.DeletedAt{}
+		}
+		for _, s := range scopes {
+			if !slices.Contains(consent.Scopes, s) {
+				consent.Scopes = append(consent.Scopes, s)
+			}
+		}
+		return tx.Unscoped().Save(&consent).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &consent, nil
+}
+
+func (r *oauthConsentRepository) Revoke(ctx context.Context, userID uuid.UUID, clientID string) error {
+	result := conn(ctx, r.db).
+		Where("user_id = ? AND client_id = ?", userID, clientID).
+		Delete(&model.OAuthConsent{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *oauthConsentRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]model.OAuthConsent, error) {
+	var consents []model.OAuthConsent
+	err := conn(ctx, r.db).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&consents).Error
+	if err != nil {
+		return nil, err
+	}
+	return consents, nil
+}
+
+func (r *oauthConsentRepository) DeleteByClientID(ctx context.Context, clientID string) error {
+	return conn(ctx, r.db).
+		Where("client_id = ?", clientID).
+		Delete(&model.OAuthConsent{}).Error
+}
+
+type oauthDeviceCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewOAuthDeviceCodeRepository(db *gorm.DB) OAuthDeviceCodeRepository {
+	return &oauthDeviceCodeRepository{db: db}
+}
+
+func (r *oauthDeviceCodeRepository) Create(ctx context.Context, code *model.OAuthDeviceCode) error {
+	return conn(ctx, r.db).Create(code).Error
+}
+
+func (r *oauthDeviceCodeRepository) GetPending(ctx context.Context, userCode string) (*model.OAuthDeviceCode, error) {
+	var code model.OAuthDeviceCode
+	err := conn(ctx, r.db).
+		Where("user_code = ? AND status = ? AND expires_at > ?", userCode, model.OAuthDeviceCodeStatusPending, time.Now()).
+		First(&code).Error
+	if err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+func (r *oauthDeviceCodeRepository) Decide(ctx context.Context, userCode string, status model.OAuthDeviceCodeStatus, userID uuid.UUID, authTime *time.Time, amr []string) error {
+	result := conn(ctx, r.db).
+		Model(&model.OAuthDeviceCode{}).
+		Where("user_code = ? AND status = ? AND expires_at > ?", userCode, model.OAuthDeviceCodeStatusPending, time.Now()).
+		Updates(map[string]interface{}{
+			"status":    status,
+			"user_id":   userID,
+			"auth_time": authTime,
+			"amr":       model.StringList(amr),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *oauthDeviceCodeRepository) Poll(ctx context.Context, deviceCodeHash string) (*model.OAuthDeviceCode, error) {
+	var code model.OAuthDeviceCode
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("device_code_hash = ?", deviceCodeHash).
+			First(&code).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&model.OAuthDeviceCode{}).
+			Where("id = ?", code.ID).
+			Update("last_polled_at", time.Now()).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+func (r *oauthDeviceCodeRepository) IncreasePollInterval(ctx context.Context, id uuid.UUID, seconds int) error {
+	return conn(ctx, r.db).
+		Model(&model.OAuthDeviceCode{}).
+		Where("id = ?", id).
+		Update("poll_interval", gorm.Expr("poll_interval + ?", seconds)).Error
+}
+
+func (r *oauthDeviceCodeRepository) Consume(ctx context.Context, deviceCodeHash string) (*model.OAuthDeviceCode, error) {
+	var code model.OAuthDeviceCode
+	result := conn(ctx, r.db).
+		Unscoped().
+		Clauses(clause.Returning{}).
+		Where("device_code_hash = ? AND status = ?", deviceCodeHash, model.OAuthDeviceCodeStatusApproved).
+		Delete(&code)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &code, nil
+}
+
+func (r *oauthDeviceCodeRepository) DeleteExpired(ctx context.Context) error {
+	return conn(ctx, r.db).
+		Unscoped().
+		Where("expires_at < ?", time.Now()).
+		Delete(&model.OAuthDeviceCode{}).Error
+}
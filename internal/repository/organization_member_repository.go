@@ -18,18 +18,18 @@ func NewOrganizationMemberRepository(db *gorm.DB) OrganizationMemberRepository {
 }
 
 func (r *organizationMemberRepository) Add(ctx context.Context, member *model.OrganizationMember) error {
-	return r.db.WithContext(ctx).Create(member).Error
+	return conn(ctx, r.db).Create(member).Error
 }
 
 func (r *organizationMemberRepository) Remove(ctx context.Context, orgID, userID uuid.UUID) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Where("org_id = ? AND user_id = ?", orgID, userID).
 		Delete(&model.OrganizationMember{}).Error
 }
 
 func (r *organizationMemberRepository) GetMembership(ctx context.Context, orgID, userID uuid.UUID) (*model.OrganizationMember, error) {
 	var member model.OrganizationMember
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("org_id = ? AND user_id = ?", orgID, userID).
 		Preload("Role").
 		Preload("User").
@@ -40,8 +40,23 @@ func (r *organizationMemberRepository) GetMembership(ctx context.Context, orgID,
 	return &member, nil
 }
 
+// ListAllByUserID returns every membership of the user without pagination, with the org and
+// role loaded.
+func (r *organizationMemberRepository) ListAllByUserID(ctx context.Context, userID uuid.UUID) ([]model.OrganizationMember, error) {
+	var members []model.OrganizationMember
+	err := conn(ctx, r.db).
+		Where("user_id = ?", userID).
+		Preload("Organization").
+		Preload("Role").
+		Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
 func (r *organizationMemberRepository) UpdateRole(ctx context.Context, orgID, userID, roleID uuid.UUID) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Model(&model.OrganizationMember{}).
 		Where("org_id = ? AND user_id = ?", orgID, userID).
 		Update("role_id", roleID).Error
@@ -51,7 +66,7 @@ func (r *organizationMemberRepository) ListByOrgID(ctx context.Context, orgID uu
 	var members []model.OrganizationMember
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&model.OrganizationMember{}).Where("org_id = ?", orgID)
+	query := conn(ctx, r.db).Model(&model.OrganizationMember{}).Where("org_id = ?", orgID)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -74,3 +89,55 @@ func (r *organizationMemberRepository) ListByOrgID(ctx context.Context, orgID uu
 
 	return members, total, nil
 }
+
+// ListAllByOrgID returns every membership of the org without pagination.
+func (r *organizationMemberRepository) ListAllByOrgID(ctx context.Context, orgID uuid.UUID) ([]model.OrganizationMember, error) {
+	var members []model.OrganizationMember
+	err := conn(ctx, r.db).
+		Where("org_id = ?", orgID).
+		Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// ListByOrgIDs returns the memberships of all the given orgs, ordered by org and join date.
+func (r *organizationMemberRepository) ListByOrgIDs(ctx context.Context, orgIDs []uuid.UUID, pagination Pagination) ([]model.OrganizationMember, int64, error) {
+	var members []model.OrganizationMember
+	var total int64
+
+	query := conn(ctx, r.db).Model(&model.OrganizationMember{}).Where("org_id IN ?", orgIDs)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	pageSize := pagination.PageSize
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	err := query.
+		Preload("User").
+		Preload("Role").
+		Order("org_id, created_at DESC").
+		Limit(pageSize).
+		Find(&members).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return members, total, nil
+}
+
+// CountDistinctUsers counts users that are members of at least one of the orgs.
+func (r *organizationMemberRepository) CountDistinctUsers(ctx context.Context, orgIDs []uuid.UUID) (int64, error) {
+	var count int64
+	err := conn(ctx, r.db).
+		Model(&model.OrganizationMember{}).
+		Where("org_id IN ?", orgIDs).
+		Distinct("user_id").
+		Count(&count).Error
+	return count, err
+}
@@ -18,12 +18,12 @@ func NewOrganizationRepository(db *gorm.DB) OrganizationRepository {
 }
 
 func (r *organizationRepository) Create(ctx context.Context, org *model.Organization) error {
-	return r.db.WithContext(ctx).Create(org).Error
+	return conn(ctx, r.db).Create(org).Error
 }
 
 func (r *organizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.Organization, error) {
 	var org model.Organization
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&org).Error; err != nil {
+	if err := conn(ctx, r.db).Where("id = ?", id).First(&org).Error; err != nil {
 		return nil, err
 	}
 	return &org, nil
@@ -31,18 +31,18 @@ func (r *organizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*mo
 
 func (r *organizationRepository) GetBySlug(ctx context.Context, slug string) (*model.Organization, error) {
 	var org model.Organization
-	if err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&org).Error; err != nil {
+	if err := conn(ctx, r.db).Where("slug = ?", slug).First(&org).Error; err != nil {
 		return nil, err
 	}
 	return &org, nil
 }
 
 func (r *organizationRepository) Update(ctx context.Context, org *model.Organization) error {
-	return r.db.WithContext(ctx).Save(org).Error
+	return conn(ctx, r.db).Save(org).Error
 }
 
 func (r *organizationRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&model.Organization{}).Error
+	return conn(ctx, r.db).Where("id = ?", id).Delete(&model.Organization{}).Error
 }
 
 func (r *organizationRepository) ListByUserID(ctx context.Context, userID uuid.UUID, pagination Pagination) ([]model.Organization, int64, error) {
@@ -51,7 +51,7 @@ func (r *organizationRepository) ListByUserID(ctx context.Context, userID uuid.U
 
 	subQuery := r.db.Model(&model.OrganizationMember{}).Select("org_id").Where("user_id = ?", userID)
 
-	query := r.db.WithContext(ctx).Model(&model.Organization{}).Where("id IN (?)", subQuery)
+	query := conn(ctx, r.db).Model(&model.Organization{}).Where("id IN (?)", subQuery)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -68,3 +68,70 @@ func (r *organizationRepository) ListByUserID(ctx context.Context, userID uuid.U
 
 	return orgs, total, nil
 }
+
+// SetParent links the org under parentID, or detaches it when parentID is nil.
+func (r *organizationRepository) SetParent(ctx context.Context, orgID uuid.UUID, parentID *uuid.UUID, inheritParentRoles bool) error {
+	return conn(ctx, r.db).
+		Model(&model.Organization{}).
+		Where("id = ?", orgID).
+		Updates(map[string]interface{}{
+			"parent_org_id":        parentID,
+			"inherit_parent_roles": inheritParentRoles,
+		}).Error
+}
+
+// GetAncestors walks the org tree upward using a recursive CTE.
+// Returns the org itself followed by its ancestors, nearest first, up to maxDepth levels.
+func (r *organizationRepository) GetAncestors(ctx context.Context, orgID uuid.UUID, maxDepth int) ([]model.Organization, error) {
+	if maxDepth <= 0 {
+		maxDepth = 10
+	}
+
+	var orgs []model.Organization
+	err := conn(ctx, r.db).Raw(`
+		WITH RECURSIVE org_hierarchy AS (
+			SELECT id, name, slug, owner_id, parent_org_id, inherit_parent_roles, created_at, updated_at, deleted_at, 1 AS depth
+			FROM organizations
+			WHERE id = ? AND deleted_at IS NULL
+			UNION ALL
+			SELECT o.id, o.name, o.slug, o.owner_id, o.parent_org_id, o.inherit_parent_roles, o.created_at, o.updated_at, o.deleted_at, oh.depth + 1
+			FROM organizations o
+			INNER JOIN org_hierarchy oh ON o.id = oh.parent_org_id
+			WHERE oh.depth < ? AND o.deleted_at IS NULL
+		)
+		SELECT * FROM org_hierarchy ORDER BY depth
+	`, orgID, maxDepth).Scan(&orgs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return orgs, nil
+}
+
+// GetDescendants walks the org tree downward using a recursive CTE.
+// Returns the org itself followed by its descendants in breadth-first order, up to maxDepth levels.
+func (r *organizationRepository) GetDescendants(ctx context.Context, orgID uuid.UUID, maxDepth int) ([]model.Organization, error) {
+	if maxDepth <= 0 {
+		maxDepth = 10
+	}
+
+	var orgs []model.Organization
+	err := conn(ctx, r.db).Raw(`
+		WITH RECURSIVE org_tree AS (
+			SELECT id, name, slug, owner_id, parent_org_id, inherit_parent_roles, created_at, updated_at, deleted_at, 1 AS depth
+			FROM organizations
+			WHERE id = ? AND deleted_at IS NULL
+			UNION ALL
+			SELECT o.id, o.name, o.slug, o.owner_id, o.parent_org_id, o.inherit_parent_roles, o.created_at, o.updated_at, o.deleted_at, ot.depth + 1
+			FROM organizations o
+			INNER JOIN org_tree ot ON o.parent_org_id = ot.id
+			WHERE ot.depth < ? AND o.deleted_at IS NULL
+		)
+		SELECT * FROM org_tree ORDER BY depth, name
+	`, orgID, maxDepth).Scan(&orgs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return orgs, nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/bernardoforcillo/authlayer/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type permissionChangeRepository struct {
+	db *gorm.DB
+}
+
+func NewPermissionChangeRepository(db *gorm.DB) PermissionChangeRepository {
+	return &permissionChangeRepository{db: db}
+}
+
+func (r *permissionChangeRepository) ListAfter(ctx context.Context, revision int64, filter PermissionChangeFilter, limit int) ([]model.PermissionChange, error) {
+	query := conn(ctx, r.db).Where("revision > ?", revision)
+	if filter.PrincipalType != "" {
+		query = query.Where("principal_type = ?", filter.PrincipalType)
+	}
+	if filter.PrincipalID != nil {
+		query = query.Where("principal_id = ?", *filter.PrincipalID)
+	}
+	if filter.ScopeType != "" {
+		query = query.Where("scope_type = ?", filter.ScopeType)
+	}
+	if filter.ScopeID != nil {
+		query = query.Where("scope_id = ?", *filter.ScopeID)
+	}
+
+	var changes []model.PermissionChange
+	if err := query.Order("revision ASC").Limit(limit).Find(&changes).Error; err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// LatestRevision returns the highest recorded revision, or 0 when none is retained.
+func (r *permissionChangeRepository) LatestRevision(ctx context.Context) (int64, error) {
+	var revision int64
+	err := conn(ctx, r.db).Model(&model.PermissionChange{}).
+		Select("COALESCE(MAX(revision), 0)").Scan(&revision).Error
+	return revision, err
+}
+
+// OldestRevision returns the lowest retained revision, or 0 when none is retained.
+func (r *permissionChangeRepository) OldestRevision(ctx context.Context) (int64, error) {
+	var revision int64
+	err := conn(ctx, r.db).Model(&model.PermissionChange{}).
+		Select("COALESCE(MIN(revision), 0)").Scan(&revision).Error
+	return revision, err
+}
+
+// DeleteBefore removes changes recorded before the given time, always keeping the latest
+// one so the revision sequence stays observable.
+func (r *permissionChangeRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := conn(ctx, r.db).
+		Where("created_at < ? AND revision < (SELECT MAX(revision) FROM permission_changes)", before).
+		Delete(&model.PermissionChange{})
+	return result.RowsAffected, result.Error
+}
@@ -18,12 +18,12 @@ func NewPermissionRepository(db *gorm.DB) PermissionRepository {
 }
 
 func (r *permissionRepository) Create(ctx context.Context, perm *model.Permission) error {
-	return r.db.WithContext(ctx).Create(perm).Error
+	return conn(ctx, r.db).Create(perm).Error
 }
 
 func (r *permissionRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.Permission, error) {
 	var perm model.Permission
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&perm).Error; err != nil {
+	if err := conn(ctx, r.db).Where("id = ?", id).First(&perm).Error; err != nil {
 		return nil, err
 	}
 	return &perm, nil
@@ -31,7 +31,7 @@ func (r *permissionRepository) GetByID(ctx context.Context, id uuid.UUID) (*mode
 
 func (r *permissionRepository) GetByName(ctx context.Context, name string) (*model.Permission, error) {
 	var perm model.Permission
-	if err := r.db.WithContext(ctx).Where("name = ?", name).First(&perm).Error; err != nil {
+	if err := conn(ctx, r.db).Where("name = ?", name).First(&perm).Error; err != nil {
 		return nil, err
 	}
 	return &perm, nil
@@ -41,7 +41,7 @@ func (r *permissionRepository) List(ctx context.Context, pagination Pagination)
 	var perms []model.Permission
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&model.Permission{})
+	query := conn(ctx, r.db).Model(&model.Permission{})
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -61,7 +61,7 @@ func (r *permissionRepository) List(ctx context.Context, pagination Pagination)
 
 func (r *permissionRepository) GetByRoleID(ctx context.Context, roleID uuid.UUID) ([]model.Permission, error) {
 	var perms []model.Permission
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
 		Where("role_permissions.role_id = ?", roleID).
 		Find(&perms).Error
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/bernardoforcillo/authlayer/internal/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type projectMemberRepository struct {
+	db *gorm.DB
+}
+
+func NewProjectMemberRepository(db *gorm.DB) ProjectMemberRepository {
+	return &projectMemberRepository{db: db}
+}
+
+// Upsert creates the binding or replaces the role of an existing binding for the same principal.
+func (r *projectMemberRepository) Upsert(ctx context.Context, member *model.ProjectMember) error {
+	return conn(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "project_id"}, {Name: "principal_type"}, {Name: "principal_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"role_id":    member.RoleID,
+				"updated_at": time.Now(),
+			}),
+		}).
+		Create(member).Error
+}
+
+// Remove hard-deletes the binding so the principal can be bound again later.
+func (r *projectMemberRepository) Remove(ctx context.Context, projectID uuid.UUID, principalType model.PrincipalType, principalID uuid.UUID) error {
+	return conn(ctx, r.db).Unscoped().
+		Where("project_id = ? AND principal_type = ? AND principal_id = ?", projectID, principalType, principalID).
+		Delete(&model.ProjectMember{}).Error
+}
+
+func (r *projectMemberRepository) ListByProjectID(ctx context.Context, projectID uuid.UUID, pagination Pagination) ([]model.ProjectMember, int64, error) {
+	var members []model.ProjectMember
+	var total int64
+
+	query := conn(ctx, r.db).Model(&model.ProjectMember{}).Where("project_id = ?", projectID)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	pageSize := pagination.PageSize
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	err := query.
+		Preload("Role").
+		Order("created_at DESC").
+		Limit(pageSize).
+		Find(&members).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return members, total, nil
+}
+
+// ListAllByProjectID returns every binding of the project.
+func (r *projectMemberRepository) ListAllByProjectID(ctx context.Context, projectID uuid.UUID) ([]model.ProjectMember, error) {
+	var members []model.ProjectMember
+	err := conn(ctx, r.db).Where("project_id = ?", projectID).Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// ListByProjectIDAndPrincipals returns the project's bindings for any of the given principals.
+func (r *projectMemberRepository) ListByProjectIDAndPrincipals(ctx context.Context, projectID uuid.UUID, principalType model.PrincipalType, principalIDs []uuid.UUID) ([]model.ProjectMember, error) {
+	if len(principalIDs) == 0 {
+		return nil, nil
+	}
+
+	var members []model.ProjectMember
+	err := conn(ctx, r.db).
+		Where("project_id = ? AND principal_type = ? AND principal_id IN ?", projectID, principalType, principalIDs).
+		Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// ListByOrgID returns the bindings of every project in the org.
+func (r *projectMemberRepository) ListByOrgID(ctx context.Context, orgID uuid.UUID) ([]model.ProjectMember, error) {
+	var members []model.ProjectMember
+	err := conn(ctx, r.db).
+		Joins("JOIN projects ON projects.id = project_members.project_id AND projects.deleted_at IS NULL").
+		Where("projects.org_id = ?", orgID).
+		Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// ListByOrgIDAndPrincipals returns the given principals' bindings in projects belonging to the org.
+func (r *projectMemberRepository) ListByOrgIDAndPrincipals(ctx context.Context, orgID uuid.UUID, principalType model.PrincipalType, principalIDs []uuid.UUID) ([]model.ProjectMember, error) {
+	if len(principalIDs) == 0 {
+		return nil, nil
+	}
+
+	var members []model.ProjectMember
+	err := conn(ctx, r.db).
+		Joins("JOIN projects ON projects.id = project_members.project_id AND projects.deleted_at IS NULL").
+		Where("projects.org_id = ? AND project_members.principal_type = ? AND project_members.principal_id IN ?", orgID, principalType, principalIDs).
+		Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
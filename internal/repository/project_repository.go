@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/bernardoforcillo/authlayer/internal/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type projectRepository struct {
+	db *gorm.DB
+}
+
+func NewProjectRepository(db *gorm.DB) ProjectRepository {
+	return &projectRepository{db: db}
+}
+
+func (r *projectRepository) Create(ctx context.Context, project *model.Project) error {
+	return conn(ctx, r.db).Create(project).Error
+}
+
+func (r *projectRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.Project, error) {
+	var project model.Project
+	if err := conn(ctx, r.db).Where("id = ?", id).First(&project).Error; err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+func (r *projectRepository) Update(ctx context.Context, project *model.Project) error {
+	return conn(ctx, r.db).Save(project).Error
+}
+
+// Delete soft-deletes the project and drops its role bindings.
+func (r *projectRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("project_id = ?", id).Delete(&model.ProjectMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&model.Project{}).Error
+	})
+}
+
+func (r *projectRepository) ListByOrgID(ctx context.Context, orgID uuid.UUID, pagination Pagination) ([]model.Project, int64, error) {
+	var projects []model.Project
+	var total int64
+
+	query := conn(ctx, r.db).Model(&model.Project{}).Where("org_id = ?", orgID)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	pageSize := pagination.PageSize
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	if err := query.Order("created_at DESC").Limit(pageSize).Find(&projects).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return projects, total, nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+)
+
+type rbacSnapshotRepository struct {
+	db *gorm.DB
+}
+
+func NewRBACSnapshotRepository(db *gorm.DB) RBACSnapshotRepository {
+	return &rbacSnapshotRepository{db: db}
+}
+
+// Load reads the whole RBAC model in one repeatable-read transaction so the snapshot is
+// consistent. Users carry only their IDs and labels.
+func (r *rbacSnapshotRepository) Load(ctx context.Context) (*RBACSnapshot, error) {
+	var snap RBACSnapshot
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Find(&snap.Organizations).Error; err != nil {
+			return err
+		}
+		if err := tx.Find(&snap.Roles).Error; err != nil {
+			return err
+		}
+		if err := tx.Raw(`
+			SELECT rp.role_id, p.name AS permission_name
+			FROM role_permissions rp
+			INNER JOIN permissions p ON p.id = rp.permission_id AND p.deleted_at IS NULL
+		`).Scan(&snap.RolePermissions).Error; err != nil {
+			return err
+		}
+		if err := tx.Select("id", "labels").Find(&snap.Users).Error; err != nil {
+			return err
+		}
+		if err := tx.Find(&snap.OrgMembers).Error; err != nil {
+			return err
+		}
+		if err := tx.Find(&snap.Teams).Error; err != nil {
+			return err
+		}
+		if err := tx.Find(&snap.TeamMembers).Error; err != nil {
+			return err
+		}
+		if err := tx.Find(&snap.ServiceAccounts).Error; err != nil {
+			return err
+		}
+		if err := tx.Find(&snap.ServiceAccountRoles).Error; err != nil {
+			return err
+		}
+		if err := tx.Find(&snap.Projects).Error; err != nil {
+			return err
+		}
+		if err := tx.Find(&snap.ProjectMembers).Error; err != nil {
+			return err
+		}
+		return tx.Find(&snap.LabelBindings).Error
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	return &snap, nil
+}
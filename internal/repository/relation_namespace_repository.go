@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/bernardoforcillo/authlayer/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type relationNamespaceRepository struct {
+	db *gorm.DB
+}
+
+func NewRelationNamespaceRepository(db *gorm.DB) RelationNamespaceRepository {
+	return &relationNamespaceRepository{db: db}
+}
+
+// Upsert creates the namespace or replaces the relations of an existing one with the same name.
+func (r *relationNamespaceRepository) Upsert(ctx context.Context, ns *model.RelationNamespace) error {
+	return conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"relations", "updated_at"}),
+	}).Create(ns).Error
+}
+
+func (r *relationNamespaceRepository) GetByName(ctx context.Context, name string) (*model.RelationNamespace, error) {
+	var ns model.RelationNamespace
+	if err := conn(ctx, r.db).Where("name = ?", name).First(&ns).Error; err != nil {
+		return nil, err
+	}
+	return &ns, nil
+}
+
+func (r *relationNamespaceRepository) List(ctx context.Context, pagination Pagination) ([]model.RelationNamespace, int64, error) {
+	var namespaces []model.RelationNamespace
+	var total int64
+
+	query := conn(ctx, r.db).Model(&model.RelationNamespace{})
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	pageSize := pagination.PageSize
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 50
+	}
+
+	if err := query.Order("name ASC").Limit(pageSize).Find(&namespaces).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return namespaces, total, nil
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/bernardoforcillo/authlayer/internal/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type relationTupleRepository struct {
+	db *gorm.DB
+}
+
+func NewRelationTupleRepository(db *gorm.DB) RelationTupleRepository {
+	return &relationTupleRepository{db: db}
+}
+
+// Write inserts and deletes tuples in a single transaction. Writing an existing tuple is a no-op.
+func (r *relationTupleRepository) Write(ctx context.Context, writes, deletes []model.RelationTuple) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for _, t := range deletes {
+			err := tx.Where(
+				"namespace = ? AND object_id = ? AND relation = ? AND subject_namespace = ? AND subject_object_id = ? AND subject_relation = ?",
+				t.Namespace, t.ObjectID, t.Relation, t.SubjectNamespace, t.SubjectObjectID, t.SubjectRelation,
+			).Delete(&model.RelationTuple{}).Error
+			if err != nil {
+				return err
+			}
+		}
+		for i := range writes {
+			if writes[i].ID == uuid.Nil {
+				writes[i].ID = uuid.New()
+			}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&writes[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ListByObjectRelation returns the tuples for object#relation.
+func (r *relationTupleRepository) ListByObjectRelation(ctx context.Context, namespace, objectID, relation string) ([]model.RelationTuple, error) {
+	var tuples []model.RelationTuple
+	err := conn(ctx, r.db).
+		Where("namespace = ? AND object_id = ? AND relation = ?", namespace, objectID, relation).
+		Find(&tuples).Error
+	if err != nil {
+		return nil, err
+	}
+	return tuples, nil
+}
+
+// ListByObject returns the tuples of an object, optionally restricted to one relation.
+func (r *relationTupleRepository) ListByObject(ctx context.Context, namespace, objectID string, relation *string, pagination Pagination) ([]model.RelationTuple, int64, error) {
+	var tuples []model.RelationTuple
+	var total int64
+
+	query := conn(ctx, r.db).Model(&model.RelationTuple{}).
+		Where("namespace = ? AND object_id = ?", namespace, objectID)
+	if relation != nil {
+		query = query.Where("relation = ?", *relation)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	pageSize := pagination.PageSize
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 50
+	}
+
+	if err := query.Order("created_at ASC").Limit(pageSize).Find(&tuples).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return tuples, total, nil
+}
+
+// ListObjectIDs returns, in order, up to limit distinct IDs after the given one of objects in
+// the namespace that have at least one tuple.
+func (r *relationTupleRepository) ListObjectIDs(ctx context.Context, namespace, after string, limit int) ([]string, error) {
+	var ids []string
+	err := conn(ctx, r.db).
+		Model(&model.RelationTuple{}).
+		Where("namespace = ? AND object_id > ?", namespace, after).
+		Distinct().
+		Order("object_id ASC").
+		Limit(limit).
+		Pluck("object_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
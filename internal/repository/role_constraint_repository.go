@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/bernardoforcillo/authlayer/internal/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type roleConstraintRepository struct {
+	db *gorm.DB
+}
+
+func NewRoleConstraintRepository(db *gorm.DB) RoleConstraintRepository {
+	return &roleConstraintRepository{db: db}
+}
+
+// Create stores the constraint together with its role set in a single transaction.
+func (r *roleConstraintRepository) Create(ctx context.Context, constraint *model.RoleConstraint, roleIDs []uuid.UUID) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Roles").Create(constraint).Error; err != nil {
+			return err
+		}
+		for _, roleID := range roleIDs {
+			rcr := model.RoleConstraintRole{
+				RoleConstraintID: constraint.ID,
+				RoleID:           roleID,
+			}
+			if err := tx.Create(&rcr).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *roleConstraintRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.RoleConstraint, error) {
+	var constraint model.RoleConstraint
+	err := conn(ctx, r.db).
+		Where("id = ?", id).
+		Preload("Roles").
+		First(&constraint).Error
+	if err != nil {
+		return nil, err
+	}
+	return &constraint, nil
+}
+
+func (r *roleConstraintRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conn(ctx, r.db).Where("id = ?", id).Delete(&model.RoleConstraint{}).Error
+}
+
+func (r *roleConstraintRepository) ListByOrgID(ctx context.Context, orgID *uuid.UUID, pagination Pagination) ([]model.RoleConstraint, int64, error) {
+	var constraints []model.RoleConstraint
+	var total int64
+
+	query := conn(ctx, r.db).Model(&model.RoleConstraint{})
+	if orgID != nil {
+		// Return both org-specific and global constraints
+		query = query.Where("org_id = ? OR org_id IS NULL", *orgID)
+	} else {
+		query = query.Where("org_id IS NULL")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	pageSize := pagination.PageSize
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 50
+	}
+
+	query = query.Order("id ASC").Limit(pageSize)
+
+	if pagination.PageToken != "" {
+		tokenID, err := uuid.Parse(pagination.PageToken)
+		if err == nil {
+			query = query.Where("id > ?", tokenID)
+		}
+	}
+
+	err := query.
+		Preload("Roles").
+		Find(&constraints).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return constraints, total, nil
+}
+
+// ListApplicable returns every constraint that applies to the org, including global ones.
+func (r *roleConstraintRepository) ListApplicable(ctx context.Context, orgID uuid.UUID) ([]model.RoleConstraint, error) {
+	var constraints []model.RoleConstraint
+	err := conn(ctx, r.db).
+		Where("org_id = ? OR org_id IS NULL", orgID).
+		Preload("Roles").
+		Find(&constraints).Error
+	if err != nil {
+		return nil, err
+	}
+	return constraints, nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"strings"
 
 	"github.com/bernardoforcillo/authlayer/internal/model"
 
@@ -22,11 +23,11 @@ func (r *rolePermissionRepository) Assign(ctx context.Context, roleID, permissio
 		RoleID:       roleID,
 		PermissionID: permissionID,
 	}
-	return r.db.WithContext(ctx).Create(&rp).Error
+	return conn(ctx, r.db).Create(&rp).Error
 }
 
 func (r *rolePermissionRepository) Revoke(ctx context.Context, roleID, permissionID uuid.UUID) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Where("role_id = ? AND permission_id = ?", roleID, permissionID).
 		Delete(&model.RolePermission{}).Error
 }
@@ -37,7 +38,7 @@ func (r *rolePermissionRepository) GetPermissionsByRoleIDs(ctx context.Context,
 	}
 
 	var perms []model.Permission
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Distinct().
 		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
 		Where("role_permissions.role_id IN ?", roleIDs).
@@ -47,3 +48,191 @@ func (r *rolePermissionRepository) GetPermissionsByRoleIDs(ctx context.Context,
 	}
 	return perms, nil
 }
+
+// ResolveGrants expands the seed roles through the role hierarchy and joins their
+// permissions in a single recursive query, which also walks the seed's org scope and
+// collects the label bindings in it. Every role visited, ancestors included, appears in
+// the grants at least once. Selectors are not evaluated here; the roles of matching
+// candidates have to be expanded with a further RoleSeed.
+func (r *rolePermissionRepository) ResolveGrants(ctx context.Context, seed RoleSeed, maxDepth int) ([]RoleGrant, []LabelCandidate, error) {
+	if maxDepth <= 0 {
+		maxDepth = 10
+	}
+
+	var q grantQuery
+	scoped := seed.OrgID != nil || seed.ProjectID != nil
+	if seed.ProjectID != nil {
+		q.cte(`project_org AS (
+			SELECT org_id FROM projects WHERE id = ? AND deleted_at IS NULL
+		)`, *seed.ProjectID)
+	}
+	if scoped {
+		root := "SELECT org_id FROM project_org"
+		var rootArgs []interface{}
+		if seed.ProjectID == nil {
+			root, rootArgs = "SELECT ?::uuid", []interface{}{*seed.OrgID}
+		}
+		// The org itself, then each ancestor for as long as the org below it inherits.
+		q.cte(`org_scope AS (
+			SELECT o.id, o.parent_org_id, o.inherit_parent_roles, 1 AS depth
+			FROM organizations o
+			WHERE o.id IN (`+root+`) AND o.deleted_at IS NULL
+			UNION
+			SELECT o.id, o.parent_org_id, o.inherit_parent_roles, s.depth + 1
+			FROM organizations o
+			INNER JOIN org_scope s ON o.id = s.parent_org_id
+			WHERE s.inherit_parent_roles AND s.depth < ? AND o.deleted_at IS NULL
+		)`, append(rootArgs, maxDepth)...)
+	}
+
+	if seed.UserID != nil && scoped {
+		q.seed(`SELECT role_id FROM organization_members
+			WHERE user_id = ? AND org_id IN (SELECT id FROM org_scope) AND deleted_at IS NULL`,
+			*seed.UserID)
+		q.candidate(`SELECT b.role_id, b.selector, u.labels
+			FROM label_role_bindings b
+			INNER JOIN users u ON u.id = ? AND u.deleted_at IS NULL
+			WHERE b.principal_type = ? AND b.deleted_at IS NULL AND b.org_id IN (
+				SELECT org_id FROM organization_members
+				WHERE user_id = ? AND org_id IN (SELECT id FROM org_scope) AND deleted_at IS NULL
+			)`, *seed.UserID, model.PrincipalTypeUser, *seed.UserID)
+		q.candidate(`SELECT b.role_id, b.selector, t.labels
+			FROM label_role_bindings b
+			INNER JOIN teams t ON t.org_id = b.org_id AND t.deleted_at IS NULL
+			INNER JOIN team_members tm ON tm.team_id = t.id AND tm.deleted_at IS NULL
+			WHERE tm.user_id = ? AND b.principal_type = ? AND b.deleted_at IS NULL
+				AND b.org_id IN (SELECT id FROM org_scope)`, *seed.UserID, model.PrincipalTypeTeam)
+	}
+	if seed.UserID != nil && seed.ProjectID != nil {
+		// Project members need not belong to the org (e.g. outside collaborators).
+		q.seed(`SELECT role_id FROM project_members
+			WHERE project_id = ? AND deleted_at IS NULL AND (
+				(principal_type = ? AND principal_id = ?) OR
+				(principal_type = ? AND principal_id IN (
+					SELECT tm.team_id FROM team_members tm
+					INNER JOIN teams t ON t.id = tm.team_id AND t.deleted_at IS NULL
+					WHERE tm.user_id = ? AND tm.deleted_at IS NULL
+						AND t.org_id IN (SELECT org_id FROM project_org)
+				))
+			)`, *seed.ProjectID, model.PrincipalTypeUser, *seed.UserID, model.PrincipalTypeTeam, *seed.UserID)
+	}
+
+	if seed.ServiceAccountID != nil {
+		// Unscoped, the grants of every org count.
+		grantScope, bindingScope := "", ""
+		if scoped {
+			grantScope = " AND org_id IN (SELECT id FROM org_scope)"
+			bindingScope = " AND b.org_id IN (SELECT id FROM org_scope)"
+		}
+		q.seed(`SELECT role_id FROM service_account_roles
+			WHERE service_account_id = ? AND deleted_at IS NULL`+grantScope, *seed.ServiceAccountID)
+		q.candidate(`SELECT b.role_id, b.selector, sa.labels
+			FROM label_role_bindings b
+			INNER JOIN service_accounts sa ON sa.org_id = b.org_id AND sa.deleted_at IS NULL
+			WHERE sa.id = ? AND b.principal_type = ? AND b.deleted_at IS NULL`+bindingScope,
+			*seed.ServiceAccountID, model.PrincipalTypeServiceAccount)
+		if seed.ProjectID != nil {
+			q.seed(`SELECT role_id FROM project_members
+				WHERE project_id = ? AND principal_type = ? AND principal_id = ? AND deleted_at IS NULL`,
+				*seed.ProjectID, model.PrincipalTypeServiceAccount, *seed.ServiceAccountID)
+		}
+	}
+
+	if len(seed.RoleIDs) > 0 {
+		q.seed(`SELECT id FROM roles WHERE id IN ?`, seed.RoleIDs)
+	}
+	if len(q.seeds) == 0 {
+		return nil, nil, nil
+	}
+
+	var rows []struct {
+		RoleID       uuid.UUID
+		PermissionID *uuid.UUID
+		Name         *string
+		Description  *string
+		Selector     *string
+		Labels       model.Labels
+	}
+	if err := conn(ctx, r.db).Raw(q.sql(), q.args(maxDepth)...).Scan(&rows).Error; err != nil {
+		return nil, nil, err
+	}
+
+	var grants []RoleGrant
+	var candidates []LabelCandidate
+	for _, row := range rows {
+		if row.Selector != nil {
+			candidates = append(candidates, LabelCandidate{RoleID: row.RoleID, Selector: *row.Selector, Labels: row.Labels})
+			continue
+		}
+		grant := RoleGrant{RoleID: row.RoleID}
+		if row.PermissionID != nil {
+			grant.Permission = &model.Permission{Name: *row.Name, Description: row.Description}
+			grant.Permission.ID = *row.PermissionID
+		}
+		grants = append(grants, grant)
+	}
+
+	return grants, candidates, nil
+}
+
+// grantQuery assembles the ResolveGrants query, keeping each fragment's arguments in the
+// order the fragments appear in the SQL.
+type grantQuery struct {
+	ctes, seeds, candidates          []string
+	cteArgs, seedArgs, candidateArgs []interface{}
+}
+
+func (q *grantQuery) cte(sql string, args ...interface{}) {
+	q.ctes = append(q.ctes, sql)
+	q.cteArgs = append(q.cteArgs, args...)
+}
+
+func (q *grantQuery) seed(sql string, args ...interface{}) {
+	q.seeds = append(q.seeds, sql)
+	q.seedArgs = append(q.seedArgs, args...)
+}
+
+func (q *grantQuery) candidate(sql string, args ...interface{}) {
+	q.candidates = append(q.candidates, sql)
+	q.candidateArgs = append(q.candidateArgs, args...)
+}
+
+func (q *grantQuery) sql() string {
+	var b strings.Builder
+	b.WriteString("WITH RECURSIVE ")
+	for _, cte := range q.ctes {
+		b.WriteString(cte + ",\n")
+	}
+	b.WriteString(`seed(role_id) AS (
+		` + strings.Join(q.seeds, "\n\t\tUNION\n\t\t") + `
+	),
+	role_tree AS (
+		SELECT r.id, r.parent_role_id, 1 AS depth
+		FROM roles r
+		WHERE r.id IN (SELECT role_id FROM seed) AND r.deleted_at IS NULL
+		UNION
+		SELECT r.id, r.parent_role_id, rt.depth + 1
+		FROM roles r
+		INNER JOIN role_tree rt ON r.id = rt.parent_role_id
+		WHERE rt.depth < ? AND r.deleted_at IS NULL
+	)
+	SELECT DISTINCT rt.id AS role_id, p.id AS permission_id, p.name::text AS name,
+		p.description::text AS description,
+		NULL::text AS selector, NULL::jsonb AS labels
+	FROM role_tree rt
+	LEFT JOIN role_permissions rp ON rp.role_id = rt.id
+	LEFT JOIN permissions p ON p.id = rp.permission_id AND p.deleted_at IS NULL`)
+	for _, c := range q.candidates {
+		b.WriteString(`
+	UNION ALL
+	SELECT c.role_id, NULL::uuid, NULL::text, NULL::text, c.selector::text, c.labels FROM (` + c + `) c`)
+	}
+	return b.String()
+}
+
+func (q *grantQuery) args(maxDepth int) []interface{} {
+	args := append([]interface{}{}, q.cteArgs...)
+	args = append(args, q.seedArgs...)
+	args = append(args, maxDepth)
+	return append(args, q.candidateArgs...)
+}
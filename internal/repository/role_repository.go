@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 
+	"github.com/bernardoforcillo/authlayer/internal/labels"
 	"github.com/bernardoforcillo/authlayer/internal/model"
 
 	"github.com/google/uuid"
@@ -18,12 +19,12 @@ func NewRoleRepository(db *gorm.DB) RoleRepository {
 }
 
 func (r *roleRepository) Create(ctx context.Context, role *model.Role) error {
-	return r.db.WithContext(ctx).Create(role).Error
+	return conn(ctx, r.db).Create(role).Error
 }
 
 func (r *roleRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.Role, error) {
 	var role model.Role
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("id = ?", id).
 		Preload("Permissions").
 		First(&role).Error
@@ -35,7 +36,7 @@ func (r *roleRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.Role
 
 func (r *roleRepository) GetByNameAndOrg(ctx context.Context, name string, orgID *uuid.UUID) (*model.Role, error) {
 	var role model.Role
-	query := r.db.WithContext(ctx).Where("name = ?", name)
+	query := conn(ctx, r.db).Where("name = ?", name)
 	if orgID != nil {
 		query = query.Where("org_id = ?", *orgID)
 	} else {
@@ -48,24 +49,25 @@ func (r *roleRepository) GetByNameAndOrg(ctx context.Context, name string, orgID
 }
 
 func (r *roleRepository) Update(ctx context.Context, role *model.Role) error {
-	return r.db.WithContext(ctx).Save(role).Error
+	return conn(ctx, r.db).Save(role).Error
 }
 
 func (r *roleRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&model.Role{}).Error
+	return conn(ctx, r.db).Where("id = ?", id).Delete(&model.Role{}).Error
 }
 
-func (r *roleRepository) ListByOrgID(ctx context.Context, orgID *uuid.UUID, pagination Pagination) ([]model.Role, int64, error) {
+func (r *roleRepository) ListByOrgID(ctx context.Context, orgID *uuid.UUID, selector labels.Selector, pagination Pagination) ([]model.Role, int64, error) {
 	var roles []model.Role
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&model.Role{})
+	query := conn(ctx, r.db).Model(&model.Role{})
 	if orgID != nil {
 		// Return both org-specific and system-level roles
 		query = query.Where("org_id = ? OR org_id IS NULL", *orgID)
 	} else {
 		query = query.Where("org_id IS NULL")
 	}
+	query = applyLabelSelector(query, "labels", selector)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -96,7 +98,7 @@ func (r *roleRepository) GetAncestors(ctx context.Context, roleID uuid.UUID, max
 	}
 
 	var roles []model.Role
-	err := r.db.WithContext(ctx).Raw(`
+	err := conn(ctx, r.db).Raw(`
 		WITH RECURSIVE role_hierarchy AS (
 			SELECT id, name, description, org_id, parent_role_id, created_at, updated_at, deleted_at, 1 AS depth
 			FROM roles
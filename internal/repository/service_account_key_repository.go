@@ -19,13 +19,27 @@ func NewServiceAccountKeyRepository(db *gorm.DB) ServiceAccountKeyRepository {
 }
 
 func (r *serviceAccountKeyRepository) Create(ctx context.Context, key *model.ServiceAccountKey) error {
-	return r.db.WithContext(ctx).Create(key).Error
+	return conn(ctx, r.db).Create(key).Error
 }
 
+// GetByID returns an unrevoked key with its service account loaded.
+func (r *serviceAccountKeyRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.ServiceAccountKey, error) {
+	var key model.ServiceAccountKey
+	err := conn(ctx, r.db).
+		Where("id = ? AND revoked = false", id).
+		Preload("ServiceAccount").
+		First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// GetByKeyHash returns an unrevoked secret key with its service account loaded.
 func (r *serviceAccountKeyRepository) GetByKeyHash(ctx context.Context, keyHash string) (*model.ServiceAccountKey, error) {
 	var key model.ServiceAccountKey
-	err := r.db.WithContext(ctx).
-		Where("key_hash = ? AND revoked = false", keyHash).
+	err := conn(ctx, r.db).
+		Where("key_hash = ? AND key_type = ? AND revoked = false", keyHash, model.ServiceAccountKeyTypeSecret).
 		Preload("ServiceAccount").
 		First(&key).Error
 	if err != nil {
@@ -35,7 +49,7 @@ func (r *serviceAccountKeyRepository) GetByKeyHash(ctx context.Context, keyHash
 }
 
 func (r *serviceAccountKeyRepository) Revoke(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Model(&model.ServiceAccountKey{}).
 		Where("id = ?", id).
 		Update("revoked", true).Error
@@ -45,7 +59,7 @@ func (r *serviceAccountKeyRepository) ListByServiceAccountID(ctx context.Context
 	var keys []model.ServiceAccountKey
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&model.ServiceAccountKey{}).Where("service_account_id = ?", saID)
+	query := conn(ctx, r.db).Model(&model.ServiceAccountKey{}).Where("service_account_id = ?", saID)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -65,7 +79,7 @@ func (r *serviceAccountKeyRepository) ListByServiceAccountID(ctx context.Context
 
 func (r *serviceAccountKeyRepository) UpdateLastUsed(ctx context.Context, id uuid.UUID) error {
 	now := time.Now()
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Model(&model.ServiceAccountKey{}).
 		Where("id = ?", id).
 		Update("last_used_at", now).Error
@@ -2,7 +2,9 @@ package repository
 
 import (
 	"context"
+	"time"
 
+	"github.com/bernardoforcillo/authlayer/internal/labels"
 	"github.com/bernardoforcillo/authlayer/internal/model"
 
 	"github.com/google/uuid"
@@ -18,12 +20,12 @@ func NewServiceAccountRepository(db *gorm.DB) ServiceAccountRepository {
 }
 
 func (r *serviceAccountRepository) Create(ctx context.Context, sa *model.ServiceAccount) error {
-	return r.db.WithContext(ctx).Create(sa).Error
+	return conn(ctx, r.db).Create(sa).Error
 }
 
 func (r *serviceAccountRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.ServiceAccount, error) {
 	var sa model.ServiceAccount
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("id = ?", id).
 		Preload("Roles").
 		Preload("Roles.Role").
@@ -35,18 +37,19 @@ func (r *serviceAccountRepository) GetByID(ctx context.Context, id uuid.UUID) (*
 }
 
 func (r *serviceAccountRepository) Update(ctx context.Context, sa *model.ServiceAccount) error {
-	return r.db.WithContext(ctx).Save(sa).Error
+	return conn(ctx, r.db).Save(sa).Error
 }
 
 func (r *serviceAccountRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&model.ServiceAccount{}).Error
+	return conn(ctx, r.db).Where("id = ?", id).Delete(&model.ServiceAccount{}).Error
 }
 
-func (r *serviceAccountRepository) ListByOrgID(ctx context.Context, orgID uuid.UUID, pagination Pagination) ([]model.ServiceAccount, int64, error) {
+func (r *serviceAccountRepository) ListByOrgID(ctx context.Context, orgID uuid.UUID, selector labels.Selector, pagination Pagination) ([]model.ServiceAccount, int64, error) {
 	var accounts []model.ServiceAccount
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&model.ServiceAccount{}).Where("org_id = ?", orgID)
+	query := conn(ctx, r.db).Model(&model.ServiceAccount{}).Where("org_id = ?", orgID)
+	query = applyLabelSelector(query, "labels", selector)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -69,3 +72,19 @@ func (r *serviceAccountRepository) ListByOrgID(ctx context.Context, orgID uuid.U
 
 	return accounts, total, nil
 }
+
+// ListAllByOrgID returns every service account of the org without pagination.
+func (r *serviceAccountRepository) ListAllByOrgID(ctx context.Context, orgID uuid.UUID) ([]model.ServiceAccount, error) {
+	var accounts []model.ServiceAccount
+	if err := conn(ctx, r.db).Where("org_id = ?", orgID).Find(&accounts).Error; err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+func (r *serviceAccountRepository) UpdateLastAuthenticated(ctx context.Context, id uuid.UUID) error {
+	return conn(ctx, r.db).
+		Model(&model.ServiceAccount{}).
+		Where("id = ?", id).
+		Update("last_authenticated_at", time.Now()).Error
+}
@@ -18,18 +18,18 @@ func NewServiceAccountRoleRepository(db *gorm.DB) ServiceAccountRoleRepository {
 }
 
 func (r *serviceAccountRoleRepository) Assign(ctx context.Context, sar *model.ServiceAccountRole) error {
-	return r.db.WithContext(ctx).Create(sar).Error
+	return conn(ctx, r.db).Create(sar).Error
 }
 
 func (r *serviceAccountRoleRepository) Revoke(ctx context.Context, saID, roleID, orgID uuid.UUID) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Where("service_account_id = ? AND role_id = ? AND org_id = ?", saID, roleID, orgID).
 		Delete(&model.ServiceAccountRole{}).Error
 }
 
 func (r *serviceAccountRoleRepository) ListByServiceAccountID(ctx context.Context, saID uuid.UUID) ([]model.ServiceAccountRole, error) {
 	var roles []model.ServiceAccountRole
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("service_account_id = ?", saID).
 		Preload("Role").
 		Preload("Role.Permissions").
@@ -39,3 +39,14 @@ func (r *serviceAccountRoleRepository) ListByServiceAccountID(ctx context.Contex
 	}
 	return roles, nil
 }
+
+func (r *serviceAccountRoleRepository) ListByOrgID(ctx context.Context, orgID uuid.UUID) ([]model.ServiceAccountRole, error) {
+	var roles []model.ServiceAccountRole
+	err := conn(ctx, r.db).
+		Where("org_id = ?", orgID).
+		Find(&roles).Error
+	if err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
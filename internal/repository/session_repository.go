@@ -19,12 +19,12 @@ func NewSessionRepository(db *gorm.DB) SessionRepository {
 }
 
 func (r *sessionRepository) Create(ctx context.Context, session *model.Session) error {
-	return r.db.WithContext(ctx).Create(session).Error
+	return conn(ctx, r.db).Create(session).Error
 }
 
 func (r *sessionRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*model.Session, error) {
 	var session model.Session
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("token_hash = ?", tokenHash).
 		Preload("User").
 		First(&session).Error
@@ -35,28 +35,38 @@ func (r *sessionRepository) GetByTokenHash(ctx context.Context, tokenHash string
 }
 
 func (r *sessionRepository) RevokeByTokenHash(ctx context.Context, tokenHash string) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Model(&model.Session{}).
 		Where("token_hash = ?", tokenHash).
 		Update("revoked", true).Error
 }
 
 func (r *sessionRepository) RevokeAllByUserID(ctx context.Context, userID uuid.UUID) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Model(&model.Session{}).
 		Where("user_id = ? AND revoked = false", userID).
 		Update("revoked", true).Error
 }
 
 func (r *sessionRepository) RevokeByFamily(ctx context.Context, family string) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Model(&model.Session{}).
 		Where("token_family = ? AND revoked = false", family).
 		Update("revoked", true).Error
 }
 
+func (r *sessionRepository) RevokeByClient(ctx context.Context, clientID string, userID *uuid.UUID) error {
+	query := conn(ctx, r.db).
+		Model(&model.Session{}).
+		Where("client_id = ? AND revoked = false", clientID)
+	if userID != nil {
+		query = query.Where("user_id = ?", *userID)
+	}
+	return query.Update("revoked", true).Error
+}
+
 func (r *sessionRepository) DeleteExpired(ctx context.Context) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Where("expires_at < ?", time.Now()).
 		Delete(&model.Session{}).Error
 }
@@ -18,20 +18,31 @@ func NewTeamMemberRepository(db *gorm.DB) TeamMemberRepository {
 }
 
 func (r *teamMemberRepository) Add(ctx context.Context, member *model.TeamMember) error {
-	return r.db.WithContext(ctx).Create(member).Error
+	return conn(ctx, r.db).Create(member).Error
 }
 
 func (r *teamMemberRepository) Remove(ctx context.Context, teamID, userID uuid.UUID) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Where("team_id = ? AND user_id = ?", teamID, userID).
 		Delete(&model.TeamMember{}).Error
 }
 
+func (r *teamMemberRepository) GetMembership(ctx context.Context, teamID, userID uuid.UUID) (*model.TeamMember, error) {
+	var member model.TeamMember
+	err := conn(ctx, r.db).
+		Where("team_id = ? AND user_id = ?", teamID, userID).
+		First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
 func (r *teamMemberRepository) ListByTeamID(ctx context.Context, teamID uuid.UUID, pagination Pagination) ([]model.TeamMember, int64, error) {
 	var members []model.TeamMember
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&model.TeamMember{}).Where("team_id = ?", teamID)
+	query := conn(ctx, r.db).Model(&model.TeamMember{}).Where("team_id = ?", teamID)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -54,3 +65,42 @@ func (r *teamMemberRepository) ListByTeamID(ctx context.Context, teamID uuid.UUI
 
 	return members, total, nil
 }
+
+// ListAllByTeamID returns every membership of the team without pagination.
+func (r *teamMemberRepository) ListAllByTeamID(ctx context.Context, teamID uuid.UUID) ([]model.TeamMember, error) {
+	var members []model.TeamMember
+	err := conn(ctx, r.db).
+		Where("team_id = ?", teamID).
+		Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// ListByOrgID returns the memberships of every team in the org.
+func (r *teamMemberRepository) ListByOrgID(ctx context.Context, orgID uuid.UUID) ([]model.TeamMember, error) {
+	var members []model.TeamMember
+	err := conn(ctx, r.db).
+		Joins("JOIN teams ON teams.id = team_members.team_id AND teams.deleted_at IS NULL").
+		Where("teams.org_id = ?", orgID).
+		Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// ListByUserIDAndOrgID returns the user's memberships in teams belonging to the org, with the team loaded.
+func (r *teamMemberRepository) ListByUserIDAndOrgID(ctx context.Context, userID, orgID uuid.UUID) ([]model.TeamMember, error) {
+	var members []model.TeamMember
+	err := conn(ctx, r.db).
+		Joins("JOIN teams ON teams.id = team_members.team_id AND teams.deleted_at IS NULL").
+		Where("team_members.user_id = ? AND teams.org_id = ?", userID, orgID).
+		Preload("Team").
+		Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
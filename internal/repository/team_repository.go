@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 
+	"github.com/bernardoforcillo/authlayer/internal/labels"
 	"github.com/bernardoforcillo/authlayer/internal/model"
 
 	"github.com/google/uuid"
@@ -18,30 +19,31 @@ func NewTeamRepository(db *gorm.DB) TeamRepository {
 }
 
 func (r *teamRepository) Create(ctx context.Context, team *model.Team) error {
-	return r.db.WithContext(ctx).Create(team).Error
+	return conn(ctx, r.db).Create(team).Error
 }
 
 func (r *teamRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.Team, error) {
 	var team model.Team
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&team).Error; err != nil {
+	if err := conn(ctx, r.db).Where("id = ?", id).First(&team).Error; err != nil {
 		return nil, err
 	}
 	return &team, nil
 }
 
 func (r *teamRepository) Update(ctx context.Context, team *model.Team) error {
-	return r.db.WithContext(ctx).Save(team).Error
+	return conn(ctx, r.db).Save(team).Error
 }
 
 func (r *teamRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&model.Team{}).Error
+	return conn(ctx, r.db).Where("id = ?", id).Delete(&model.Team{}).Error
 }
 
-func (r *teamRepository) ListByOrgID(ctx context.Context, orgID uuid.UUID, pagination Pagination) ([]model.Team, int64, error) {
+func (r *teamRepository) ListByOrgID(ctx context.Context, orgID uuid.UUID, selector labels.Selector, pagination Pagination) ([]model.Team, int64, error) {
 	var teams []model.Team
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&model.Team{}).Where("org_id = ?", orgID)
+	query := conn(ctx, r.db).Model(&model.Team{}).Where("org_id = ?", orgID)
+	query = applyLabelSelector(query, "labels", selector)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -58,3 +60,12 @@ func (r *teamRepository) ListByOrgID(ctx context.Context, orgID uuid.UUID, pagin
 
 	return teams, total, nil
 }
+
+// ListAllByOrgID returns every team of the org without pagination.
+func (r *teamRepository) ListAllByOrgID(ctx context.Context, orgID uuid.UUID) ([]model.Team, error) {
+	var teams []model.Team
+	if err := conn(ctx, r.db).Where("org_id = ?", orgID).Find(&teams).Error; err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// Transactor runs work spanning several repositories in one database transaction.
+type Transactor interface {
+	// InTx runs fn in a transaction that every repository called with fn's context joins.
+	// Called inside another InTx, it joins the outer transaction.
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+	// Lock takes an exclusive lock on key until the transaction of ctx ends. It must be
+	// called inside InTx.
+	Lock(ctx context.Context, key string) error
+}
+
+type transactor struct {
+	db *gorm.DB
+}
+
+func NewTransactor(db *gorm.DB) Transactor {
+	return &transactor{db: db}
+}
+
+func (t *transactor) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+	return t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+func (t *transactor) Lock(ctx context.Context, key string) error {
+	return conn(ctx, t.db).Exec("SELECT pg_advisory_xact_lock(hashtext(?))", key).Error
+}
+
+// conn returns the transaction ctx carries, or db outside a transaction.
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
@@ -18,12 +18,12 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 }
 
 func (r *userRepository) Create(ctx context.Context, user *model.User) error {
-	return r.db.WithContext(ctx).Create(user).Error
+	return conn(ctx, r.db).Create(user).Error
 }
 
 func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.User, error) {
 	var user model.User
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&user).Error; err != nil {
+	if err := conn(ctx, r.db).Where("id = ?", id).First(&user).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
@@ -31,25 +31,25 @@ func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.User
 
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*model.User, error) {
 	var user model.User
-	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
+	if err := conn(ctx, r.db).Where("email = ?", email).First(&user).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
 func (r *userRepository) Update(ctx context.Context, user *model.User) error {
-	return r.db.WithContext(ctx).Save(user).Error
+	return conn(ctx, r.db).Save(user).Error
 }
 
 func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&model.User{}).Error
+	return conn(ctx, r.db).Where("id = ?", id).Delete(&model.User{}).Error
 }
 
 func (r *userRepository) List(ctx context.Context, filter UserFilter, pagination Pagination) ([]model.User, int64, error) {
 	var users []model.User
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&model.User{})
+	query := conn(ctx, r.db).Model(&model.User{})
 
 	if filter.Search != nil && *filter.Search != "" {
 		search := "%" + *filter.Search + "%"
@@ -58,6 +58,7 @@ func (r *userRepository) List(ctx context.Context, filter UserFilter, pagination
 	if filter.Status != nil {
 		query = query.Where("status = ?", *filter.Status)
 	}
+	query = applyLabelSelector(query, "labels", filter.Labels)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
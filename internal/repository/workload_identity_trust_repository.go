@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/bernardoforcillo/authlayer/internal/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type workloadIdentityTrustRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkloadIdentityTrustRepository(db *gorm.DB) WorkloadIdentityTrustRepository {
+	return &workloadIdentityTrustRepository{db: db}
+}
+
+func (r *workloadIdentityTrustRepository) Create(ctx context.Context, trust *model.WorkloadIdentityTrust) error {
+	return conn(ctx, r.db).Create(trust).Error
+}
+
+func (r *workloadIdentityTrustRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.WorkloadIdentityTrust, error) {
+	var trust model.WorkloadIdentityTrust
+	err := conn(ctx, r.db).Preload("ServiceAccount").Where("id = ?", id).First(&trust).Error
+	if err != nil {
+		return nil, err
+	}
+	return &trust, nil
+}
+
+func (r *workloadIdentityTrustRepository) ListByServiceAccountID(ctx context.Context, saID uuid.UUID) ([]model.WorkloadIdentityTrust, error) {
+	var trusts []model.WorkloadIdentityTrust
+	err := conn(ctx, r.db).
+		Where("service_account_id = ?", saID).
+		Order("created_at ASC").
+		Find(&trusts).Error
+	return trusts, err
+}
+
+func (r *workloadIdentityTrustRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := conn(ctx, r.db).Delete(&model.WorkloadIdentityTrust{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *workloadIdentityTrustRepository) UpdateLastUsed(ctx context.Context, id uuid.UUID) error {
+	return conn(ctx, r.db).
+		Model(&model.WorkloadIdentityTrust{}).
+		Where("id = ?", id).
+		Update("last_used_at", time.Now()).Error
+}
@@ -19,5 +19,7 @@ func RegisterHealthService(s *grpc.Server) {
 	healthServer.SetServingStatus("authlayer.v1.RBACService", healthpb.HealthCheckResponse_SERVING)
 	healthServer.SetServingStatus("authlayer.v1.APIKeyService", healthpb.HealthCheckResponse_SERVING)
 	healthServer.SetServingStatus("authlayer.v1.ServiceAccountService", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("authlayer.v1.RelationService", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("authlayer.v1.ProjectService", healthpb.HealthCheckResponse_SERVING)
 	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
 }
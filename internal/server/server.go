@@ -20,49 +20,72 @@ import (
 
 // Server wraps a gRPC server with all registered services and interceptors.
 type Server struct {
-	cfg        *config.Config
-	grpcServer *grpc.Server
-	logger     *zap.Logger
+	cfg             *config.Config
+	grpcServer      *grpc.Server
+	httpMux         *http.ServeMux
+	listenHTTP      bool
+	authInterceptor *middleware.AuthInterceptor
+	logger          *zap.Logger
 }
 
-// New creates a new gRPC server with all services and interceptors wired up.
-func New(
+// Services holds the implementations registered on the gRPC server.
+type Services struct {
+	Auth           *service.AuthService
+	User           *service.UserService
+	Organization   *service.OrganizationService
+	Team           *service.TeamService
+	RBAC           *service.RBACService
+	APIKey         *service.APIKeyService
+	ServiceAccount *service.ServiceAccountService
+	Relation       *service.RelationService
+	Project        *service.ProjectService
+	OAuth          *service.OAuthService
+}
+
+// ServerOptions returns the options installing authlayer's interceptor chain and the
+// recording of request bodies that signed requests are verified against. Extra
+// interceptors run last, so they see the authenticated and authorized context.
+func ServerOptions(
 	cfg *config.Config,
 	logger *zap.Logger,
 	authInterceptor *middleware.AuthInterceptor,
 	rbacInterceptor *middleware.RBACInterceptor,
-	authSvc *service.AuthService,
-	userSvc *service.UserService,
-	orgSvc *service.OrganizationService,
-	teamSvc *service.TeamService,
-	rbacSvc *service.RBACService,
-	apiKeySvc *service.APIKeyService,
-	serviceAccountSvc *service.ServiceAccountService,
-) *Server {
-	// Create gRPC server with chained interceptors
-	// Order: Recovery -> Logging -> RateLimit -> Auth -> RBAC
-	grpcServer := grpc.NewServer(
-		grpc.ChainUnaryInterceptor(
-			middleware.RecoveryUnaryInterceptor(logger),
-			middleware.LoggingUnaryInterceptor(logger),
-			middleware.RateLimitUnaryInterceptor(cfg.RateLimitPerSecond),
-			authInterceptor.UnaryServerInterceptor(),
-			rbacInterceptor.UnaryServerInterceptor(),
-		),
-		grpc.ChainStreamInterceptor(
-			middleware.RecoveryStreamInterceptor(logger),
-			authInterceptor.StreamServerInterceptor(),
-		),
-	)
+	extraUnary []grpc.UnaryServerInterceptor,
+	extraStream []grpc.StreamServerInterceptor,
+) []grpc.ServerOption {
+	// Order: Recovery -> Logging -> RateLimit -> Auth -> RBAC -> extra
+	unary := append([]grpc.UnaryServerInterceptor{
+		middleware.RecoveryUnaryInterceptor(logger),
+		middleware.LoggingUnaryInterceptor(logger),
+		middleware.RateLimitUnaryInterceptor(cfg.RateLimitPerSecond),
+		authInterceptor.UnaryServerInterceptor(),
+		rbacInterceptor.UnaryServerInterceptor(),
+	}, extraUnary...)
+	stream := append([]grpc.StreamServerInterceptor{
+		middleware.RecoveryStreamInterceptor(logger),
+		authInterceptor.StreamServerInterceptor(),
+		rbacInterceptor.StreamServerInterceptor(),
+	}, extraStream...)
+	opts := []grpc.ServerOption{grpc.ChainUnaryInterceptor(unary...), grpc.ChainStreamInterceptor(stream...)}
+	return append(opts, middleware.RequestBodyOptions()...)
+}
 
+// New registers the services on grpcServer, which must have been created with
+// ServerOptions. When httpMux is nil the server creates its own and listens on the HTTP
+// port; otherwise the gateway and HTTP handlers are mounted on httpMux and serving it is
+// left to the caller. authInterceptor verifies signed requests to the gateway.
+func New(cfg *config.Config, logger *zap.Logger, grpcServer *grpc.Server, httpMux *http.ServeMux, authInterceptor *middleware.AuthInterceptor, services Services) *Server {
 	// Register services
-	authlayerv1.RegisterAuthServiceServer(grpcServer, authSvc)
-	authlayerv1.RegisterUserServiceServer(grpcServer, userSvc)
-	authlayerv1.RegisterOrganizationServiceServer(grpcServer, orgSvc)
-	authlayerv1.RegisterTeamServiceServer(grpcServer, teamSvc)
-	authlayerv1.RegisterRBACServiceServer(grpcServer, rbacSvc)
-	authlayerv1.RegisterAPIKeyServiceServer(grpcServer, apiKeySvc)
-	authlayerv1.RegisterServiceAccountServiceServer(grpcServer, serviceAccountSvc)
+	authlayerv1.RegisterAuthServiceServer(grpcServer, services.Auth)
+	authlayerv1.RegisterUserServiceServer(grpcServer, services.User)
+	authlayerv1.RegisterOrganizationServiceServer(grpcServer, services.Organization)
+	authlayerv1.RegisterTeamServiceServer(grpcServer, services.Team)
+	authlayerv1.RegisterRBACServiceServer(grpcServer, services.RBAC)
+	authlayerv1.RegisterAPIKeyServiceServer(grpcServer, services.APIKey)
+	authlayerv1.RegisterServiceAccountServiceServer(grpcServer, services.ServiceAccount)
+	authlayerv1.RegisterRelationServiceServer(grpcServer, services.Relation)
+	authlayerv1.RegisterProjectServiceServer(grpcServer, services.Project)
+	authlayerv1.RegisterOAuthServiceServer(grpcServer, services.OAuth)
 
 	// Register reflection for grpcurl/debugging
 	reflection.Register(grpcServer)
@@ -70,13 +93,26 @@ func New(
 	// Register health check
 	RegisterHealthService(grpcServer)
 
+	listenHTTP := httpMux == nil
+	if listenHTTP {
+		httpMux = http.NewServeMux()
+	}
 	return &Server{
-		cfg:        cfg,
-		grpcServer: grpcServer,
-		logger:     logger,
+		cfg:             cfg,
+		grpcServer:      grpcServer,
+		httpMux:         httpMux,
+		listenHTTP:      listenHTTP,
+		authInterceptor: authInterceptor,
+		logger:          logger,
 	}
 }
 
+// HandleHTTP registers a plain HTTP handler on the gateway port, alongside the gateway
+// routes. It must be called before Start.
+func (s *Server) HandleHTTP(pattern string, handler http.Handler) {
+	s.httpMux.Handle(pattern, handler)
+}
+
 // Start begins listening and serving gRPC requests.
 func (s *Server) Start() error {
 	addr := fmt.Sprintf(":%d", s.cfg.GRPCPort)
@@ -85,21 +121,28 @@ func (s *Server) Start() error {
 		return fmt.Errorf("failed to listen on %s: %w", addr, err)
 	}
 
-	// Start Gateway in background
-	go func() {
-		if err := s.startGateway(); err != nil {
-			s.logger.Error("failed to start gateway", zap.Error(err))
-		}
-	}()
+	// Mount the gateway, then serve HTTP in background unless the caller serves the mux
+	if err := s.mountGateway(); err != nil {
+		return err
+	}
+	if s.listenHTTP {
+		go func() {
+			httpAddr := fmt.Sprintf(":%d", s.cfg.HTTPPort)
+			s.logger.Info("HTTP gateway starting", zap.String("address", httpAddr))
+			if err := http.ListenAndServe(httpAddr, s.httpMux); err != nil {
+				s.logger.Error("failed to start gateway", zap.Error(err))
+			}
+		}()
+	}
 
 	s.logger.Info("gRPC server starting", zap.String("address", addr))
 	return s.grpcServer.Serve(lis)
 }
 
-func (s *Server) startGateway() error {
+// mountGateway registers the gateway routes on the HTTP mux. The gateway's connections to
+// the gRPC port live as long as the process.
+func (s *Server) mountGateway() error {
 	ctx := context.Background()
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
 
 	mux := runtime.NewServeMux()
 	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
@@ -112,12 +155,13 @@ func (s *Server) startGateway() error {
 	if err := authlayerv1.RegisterUserServiceHandlerFromEndpoint(ctx, mux, grpcAddr, opts); err != nil {
 		return fmt.Errorf("failed to register user service gateway: %w", err)
 	}
+	if err := authlayerv1.RegisterProjectServiceHandlerFromEndpoint(ctx, mux, grpcAddr, opts); err != nil {
+		return fmt.Errorf("failed to register project service gateway: %w", err)
+	}
 
-	httpAddr := fmt.Sprintf(":%d", s.cfg.HTTPPort)
-	s.logger.Info("HTTP gateway starting", zap.String("address", httpAddr))
-
-	// Use standard http server
-	return http.ListenAndServe(httpAddr, mux)
+	// The gateway serves every path not registered with HandleHTTP
+	s.httpMux.Handle("/", s.authInterceptor.GatewayHandler(mux))
+	return nil
 }
 
 // GracefulStop gracefully shuts down the server.
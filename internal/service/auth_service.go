@@ -80,7 +80,7 @@ func (s *AuthService) Register(ctx context.Context, req *authlayerv1.RegisterReq
 	}
 
 	// Generate tokens
-	tokens, err := s.jwtManager.GenerateTokenPair(user.ID.String(), user.Email, "")
+	tokens, err := s.jwtManager.GenerateTokenPair(user.ID.String(), user.Email, "", auth.NewAuthentication(auth.AMRPassword))
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to generate tokens")
 	}
@@ -121,7 +121,7 @@ func (s *AuthService) Login(ctx context.Context, req *authlayerv1.LoginRequest)
 		return nil, status.Errorf(codes.Unauthenticated, "invalid credentials")
 	}
 
-	tokens, err := s.jwtManager.GenerateTokenPair(user.ID.String(), user.Email, "")
+	tokens, err := s.jwtManager.GenerateTokenPair(user.ID.String(), user.Email, "", auth.NewAuthentication(auth.AMRPassword))
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to generate tokens")
 	}
@@ -159,6 +159,9 @@ func (s *AuthService) RefreshToken(ctx context.Context, req *authlayerv1.Refresh
 	if err != nil {
 		return nil, status.Errorf(codes.Unauthenticated, "invalid refresh token: %v", err)
 	}
+	if claims.ClientID != "" {
+		return nil, status.Errorf(codes.InvalidArgument, "refresh token was issued to an OAuth client; refresh it at the token endpoint")
+	}
 
 	// Check session in DB
 	tokenHash := auth.HashToken(req.RefreshToken)
@@ -181,7 +184,7 @@ func (s *AuthService) RefreshToken(ctx context.Context, req *authlayerv1.Refresh
 	_ = s.sessionRepo.RevokeByTokenHash(ctx, tokenHash)
 
 	// Generate new token pair with same family
-	tokens, err := s.jwtManager.GenerateTokenPair(claims.UserID, claims.Email, claims.TokenFamily)
+	tokens, err := s.jwtManager.GenerateTokenPair(claims.UserID, claims.Email, claims.TokenFamily, claims.Authentication())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to generate tokens")
 	}
@@ -281,7 +284,7 @@ func (s *AuthService) OAuthCallback(ctx context.Context, req *authlayerv1.OAuthC
 		}
 	}
 
-	tokens, err := s.jwtManager.GenerateTokenPair(user.ID.String(), user.Email, "")
+	tokens, err := s.jwtManager.GenerateTokenPair(user.ID.String(), user.Email, "", auth.NewAuthentication(auth.AMRFederated))
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to generate tokens")
 	}
@@ -340,6 +343,7 @@ func userToProto(u *model.User) *authlayerv1.UserInfo {
 		Status:        userStatusToProto(u.Status),
 		CreatedAt:     timestamppb.New(u.CreatedAt),
 		UpdatedAt:     timestamppb.New(u.UpdatedAt),
+		Labels:        u.Labels,
 	}
 	return info
 }
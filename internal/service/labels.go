@@ -0,0 +1,30 @@
+package service
+
+import (
+	"github.com/bernardoforcillo/authlayer/internal/labels"
+	"github.com/bernardoforcillo/authlayer/internal/model"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// labelsFromProto validates labels received in a request.
+func labelsFromProto(m map[string]string) (model.Labels, error) {
+	l := model.Labels(m)
+	if err := labels.Validate(l); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return l, nil
+}
+
+// parseLabelSelector parses an optional label_selector request field.
+func parseLabelSelector(expr *string) (labels.Selector, error) {
+	if expr == nil {
+		return nil, nil
+	}
+	sel, err := labels.Parse(*expr)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return sel, nil
+}
@@ -0,0 +1,508 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bernardoforcillo/authlayer/internal/auth"
+	"github.com/bernardoforcillo/authlayer/internal/middleware"
+	"github.com/bernardoforcillo/authlayer/internal/model"
+	"github.com/bernardoforcillo/authlayer/internal/rbac"
+	"github.com/bernardoforcillo/authlayer/internal/repository"
+	authlayerv1 "github.com/bernardoforcillo/authlayer/pkg/proto/authlayer/v1"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+type OAuthService struct {
+	authlayerv1.UnimplementedOAuthServiceServer
+
+	clientRepo  repository.OAuthClientRepository
+	consentRepo repository.OAuthConsentRepository
+	deviceRepo  repository.OAuthDeviceCodeRepository
+	sessionRepo repository.SessionRepository
+	issuer      string
+	checker     *rbac.Checker
+	logger      *zap.Logger
+}
+
+func NewOAuthService(
+	clientRepo repository.OAuthClientRepository,
+	consentRepo repository.OAuthConsentRepository,
+	deviceRepo repository.OAuthDeviceCodeRepository,
+	sessionRepo repository.SessionRepository,
+	issuer string,
+	checker *rbac.Checker,
+	logger *zap.Logger,
+) *OAuthService {
+	return &OAuthService{
+		clientRepo:  clientRepo,
+		consentRepo: consentRepo,
+		deviceRepo:  deviceRepo,
+		sessionRepo: sessionRepo,
+		issuer:      issuer,
+		checker:     checker,
+		logger:      logger,
+	}
+}
+
+func (s *OAuthService) CreateOAuthClient(ctx context.Context, req *authlayerv1.CreateOAuthClientRequest) (*authlayerv1.CreateOAuthClientResponse, error) {
+	callerID, err := middleware.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "not authenticated")
+	}
+
+	orgID, err := uuid.Parse(req.OrgId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid org_id")
+	}
+	if err := requireOrgPermission(ctx, s.checker, orgID, "oauth_client:create"); err != nil {
+		return nil, err
+	}
+
+	if req.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "name is required")
+	}
+	if err := validateRedirectURIs(req.RedirectUris); err != nil {
+		return nil, err
+	}
+	if err := s.validateAudience(req.Audience); err != nil {
+		return nil, err
+	}
+	if req.Trusted {
+		if err := requireGlobalPermission(ctx, s.checker, "oauth_client:trust"); err != nil {
+			return nil, err
+		}
+	}
+
+	clientID, err := auth.GenerateRandomToken(16)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate client ID")
+	}
+
+	client := &model.OAuthClient{
+		ClientID:     clientID,
+		Name:         req.Name,
+		RedirectURIs: model.StringList(req.RedirectUris),
+		Scopes:       model.StringList(req.Scopes),
+		Public:       req.Public,
+		Trusted:      req.Trusted,
+		CreatedBy:    callerID,
+		OrgID:        orgID,
+	}
+	if req.Audience != "" {
+		client.Audience = &req.Audience
+	}
+
+	var secret string
+	if !req.Public {
+		if secret, err = auth.GenerateRandomToken(32); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to generate client secret")
+		}
+		hash := auth.HashToken(secret)
+		client.SecretHash = &hash
+	}
+
+	if err := s.clientRepo.Create(ctx, client); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create OAuth client: %v", err)
+	}
+
+	return &authlayerv1.CreateOAuthClientResponse{
+		Client:       oauthClientToProto(client),
+		ClientSecret: secret,
+	}, nil
+}
+
+func (s *OAuthService) GetOAuthClient(ctx context.Context, req *authlayerv1.GetOAuthClientRequest) (*authlayerv1.GetOAuthClientResponse, error) {
+	client, err := s.getClient(ctx, req.ClientId)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireOrgPermission(ctx, s.checker, client.OrgID, "oauth_client:read"); err != nil {
+		return nil, err
+	}
+
+	return &authlayerv1.GetOAuthClientResponse{
+		Client: oauthClientToProto(client),
+	}, nil
+}
+
+func (s *OAuthService) ListOAuthClients(ctx context.Context, req *authlayerv1.ListOAuthClientsRequest) (*authlayerv1.ListOAuthClientsResponse, error) {
+	orgID, err := uuid.Parse(req.OrgId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid org_id")
+	}
+	if err := requireOrgPermission(ctx, s.checker, orgID, "oauth_client:read"); err != nil {
+		return nil, err
+	}
+
+	pagination := repository.Pagination{PageSize: 20}
+	if req.Pagination != nil {
+		pagination.PageSize = int(req.Pagination.PageSize)
+		pagination.PageToken = req.Pagination.PageToken
+	}
+
+	clients, total, err := s.clientRepo.ListByOrgID(ctx, orgID, pagination)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list OAuth clients")
+	}
+
+	protoClients := make([]*authlayerv1.OAuthClientInfo, len(clients))
+	for i := range clients {
+		protoClients[i] = oauthClientToProto(&clients[i])
+	}
+
+	return &authlayerv1.ListOAuthClientsResponse{
+		Clients: protoClients,
+		Pagination: &authlayerv1.PaginationResponse{
+			TotalCount: int32(total),
+		},
+	}, nil
+}
+
+func (s *OAuthService) UpdateOAuthClient(ctx context.Context, req *authlayerv1.UpdateOAuthClientRequest) (*authlayerv1.UpdateOAuthClientResponse, error) {
+	client, err := s.getClient(ctx, req.ClientId)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireOrgPermission(ctx, s.checker, client.OrgID, "oauth_client:update"); err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		if *req.Name == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "name cannot be empty")
+		}
+		client.Name = *req.Name
+	}
+	if req.RedirectUris != nil {
+		if err := validateRedirectURIs(req.RedirectUris.Values); err != nil {
+			return nil, err
+		}
+		client.RedirectURIs = model.StringList(req.RedirectUris.Values)
+	}
+	if req.Scopes != nil {
+		client.Scopes = model.StringList(req.Scopes.Values)
+	}
+	if req.Audience != nil {
+		if err := s.validateAudience(*req.Audience); err != nil {
+			return nil, err
+		}
+		client.Audience = nil
+		if *req.Audience != "" {
+			client.Audience = req.Audience
+		}
+	}
+	if req.Trusted != nil {
+		if *req.Trusted && !client.Trusted {
+			if err := requireGlobalPermission(ctx, s.checker, "oauth_client:trust"); err != nil {
+				return nil, err
+			}
+		}
+		client.Trusted = *req.Trusted
+	}
+
+	if err := s.clientRepo.Update(ctx, client); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update OAuth client")
+	}
+
+	return &authlayerv1.UpdateOAuthClientResponse{
+		Client: oauthClientToProto(client),
+	}, nil
+}
+
+func (s *OAuthService) DeleteOAuthClient(ctx context.Context, req *authlayerv1.DeleteOAuthClientRequest) (*authlayerv1.DeleteOAuthClientResponse, error) {
+	client, err := s.getClient(ctx, req.ClientId)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireOrgPermission(ctx, s.checker, client.OrgID, "oauth_client:delete"); err != nil {
+		return nil, err
+	}
+
+	if err := s.clientRepo.Delete(ctx, req.ClientId); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "OAuth client not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to delete OAuth client")
+	}
+
+	// The client's refresh tokens and consents die with it
+	if err := s.sessionRepo.RevokeByClient(ctx, req.ClientId, nil); err != nil {
+		s.logger.Error("failed to revoke OAuth client sessions", zap.String("client_id", req.ClientId), zap.Error(err))
+	}
+	if err := s.consentRepo.DeleteByClientID(ctx, req.ClientId); err != nil {
+		s.logger.Error("failed to delete OAuth client consents", zap.String("client_id", req.ClientId), zap.Error(err))
+	}
+
+	return &authlayerv1.DeleteOAuthClientResponse{}, nil
+}
+
+func (s *OAuthService) RotateOAuthClientSecret(ctx context.Context, req *authlayerv1.RotateOAuthClientSecretRequest) (*authlayerv1.RotateOAuthClientSecretResponse, error) {
+	client, err := s.getClient(ctx, req.ClientId)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireOrgPermission(ctx, s.checker, client.OrgID, "oauth_client:update"); err != nil {
+		return nil, err
+	}
+	if client.Public {
+		return nil, status.Errorf(codes.FailedPrecondition, "public clients have no secret")
+	}
+
+	secret, err := auth.GenerateRandomToken(32)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate client secret")
+	}
+	hash := auth.HashToken(secret)
+	client.SecretHash = &hash
+
+	if err := s.clientRepo.Update(ctx, client); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to rotate client secret")
+	}
+
+	return &authlayerv1.RotateOAuthClientSecretResponse{ClientSecret: secret}, nil
+}
+
+func (s *OAuthService) GrantOAuthConsent(ctx context.Context, req *authlayerv1.GrantOAuthConsentRequest) (*authlayerv1.GrantOAuthConsentResponse, error) {
+	userID, err := consentingUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := s.getClient(ctx, req.ClientId)
+	if err != nil {
+		return nil, err
+	}
+	for _, scope := range req.Scopes {
+		if !slices.Contains(client.Scopes, scope) {
+			return nil, status.Errorf(codes.InvalidArgument, "scope %q is not allowed for this client", scope)
+		}
+	}
+
+	consent, err := s.consentRepo.Grant(ctx, userID, client.ClientID, req.Scopes)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to grant consent")
+	}
+
+	return &authlayerv1.GrantOAuthConsentResponse{
+		Consent: oauthConsentToProto(consent, client),
+	}, nil
+}
+
+func (s *OAuthService) ListOAuthConsents(ctx context.Context, req *authlayerv1.ListOAuthConsentsRequest) (*authlayerv1.ListOAuthConsentsResponse, error) {
+	userID, err := consentingUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	consents, err := s.consentRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list consents")
+	}
+
+	protoConsents := make([]*authlayerv1.OAuthConsentInfo, 0, len(consents))
+	for i := range consents {
+		client, err := s.clientRepo.GetByClientID(ctx, consents[i].ClientID)
+		if err != nil {
+			continue
+		}
+		protoConsents = append(protoConsents, oauthConsentToProto(&consents[i], client))
+	}
+
+	return &authlayerv1.ListOAuthConsentsResponse{Consents: protoConsents}, nil
+}
+
+func (s *OAuthService) RevokeOAuthConsent(ctx context.Context, req *authlayerv1.RevokeOAuthConsentRequest) (*authlayerv1.RevokeOAuthConsentResponse, error) {
+	userID, err := consentingUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.ClientId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "client_id is required")
+	}
+
+	if err := s.consentRepo.Revoke(ctx, userID, req.ClientId); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "consent not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to revoke consent")
+	}
+
+	// Refresh tokens issued under the consent stop working; access tokens expire on their own
+	if err := s.sessionRepo.RevokeByClient(ctx, req.ClientId, &userID); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke client sessions")
+	}
+
+	return &authlayerv1.RevokeOAuthConsentResponse{}, nil
+}
+
+func (s *OAuthService) GetDeviceAuthorization(ctx context.Context, req *authlayerv1.GetDeviceAuthorizationRequest) (*authlayerv1.GetDeviceAuthorizationResponse, error) {
+	if _, err := consentingUser(ctx); err != nil {
+		return nil, err
+	}
+
+	code, err := s.deviceRepo.GetPending(ctx, auth.NormalizeUserCode(req.UserCode))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "unknown or expired code")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get device authorization")
+	}
+	client, err := s.getClient(ctx, code.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &authlayerv1.GetDeviceAuthorizationResponse{
+		Authorization: &authlayerv1.DeviceAuthorizationInfo{
+			UserCode:   auth.FormatUserCode(code.UserCode),
+			ClientId:   client.ClientID,
+			ClientName: client.Name,
+			Scopes:     code.Scopes,
+			ExpiresAt:  timestamppb.New(code.ExpiresAt),
+		},
+	}, nil
+}
+
+func (s *OAuthService) ApproveDeviceAuthorization(ctx context.Context, req *authlayerv1.ApproveDeviceAuthorizationRequest) (*authlayerv1.ApproveDeviceAuthorizationResponse, error) {
+	if err := s.decideDeviceAuthorization(ctx, req.UserCode, model.OAuthDeviceCodeStatusApproved); err != nil {
+		return nil, err
+	}
+	return &authlayerv1.ApproveDeviceAuthorizationResponse{}, nil
+}
+
+func (s *OAuthService) DenyDeviceAuthorization(ctx context.Context, req *authlayerv1.DenyDeviceAuthorizationRequest) (*authlayerv1.DenyDeviceAuthorizationResponse, error) {
+	if err := s.decideDeviceAuthorization(ctx, req.UserCode, model.OAuthDeviceCodeStatusDenied); err != nil {
+		return nil, err
+	}
+	return &authlayerv1.DenyDeviceAuthorizationResponse{}, nil
+}
+
+// decideDeviceAuthorization records the calling user's decision. The device's tokens
+// report how the approving user signed in.
+func (s *OAuthService) decideDeviceAuthorization(ctx context.Context, userCode string, decision model.OAuthDeviceCodeStatus) error {
+	userID, err := consentingUser(ctx)
+	if err != nil {
+		return err
+	}
+	if userCode == "" {
+		return status.Errorf(codes.InvalidArgument, "user_code is required")
+	}
+
+	authn := middleware.AuthenticationFromContext(ctx)
+	var authTime *time.Time
+	if !authn.Time.IsZero() {
+		authTime = &authn.Time
+	}
+	err = s.deviceRepo.Decide(ctx, auth.NormalizeUserCode(userCode), decision, userID, authTime, authn.Methods)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return status.Errorf(codes.NotFound, "unknown or expired code")
+		}
+		return status.Errorf(codes.Internal, "failed to record device authorization")
+	}
+	return nil
+}
+
+func (s *OAuthService) getClient(ctx context.Context, clientID string) (*model.OAuthClient, error) {
+	if clientID == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "client_id is required")
+	}
+	client, err := s.clientRepo.GetByClientID(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "OAuth client not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get OAuth client")
+	}
+	return client, nil
+}
+
+// consentingUser returns the calling user. Consents are managed by users themselves, never
+// through an API key, a token issued to an OAuth client or a delegated token.
+func consentingUser(ctx context.Context) (uuid.UUID, error) {
+	if middleware.AuthTypeFromContext(ctx) != middleware.AuthTypeUser {
+		return uuid.Nil, status.Errorf(codes.PermissionDenied, "consents can only be managed by a signed-in user")
+	}
+	if _, ok := middleware.OAuthClientFromContext(ctx); ok {
+		return uuid.Nil, status.Errorf(codes.PermissionDenied, "consents cannot be managed with an OAuth client token")
+	}
+	if _, ok := middleware.ActorFromContext(ctx); ok {
+		return uuid.Nil, status.Errorf(codes.PermissionDenied, "consents cannot be managed with a delegated token")
+	}
+	userID, err := middleware.UserIDFromContext(ctx)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.Unauthenticated, "not authenticated")
+	}
+	return userID, nil
+}
+
+// validateRedirectURIs requires absolute URIs without fragments. Plain http is only
+// accepted for loopback addresses, used by native apps and local development.
+func validateRedirectURIs(uris []string) error {
+	if len(uris) == 0 {
+		return status.Errorf(codes.InvalidArgument, "at least one redirect URI is required")
+	}
+	for _, raw := range uris {
+		u, err := url.Parse(raw)
+		if err != nil || u.Scheme == "" || u.Fragment != "" {
+			return status.Errorf(codes.InvalidArgument, "invalid redirect URI %q", raw)
+		}
+		if u.Scheme == "http" {
+			switch u.Hostname() {
+			case "localhost", "127.0.0.1", "::1":
+			default:
+				return status.Errorf(codes.InvalidArgument, "redirect URI %q must use https", raw)
+			}
+		}
+	}
+	return nil
+}
+
+func oauthClientToProto(c *model.OAuthClient) *authlayerv1.OAuthClientInfo {
+	info := &authlayerv1.OAuthClientInfo{
+		ClientId:     c.ClientID,
+		Name:         c.Name,
+		RedirectUris: c.RedirectURIs,
+		Scopes:       c.Scopes,
+		Public:       c.Public,
+		Trusted:      c.Trusted,
+		CreatedBy:    c.CreatedBy.String(),
+		OrgId:        c.OrgID.String(),
+		CreatedAt:    timestamppb.New(c.CreatedAt),
+		UpdatedAt:    timestamppb.New(c.UpdatedAt),
+	}
+	if c.Audience != nil {
+		info.Audience = *c.Audience
+	}
+	return info
+}
+
+func oauthConsentToProto(c *model.OAuthConsent, client *model.OAuthClient) *authlayerv1.OAuthConsentInfo {
+	return &authlayerv1.OAuthConsentInfo{
+		ClientId:   c.ClientID,
+		ClientName: client.Name,
+		Scopes:     c.Scopes,
+		CreatedAt:  timestamppb.New(c.CreatedAt),
+		UpdatedAt:  timestamppb.New(c.UpdatedAt),
+	}
+}
+
+// validateAudience rejects client audiences naming authlayer itself: its access tokens
+// would be accepted by authlayer's own API, with the scopes of the user who consented.
+func (s *OAuthService) validateAudience(audience string) error {
+	if audience != "" && strings.TrimRight(audience, "/") == strings.TrimRight(s.issuer, "/") {
+		return status.Errorf(codes.InvalidArgument, "audience must not be authlayer's issuer")
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/bernardoforcillo/authlayer/internal/auth"
 	"github.com/bernardoforcillo/authlayer/internal/middleware"
 	"github.com/bernardoforcillo/authlayer/internal/model"
+	"github.com/bernardoforcillo/authlayer/internal/rbac"
 	"github.com/bernardoforcillo/authlayer/internal/repository"
 	authlayerv1 "github.com/bernardoforcillo/authlayer/pkg/proto/authlayer/v1"
 
@@ -22,29 +23,47 @@ import (
 type OrganizationService struct {
 	authlayerv1.UnimplementedOrganizationServiceServer
 
-	orgRepo       repository.OrganizationRepository
-	orgMemberRepo repository.OrganizationMemberRepository
-	roleRepo      repository.RoleRepository
-	inviteRepo    repository.InvitationRepository
-	userRepo      repository.UserRepository
-	logger        *zap.Logger
+	orgRepo        repository.OrganizationRepository
+	orgMemberRepo  repository.OrganizationMemberRepository
+	teamMemberRepo repository.TeamMemberRepository
+	saRepo         repository.ServiceAccountRepository
+	saRoleRepo     repository.ServiceAccountRoleRepository
+	roleRepo       repository.RoleRepository
+	inviteRepo     repository.InvitationRepository
+	userRepo       repository.UserRepository
+	checker        *rbac.Checker
+	enforcer       *rbac.ConstraintEnforcer
+	logger         *zap.Logger
 }
 
+// maxOrgTreeDepth bounds how deep organizations can be nested.
+const maxOrgTreeDepth = 10
+
 func NewOrganizationService(
 	orgRepo repository.OrganizationRepository,
 	orgMemberRepo repository.OrganizationMemberRepository,
+	teamMemberRepo repository.TeamMemberRepository,
+	saRepo repository.ServiceAccountRepository,
+	saRoleRepo repository.ServiceAccountRoleRepository,
 	roleRepo repository.RoleRepository,
 	inviteRepo repository.InvitationRepository,
 	userRepo repository.UserRepository,
+	checker *rbac.Checker,
+	enforcer *rbac.ConstraintEnforcer,
 	logger *zap.Logger,
 ) *OrganizationService {
 	return &OrganizationService{
-		orgRepo:       orgRepo,
-		orgMemberRepo: orgMemberRepo,
-		roleRepo:      roleRepo,
-		inviteRepo:    inviteRepo,
-		userRepo:      userRepo,
-		logger:        logger,
+		orgRepo:        orgRepo,
+		orgMemberRepo:  orgMemberRepo,
+		teamMemberRepo: teamMemberRepo,
+		saRepo:         saRepo,
+		saRoleRepo:     saRoleRepo,
+		roleRepo:       roleRepo,
+		inviteRepo:     inviteRepo,
+		userRepo:       userRepo,
+		checker:        checker,
+		enforcer:       enforcer,
+		logger:         logger,
 	}
 }
 
@@ -64,24 +83,28 @@ func (s *OrganizationService) CreateOrganization(ctx context.Context, req *authl
 		OwnerID: callerID,
 	}
 
-	if err := s.orgRepo.Create(ctx, org); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create organization: %v", err)
-	}
+	err = s.checker.Update(ctx, func(ctx context.Context, changes *rbac.Changes) error {
+		if err := s.orgRepo.Create(ctx, org); err != nil {
+			return err
+		}
 
-	// Find the "owner" system role
-	ownerRole, err := s.roleRepo.GetByNameAndOrg(ctx, "owner", nil)
-	if err != nil {
-		s.logger.Warn("owner role not found, skipping auto-membership", zap.Error(err))
-	} else {
+		// Find the "owner" system role
+		ownerRole, err := s.roleRepo.GetByNameAndOrg(ctx, "owner", nil)
+		if err != nil {
+			s.logger.Warn("owner role not found, skipping auto-membership", zap.Error(err))
+			return nil
+		}
 		// Add creator as owner member
 		member := &model.OrganizationMember{
 			OrgID:  org.ID,
 			UserID: callerID,
 			RoleID: ownerRole.ID,
 		}
-		if err := s.orgMemberRepo.Add(ctx, member); err != nil {
-			s.logger.Error("failed to add owner membership", zap.Error(err))
-		}
+		changes.User(callerID)
+		return s.orgMemberRepo.Add(ctx, member)
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create organization: %v", err)
 	}
 
 	return &authlayerv1.CreateOrganizationResponse{
@@ -146,7 +169,19 @@ func (s *OrganizationService) DeleteOrganization(ctx context.Context, req *authl
 		return nil, status.Errorf(codes.InvalidArgument, "invalid org_id")
 	}
 
-	if err := s.orgRepo.Delete(ctx, orgID); err != nil {
+	// The org's grants and those cascading into it from its ancestors go with it
+	ancestors, err := s.orgRepo.GetAncestors(ctx, orgID, maxOrgTreeDepth+1)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get organization ancestors")
+	}
+
+	err = s.checker.Update(ctx, func(ctx context.Context, changes *rbac.Changes) error {
+		if err := s.orgRepo.Delete(ctx, orgID); err != nil {
+			return err
+		}
+		return s.recordTreeChange(ctx, changes, ancestors)
+	})
+	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to delete organization")
 	}
 
@@ -287,8 +322,17 @@ func (s *OrganizationService) AcceptInvitation(ctx context.Context, req *authlay
 		UserID: callerID,
 		RoleID: invitation.RoleID,
 	}
-	if err := s.orgMemberRepo.Add(ctx, member); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to add member")
+	err = s.checker.Update(ctx, func(ctx context.Context, changes *rbac.Changes) error {
+		return s.enforcer.Assign(ctx, invitation.OrgID, func(ctx context.Context) error {
+			if err := s.enforcer.ValidateUserOrgRole(ctx, invitation.OrgID, callerID, invitation.RoleID); err != nil {
+				return err
+			}
+			changes.User(callerID)
+			return s.orgMemberRepo.Add(ctx, member)
+		})
+	})
+	if err != nil {
+		return nil, constraintStatus(err, "failed to add member")
 	}
 
 	_ = s.inviteRepo.UpdateStatus(ctx, invitation.ID, model.InvitationStatusAccepted)
@@ -310,7 +354,11 @@ func (s *OrganizationService) RemoveMember(ctx context.Context, req *authlayerv1
 		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id")
 	}
 
-	if err := s.orgMemberRepo.Remove(ctx, orgID, userID); err != nil {
+	err = s.checker.Update(ctx, func(ctx context.Context, changes *rbac.Changes) error {
+		changes.User(userID)
+		return s.orgMemberRepo.Remove(ctx, orgID, userID)
+	})
+	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to remove member")
 	}
 
@@ -331,22 +379,303 @@ func (s *OrganizationService) UpdateMemberRole(ctx context.Context, req *authlay
 		return nil, status.Errorf(codes.InvalidArgument, "invalid role_id")
 	}
 
-	if err := s.orgMemberRepo.UpdateRole(ctx, orgID, userID, roleID); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to update member role")
+	err = s.checker.Update(ctx, func(ctx context.Context, changes *rbac.Changes) error {
+		return s.enforcer.Assign(ctx, orgID, func(ctx context.Context) error {
+			if err := s.enforcer.ValidateUserOrgRole(ctx, orgID, userID, roleID); err != nil {
+				return err
+			}
+			changes.User(userID)
+			return s.orgMemberRepo.UpdateRole(ctx, orgID, userID, roleID)
+		})
+	})
+	if err != nil {
+		return nil, constraintStatus(err, "failed to update member role")
 	}
 
 	return &authlayerv1.UpdateOrgMemberRoleResponse{}, nil
 }
 
+func (s *OrganizationService) AttachChildOrganization(ctx context.Context, req *authlayerv1.AttachChildOrganizationRequest) (*authlayerv1.AttachChildOrganizationResponse, error) {
+	parentID, err := uuid.Parse(req.ParentOrgId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid parent_org_id")
+	}
+	childID, err := uuid.Parse(req.ChildOrgId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid child_org_id")
+	}
+	if parentID == childID {
+		return nil, status.Errorf(codes.InvalidArgument, "an organization cannot be its own parent")
+	}
+	// The parent's roles cascade into the child, so the caller must manage both
+	if err := requireOrgPermission(ctx, s.checker, parentID, "org:manage_hierarchy"); err != nil {
+		return nil, err
+	}
+	if err := requireOrgPermission(ctx, s.checker, childID, "org:manage_hierarchy"); err != nil {
+		return nil, err
+	}
+
+	child, err := s.orgRepo.GetByID(ctx, childID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "child organization not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get organization")
+	}
+	if child.ParentOrgID != nil && *child.ParentOrgID != parentID {
+		return nil, status.Errorf(codes.FailedPrecondition, "organization already has a parent; detach it first")
+	}
+
+	// Walk one level past the limit so an over-deep tree is detected rather than truncated.
+	ancestors, err := s.orgRepo.GetAncestors(ctx, parentID, maxOrgTreeDepth+1)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get organization ancestors")
+	}
+	if len(ancestors) == 0 {
+		return nil, status.Errorf(codes.NotFound, "parent organization not found")
+	}
+	for _, a := range ancestors {
+		if a.ID == childID {
+			return nil, status.Errorf(codes.FailedPrecondition, "attaching would create a cycle")
+		}
+	}
+	descendants, err := s.orgRepo.GetDescendants(ctx, childID, maxOrgTreeDepth+1)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get organization descendants")
+	}
+	if len(ancestors)+subtreeHeight(childID, descendants) > maxOrgTreeDepth {
+		return nil, status.Errorf(codes.FailedPrecondition, "organization tree would exceed %d levels", maxOrgTreeDepth)
+	}
+
+	err = s.checker.Update(ctx, func(ctx context.Context, changes *rbac.Changes) error {
+		if err := s.orgRepo.SetParent(ctx, childID, &parentID, req.InheritParentRoles); err != nil {
+			return err
+		}
+		return s.recordTreeChange(ctx, changes, ancestors)
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to attach organization")
+	}
+	child.ParentOrgID = &parentID
+	child.InheritParentRoles = req.InheritParentRoles
+
+	return &authlayerv1.AttachChildOrganizationResponse{Organization: orgToProto(child)}, nil
+}
+
+func (s *OrganizationService) DetachChildOrganization(ctx context.Context, req *authlayerv1.DetachChildOrganizationRequest) (*authlayerv1.DetachChildOrganizationResponse, error) {
+	parentID, err := uuid.Parse(req.ParentOrgId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid parent_org_id")
+	}
+	childID, err := uuid.Parse(req.ChildOrgId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid child_org_id")
+	}
+
+	child, err := s.orgRepo.GetByID(ctx, childID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "child organization not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get organization")
+	}
+	if child.ParentOrgID == nil || *child.ParentOrgID != parentID {
+		return nil, status.Errorf(codes.FailedPrecondition, "organization is not a child of parent_org_id")
+	}
+	// Detaching only withdraws what the parent grants in the child
+	if err := requireOrgPermission(ctx, s.checker, parentID, "org:manage_hierarchy"); err != nil {
+		return nil, err
+	}
+
+	ancestors, err := s.orgRepo.GetAncestors(ctx, parentID, maxOrgTreeDepth+1)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get organization ancestors")
+	}
+
+	err = s.checker.Update(ctx, func(ctx context.Context, changes *rbac.Changes) error {
+		if err := s.orgRepo.SetParent(ctx, childID, nil, false); err != nil {
+			return err
+		}
+		return s.recordTreeChange(ctx, changes, ancestors)
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to detach organization")
+	}
+
+	return &authlayerv1.DetachChildOrganizationResponse{}, nil
+}
+
+func (s *OrganizationService) ListDescendants(ctx context.Context, req *authlayerv1.ListDescendantsRequest) (*authlayerv1.ListDescendantsResponse, error) {
+	orgID, err := uuid.Parse(req.OrgId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid org_id")
+	}
+
+	orgs, err := s.orgRepo.GetDescendants(ctx, orgID, maxOrgTreeDepth)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list descendants")
+	}
+	if len(orgs) == 0 {
+		return nil, status.Errorf(codes.NotFound, "organization not found")
+	}
+
+	// The first entry is the org itself.
+	protoOrgs := make([]*authlayerv1.OrganizationInfo, 0, len(orgs)-1)
+	for i := 1; i < len(orgs); i++ {
+		protoOrgs = append(protoOrgs, orgToProto(&orgs[i]))
+	}
+
+	return &authlayerv1.ListDescendantsResponse{Organizations: protoOrgs}, nil
+}
+
+func (s *OrganizationService) ListTreeMembers(ctx context.Context, req *authlayerv1.ListTreeMembersRequest) (*authlayerv1.ListTreeMembersResponse, error) {
+	orgID, err := uuid.Parse(req.OrgId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid org_id")
+	}
+
+	orgs, err := s.orgRepo.GetDescendants(ctx, orgID, maxOrgTreeDepth)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list descendants")
+	}
+	if len(orgs) == 0 {
+		return nil, status.Errorf(codes.NotFound, "organization not found")
+	}
+
+	orgIDs := make([]uuid.UUID, len(orgs))
+	for i, o := range orgs {
+		orgIDs[i] = o.ID
+	}
+
+	pagination := repository.Pagination{PageSize: 20}
+	if req.Pagination != nil {
+		pagination.PageSize = int(req.Pagination.PageSize)
+		pagination.PageToken = req.Pagination.PageToken
+	}
+
+	members, total, err := s.orgMemberRepo.ListByOrgIDs(ctx, orgIDs, pagination)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list members")
+	}
+	distinctUsers, err := s.orgMemberRepo.CountDistinctUsers(ctx, orgIDs)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to count members")
+	}
+
+	protoMembers := make([]*authlayerv1.TreeMemberInfo, len(members))
+	for i, m := range members {
+		protoMembers[i] = &authlayerv1.TreeMemberInfo{
+			OrgId: m.OrgID.String(),
+			Member: &authlayerv1.MemberInfo{
+				UserId:   m.UserID.String(),
+				Name:     m.User.Name,
+				Email:    m.User.Email,
+				RoleId:   m.RoleID.String(),
+				RoleName: m.Role.Name,
+				JoinedAt: timestamppb.New(m.CreatedAt),
+			},
+		}
+	}
+
+	return &authlayerv1.ListTreeMembersResponse{
+		Members: protoMembers,
+		Pagination: &authlayerv1.PaginationResponse{
+			TotalCount: int32(total),
+		},
+		DistinctUserCount: int32(distinctUsers),
+	}, nil
+}
+
+// recordTreeChange records every principal holding roles in the given orgs, the
+// ancestors whose roles a changed subtree gained or lost. Only those roles cascade
+// differently; principals holding roles only inside the subtree are unaffected.
+func (s *OrganizationService) recordTreeChange(ctx context.Context, changes *rbac.Changes, orgs []model.Organization) error {
+	users := make(map[uuid.UUID]bool)
+	serviceAccounts := make(map[uuid.UUID]bool)
+	for _, org := range orgs {
+		if err := s.collectPrincipals(ctx, org.ID, users, serviceAccounts); err != nil {
+			return err
+		}
+	}
+
+	for userID := range users {
+		changes.User(userID)
+	}
+	for saID := range serviceAccounts {
+		changes.ServiceAccount(saID)
+	}
+	return nil
+}
+
+// collectPrincipals adds the users and service accounts that can hold roles in the org:
+// members, team members, service accounts with role grants and the org's own service
+// accounts, which label bindings may select.
+func (s *OrganizationService) collectPrincipals(ctx context.Context, orgID uuid.UUID, users, serviceAccounts map[uuid.UUID]bool) error {
+	members, err := s.orgMemberRepo.ListAllByOrgID(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	for _, m := range members {
+		users[m.UserID] = true
+	}
+	teamMembers, err := s.teamMemberRepo.ListByOrgID(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	for _, tm := range teamMembers {
+		users[tm.UserID] = true
+	}
+
+	grants, err := s.saRoleRepo.ListByOrgID(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	for _, g := range grants {
+		serviceAccounts[g.ServiceAccountID] = true
+	}
+	owned, err := s.saRepo.ListAllByOrgID(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	for _, sa := range owned {
+		serviceAccounts[sa.ID] = true
+	}
+	return nil
+}
+
+// subtreeHeight returns the number of levels in the subtree rooted at rootID.
+func subtreeHeight(rootID uuid.UUID, orgs []model.Organization) int {
+	depth := map[uuid.UUID]int{rootID: 1}
+	height := 0
+	// GetDescendants returns orgs breadth-first, so parents are seen before their children.
+	for _, o := range orgs {
+		if o.ParentOrgID != nil {
+			if d, ok := depth[*o.ParentOrgID]; ok && o.ID != rootID {
+				depth[o.ID] = d + 1
+			}
+		}
+		if depth[o.ID] > height {
+			height = depth[o.ID]
+		}
+	}
+	return height
+}
+
 func orgToProto(o *model.Organization) *authlayerv1.OrganizationInfo {
 	if o == nil {
 		return nil
 	}
-	return &authlayerv1.OrganizationInfo{
-		Id:        o.ID.String(),
-		Name:      o.Name,
-		Slug:      o.Slug,
-		OwnerId:   o.OwnerID.String(),
-		CreatedAt: timestamppb.New(o.CreatedAt),
+	info := &authlayerv1.OrganizationInfo{
+		Id:                 o.ID.String(),
+		Name:               o.Name,
+		Slug:               o.Slug,
+		OwnerId:            o.OwnerID.String(),
+		CreatedAt:          timestamppb.New(o.CreatedAt),
+		InheritParentRoles: o.InheritParentRoles,
+	}
+	if o.ParentOrgID != nil {
+		parentID := o.ParentOrgID.String()
+		info.ParentOrgId = &parentID
 	}
+	return info
 }
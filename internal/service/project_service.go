@@ -0,0 +1,376 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bernardoforcillo/authlayer/internal/model"
+	"github.com/bernardoforcillo/authlayer/internal/rbac"
+	"github.com/bernardoforcillo/authlayer/internal/repository"
+	authlayerv1 "github.com/bernardoforcillo/authlayer/pkg/proto/authlayer/v1"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+type ProjectService struct {
+	authlayerv1.UnimplementedProjectServiceServer
+
+	projectRepo       repository.ProjectRepository
+	projectMemberRepo repository.ProjectMemberRepository
+	teamRepo          repository.TeamRepository
+	teamMemberRepo    repository.TeamMemberRepository
+	saRepo            repository.ServiceAccountRepository
+	roleRepo          repository.RoleRepository
+	checker           *rbac.Checker
+	enforcer          *rbac.ConstraintEnforcer
+	logger            *zap.Logger
+}
+
+func NewProjectService(
+	projectRepo repository.ProjectRepository,
+	projectMemberRepo repository.ProjectMemberRepository,
+	teamRepo repository.TeamRepository,
+	teamMemberRepo repository.TeamMemberRepository,
+	saRepo repository.ServiceAccountRepository,
+	roleRepo repository.RoleRepository,
+	checker *rbac.Checker,
+	enforcer *rbac.ConstraintEnforcer,
+	logger *zap.Logger,
+) *ProjectService {
+	return &ProjectService{
+		projectRepo:       projectRepo,
+		projectMemberRepo: projectMemberRepo,
+		teamRepo:          teamRepo,
+		teamMemberRepo:    teamMemberRepo,
+		saRepo:            saRepo,
+		roleRepo:          roleRepo,
+		checker:           checker,
+		enforcer:          enforcer,
+		logger:            logger,
+	}
+}
+
+func (s *ProjectService) CreateProject(ctx context.Context, req *authlayerv1.CreateProjectRequest) (*authlayerv1.CreateProjectResponse, error) {
+	if req.OrgId == "" || req.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "org_id and name are required")
+	}
+
+	orgID, err := uuid.Parse(req.OrgId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid org_id")
+	}
+	if err := requireOrgPermission(ctx, s.checker, orgID, "project:create"); err != nil {
+		return nil, err
+	}
+
+	project := &model.Project{
+		Name:        req.Name,
+		Description: req.Description,
+		OrgID:       orgID,
+	}
+
+	if err := s.projectRepo.Create(ctx, project); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create project: %v", err)
+	}
+
+	return &authlayerv1.CreateProjectResponse{
+		Project: projectToProto(project),
+	}, nil
+}
+
+func (s *ProjectService) GetProject(ctx context.Context, req *authlayerv1.GetProjectRequest) (*authlayerv1.GetProjectResponse, error) {
+	project, err := s.getProject(ctx, req.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireProjectPermission(ctx, s.checker, project.ID, "project:read"); err != nil {
+		return nil, err
+	}
+
+	return &authlayerv1.GetProjectResponse{Project: projectToProto(project)}, nil
+}
+
+func (s *ProjectService) UpdateProject(ctx context.Context, req *authlayerv1.UpdateProjectRequest) (*authlayerv1.UpdateProjectResponse, error) {
+	project, err := s.getProject(ctx, req.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireProjectPermission(ctx, s.checker, project.ID, "project:update"); err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		project.Name = *req.Name
+	}
+	if req.Description != nil {
+		project.Description = req.Description
+	}
+
+	if err := s.projectRepo.Update(ctx, project); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update project")
+	}
+
+	return &authlayerv1.UpdateProjectResponse{Project: projectToProto(project)}, nil
+}
+
+func (s *ProjectService) DeleteProject(ctx context.Context, req *authlayerv1.DeleteProjectRequest) (*authlayerv1.DeleteProjectResponse, error) {
+	project, err := s.getProject(ctx, req.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	// Deleting a project is an org-level decision; project bindings cannot grant it
+	if err := requireOrgPermission(ctx, s.checker, project.OrgID, "project:delete"); err != nil {
+		return nil, err
+	}
+
+	err = s.checker.Update(ctx, func(ctx context.Context, changes *rbac.Changes) error {
+		members, err := s.projectMemberRepo.ListAllByProjectID(ctx, project.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.projectRepo.Delete(ctx, project.ID); err != nil {
+			return err
+		}
+		// The project's bindings went with it
+		for _, m := range members {
+			if err := s.recordPrincipalChange(ctx, changes, m.PrincipalType, m.PrincipalID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete project")
+	}
+
+	return &authlayerv1.DeleteProjectResponse{}, nil
+}
+
+func (s *ProjectService) ListProjects(ctx context.Context, req *authlayerv1.ListProjectsRequest) (*authlayerv1.ListProjectsResponse, error) {
+	orgID, err := uuid.Parse(req.OrgId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid org_id")
+	}
+	if err := requireOrgPermission(ctx, s.checker, orgID, "project:read"); err != nil {
+		return nil, err
+	}
+
+	pagination := repository.Pagination{PageSize: 20}
+	if req.Pagination != nil {
+		pagination.PageSize = int(req.Pagination.PageSize)
+		pagination.PageToken = req.Pagination.PageToken
+	}
+
+	projects, total, err := s.projectRepo.ListByOrgID(ctx, orgID, pagination)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list projects")
+	}
+
+	protoProjects := make([]*authlayerv1.ProjectInfo, len(projects))
+	for i := range projects {
+		protoProjects[i] = projectToProto(&projects[i])
+	}
+
+	return &authlayerv1.ListProjectsResponse{
+		Projects: protoProjects,
+		Pagination: &authlayerv1.PaginationResponse{
+			TotalCount: int32(total),
+		},
+	}, nil
+}
+
+func (s *ProjectService) AddMember(ctx context.Context, req *authlayerv1.AddProjectMemberRequest) (*authlayerv1.AddProjectMemberResponse, error) {
+	principalType, ok := principalTypeFromProto(req.PrincipalType)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "principal_type is required")
+	}
+	principalID, err := uuid.Parse(req.PrincipalId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid principal_id")
+	}
+	roleID, err := uuid.Parse(req.RoleId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid role_id")
+	}
+
+	project, err := s.getProject(ctx, req.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireProjectPermission(ctx, s.checker, project.ID, "project:manage_members"); err != nil {
+		return nil, err
+	}
+
+	role, err := s.roleRepo.GetByID(ctx, roleID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "role not found")
+	}
+	if role.OrgID != nil && *role.OrgID != project.OrgID {
+		return nil, status.Errorf(codes.InvalidArgument, "role belongs to a different organization")
+	}
+
+	// Teams and service accounts are owned by an org and can only join its projects.
+	// Users may be outside collaborators, so they are not required to be org members.
+	switch principalType {
+	case model.PrincipalTypeTeam:
+		team, err := s.teamRepo.GetByID(ctx, principalID)
+		if err != nil {
+			return nil, status.Errorf(codes.NotFound, "team not found")
+		}
+		if team.OrgID != project.OrgID {
+			return nil, status.Errorf(codes.InvalidArgument, "team belongs to a different organization")
+		}
+	case model.PrincipalTypeServiceAccount:
+		sa, err := s.saRepo.GetByID(ctx, principalID)
+		if err != nil {
+			return nil, status.Errorf(codes.NotFound, "service account not found")
+		}
+		if sa.OrgID != project.OrgID {
+			return nil, status.Errorf(codes.InvalidArgument, "service account belongs to a different organization")
+		}
+	}
+
+	member := &model.ProjectMember{
+		ProjectID:     project.ID,
+		PrincipalType: principalType,
+		PrincipalID:   principalID,
+		RoleID:        roleID,
+	}
+
+	err = s.checker.Update(ctx, func(ctx context.Context, changes *rbac.Changes) error {
+		return s.enforcer.Assign(ctx, project.OrgID, func(ctx context.Context) error {
+			if err := s.enforcer.ValidateProjectMember(ctx, project.OrgID, project.ID, principalType, principalID, roleID); err != nil {
+				return err
+			}
+			if err := s.projectMemberRepo.Upsert(ctx, member); err != nil {
+				return err
+			}
+			return s.recordPrincipalChange(ctx, changes, principalType, principalID)
+		})
+	})
+	if err != nil {
+		return nil, constraintStatus(err, "failed to add project member")
+	}
+
+	return &authlayerv1.AddProjectMemberResponse{}, nil
+}
+
+func (s *ProjectService) RemoveMember(ctx context.Context, req *authlayerv1.RemoveProjectMemberRequest) (*authlayerv1.RemoveProjectMemberResponse, error) {
+	project, err := s.getProject(ctx, req.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireProjectPermission(ctx, s.checker, project.ID, "project:manage_members"); err != nil {
+		return nil, err
+	}
+	principalType, ok := principalTypeFromProto(req.PrincipalType)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "principal_type is required")
+	}
+	principalID, err := uuid.Parse(req.PrincipalId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid principal_id")
+	}
+
+	err = s.checker.Update(ctx, func(ctx context.Context, changes *rbac.Changes) error {
+		if err := s.projectMemberRepo.Remove(ctx, project.ID, principalType, principalID); err != nil {
+			return err
+		}
+		return s.recordPrincipalChange(ctx, changes, principalType, principalID)
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to remove project member")
+	}
+
+	return &authlayerv1.RemoveProjectMemberResponse{}, nil
+}
+
+func (s *ProjectService) ListMembers(ctx context.Context, req *authlayerv1.ListProjectMembersRequest) (*authlayerv1.ListProjectMembersResponse, error) {
+	project, err := s.getProject(ctx, req.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireProjectPermission(ctx, s.checker, project.ID, "project:read"); err != nil {
+		return nil, err
+	}
+
+	pagination := repository.Pagination{PageSize: 20}
+	if req.Pagination != nil {
+		pagination.PageSize = int(req.Pagination.PageSize)
+		pagination.PageToken = req.Pagination.PageToken
+	}
+
+	members, total, err := s.projectMemberRepo.ListByProjectID(ctx, project.ID, pagination)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list members")
+	}
+
+	protoMembers := make([]*authlayerv1.ProjectMemberInfo, len(members))
+	for i, m := range members {
+		protoMembers[i] = &authlayerv1.ProjectMemberInfo{
+			ProjectId:     m.ProjectID.String(),
+			PrincipalType: principalTypeToProto(m.PrincipalType),
+			PrincipalId:   m.PrincipalID.String(),
+			RoleId:        m.RoleID.String(),
+			RoleName:      m.Role.Name,
+			CreatedAt:     timestamppb.New(m.CreatedAt),
+		}
+	}
+
+	return &authlayerv1.ListProjectMembersResponse{
+		Members: protoMembers,
+		Pagination: &authlayerv1.PaginationResponse{
+			TotalCount: int32(total),
+		},
+	}, nil
+}
+
+func (s *ProjectService) getProject(ctx context.Context, rawID string) (*model.Project, error) {
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid project_id")
+	}
+
+	project, err := s.projectRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "project not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get project")
+	}
+	return project, nil
+}
+
+// recordPrincipalChange records every principal affected by a project binding change.
+func (s *ProjectService) recordPrincipalChange(ctx context.Context, changes *rbac.Changes, principalType model.PrincipalType, principalID uuid.UUID) error {
+	switch principalType {
+	case model.PrincipalTypeUser:
+		changes.User(principalID)
+	case model.PrincipalTypeServiceAccount:
+		changes.ServiceAccount(principalID)
+	case model.PrincipalTypeTeam:
+		members, err := s.teamMemberRepo.ListAllByTeamID(ctx, principalID)
+		if err != nil {
+			return err
+		}
+		for _, m := range members {
+			changes.User(m.UserID)
+		}
+	}
+	return nil
+}
+
+func projectToProto(p *model.Project) *authlayerv1.ProjectInfo {
+	return &authlayerv1.ProjectInfo{
+		Id:          p.ID.String(),
+		OrgId:       p.OrgID.String(),
+		Name:        p.Name,
+		Description: p.Description,
+		CreatedAt:   timestamppb.New(p.CreatedAt),
+	}
+}
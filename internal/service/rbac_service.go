@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/bernardoforcillo/authlayer/internal/labels"
 	"github.com/bernardoforcillo/authlayer/internal/model"
 	"github.com/bernardoforcillo/authlayer/internal/rbac"
 	"github.com/bernardoforcillo/authlayer/internal/repository"
@@ -13,19 +14,25 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"gorm.io/gorm"
 )
 
 type RBACService struct {
 	authlayerv1.UnimplementedRBACServiceServer
 
-	roleRepo       repository.RoleRepository
-	permRepo       repository.PermissionRepository
-	rolePermRepo   repository.RolePermissionRepository
-	orgMemberRepo  repository.OrganizationMemberRepository
-	teamMemberRepo repository.TeamMemberRepository
-	checker        *rbac.Checker
-	logger         *zap.Logger
+	roleRepo         repository.RoleRepository
+	permRepo         repository.PermissionRepository
+	rolePermRepo     repository.RolePermissionRepository
+	orgMemberRepo    repository.OrganizationMemberRepository
+	teamMemberRepo   repository.TeamMemberRepository
+	saRepo           repository.ServiceAccountRepository
+	constraintRepo   repository.RoleConstraintRepository
+	labelBindingRepo repository.LabelRoleBindingRepository
+	checker          *rbac.Checker
+	enforcer         *rbac.ConstraintEnforcer
+	feed             *rbac.ChangeFeed
+	logger           *zap.Logger
 }
 
 func NewRBACService(
@@ -34,17 +41,27 @@ func NewRBACService(
 	rolePermRepo repository.RolePermissionRepository,
 	orgMemberRepo repository.OrganizationMemberRepository,
 	teamMemberRepo repository.TeamMemberRepository,
+	saRepo repository.ServiceAccountRepository,
+	constraintRepo repository.RoleConstraintRepository,
+	labelBindingRepo repository.LabelRoleBindingRepository,
 	checker *rbac.Checker,
+	enforcer *rbac.ConstraintEnforcer,
+	feed *rbac.ChangeFeed,
 	logger *zap.Logger,
 ) *RBACService {
 	return &RBACService{
-		roleRepo:       roleRepo,
-		permRepo:       permRepo,
-		rolePermRepo:   rolePermRepo,
-		orgMemberRepo:  orgMemberRepo,
-		teamMemberRepo: teamMemberRepo,
-		checker:        checker,
-		logger:         logger,
+		roleRepo:         roleRepo,
+		permRepo:         permRepo,
+		rolePermRepo:     rolePermRepo,
+		orgMemberRepo:    orgMemberRepo,
+		teamMemberRepo:   teamMemberRepo,
+		saRepo:           saRepo,
+		constraintRepo:   constraintRepo,
+		labelBindingRepo: labelBindingRepo,
+		checker:          checker,
+		enforcer:         enforcer,
+		feed:             feed,
+		logger:           logger,
 	}
 }
 
@@ -53,9 +70,15 @@ func (s *RBACService) CreateRole(ctx context.Context, req *authlayerv1.CreateRol
 		return nil, status.Errorf(codes.InvalidArgument, "name is required")
 	}
 
+	roleLabels, err := labelsFromProto(req.Labels)
+	if err != nil {
+		return nil, err
+	}
+
 	role := &model.Role{
 		Name:        req.Name,
 		Description: req.Description,
+		Labels:      roleLabels,
 	}
 
 	if req.OrgId != nil {
@@ -148,8 +171,19 @@ func (s *RBACService) UpdateRole(ctx context.Context, req *authlayerv1.UpdateRol
 		}
 		role.ParentRoleID = &parentID
 	}
+	if req.Labels != nil {
+		roleLabels, err := labelsFromProto(req.Labels.Labels)
+		if err != nil {
+			return nil, err
+		}
+		role.Labels = roleLabels
+	}
 
-	if err := s.roleRepo.Update(ctx, role); err != nil {
+	err = s.checker.Update(ctx, func(ctx context.Context, changes *rbac.Changes) error {
+		changes.Role(role.ID)
+		return s.roleRepo.Update(ctx, role)
+	})
+	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to update role")
 	}
 
@@ -162,7 +196,11 @@ func (s *RBACService) DeleteRole(ctx context.Context, req *authlayerv1.DeleteRol
 		return nil, status.Errorf(codes.InvalidArgument, "invalid role_id")
 	}
 
-	if err := s.roleRepo.Delete(ctx, id); err != nil {
+	err = s.checker.Update(ctx, func(ctx context.Context, changes *rbac.Changes) error {
+		changes.Role(id)
+		return s.roleRepo.Delete(ctx, id)
+	})
+	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to delete role")
 	}
 
@@ -185,7 +223,12 @@ func (s *RBACService) ListRoles(ctx context.Context, req *authlayerv1.ListRolesR
 		pagination.PageToken = req.Pagination.PageToken
 	}
 
-	roles, total, err := s.roleRepo.ListByOrgID(ctx, orgID, pagination)
+	selector, err := parseLabelSelector(req.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, total, err := s.roleRepo.ListByOrgID(ctx, orgID, selector, pagination)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to list roles")
 	}
@@ -218,16 +261,23 @@ func (s *RBACService) AssignRole(ctx context.Context, req *authlayerv1.AssignRol
 		if err != nil {
 			return nil, status.Errorf(codes.InvalidArgument, "invalid org_id")
 		}
-		if err := s.orgMemberRepo.UpdateRole(ctx, orgID, userID, roleID); err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to assign role")
+		err = s.checker.Update(ctx, func(ctx context.Context, changes *rbac.Changes) error {
+			return s.enforcer.Assign(ctx, orgID, func(ctx context.Context) error {
+				if err := s.enforcer.ValidateUserOrgRole(ctx, orgID, userID, roleID); err != nil {
+					return err
+				}
+				changes.User(userID)
+				return s.orgMemberRepo.UpdateRole(ctx, orgID, userID, roleID)
+			})
+		})
+		if err != nil {
+			return nil, constraintStatus(err, "failed to assign role")
 		}
 	} else if req.GetTeamId() != "" {
 		// For teams, we'd update team member role
 		return nil, status.Errorf(codes.Unimplemented, "team role assignment not yet implemented")
 	}
 
-	s.checker.InvalidateUserCache(userID)
-
 	return &authlayerv1.AssignRoleResponse{}, nil
 }
 
@@ -242,15 +292,17 @@ func (s *RBACService) RevokeRole(ctx context.Context, req *authlayerv1.RevokeRol
 		if err != nil {
 			return nil, status.Errorf(codes.InvalidArgument, "invalid org_id")
 		}
-		if err := s.orgMemberRepo.Remove(ctx, orgID, userID); err != nil {
+		err = s.checker.Update(ctx, func(ctx context.Context, changes *rbac.Changes) error {
+			changes.User(userID)
+			return s.orgMemberRepo.Remove(ctx, orgID, userID)
+		})
+		if err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to revoke role")
 		}
 	} else if req.GetTeamId() != "" {
 		// handle team
 	}
 
-	s.checker.InvalidateUserCache(userID)
-
 	return &authlayerv1.RevokeRoleResponse{}, nil
 }
 
@@ -308,7 +360,11 @@ func (s *RBACService) AssignPermission(ctx context.Context, req *authlayerv1.Ass
 		return nil, status.Errorf(codes.InvalidArgument, "invalid permission_id")
 	}
 
-	if err := s.rolePermRepo.Assign(ctx, roleID, permID); err != nil {
+	err = s.checker.Update(ctx, func(ctx context.Context, changes *rbac.Changes) error {
+		changes.Role(roleID)
+		return s.rolePermRepo.Assign(ctx, roleID, permID)
+	})
+	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to assign permission")
 	}
 
@@ -325,7 +381,11 @@ func (s *RBACService) RevokePermission(ctx context.Context, req *authlayerv1.Rev
 		return nil, status.Errorf(codes.InvalidArgument, "invalid permission_id")
 	}
 
-	if err := s.rolePermRepo.Revoke(ctx, roleID, permID); err != nil {
+	err = s.checker.Update(ctx, func(ctx context.Context, changes *rbac.Changes) error {
+		changes.Role(roleID)
+		return s.rolePermRepo.Revoke(ctx, roleID, permID)
+	})
+	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to revoke permission")
 	}
 
@@ -347,6 +407,31 @@ func (s *RBACService) CheckPermission(ctx context.Context, req *authlayerv1.Chec
 		orgID = &id
 	}
 
+	switch req.PrincipalType {
+	case authlayerv1.PrincipalType_PRINCIPAL_TYPE_UNSPECIFIED, authlayerv1.PrincipalType_PRINCIPAL_TYPE_USER:
+	case authlayerv1.PrincipalType_PRINCIPAL_TYPE_SERVICE_ACCOUNT:
+		return s.checkServiceAccountPermission(ctx, userID, req.PermissionName, orgID, req.ProjectId)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "permissions can only be checked for users and service accounts")
+	}
+
+	if req.ProjectId != nil {
+		projectID, err := uuid.Parse(*req.ProjectId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid project_id")
+		}
+
+		allowed, err := s.checker.CheckProjectPermission(ctx, userID, req.PermissionName, projectID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, status.Errorf(codes.NotFound, "project not found")
+			}
+			return nil, status.Errorf(codes.Internal, "failed to check permission")
+		}
+
+		return &authlayerv1.CheckPermissionResponse{Allowed: allowed}, nil
+	}
+
 	allowed, matchedRole, err := s.checker.CheckPermission(ctx, userID, req.PermissionName, orgID)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to check permission")
@@ -358,6 +443,30 @@ func (s *RBACService) CheckPermission(ctx context.Context, req *authlayerv1.Chec
 	}, nil
 }
 
+func (s *RBACService) checkServiceAccountPermission(ctx context.Context, saID uuid.UUID, permission string, orgID *uuid.UUID, project *string) (*authlayerv1.CheckPermissionResponse, error) {
+	var allowed bool
+	if project != nil {
+		projectID, err := uuid.Parse(*project)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid project_id")
+		}
+		allowed, err = s.checker.CheckServiceAccountProjectPermission(ctx, saID, permission, projectID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, status.Errorf(codes.NotFound, "project not found")
+			}
+			return nil, status.Errorf(codes.Internal, "failed to check permission")
+		}
+		return &authlayerv1.CheckPermissionResponse{Allowed: allowed}, nil
+	}
+
+	allowed, err := s.checker.CheckServiceAccountPermission(ctx, saID, permission, orgID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check permission")
+	}
+	return &authlayerv1.CheckPermissionResponse{Allowed: allowed}, nil
+}
+
 func (s *RBACService) GetUserPermissions(ctx context.Context, req *authlayerv1.GetUserPermissionsRequest) (*authlayerv1.GetUserPermissionsResponse, error) {
 	userID, err := uuid.Parse(req.UserId)
 	if err != nil {
@@ -379,6 +488,419 @@ func (s *RBACService) GetUserPermissions(ctx context.Context, req *authlayerv1.G
 	return nil, status.Errorf(codes.Unimplemented, "GetUserPermissions not yet implemented; use CheckPermission for individual checks")
 }
 
+func (s *RBACService) CreateRoleConstraint(ctx context.Context, req *authlayerv1.CreateRoleConstraintRequest) (*authlayerv1.CreateRoleConstraintResponse, error) {
+	if req.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "name is required")
+	}
+
+	constraint := &model.RoleConstraint{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	if req.OrgId != nil {
+		orgID, err := uuid.Parse(*req.OrgId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid org_id")
+		}
+		constraint.OrgID = &orgID
+	}
+	if err := requireConstraintPermission(ctx, s.checker, constraint.OrgID, "constraint:create"); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	var roleIDs []uuid.UUID
+	for _, raw := range req.RoleIds {
+		roleID, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid role_id %q", raw)
+		}
+		if seen[roleID] {
+			continue
+		}
+		seen[roleID] = true
+
+		role, err := s.roleRepo.GetByID(ctx, roleID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, status.Errorf(codes.NotFound, "role %s not found", roleID)
+			}
+			return nil, status.Errorf(codes.Internal, "failed to get role")
+		}
+		// An org constraint may reference system roles, a global constraint only system roles.
+		if role.OrgID != nil && (constraint.OrgID == nil || *role.OrgID != *constraint.OrgID) {
+			return nil, status.Errorf(codes.InvalidArgument, "role %s belongs to a different organization", roleID)
+		}
+		roleIDs = append(roleIDs, roleID)
+	}
+
+	if len(roleIDs) < 2 {
+		return nil, status.Errorf(codes.InvalidArgument, "at least two distinct role_ids are required")
+	}
+
+	if err := s.constraintRepo.Create(ctx, constraint, roleIDs); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create role constraint: %v", err)
+	}
+
+	created, err := s.constraintRepo.GetByID(ctx, constraint.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get role constraint")
+	}
+
+	return &authlayerv1.CreateRoleConstraintResponse{
+		Constraint: roleConstraintToProto(created),
+	}, nil
+}
+
+func (s *RBACService) ListRoleConstraints(ctx context.Context, req *authlayerv1.ListRoleConstraintsRequest) (*authlayerv1.ListRoleConstraintsResponse, error) {
+	var orgID *uuid.UUID
+	if req.OrgId != nil {
+		id, err := uuid.Parse(*req.OrgId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid org_id")
+		}
+		orgID = &id
+	}
+
+	pagination := repository.Pagination{PageSize: 50}
+	if req.Pagination != nil {
+		pagination.PageSize = int(req.Pagination.PageSize)
+		pagination.PageToken = req.Pagination.PageToken
+	}
+
+	constraints, total, err := s.constraintRepo.ListByOrgID(ctx, orgID, pagination)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list role constraints")
+	}
+
+	protoConstraints := make([]*authlayerv1.RoleConstraintInfo, len(constraints))
+	for i, c := range constraints {
+		protoConstraints[i] = roleConstraintToProto(&c)
+	}
+
+	var nextToken string
+	if len(constraints) > 0 {
+		nextToken = constraints[len(constraints)-1].ID.String()
+	}
+
+	return &authlayerv1.ListRoleConstraintsResponse{
+		Constraints: protoConstraints,
+		Pagination: &authlayerv1.PaginationResponse{
+			NextPageToken: nextToken,
+			TotalCount:    int32(total),
+		},
+	}, nil
+}
+
+func (s *RBACService) DeleteRoleConstraint(ctx context.Context, req *authlayerv1.DeleteRoleConstraintRequest) (*authlayerv1.DeleteRoleConstraintResponse, error) {
+	id, err := uuid.Parse(req.ConstraintId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid constraint_id")
+	}
+
+	constraint, err := s.constraintRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "role constraint not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get role constraint")
+	}
+	if err := requireConstraintPermission(ctx, s.checker, constraint.OrgID, "constraint:delete"); err != nil {
+		return nil, err
+	}
+
+	if err := s.constraintRepo.Delete(ctx, id); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete role constraint")
+	}
+
+	return &authlayerv1.DeleteRoleConstraintResponse{}, nil
+}
+
+func (s *RBACService) ListConstraintViolations(ctx context.Context, req *authlayerv1.ListConstraintViolationsRequest) (*authlayerv1.ListConstraintViolationsResponse, error) {
+	orgID, err := uuid.Parse(req.OrgId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid org_id")
+	}
+	if err := requireOrgPermission(ctx, s.checker, orgID, "constraint:read"); err != nil {
+		return nil, err
+	}
+
+	violations, err := s.enforcer.FindViolations(ctx, orgID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to evaluate role constraints")
+	}
+
+	protoViolations := make([]*authlayerv1.ConstraintViolation, len(violations))
+	for i, v := range violations {
+		roleIDs := make([]string, len(v.RoleIDs))
+		for j, id := range v.RoleIDs {
+			roleIDs[j] = id.String()
+		}
+		protoViolations[i] = &authlayerv1.ConstraintViolation{
+			ConstraintId:   v.Constraint.ID.String(),
+			ConstraintName: v.Constraint.Name,
+			PrincipalType:  principalTypeToProto(v.PrincipalType),
+			PrincipalId:    v.PrincipalID.String(),
+			RoleIds:        roleIDs,
+		}
+	}
+
+	return &authlayerv1.ListConstraintViolationsResponse{Violations: protoViolations}, nil
+}
+
+// WatchPermissionChanges streams effective permission changes. The feed is derived from the
+// materialized table, so it is only available in materialized mode.
+func (s *RBACService) WatchPermissionChanges(req *authlayerv1.WatchPermissionChangesRequest, stream authlayerv1.RBACService_WatchPermissionChangesServer) error {
+	if s.feed == nil {
+		return status.Errorf(codes.FailedPrecondition, "permission change feed requires materialized permissions")
+	}
+
+	var filter repository.PermissionChangeFilter
+	if req.PrincipalType != authlayerv1.PrincipalType_PRINCIPAL_TYPE_UNSPECIFIED {
+		principalType, ok := principalTypeFromProto(req.PrincipalType)
+		if !ok || principalType == model.PrincipalTypeTeam {
+			return status.Errorf(codes.InvalidArgument, "principal_type must be user or service account")
+		}
+		filter.PrincipalType = principalType
+	}
+	if req.PrincipalId != "" {
+		id, err := uuid.Parse(req.PrincipalId)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid principal_id")
+		}
+		filter.PrincipalID = &id
+	}
+	if req.ScopeType != "" {
+		switch scopeType := model.ScopeType(req.ScopeType); scopeType {
+		case model.ScopeTypeGlobal, model.ScopeTypeOrg, model.ScopeTypeProject:
+			filter.ScopeType = scopeType
+		default:
+			return status.Errorf(codes.InvalidArgument, "scope_type must be global, org or project")
+		}
+	}
+	if req.ScopeId != "" {
+		id, err := uuid.Parse(req.ScopeId)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid scope_id")
+		}
+		filter.ScopeID = &id
+	}
+	if err := requireWatchPermission(stream.Context(), s.checker, filter); err != nil {
+		return err
+	}
+
+	err := s.feed.Watch(stream.Context(), req.FromRevision, filter, func(change model.PermissionChange) error {
+		return stream.Send(permissionChangeToProto(&change))
+	})
+	switch {
+	case errors.Is(err, rbac.ErrRevisionCompacted):
+		return status.Errorf(codes.OutOfRange, "revision %d has been compacted; resynchronize and watch from the head", req.GetFromRevision())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	case err != nil:
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.Errorf(codes.Internal, "failed to watch permission changes")
+	}
+	return nil
+}
+
+// requireConstraintPermission checks permission in the constraint's org, or outside any
+// org for a global constraint.
+func requireConstraintPermission(ctx context.Context, checker *rbac.Checker, orgID *uuid.UUID, permission string) error {
+	if orgID == nil {
+		return requireGlobalPermission(ctx, checker, permission)
+	}
+	return requireOrgPermission(ctx, checker, *orgID, permission)
+}
+
+// requireWatchPermission checks permission:watch in the org or project the filter is
+// scoped to, or outside any org when it spans every scope.
+func requireWatchPermission(ctx context.Context, checker *rbac.Checker, filter repository.PermissionChangeFilter) error {
+	if filter.ScopeID != nil {
+		switch filter.ScopeType {
+		case model.ScopeTypeOrg:
+			return requireOrgPermission(ctx, checker, *filter.ScopeID, "permission:watch")
+		case model.ScopeTypeProject:
+			return requireProjectPermission(ctx, checker, *filter.ScopeID, "permission:watch")
+		}
+	}
+	return requireGlobalPermission(ctx, checker, "permission:watch")
+}
+
+// constraintStatus reports a constraint violation as FailedPrecondition and any other
+// failure of the assignment as Internal with msg.
+func constraintStatus(err error, msg string) error {
+	var cve *rbac.ConstraintViolationError
+	if errors.As(err, &cve) {
+		return status.Errorf(codes.FailedPrecondition, "%v", cve)
+	}
+	return status.Errorf(codes.Internal, "%s", msg)
+}
+
+func (s *RBACService) CreateLabelRoleBinding(ctx context.Context, req *authlayerv1.CreateLabelRoleBindingRequest) (*authlayerv1.CreateLabelRoleBindingResponse, error) {
+	orgID, err := uuid.Parse(req.OrgId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid org_id")
+	}
+	roleID, err := uuid.Parse(req.RoleId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid role_id")
+	}
+	principalType, ok := principalTypeFromProto(req.PrincipalType)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "principal_type is required")
+	}
+
+	selector, err := labels.Parse(req.Selector)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if selector.Empty() {
+		// An empty selector would match every principal in the org.
+		return nil, status.Errorf(codes.InvalidArgument, "selector must have at least one requirement")
+	}
+
+	if err := requireOrgPermission(ctx, s.checker, orgID, "role:assign"); err != nil {
+		return nil, err
+	}
+
+	role, err := s.roleRepo.GetByID(ctx, roleID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "role not found")
+	}
+	if role.OrgID != nil && *role.OrgID != orgID {
+		return nil, status.Errorf(codes.InvalidArgument, "role belongs to a different organization")
+	}
+
+	binding := &model.LabelRoleBinding{
+		OrgID:         orgID,
+		RoleID:        roleID,
+		PrincipalType: principalType,
+		Selector:      selector.String(),
+	}
+
+	err = s.checker.Update(ctx, func(ctx context.Context, changes *rbac.Changes) error {
+		if err := s.labelBindingRepo.Create(ctx, binding); err != nil {
+			return err
+		}
+		return s.recordLabelBindingChange(ctx, changes, binding)
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create label role binding: %v", err)
+	}
+
+	return &authlayerv1.CreateLabelRoleBindingResponse{
+		Binding: labelRoleBindingToProto(binding),
+	}, nil
+}
+
+func (s *RBACService) ListLabelRoleBindings(ctx context.Context, req *authlayerv1.ListLabelRoleBindingsRequest) (*authlayerv1.ListLabelRoleBindingsResponse, error) {
+	orgID, err := uuid.Parse(req.OrgId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid org_id")
+	}
+
+	pagination := repository.Pagination{PageSize: 50}
+	if req.Pagination != nil {
+		pagination.PageSize = int(req.Pagination.PageSize)
+		pagination.PageToken = req.Pagination.PageToken
+	}
+
+	bindings, total, err := s.labelBindingRepo.ListByOrgID(ctx, orgID, pagination)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list label role bindings")
+	}
+
+	protoBindings := make([]*authlayerv1.LabelRoleBindingInfo, len(bindings))
+	for i := range bindings {
+		protoBindings[i] = labelRoleBindingToProto(&bindings[i])
+	}
+
+	return &authlayerv1.ListLabelRoleBindingsResponse{
+		Bindings: protoBindings,
+		Pagination: &authlayerv1.PaginationResponse{
+			TotalCount: int32(total),
+		},
+	}, nil
+}
+
+func (s *RBACService) DeleteLabelRoleBinding(ctx context.Context, req *authlayerv1.DeleteLabelRoleBindingRequest) (*authlayerv1.DeleteLabelRoleBindingResponse, error) {
+	id, err := uuid.Parse(req.BindingId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid binding_id")
+	}
+
+	binding, err := s.labelBindingRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "label role binding not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get label role binding")
+	}
+	if err := requireOrgPermission(ctx, s.checker, binding.OrgID, "role:assign"); err != nil {
+		return nil, err
+	}
+
+	err = s.checker.Update(ctx, func(ctx context.Context, changes *rbac.Changes) error {
+		if err := s.labelBindingRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.recordLabelBindingChange(ctx, changes, binding)
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete label role binding")
+	}
+
+	return &authlayerv1.DeleteLabelRoleBindingResponse{}, nil
+}
+
+// recordLabelBindingChange records every principal a label binding may apply to: the org's
+// members for user bindings, the members of the org's teams for team bindings, or the org's
+// service accounts.
+func (s *RBACService) recordLabelBindingChange(ctx context.Context, changes *rbac.Changes, binding *model.LabelRoleBinding) error {
+	switch binding.PrincipalType {
+	case model.PrincipalTypeUser:
+		members, err := s.orgMemberRepo.ListAllByOrgID(ctx, binding.OrgID)
+		if err != nil {
+			return err
+		}
+		for _, m := range members {
+			changes.User(m.UserID)
+		}
+	case model.PrincipalTypeTeam:
+		members, err := s.teamMemberRepo.ListByOrgID(ctx, binding.OrgID)
+		if err != nil {
+			return err
+		}
+		for _, m := range members {
+			changes.User(m.UserID)
+		}
+	case model.PrincipalTypeServiceAccount:
+		accounts, err := s.saRepo.ListAllByOrgID(ctx, binding.OrgID)
+		if err != nil {
+			return err
+		}
+		for _, sa := range accounts {
+			changes.ServiceAccount(sa.ID)
+		}
+	}
+	return nil
+}
+
+func labelRoleBindingToProto(b *model.LabelRoleBinding) *authlayerv1.LabelRoleBindingInfo {
+	return &authlayerv1.LabelRoleBindingInfo{
+		Id:            b.ID.String(),
+		OrgId:         b.OrgID.String(),
+		RoleId:        b.RoleID.String(),
+		PrincipalType: principalTypeToProto(b.PrincipalType),
+		Selector:      b.Selector,
+		CreatedAt:     timestamppb.New(b.CreatedAt),
+	}
+}
+
 func roleToProto(r *model.Role) *authlayerv1.RoleInfo {
 	info := &authlayerv1.RoleInfo{
 		Id:   r.ID.String(),
@@ -395,6 +917,9 @@ func roleToProto(r *model.Role) *authlayerv1.RoleInfo {
 		parentID := r.ParentRoleID.String()
 		info.ParentRoleId = &parentID
 	}
+	if len(r.Labels) > 0 {
+		info.Labels = r.Labels
+	}
 	if len(r.Permissions) > 0 {
 		info.Permissions = make([]*authlayerv1.PermissionInfo, len(r.Permissions))
 		for i, p := range r.Permissions {
@@ -414,3 +939,60 @@ func permToProto(p *model.Permission) *authlayerv1.PermissionInfo {
 	}
 	return info
 }
+
+func roleConstraintToProto(c *model.RoleConstraint) *authlayerv1.RoleConstraintInfo {
+	info := &authlayerv1.RoleConstraintInfo{
+		Id:          c.ID.String(),
+		Name:        c.Name,
+		Description: c.Description,
+		CreatedAt:   timestamppb.New(c.CreatedAt),
+	}
+	if c.OrgID != nil {
+		orgID := c.OrgID.String()
+		info.OrgId = &orgID
+	}
+	info.Roles = make([]*authlayerv1.RoleInfo, len(c.Roles))
+	for i, r := range c.Roles {
+		info.Roles[i] = roleToProto(&r)
+	}
+	return info
+}
+
+func permissionChangeToProto(c *model.PermissionChange) *authlayerv1.PermissionChangeEvent {
+	return &authlayerv1.PermissionChangeEvent{
+		Revision:           c.Revision,
+		PrincipalType:      principalTypeToProto(c.PrincipalType),
+		PrincipalId:        c.PrincipalID.String(),
+		ScopeType:          string(c.ScopeType),
+		ScopeId:            c.ScopeID.String(),
+		AddedPermissions:   c.Added,
+		RemovedPermissions: c.Removed,
+		ChangedAt:          timestamppb.New(c.CreatedAt),
+	}
+}
+
+func principalTypeToProto(t model.PrincipalType) authlayerv1.PrincipalType {
+	switch t {
+	case model.PrincipalTypeUser:
+		return authlayerv1.PrincipalType_PRINCIPAL_TYPE_USER
+	case model.PrincipalTypeServiceAccount:
+		return authlayerv1.PrincipalType_PRINCIPAL_TYPE_SERVICE_ACCOUNT
+	case model.PrincipalTypeTeam:
+		return authlayerv1.PrincipalType_PRINCIPAL_TYPE_TEAM
+	default:
+		return authlayerv1.PrincipalType_PRINCIPAL_TYPE_UNSPECIFIED
+	}
+}
+
+func principalTypeFromProto(t authlayerv1.PrincipalType) (model.PrincipalType, bool) {
+	switch t {
+	case authlayerv1.PrincipalType_PRINCIPAL_TYPE_USER:
+		return model.PrincipalTypeUser, true
+	case authlayerv1.PrincipalType_PRINCIPAL_TYPE_SERVICE_ACCOUNT:
+		return model.PrincipalTypeServiceAccount, true
+	case authlayerv1.PrincipalType_PRINCIPAL_TYPE_TEAM:
+		return model.PrincipalTypeTeam, true
+	default:
+		return "", false
+	}
+}
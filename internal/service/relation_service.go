@@ -0,0 +1,313 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bernardoforcillo/authlayer/internal/rebac"
+	"github.com/bernardoforcillo/authlayer/internal/repository"
+	authlayerv1 "github.com/bernardoforcillo/authlayer/pkg/proto/authlayer/v1"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type RelationService struct {
+	authlayerv1.UnimplementedRelationServiceServer
+
+	nsRepo repository.RelationNamespaceRepository
+	engine *rebac.Engine
+	logger *zap.Logger
+}
+
+func NewRelationService(
+	nsRepo repository.RelationNamespaceRepository,
+	engine *rebac.Engine,
+	logger *zap.Logger,
+) *RelationService {
+	return &RelationService{
+		nsRepo: nsRepo,
+		engine: engine,
+		logger: logger,
+	}
+}
+
+func (s *RelationService) WriteNamespace(ctx context.Context, req *authlayerv1.WriteNamespaceRequest) (*authlayerv1.WriteNamespaceResponse, error) {
+	if req.Namespace == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "namespace is required")
+	}
+
+	ns := protoToNamespace(req.Namespace)
+	if err := s.engine.WriteNamespace(ctx, ns); err != nil {
+		return nil, rebacStatus(err, "failed to write namespace")
+	}
+
+	return &authlayerv1.WriteNamespaceResponse{Namespace: namespaceToProto(ns)}, nil
+}
+
+func (s *RelationService) ListNamespaces(ctx context.Context, req *authlayerv1.ListNamespacesRequest) (*authlayerv1.ListNamespacesResponse, error) {
+	pagination := repository.Pagination{PageSize: 50}
+	if req.Pagination != nil {
+		pagination.PageSize = int(req.Pagination.PageSize)
+		pagination.PageToken = req.Pagination.PageToken
+	}
+
+	stored, total, err := s.nsRepo.List(ctx, pagination)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list namespaces")
+	}
+
+	protoNamespaces := make([]*authlayerv1.NamespaceDefinition, 0, len(stored))
+	for i := range stored {
+		ns, err := rebac.NamespaceFromModel(&stored[i])
+		if err != nil {
+			s.logger.Error("failed to parse namespace", zap.String("namespace", stored[i].Name), zap.Error(err))
+			continue
+		}
+		protoNamespaces = append(protoNamespaces, namespaceToProto(ns))
+	}
+
+	return &authlayerv1.ListNamespacesResponse{
+		Namespaces: protoNamespaces,
+		Pagination: &authlayerv1.PaginationResponse{
+			TotalCount: int32(total),
+		},
+	}, nil
+}
+
+func (s *RelationService) WriteTuples(ctx context.Context, req *authlayerv1.WriteTuplesRequest) (*authlayerv1.WriteTuplesResponse, error) {
+	if len(req.Writes) == 0 && len(req.Deletes) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "writes or deletes are required")
+	}
+
+	writes, err := protoToTuples(req.Writes)
+	if err != nil {
+		return nil, err
+	}
+	deletes, err := protoToTuples(req.Deletes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.engine.WriteTuples(ctx, writes, deletes); err != nil {
+		return nil, rebacStatus(err, "failed to write tuples")
+	}
+
+	return &authlayerv1.WriteTuplesResponse{}, nil
+}
+
+func (s *RelationService) ReadTuples(ctx context.Context, req *authlayerv1.ReadTuplesRequest) (*authlayerv1.ReadTuplesResponse, error) {
+	object, err := protoToObject(req.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	pagination := repository.Pagination{PageSize: 50}
+	if req.Pagination != nil {
+		pagination.PageSize = int(req.Pagination.PageSize)
+		pagination.PageToken = req.Pagination.PageToken
+	}
+
+	tuples, total, err := s.engine.ReadTuples(ctx, object, req.Relation, pagination)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read tuples")
+	}
+
+	protoTuples := make([]*authlayerv1.RelationTuple, len(tuples))
+	for i, t := range tuples {
+		protoTuples[i] = tupleToProto(t)
+	}
+
+	return &authlayerv1.ReadTuplesResponse{
+		Tuples: protoTuples,
+		Pagination: &authlayerv1.PaginationResponse{
+			TotalCount: int32(total),
+		},
+	}, nil
+}
+
+func (s *RelationService) Check(ctx context.Context, req *authlayerv1.CheckRequest) (*authlayerv1.CheckResponse, error) {
+	object, err := protoToObject(req.Object)
+	if err != nil {
+		return nil, err
+	}
+	subject, err := protoToSubject(req.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if req.Relation == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "relation is required")
+	}
+
+	allowed, err := s.engine.Check(ctx, object, req.Relation, subject)
+	if err != nil {
+		return nil, rebacStatus(err, "failed to check relation")
+	}
+
+	return &authlayerv1.CheckResponse{Allowed: allowed}, nil
+}
+
+func (s *RelationService) Expand(ctx context.Context, req *authlayerv1.ExpandRequest) (*authlayerv1.ExpandResponse, error) {
+	object, err := protoToObject(req.Object)
+	if err != nil {
+		return nil, err
+	}
+	if req.Relation == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "relation is required")
+	}
+
+	tree, err := s.engine.Expand(ctx, object, req.Relation)
+	if err != nil {
+		return nil, rebacStatus(err, "failed to expand relation")
+	}
+
+	return &authlayerv1.ExpandResponse{Tree: usersetTreeToProto(tree)}, nil
+}
+
+func (s *RelationService) ListObjects(ctx context.Context, req *authlayerv1.ListObjectsRequest) (*authlayerv1.ListObjectsResponse, error) {
+	if req.Namespace == "" || req.Relation == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "namespace and relation are required")
+	}
+	subject, err := protoToSubject(req.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	pagination := repository.Pagination{PageSize: 50}
+	if req.Pagination != nil {
+		pagination.PageSize = int(req.Pagination.PageSize)
+		pagination.PageToken = req.Pagination.PageToken
+	}
+
+	objectIDs, nextToken, err := s.engine.ListObjects(ctx, req.Namespace, req.Relation, subject, pagination)
+	if err != nil {
+		return nil, rebacStatus(err, "failed to list objects")
+	}
+
+	return &authlayerv1.ListObjectsResponse{
+		ObjectIds: objectIDs,
+		Pagination: &authlayerv1.PaginationResponse{
+			NextPageToken: nextToken,
+		},
+	}, nil
+}
+
+// rebacStatus maps errors from the rebac package to gRPC statuses.
+func rebacStatus(err error, msg string) error {
+	switch {
+	case errors.Is(err, rebac.ErrUnknownNamespace), errors.Is(err, rebac.ErrUnknownRelation),
+		errors.Is(err, rebac.ErrInvalidSchema), errors.Is(err, rebac.ErrInvalidTuple):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, rebac.ErrDepthExceeded):
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	default:
+		return status.Errorf(codes.Internal, "%s", msg)
+	}
+}
+
+// ---- Converters ----
+
+func protoToNamespace(p *authlayerv1.NamespaceDefinition) *rebac.Namespace {
+	ns := &rebac.Namespace{Name: p.Name}
+	for _, r := range p.Relations {
+		def := rebac.RelationDefinition{
+			Name:             r.Name,
+			ComputedUsersets: r.ComputedUsersets,
+		}
+		for _, ttu := range r.TupleToUsersets {
+			def.TupleToUsersets = append(def.TupleToUsersets, rebac.TupleToUserset{
+				Tupleset:        ttu.Tupleset,
+				ComputedUserset: ttu.ComputedUserset,
+			})
+		}
+		ns.Relations = append(ns.Relations, def)
+	}
+	return ns
+}
+
+func namespaceToProto(ns *rebac.Namespace) *authlayerv1.NamespaceDefinition {
+	info := &authlayerv1.NamespaceDefinition{Name: ns.Name}
+	for _, r := range ns.Relations {
+		def := &authlayerv1.RelationDefinition{
+			Name:             r.Name,
+			ComputedUsersets: r.ComputedUsersets,
+		}
+		for _, ttu := range r.TupleToUsersets {
+			def.TupleToUsersets = append(def.TupleToUsersets, &authlayerv1.TupleToUserset{
+				Tupleset:        ttu.Tupleset,
+				ComputedUserset: ttu.ComputedUserset,
+			})
+		}
+		info.Relations = append(info.Relations, def)
+	}
+	return info
+}
+
+func protoToObject(p *authlayerv1.ObjectRef) (rebac.ObjectRef, error) {
+	if p == nil || p.Namespace == "" || p.ObjectId == "" {
+		return rebac.ObjectRef{}, status.Errorf(codes.InvalidArgument, "object namespace and object_id are required")
+	}
+	return rebac.ObjectRef{Namespace: p.Namespace, ObjectID: p.ObjectId}, nil
+}
+
+func protoToSubject(p *authlayerv1.SubjectRef) (rebac.SubjectRef, error) {
+	if p == nil || p.Namespace == "" || p.ObjectId == "" {
+		return rebac.SubjectRef{}, status.Errorf(codes.InvalidArgument, "subject namespace and object_id are required")
+	}
+	return rebac.SubjectRef{Namespace: p.Namespace, ObjectID: p.ObjectId, Relation: p.GetRelation()}, nil
+}
+
+func protoToTuples(ps []*authlayerv1.RelationTuple) ([]rebac.Tuple, error) {
+	tuples := make([]rebac.Tuple, len(ps))
+	for i, p := range ps {
+		object, err := protoToObject(p.Object)
+		if err != nil {
+			return nil, err
+		}
+		subject, err := protoToSubject(p.Subject)
+		if err != nil {
+			return nil, err
+		}
+		if p.Relation == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "relation is required")
+		}
+		tuples[i] = rebac.Tuple{Object: object, Relation: p.Relation, Subject: subject}
+	}
+	return tuples, nil
+}
+
+func objectToProto(o rebac.ObjectRef) *authlayerv1.ObjectRef {
+	return &authlayerv1.ObjectRef{Namespace: o.Namespace, ObjectId: o.ObjectID}
+}
+
+func subjectToProto(s rebac.SubjectRef) *authlayerv1.SubjectRef {
+	info := &authlayerv1.SubjectRef{Namespace: s.Namespace, ObjectId: s.ObjectID}
+	if s.Relation != "" {
+		relation := s.Relation
+		info.Relation = &relation
+	}
+	return info
+}
+
+func tupleToProto(t rebac.Tuple) *authlayerv1.RelationTuple {
+	return &authlayerv1.RelationTuple{
+		Object:   objectToProto(t.Object),
+		Relation: t.Relation,
+		Subject:  subjectToProto(t.Subject),
+	}
+}
+
+func usersetTreeToProto(t *rebac.UsersetTree) *authlayerv1.UsersetTree {
+	info := &authlayerv1.UsersetTree{
+		Object:   objectToProto(t.Object),
+		Relation: t.Relation,
+	}
+	for _, s := range t.Subjects {
+		info.Subjects = append(info.Subjects, subjectToProto(s))
+	}
+	for _, c := range t.Children {
+		info.Children = append(info.Children, usersetTreeToProto(c))
+	}
+	return info
+}
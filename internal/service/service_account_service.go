@@ -2,14 +2,18 @@ package service
 
 import (
 	"context"
+	"crypto"
 	"errors"
+	"net/url"
 
 	"github.com/bernardoforcillo/authlayer/internal/auth"
 	"github.com/bernardoforcillo/authlayer/internal/middleware"
 	"github.com/bernardoforcillo/authlayer/internal/model"
+	"github.com/bernardoforcillo/authlayer/internal/rbac"
 	"github.com/bernardoforcillo/authlayer/internal/repository"
 	authlayerv1 "github.com/bernardoforcillo/authlayer/pkg/proto/authlayer/v1"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
@@ -24,7 +28,11 @@ type ServiceAccountService struct {
 	saRepo     repository.ServiceAccountRepository
 	saKeyRepo  repository.ServiceAccountKeyRepository
 	saRoleRepo repository.ServiceAccountRoleRepository
+	trustRepo  repository.WorkloadIdentityTrustRepository
 	roleRepo   repository.RoleRepository
+	secretBox  *auth.SecretBox
+	checker    *rbac.Checker
+	enforcer   *rbac.ConstraintEnforcer
 	logger     *zap.Logger
 }
 
@@ -32,14 +40,22 @@ func NewServiceAccountService(
 	saRepo repository.ServiceAccountRepository,
 	saKeyRepo repository.ServiceAccountKeyRepository,
 	saRoleRepo repository.ServiceAccountRoleRepository,
+	trustRepo repository.WorkloadIdentityTrustRepository,
 	roleRepo repository.RoleRepository,
+	secretBox *auth.SecretBox,
+	checker *rbac.Checker,
+	enforcer *rbac.ConstraintEnforcer,
 	logger *zap.Logger,
 ) *ServiceAccountService {
 	return &ServiceAccountService{
 		saRepo:     saRepo,
 		saKeyRepo:  saKeyRepo,
 		saRoleRepo: saRoleRepo,
+		trustRepo:  trustRepo,
 		roleRepo:   roleRepo,
+		secretBox:  secretBox,
+		checker:    checker,
+		enforcer:   enforcer,
 		logger:     logger,
 	}
 }
@@ -59,12 +75,19 @@ func (s *ServiceAccountService) CreateServiceAccount(ctx context.Context, req *a
 		return nil, status.Errorf(codes.InvalidArgument, "invalid org_id")
 	}
 
+	saLabels, err := labelsFromProto(req.Labels)
+	if err != nil {
+		return nil, err
+	}
+
 	sa := &model.ServiceAccount{
 		DisplayName: req.DisplayName,
 		Description: req.Description,
 		OrgID:       orgID,
 		CreatedBy:   callerID,
 		Status:      model.ServiceAccountStatusActive,
+		Labels:      saLabels,
+		Scopes:      model.StringList(req.Scopes),
 	}
 
 	if err := s.saRepo.Create(ctx, sa); err != nil {
@@ -120,8 +143,24 @@ func (s *ServiceAccountService) UpdateServiceAccount(ctx context.Context, req *a
 			sa.Status = model.ServiceAccountStatusDisabled
 		}
 	}
+	if req.Labels != nil {
+		saLabels, err := labelsFromProto(req.Labels.Labels)
+		if err != nil {
+			return nil, err
+		}
+		sa.Labels = saLabels
+	}
+	if req.Scopes != nil {
+		sa.Scopes = model.StringList(req.Scopes.Values)
+	}
 
-	if err := s.saRepo.Update(ctx, sa); err != nil {
+	err = s.checker.Update(ctx, func(ctx context.Context, changes *rbac.Changes) error {
+		if req.Labels != nil {
+			changes.ServiceAccount(sa.ID)
+		}
+		return s.saRepo.Update(ctx, sa)
+	})
+	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to update service account")
 	}
 
@@ -136,7 +175,11 @@ func (s *ServiceAccountService) DeleteServiceAccount(ctx context.Context, req *a
 		return nil, status.Errorf(codes.InvalidArgument, "invalid service_account_id")
 	}
 
-	if err := s.saRepo.Delete(ctx, id); err != nil {
+	err = s.checker.Update(ctx, func(ctx context.Context, changes *rbac.Changes) error {
+		changes.ServiceAccount(id)
+		return s.saRepo.Delete(ctx, id)
+	})
+	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to delete service account")
 	}
 
@@ -155,7 +198,12 @@ func (s *ServiceAccountService) ListServiceAccounts(ctx context.Context, req *au
 		pagination.PageToken = req.Pagination.PageToken
 	}
 
-	accounts, total, err := s.saRepo.ListByOrgID(ctx, orgID, pagination)
+	selector, err := parseLabelSelector(req.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, total, err := s.saRepo.ListByOrgID(ctx, orgID, selector, pagination)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to list service accounts")
 	}
@@ -189,31 +237,87 @@ func (s *ServiceAccountService) CreateServiceAccountKey(ctx context.Context, req
 		return nil, status.Errorf(codes.NotFound, "service account not found")
 	}
 
-	plainKey, err := auth.GenerateRandomToken(32)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to generate key")
-	}
-
 	key := &model.ServiceAccountKey{
 		ServiceAccountID: saID,
 		Name:             req.Name,
-		KeyPrefix:        plainKey[:8],
-		KeyHash:          auth.HashToken(plainKey),
 	}
-
 	if req.ExpiresAt != nil {
 		t := req.ExpiresAt.AsTime()
 		key.ExpiresAt = &t
 	}
 
+	resp := &authlayerv1.CreateServiceAccountKeyResponse{}
+	switch {
+	case req.PublicKey != nil && req.GenerateKeyPair:
+		return nil, status.Errorf(codes.InvalidArgument, "public_key and generate_key_pair are mutually exclusive")
+
+	case req.RequestSigning && (req.PublicKey != nil || req.GenerateKeyPair):
+		return nil, status.Errorf(codes.InvalidArgument, "only secrets can sign requests")
+
+	case req.PublicKey != nil:
+		pub, method, err := auth.ParsePublicKey(*req.PublicKey)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid public_key: %v", err)
+		}
+		if err := setPublicKey(key, pub, method); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid public_key: %v", err)
+		}
+
+	case req.GenerateKeyPair:
+		privateKey, pub, err := auth.GenerateKeyPair()
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to generate key pair")
+		}
+		if err := setPublicKey(key, pub, jwt.SigningMethodES256); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to generate key pair")
+		}
+		resp.PrivateKey = privateKey
+
+	default:
+		plainKey, err := auth.GenerateRandomToken(32)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to generate key")
+		}
+		key.KeyType = model.ServiceAccountKeyTypeSecret
+		key.KeyPrefix = plainKey[:8]
+		key.KeyHash = auth.HashToken(plainKey)
+		resp.PlainTextKey = plainKey
+
+		// Verifying signatures takes the secret itself, so it is kept sealed
+		if req.RequestSigning {
+			if s.secretBox == nil {
+				return nil, status.Errorf(codes.FailedPrecondition, "request signing is not enabled on this server")
+			}
+			if key.SigningSecret, err = s.secretBox.Seal([]byte(plainKey)); err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to seal key")
+			}
+		}
+	}
+
 	if err := s.saKeyRepo.Create(ctx, key); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create service account key")
 	}
 
-	return &authlayerv1.CreateServiceAccountKeyResponse{
-		KeyInfo:      saKeyToProto(key),
-		PlainTextKey: plainKey,
-	}, nil
+	resp.KeyInfo = saKeyToProto(key)
+	return resp, nil
+}
+
+// setPublicKey makes key a public key. Its prefix and hash come from the JWK thumbprint.
+func setPublicKey(key *model.ServiceAccountKey, pub crypto.PublicKey, method jwt.SigningMethod) error {
+	jwk, err := auth.PublicJWK(pub, method)
+	if err != nil {
+		return err
+	}
+	pemKey, err := auth.EncodePublicKey(pub)
+	if err != nil {
+		return err
+	}
+	key.KeyType = model.ServiceAccountKeyTypePublicKey
+	key.KeyPrefix = jwk.Kid[:8]
+	key.KeyHash = "jwk:" + jwk.Kid
+	key.PublicKey = pemKey
+	key.Algorithm = method.Alg()
+	return nil
 }
 
 func (s *ServiceAccountService) RevokeServiceAccountKey(ctx context.Context, req *authlayerv1.RevokeServiceAccountKeyRequest) (*authlayerv1.RevokeServiceAccountKeyResponse, error) {
@@ -279,8 +383,17 @@ func (s *ServiceAccountService) AssignRole(ctx context.Context, req *authlayerv1
 		OrgID:            orgID,
 	}
 
-	if err := s.saRoleRepo.Assign(ctx, sar); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to assign role: %v", err)
+	err = s.checker.Update(ctx, func(ctx context.Context, changes *rbac.Changes) error {
+		return s.enforcer.Assign(ctx, orgID, func(ctx context.Context) error {
+			if err := s.enforcer.ValidateServiceAccountRole(ctx, orgID, saID, roleID); err != nil {
+				return err
+			}
+			changes.ServiceAccount(saID)
+			return s.saRoleRepo.Assign(ctx, sar)
+		})
+	})
+	if err != nil {
+		return nil, constraintStatus(err, "failed to assign role")
 	}
 
 	return &authlayerv1.AssignServiceAccountRoleResponse{}, nil
@@ -300,13 +413,124 @@ func (s *ServiceAccountService) RevokeRole(ctx context.Context, req *authlayerv1
 		return nil, status.Errorf(codes.InvalidArgument, "invalid org_id")
 	}
 
-	if err := s.saRoleRepo.Revoke(ctx, saID, roleID, orgID); err != nil {
+	err = s.checker.Update(ctx, func(ctx context.Context, changes *rbac.Changes) error {
+		changes.ServiceAccount(saID)
+		return s.saRoleRepo.Revoke(ctx, saID, roleID, orgID)
+	})
+	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to revoke role")
 	}
 
 	return &authlayerv1.RevokeServiceAccountRoleResponse{}, nil
 }
 
+func (s *ServiceAccountService) CreateWorkloadIdentityTrust(ctx context.Context, req *authlayerv1.CreateWorkloadIdentityTrustRequest) (*authlayerv1.CreateWorkloadIdentityTrustResponse, error) {
+	callerID, err := middleware.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "not authenticated")
+	}
+	saID, err := uuid.Parse(req.ServiceAccountId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid service_account_id")
+	}
+	if req.Name == "" || req.Audience == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "name and audience are required")
+	}
+	issuer, err := url.Parse(req.IssuerUrl)
+	if err != nil || (issuer.Scheme != "https" && issuer.Scheme != "http") || issuer.Host == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "issuer_url must be an http(s) URL")
+	}
+	// Without conditions, any workload of the issuer, such as any repository on a CI
+	// service, could act as the service account
+	if len(req.Conditions) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "at least one condition is required")
+	}
+	for claim, value := range req.Conditions {
+		if claim == "" || value == "" || value == "*" {
+			return nil, status.Errorf(codes.InvalidArgument, "condition on %q must name a claim and a value", claim)
+		}
+	}
+
+	sa, err := s.saRepo.GetByID(ctx, saID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "service account not found")
+	}
+	// A trust lets outside workloads act as the service account, like a key would
+	if err := requireOrgPermission(ctx, s.checker, sa.OrgID, "service_account:manage_keys"); err != nil {
+		return nil, err
+	}
+
+	trust := &model.WorkloadIdentityTrust{
+		ServiceAccountID: saID,
+		Name:             req.Name,
+		IssuerURL:        req.IssuerUrl,
+		Audience:         req.Audience,
+		Conditions:       model.Labels(req.Conditions),
+		CreatedBy:        callerID,
+	}
+	if err := s.trustRepo.Create(ctx, trust); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create workload identity trust")
+	}
+
+	return &authlayerv1.CreateWorkloadIdentityTrustResponse{Trust: trustToProto(trust)}, nil
+}
+
+func (s *ServiceAccountService) ListWorkloadIdentityTrusts(ctx context.Context, req *authlayerv1.ListWorkloadIdentityTrustsRequest) (*authlayerv1.ListWorkloadIdentityTrustsResponse, error) {
+	saID, err := uuid.Parse(req.ServiceAccountId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid service_account_id")
+	}
+
+	sa, err := s.saRepo.GetByID(ctx, saID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "service account not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get service account")
+	}
+	if err := requireOrgPermission(ctx, s.checker, sa.OrgID, "service_account:read"); err != nil {
+		return nil, err
+	}
+
+	trusts, err := s.trustRepo.ListByServiceAccountID(ctx, saID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list workload identity trusts")
+	}
+
+	protoTrusts := make([]*authlayerv1.WorkloadIdentityTrustInfo, len(trusts))
+	for i := range trusts {
+		protoTrusts[i] = trustToProto(&trusts[i])
+	}
+	return &authlayerv1.ListWorkloadIdentityTrustsResponse{Trusts: protoTrusts}, nil
+}
+
+func (s *ServiceAccountService) DeleteWorkloadIdentityTrust(ctx context.Context, req *authlayerv1.DeleteWorkloadIdentityTrustRequest) (*authlayerv1.DeleteWorkloadIdentityTrustResponse, error) {
+	id, err := uuid.Parse(req.TrustId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid trust_id")
+	}
+
+	trust, err := s.trustRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "workload identity trust not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get workload identity trust")
+	}
+	if err := requireOrgPermission(ctx, s.checker, trust.ServiceAccount.OrgID, "service_account:manage_keys"); err != nil {
+		return nil, err
+	}
+
+	if err := s.trustRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "workload identity trust not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to delete workload identity trust")
+	}
+
+	return &authlayerv1.DeleteWorkloadIdentityTrustResponse{}, nil
+}
+
 // ---- Converters ----
 
 func serviceAccountToProto(sa *model.ServiceAccount) *authlayerv1.ServiceAccountInfo {
@@ -318,6 +542,8 @@ func serviceAccountToProto(sa *model.ServiceAccount) *authlayerv1.ServiceAccount
 		CreatedBy:   sa.CreatedBy.String(),
 		CreatedAt:   timestamppb.New(sa.CreatedAt),
 		UpdatedAt:   timestamppb.New(sa.UpdatedAt),
+		Labels:      sa.Labels,
+		Scopes:      sa.Scopes,
 	}
 
 	switch sa.Status {
@@ -349,6 +575,13 @@ func saKeyToProto(k *model.ServiceAccountKey) *authlayerv1.ServiceAccountKeyInfo
 		Name:             k.Name,
 		CreatedAt:        timestamppb.New(k.CreatedAt),
 		Revoked:          k.Revoked,
+		KeyType:          authlayerv1.ServiceAccountKeyType_SERVICE_ACCOUNT_KEY_TYPE_SECRET,
+		PublicKey:        k.PublicKey,
+		Algorithm:        k.Algorithm,
+		RequestSigning:   k.SigningSecret != "",
+	}
+	if k.KeyType == model.ServiceAccountKeyTypePublicKey {
+		info.KeyType = authlayerv1.ServiceAccountKeyType_SERVICE_ACCOUNT_KEY_TYPE_PUBLIC_KEY
 	}
 
 	if k.ExpiresAt != nil {
@@ -360,3 +593,19 @@ func saKeyToProto(k *model.ServiceAccountKey) *authlayerv1.ServiceAccountKeyInfo
 
 	return info
 }
+
+func trustToProto(t *model.WorkloadIdentityTrust) *authlayerv1.WorkloadIdentityTrustInfo {
+	info := &authlayerv1.WorkloadIdentityTrustInfo{
+		Id:               t.ID.String(),
+		ServiceAccountId: t.ServiceAccountID.String(),
+		Name:             t.Name,
+		IssuerUrl:        t.IssuerURL,
+		Audience:         t.Audience,
+		Conditions:       t.Conditions,
+		CreatedAt:        timestamppb.New(t.CreatedAt),
+	}
+	if t.LastUsedAt != nil {
+		info.LastUsedAt = timestamppb.New(*t.LastUsedAt)
+	}
+	return info
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/bernardoforcillo/authlayer/internal/middleware"
 	"github.com/bernardoforcillo/authlayer/internal/model"
+	"github.com/bernardoforcillo/authlayer/internal/rbac"
 	"github.com/bernardoforcillo/authlayer/internal/repository"
 	authlayerv1 "github.com/bernardoforcillo/authlayer/pkg/proto/authlayer/v1"
 
@@ -22,17 +23,23 @@ type TeamService struct {
 
 	teamRepo       repository.TeamRepository
 	teamMemberRepo repository.TeamMemberRepository
+	checker        *rbac.Checker
+	enforcer       *rbac.ConstraintEnforcer
 	logger         *zap.Logger
 }
 
 func NewTeamService(
 	teamRepo repository.TeamRepository,
 	teamMemberRepo repository.TeamMemberRepository,
+	checker *rbac.Checker,
+	enforcer *rbac.ConstraintEnforcer,
 	logger *zap.Logger,
 ) *TeamService {
 	return &TeamService{
 		teamRepo:       teamRepo,
 		teamMemberRepo: teamMemberRepo,
+		checker:        checker,
+		enforcer:       enforcer,
 		logger:         logger,
 	}
 }
@@ -47,9 +54,15 @@ func (s *TeamService) CreateTeam(ctx context.Context, req *authlayerv1.CreateTea
 		return nil, status.Errorf(codes.InvalidArgument, "invalid org_id")
 	}
 
+	teamLabels, err := labelsFromProto(req.Labels)
+	if err != nil {
+		return nil, err
+	}
+
 	team := &model.Team{
-		Name:  req.Name,
-		OrgID: orgID,
+		Name:   req.Name,
+		OrgID:  orgID,
+		Labels: teamLabels,
 	}
 
 	if err := s.teamRepo.Create(ctx, team); err != nil {
@@ -92,8 +105,32 @@ func (s *TeamService) UpdateTeam(ctx context.Context, req *authlayerv1.UpdateTea
 	if req.Name != nil {
 		team.Name = *req.Name
 	}
+	if req.Labels != nil {
+		teamLabels, err := labelsFromProto(req.Labels.Labels)
+		if err != nil {
+			return nil, err
+		}
+		team.Labels = teamLabels
+	}
 
-	if err := s.teamRepo.Update(ctx, team); err != nil {
+	err = s.checker.Update(ctx, func(ctx context.Context, changes *rbac.Changes) error {
+		if err := s.teamRepo.Update(ctx, team); err != nil {
+			return err
+		}
+		if req.Labels == nil {
+			return nil
+		}
+		// Team label role bindings may now match differently for every member.
+		members, err := s.teamMemberRepo.ListAllByTeamID(ctx, team.ID)
+		if err != nil {
+			return err
+		}
+		for _, m := range members {
+			changes.User(m.UserID)
+		}
+		return nil
+	})
+	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to update team")
 	}
 
@@ -106,7 +143,21 @@ func (s *TeamService) DeleteTeam(ctx context.Context, req *authlayerv1.DeleteTea
 		return nil, status.Errorf(codes.InvalidArgument, "invalid team_id")
 	}
 
-	if err := s.teamRepo.Delete(ctx, id); err != nil {
+	err = s.checker.Update(ctx, func(ctx context.Context, changes *rbac.Changes) error {
+		// Members lose the project grants held through the team.
+		members, err := s.teamMemberRepo.ListAllByTeamID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.teamRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		for _, m := range members {
+			changes.User(m.UserID)
+		}
+		return nil
+	})
+	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to delete team")
 	}
 
@@ -125,7 +176,12 @@ func (s *TeamService) ListTeams(ctx context.Context, req *authlayerv1.ListTeamsR
 		pagination.PageToken = req.Pagination.PageToken
 	}
 
-	teams, total, err := s.teamRepo.ListByOrgID(ctx, orgID, pagination)
+	selector, err := parseLabelSelector(req.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	teams, total, err := s.teamRepo.ListByOrgID(ctx, orgID, selector, pagination)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to list teams")
 	}
@@ -157,14 +213,31 @@ func (s *TeamService) AddMember(ctx context.Context, req *authlayerv1.AddTeamMem
 		return nil, status.Errorf(codes.InvalidArgument, "invalid role_id")
 	}
 
+	team, err := s.teamRepo.GetByID(ctx, teamID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "team not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get team")
+	}
+
 	member := &model.TeamMember{
 		TeamID: teamID,
 		UserID: userID,
 		RoleID: roleID,
 	}
 
-	if err := s.teamMemberRepo.Add(ctx, member); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to add team member")
+	err = s.checker.Update(ctx, func(ctx context.Context, changes *rbac.Changes) error {
+		return s.enforcer.Assign(ctx, team.OrgID, func(ctx context.Context) error {
+			if err := s.enforcer.ValidateUserTeamRole(ctx, team.OrgID, teamID, userID, roleID); err != nil {
+				return err
+			}
+			changes.User(userID)
+			return s.teamMemberRepo.Add(ctx, member)
+		})
+	})
+	if err != nil {
+		return nil, constraintStatus(err, "failed to add team member")
 	}
 
 	return &authlayerv1.AddTeamMemberResponse{}, nil
@@ -180,7 +253,11 @@ func (s *TeamService) RemoveMember(ctx context.Context, req *authlayerv1.RemoveT
 		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id")
 	}
 
-	if err := s.teamMemberRepo.Remove(ctx, teamID, userID); err != nil {
+	err = s.checker.Update(ctx, func(ctx context.Context, changes *rbac.Changes) error {
+		changes.User(userID)
+		return s.teamMemberRepo.Remove(ctx, teamID, userID)
+	})
+	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to remove team member")
 	}
 
@@ -226,9 +303,10 @@ func (s *TeamService) ListMembers(ctx context.Context, req *authlayerv1.ListTeam
 
 func teamToProto(t *model.Team) *authlayerv1.TeamInfo {
 	return &authlayerv1.TeamInfo{
-		Id:    t.ID.String(),
-		Name:  t.Name,
-		OrgId: t.OrgID.String(),
+		Id:     t.ID.String(),
+		Name:   t.Name,
+		OrgId:  t.OrgID.String(),
+		Labels: t.Labels,
 	}
 }
 
@@ -7,6 +7,7 @@ import (
 	"github.com/bernardoforcillo/authlayer/internal/auth"
 	"github.com/bernardoforcillo/authlayer/internal/middleware"
 	"github.com/bernardoforcillo/authlayer/internal/model"
+	"github.com/bernardoforcillo/authlayer/internal/rbac"
 	"github.com/bernardoforcillo/authlayer/internal/repository"
 	authlayerv1 "github.com/bernardoforcillo/authlayer/pkg/proto/authlayer/v1"
 
@@ -20,20 +21,26 @@ import (
 type UserService struct {
 	authlayerv1.UnimplementedUserServiceServer
 
-	userRepo    repository.UserRepository
-	sessionRepo repository.SessionRepository
-	logger      *zap.Logger
+	userRepo      repository.UserRepository
+	sessionRepo   repository.SessionRepository
+	orgMemberRepo repository.OrganizationMemberRepository
+	checker       *rbac.Checker
+	logger        *zap.Logger
 }
 
 func NewUserService(
 	userRepo repository.UserRepository,
 	sessionRepo repository.SessionRepository,
+	orgMemberRepo repository.OrganizationMemberRepository,
+	checker *rbac.Checker,
 	logger *zap.Logger,
 ) *UserService {
 	return &UserService{
-		userRepo:    userRepo,
-		sessionRepo: sessionRepo,
-		logger:      logger,
+		userRepo:      userRepo,
+		sessionRepo:   sessionRepo,
+		orgMemberRepo: orgMemberRepo,
+		checker:       checker,
+		logger:        logger,
 	}
 }
 
@@ -97,13 +104,63 @@ func (s *UserService) DeleteUser(ctx context.Context, req *authlayerv1.DeleteUse
 	// Revoke all sessions
 	_ = s.sessionRepo.RevokeAllByUserID(ctx, id)
 
-	if err := s.userRepo.Delete(ctx, id); err != nil {
+	err = s.checker.Update(ctx, func(ctx context.Context, changes *rbac.Changes) error {
+		changes.User(id)
+		return s.userRepo.Delete(ctx, id)
+	})
+	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to delete user")
 	}
 
 	return &authlayerv1.DeleteUserResponse{}, nil
 }
 
+func (s *UserService) SetUserLabels(ctx context.Context, req *authlayerv1.SetUserLabelsRequest) (*authlayerv1.SetUserLabelsResponse, error) {
+	id, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id")
+	}
+	l, err := labelsFromProto(req.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get user")
+	}
+
+	// Labels select roles through the label bindings of every org the user belongs to, so
+	// the caller must be allowed to update users in all of them.
+	memberships, err := s.orgMemberRepo.ListAllByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list user memberships")
+	}
+	if len(memberships) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "user belongs to no organization")
+	}
+	for _, m := range memberships {
+		if err := requireOrgPermission(ctx, s.checker, m.OrgID, "user:update"); err != nil {
+			return nil, err
+		}
+	}
+
+	user.Labels = l
+	err = s.checker.Update(ctx, func(ctx context.Context, changes *rbac.Changes) error {
+		// Label role bindings may now match differently.
+		changes.User(user.ID)
+		return s.userRepo.Update(ctx, user)
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update user labels")
+	}
+
+	return &authlayerv1.SetUserLabelsResponse{User: userToProto(user)}, nil
+}
+
 func (s *UserService) ListUsers(ctx context.Context, req *authlayerv1.ListUsersRequest) (*authlayerv1.ListUsersResponse, error) {
 	filter := repository.UserFilter{
 		Search: req.Search,
@@ -112,6 +169,11 @@ func (s *UserService) ListUsers(ctx context.Context, req *authlayerv1.ListUsersR
 		st := protoToUserStatus(*req.Status)
 		filter.Status = &st
 	}
+	selector, err := parseLabelSelector(req.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+	filter.Labels = selector
 
 	pagination := repository.Pagination{PageSize: 20}
 	if req.Pagination != nil {
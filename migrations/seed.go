@@ -17,6 +17,7 @@ var DefaultPermissions = []struct {
 	{"org:read", "View organization details"},
 	{"org:update", "Update organization settings"},
 	{"org:delete", "Delete organizations"},
+	{"org:manage_hierarchy", "Attach and detach child organizations"},
 
 	// Team
 	{"team:create", "Create teams within an organization"},
@@ -39,6 +40,24 @@ var DefaultPermissions = []struct {
 	// Permissions
 	{"permission:read", "View permissions"},
 	{"permission:assign", "Assign permissions to roles"},
+	{"permission:watch", "Stream effective permission changes"},
+
+	// Separation-of-duties constraints
+	{"constraint:create", "Create separation-of-duties constraints"},
+	{"constraint:read", "View constraints and their violations"},
+	{"constraint:delete", "Delete separation-of-duties constraints"},
+
+	// Relationship-based access control
+	{"relation:schema_write", "Define relation namespaces"},
+	{"relation:read", "Check, expand and read relation tuples"},
+	{"relation:write", "Write and delete relation tuples"},
+
+	// Projects
+	{"project:create", "Create projects"},
+	{"project:read", "View projects and their members"},
+	{"project:update", "Update project details"},
+	{"project:delete", "Delete projects"},
+	{"project:manage_members", "Add and remove project members"},
 
 	// Users
 	{"user:read", "View user profiles"},
@@ -58,6 +77,14 @@ var DefaultPermissions = []struct {
 	{"service_account:delete", "Delete service accounts"},
 	{"service_account:manage_keys", "Manage service account keys"},
 	{"service_account:assign_role", "Assign roles to service accounts"},
+
+	// OAuth clients
+	{"oauth_client:create", "Register OAuth clients"},
+	{"oauth_client:read", "View OAuth clients"},
+	{"oauth_client:update", "Update OAuth clients and rotate their secrets"},
+	{"oauth_client:delete", "Delete OAuth clients"},
+	// Granted to no default role: trusted clients skip consent for every user
+	{"oauth_client:trust", "Mark OAuth clients as trusted, skipping user consent"},
 }
 
 // DefaultRoles defines the system role hierarchy: viewer -> member -> admin -> owner.
@@ -72,7 +99,7 @@ var DefaultRoles = []struct {
 		Description: "Read-only access",
 		Permissions: []string{
 			"org:read", "team:read", "role:read", "permission:read", "user:read",
-			"service_account:read",
+			"service_account:read", "project:read",
 		},
 	},
 	{
@@ -91,9 +118,13 @@ var DefaultRoles = []struct {
 			"org:update", "team:update", "team:delete",
 			"member:invite", "member:remove", "member:update_role",
 			"role:create", "role:update", "role:assign",
+			"constraint:read", "permission:watch",
+			"relation:read", "relation:write",
+			"project:create", "project:update", "project:manage_members",
 			"user:list",
 			"service_account:create", "service_account:update",
 			"service_account:manage_keys", "service_account:assign_role",
+			"oauth_client:read",
 		},
 	},
 	{
@@ -101,10 +132,14 @@ var DefaultRoles = []struct {
 		Description: "Full access (organization owner)",
 		ParentName:  "admin",
 		Permissions: []string{
-			"org:create", "org:delete",
+			"org:create", "org:delete", "org:manage_hierarchy",
 			"role:delete", "permission:assign",
+			"constraint:create", "constraint:delete",
+			"relation:schema_write",
+			"project:delete",
 			"user:delete", "user:update",
 			"service_account:delete",
+			"oauth_client:create", "oauth_client:update", "oauth_client:delete",
 		},
 	},
 }
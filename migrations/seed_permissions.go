@@ -0,0 +1,71 @@
+package migrations
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bernardoforcillo/authlayer/internal/model"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// PermissionSeed is an additional permission created at startup, for embedders that
+// protect their own resources with authlayer.
+type PermissionSeed struct {
+	Name        string
+	Description string
+	// Roles names the system roles (viewer, member, admin, owner, ...) granted the
+	// permission. Unlike the defaults, grants are added to roles that already exist.
+	Roles []string
+}
+
+// SeedPermissions creates the permissions if they don't already exist and grants them to
+// the named system roles. It returns the IDs of the roles that gained a permission, whose
+// cached and materialized permissions are now stale.
+func SeedPermissions(db *gorm.DB, logger *zap.Logger, seeds []PermissionSeed) ([]uuid.UUID, error) {
+	var changed []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+
+	for _, p := range seeds {
+		var perm model.Permission
+		err := db.Where("name = ?", p.Name).First(&perm).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			desc := p.Description
+			perm = model.Permission{Name: p.Name, Description: &desc}
+			if err := db.Create(&perm).Error; err != nil {
+				return nil, fmt.Errorf("create permission %q: %w", p.Name, err)
+			}
+			logger.Info("created permission", zap.String("name", p.Name))
+		} else if err != nil {
+			return nil, fmt.Errorf("load permission %q: %w", p.Name, err)
+		}
+
+		for _, roleName := range p.Roles {
+			var role model.Role
+			if err := db.Where("name = ? AND org_id IS NULL", roleName).First(&role).Error; err != nil {
+				return nil, fmt.Errorf("load system role %q: %w", roleName, err)
+			}
+
+			var count int64
+			if err := db.Model(&model.RolePermission{}).
+				Where("role_id = ? AND permission_id = ?", role.ID, perm.ID).
+				Count(&count).Error; err != nil {
+				return nil, err
+			}
+			if count > 0 {
+				continue
+			}
+			if err := db.Create(&model.RolePermission{RoleID: role.ID, PermissionID: perm.ID}).Error; err != nil {
+				return nil, fmt.Errorf("grant %q to role %q: %w", p.Name, roleName, err)
+			}
+			logger.Info("granted permission to role", zap.String("permission", p.Name), zap.String("role", roleName))
+			if !seen[role.ID] {
+				seen[role.ID] = true
+				changed = append(changed, role.ID)
+			}
+		}
+	}
+	return changed, nil
+}
@@ -0,0 +1,414 @@
+// Package authlayer assembles the authlayer server so it can be embedded in another Go
+// program, with functional options to swap repositories, add OAuth providers,
+// interceptors and permissions, and bring your own logger, gRPC server or HTTP mux.
+//
+//	cfg, err := authlayer.LoadConfig()
+//	if err != nil { ... }
+//	app, err := authlayer.New(cfg, authlayer.WithLogger(logger))
+//	if err != nil { ... }
+//	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+//	defer stop()
+//	if err := app.Run(ctx); err != nil { ... }
+package authlayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/bernardoforcillo/authlayer/internal/auth"
+	"github.com/bernardoforcillo/authlayer/internal/config"
+	"github.com/bernardoforcillo/authlayer/internal/database"
+	"github.com/bernardoforcillo/authlayer/internal/extauthz"
+	"github.com/bernardoforcillo/authlayer/internal/k8swebhook"
+	"github.com/bernardoforcillo/authlayer/internal/middleware"
+	"github.com/bernardoforcillo/authlayer/internal/oauth"
+	"github.com/bernardoforcillo/authlayer/internal/oauthserver"
+	"github.com/bernardoforcillo/authlayer/internal/opa"
+	"github.com/bernardoforcillo/authlayer/internal/proxyauth"
+	"github.com/bernardoforcillo/authlayer/internal/rbac"
+	"github.com/bernardoforcillo/authlayer/internal/rebac"
+	"github.com/bernardoforcillo/authlayer/internal/server"
+	"github.com/bernardoforcillo/authlayer/internal/service"
+	"github.com/bernardoforcillo/authlayer/migrations"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+)
+
+// publicMethods are served without credentials.
+var publicMethods = []string{
+	"/authlayer.v1.AuthService/Register",
+	"/authlayer.v1.AuthService/Login",
+	"/authlayer.v1.AuthService/RefreshToken",
+	"/authlayer.v1.AuthService/GetOAuthURL",
+	"/authlayer.v1.AuthService/OAuthCallback",
+	"/authlayer.v1.AuthService/VerifyEmail",
+	"/authlayer.v1.AuthService/RequestPasswordReset",
+	"/authlayer.v1.AuthService/ResetPassword",
+	"/authlayer.v1.APIKeyService/ValidateAPIKey",
+	"/grpc.health.v1.Health/Check",
+	"/grpc.health.v1.Health/Watch",
+}
+
+// methodPerms are the method-level permission requirements (can be expanded). They are
+// checked outside any org, which only service account grants can satisfy, so RPCs acting on
+// an org or project check their permission there in the handler instead.
+var methodPerms = map[string]string{
+	"/authlayer.v1.UserService/ListUsers":                  "user:list",
+	"/authlayer.v1.UserService/DeleteUser":                 "user:delete",
+	"/authlayer.v1.OrganizationService/DeleteOrganization": "org:delete",
+	"/authlayer.v1.RBACService/CreateRole":                 "role:create",
+	"/authlayer.v1.RBACService/DeleteRole":                 "role:delete",
+	"/authlayer.v1.RBACService/AssignRole":                 "role:assign",
+	"/authlayer.v1.RBACService/AssignPermission":           "permission:assign",
+	"/authlayer.v1.RBACService/RevokePermission":           "permission:assign",
+	"/authlayer.v1.RelationService/WriteNamespace":         "relation:schema_write",
+	"/authlayer.v1.RelationService/WriteTuples":            "relation:write",
+	"/authlayer.v1.RelationService/ReadTuples":             "relation:read",
+	"/authlayer.v1.RelationService/Check":                  "relation:read",
+	"/authlayer.v1.RelationService/Expand":                 "relation:read",
+	"/authlayer.v1.RelationService/ListObjects":            "relation:read",
+	"/authlayer.v1.OrganizationService/InviteMember":       "member:invite",
+	"/authlayer.v1.OrganizationService/RemoveMember":       "member:remove",
+	"/authlayer.v1.OrganizationService/UpdateMemberRole":   "member:update_role",
+}
+
+// LoadConfig reads the configuration from environment variables.
+func LoadConfig() (*Config, error) {
+	return config.Load()
+}
+
+// App is an assembled authlayer server.
+type App struct {
+	cfg        *Config
+	logger     *zap.Logger
+	db         *gorm.DB
+	repos      Repositories
+	grpcServer *grpc.Server
+	srv        *server.Server
+	checker    *rbac.Checker
+	changeFeed *rbac.ChangeFeed
+	extAuthz   *extauthz.Server
+	authz      *oauthserver.AuthorizationServer
+}
+
+// New connects to the database, migrates and seeds it, and wires every authlayer
+// component. Nothing listens until Run is called.
+func New(cfg *Config, opts ...Option) (*App, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	// 1. Initialize logger
+	logger := o.logger
+	if logger == nil {
+		var err error
+		if cfg.Environment == "production" {
+			logger, err = zap.NewProduction()
+		} else {
+			logger, err = zap.NewDevelopment()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("create logger: %w", err)
+		}
+	}
+
+	// 2. Connect to database
+	db := o.db
+	if db == nil {
+		var err error
+		if db, err = database.New(cfg, logger); err != nil {
+			return nil, fmt.Errorf("connect to database: %w", err)
+		}
+	}
+
+	// 3. Run migrations
+	if err := database.Migrate(db); err != nil {
+		return nil, fmt.Errorf("run migrations: %w", err)
+	}
+	logger.Info("database migrations completed")
+
+	// 3b. Seed default roles and permissions, then the embedder's
+	if err := migrations.Seed(db, logger); err != nil {
+		return nil, fmt.Errorf("seed data: %w", err)
+	}
+	changedRoles, err := migrations.SeedPermissions(db, logger, o.permissions)
+	if err != nil {
+		return nil, fmt.Errorf("seed permissions: %w", err)
+	}
+
+	// 4. Create repositories
+	repos := defaultRepositories(db)
+	repos.merge(o.repos)
+
+	// 5. Create auth subsystem
+	jwtManager, err := auth.NewJWTManager(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("create JWT manager: %w", err)
+	}
+	// Request signing needs the key secrets back, so it is only enabled with a key to seal them
+	var secretBox *auth.SecretBox
+	if cfg.ServiceKeyEncryptionKey != "" {
+		if secretBox, err = auth.NewSecretBox(cfg.ServiceKeyEncryptionKey); err != nil {
+			return nil, fmt.Errorf("invalid SERVICE_KEY_ENCRYPTION_KEY: %w", err)
+		}
+	}
+
+	// 6. Create OAuth registry and register providers
+	oauthRegistry := oauth.NewRegistry()
+	for name, providerCfg := range cfg.OAuthProviders {
+		switch name {
+		case "google":
+			p, err := oauth.NewGoogleProvider(context.Background(), providerCfg)
+			if err != nil {
+				logger.Warn("failed to initialize Google OAuth provider", zap.Error(err))
+			} else {
+				oauthRegistry.Register(p)
+				logger.Info("registered OAuth provider", zap.String("provider", "google"))
+			}
+		case "github":
+			p := oauth.NewGitHubProvider(providerCfg)
+			oauthRegistry.Register(p)
+			logger.Info("registered OAuth provider", zap.String("provider", "github"))
+		default:
+			// Try as generic OIDC provider
+			p, err := oauth.NewOIDCProvider(context.Background(), name, providerCfg)
+			if err != nil {
+				logger.Warn("failed to initialize OIDC provider", zap.String("name", name), zap.Error(err))
+			} else {
+				oauthRegistry.Register(p)
+				logger.Info("registered OAuth provider", zap.String("provider", name))
+			}
+		}
+	}
+	for _, p := range o.oauthProviders {
+		oauthRegistry.Register(p)
+		logger.Info("registered OAuth provider", zap.String("provider", p.Name()))
+	}
+
+	// 7. Create RBAC engine
+	var rbacCache rbac.Cache
+	var nonceStore middleware.NonceStore
+	switch cfg.CacheBackend {
+	case "redis":
+		redisOpts, err := redis.ParseURL(cfg.RedisURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid REDIS_URL: %w", err)
+		}
+		redisClient := redis.NewClient(redisOpts)
+		rbacCache = rbac.NewRedisCache(redisClient, cfg.CacheTTL, logger)
+		nonceStore = middleware.NewRedisNonceStore(redisClient)
+	default:
+		rbacCache = rbac.NewMemoryCache(cfg.CacheTTL, cfg.CacheMaxEntries)
+		nonceStore = middleware.NewMemoryNonceStore()
+	}
+	rbacResolver := rbac.NewResolver(repos.RolePermissions, repos.Users, repos.ServiceAccounts, rbacCache)
+	var invalidationBus rbac.InvalidationBus
+	if cfg.CacheInvalidationBus == "postgres" {
+		invalidationBus = rbac.NewPostgresBus(db, cfg.DatabaseURL, logger)
+	}
+	var materializer *rbac.Materializer
+	var changeFeed *rbac.ChangeFeed
+	if cfg.MaterializedPermissions {
+		changeFeed = rbac.NewChangeFeed(repos.PermissionChanges, logger)
+		materializer = rbac.NewMaterializer(rbacResolver, repos.EffectivePerms, repos.Transactor, changeFeed, logger)
+	}
+	rbacChecker := rbac.NewChecker(rbacResolver, materializer, invalidationBus, logger)
+	constraintEnforcer := rbac.NewConstraintEnforcer(repos.RoleConstraints, repos.Roles, repos.OrganizationMembers, repos.TeamMembers, repos.ServiceAccountRoles, repos.ProjectMembers, repos.Transactor)
+
+	// Roles that gained seeded permissions must not be served from stale caches or rows
+	err = rbacChecker.Update(context.Background(), func(_ context.Context, changes *rbac.Changes) error {
+		for _, roleID := range changedRoles {
+			changes.Role(roleID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh seeded roles: %w", err)
+	}
+
+	// 7b. Create ReBAC engine
+	rebacEngine := rebac.NewEngine(repos.RelationNamespaces, repos.RelationTuples, repos.OrganizationMembers, repos.TeamMembers)
+
+	// 8. Create services
+	services := server.Services{
+		Auth:           service.NewAuthService(repos.Users, repos.Accounts, repos.Sessions, jwtManager, oauthRegistry, logger),
+		User:           service.NewUserService(repos.Users, repos.Sessions, repos.OrganizationMembers, rbacChecker, logger),
+		Organization:   service.NewOrganizationService(repos.Organizations, repos.OrganizationMembers, repos.TeamMembers, repos.ServiceAccounts, repos.ServiceAccountRoles, repos.Roles, repos.Invitations, repos.Users, rbacChecker, constraintEnforcer, logger),
+		Team:           service.NewTeamService(repos.Teams, repos.TeamMembers, rbacChecker, constraintEnforcer, logger),
+		RBAC:           service.NewRBACService(repos.Roles, repos.Permissions, repos.RolePermissions, repos.OrganizationMembers, repos.TeamMembers, repos.ServiceAccounts, repos.RoleConstraints, repos.LabelRoleBindings, rbacChecker, constraintEnforcer, changeFeed, logger),
+		APIKey:         service.NewAPIKeyService(repos.APIKeys, logger),
+		ServiceAccount: service.NewServiceAccountService(repos.ServiceAccounts, repos.ServiceAccountKeys, repos.ServiceAccountRoles, repos.WorkloadIdentityTrusts, repos.Roles, secretBox, rbacChecker, constraintEnforcer, logger),
+		Relation:       service.NewRelationService(repos.RelationNamespaces, rebacEngine, logger),
+		Project:        service.NewProjectService(repos.Projects, repos.ProjectMembers, repos.Teams, repos.TeamMembers, repos.ServiceAccounts, repos.Roles, rbacChecker, constraintEnforcer, logger),
+		OAuth:          service.NewOAuthService(repos.OAuthClients, repos.OAuthConsents, repos.OAuthDeviceCodes, repos.Sessions, jwtManager.Issuer(), rbacChecker, logger),
+	}
+
+	// 9. Create interceptors
+	authInterceptor := middleware.NewAuthInterceptor(jwtManager, repos.APIKeys, repos.ServiceAccountKeys, secretBox, nonceStore, cfg.RequestSigningClockSkew, append(publicMethods, o.publicMethods...))
+
+	requirements := make(map[string]middleware.PermissionRequirement, len(methodPerms)+len(o.methodPerms))
+	for method, perm := range methodPerms {
+		requirements[method] = middleware.PermissionRequirement{Permission: perm}
+	}
+	for method, perm := range o.methodPerms {
+		requirements[method] = middleware.PermissionRequirement{Permission: perm}
+	}
+	rbacInterceptor := middleware.NewRBACInterceptor(rbacChecker, requirements)
+
+	// 10. Create server
+	serverOpts := server.ServerOptions(cfg, logger, authInterceptor, rbacInterceptor, o.unary, o.stream)
+	newGRPCServer := o.newGRPCServer
+	if newGRPCServer == nil {
+		newGRPCServer = grpc.NewServer
+	}
+	grpcServer := newGRPCServer(serverOpts...)
+	srv := server.New(cfg, logger, grpcServer, o.httpMux, authInterceptor, services)
+	if cfg.OPABundleToken != "" {
+		srv.HandleHTTP("/opa/bundles/authlayer.tar.gz", opa.NewBundleHandler(repos.RBACSnapshots, cfg.OPABundleToken, cfg.OPABundleCacheTTL, logger))
+	}
+
+	// 10b. Create proxy authorization endpoints
+	proxyAudience := cfg.ProxyAudience
+	if proxyAudience == "" {
+		proxyAudience = authInterceptor.Audience()
+	}
+	proxyAuthorizer := proxyauth.NewAuthorizer(authInterceptor, rbacChecker, rbacResolver, proxyAudience)
+	srv.HandleHTTP("/auth/verify", proxyauth.NewForwardAuthHandler(
+		proxyAuthorizer, cfg.ForwardAuthRules, cfg.ProxyOrgHeader, cfg.ForwardAuthCookie, cfg.ForwardAuthCacheTTL, logger,
+	))
+	var extAuthzSrv *extauthz.Server
+	if cfg.ExtAuthzPort != 0 {
+		extAuthzSrv = extauthz.NewServer(proxyAuthorizer, cfg.ExtAuthzRules, cfg.ProxyOrgHeader, logger)
+	}
+
+	if cfg.K8sWebhookToken != "" {
+		// The prefix is what tells authlayer's users apart from other authenticators' users
+		if cfg.K8sGroupPrefix == "" {
+			return nil, errors.New("K8S_GROUP_PREFIX must not be empty when the Kubernetes webhooks are enabled")
+		}
+		k8sWebhook := k8swebhook.NewHandler(
+			authInterceptor, rbacChecker, repos.Users, repos.Organizations, repos.OrganizationMembers, repos.TeamMembers, repos.ServiceAccounts,
+			cfg.K8sAuthzRules, cfg.K8sWebhookToken, cfg.K8sGroupPrefix, logger,
+		)
+		srv.HandleHTTP("/k8s/tokenreview", http.HandlerFunc(k8sWebhook.TokenReview))
+		srv.HandleHTTP("/k8s/subjectaccessreview", http.HandlerFunc(k8sWebhook.SubjectAccessReview))
+	}
+
+	// 10c. Create token verification endpoints for downstream services
+	srv.HandleHTTP("/.well-known/jwks.json", oauthserver.NewJWKSHandler(jwtManager))
+	srv.HandleHTTP("/oauth/introspect", oauthserver.NewIntrospectionHandler(authInterceptor, jwtManager))
+
+	// 10d. Create OAuth 2.0 authorization server and OpenID Connect endpoints
+	authzServer := oauthserver.NewAuthorizationServer(
+		cfg, authInterceptor, jwtManager,
+		repos.OAuthClients, repos.OAuthAuthorizationCodes, repos.OAuthDeviceCodes, repos.OAuthConsents, repos.Sessions, repos.Users,
+		repos.OrganizationMembers, repos.ServiceAccounts, repos.ServiceAccountKeys, repos.WorkloadIdentityTrusts, logger,
+	)
+	srv.HandleHTTP("/oauth/authorize", http.HandlerFunc(authzServer.Authorize))
+	srv.HandleHTTP("/oauth/token", http.HandlerFunc(authzServer.Token))
+	srv.HandleHTTP("/.well-known/oauth-authorization-server", http.HandlerFunc(authzServer.Discovery))
+	if authzServer.OIDCEnabled() {
+		srv.HandleHTTP("/userinfo", http.HandlerFunc(authzServer.UserInfo))
+		srv.HandleHTTP("/.well-known/openid-configuration", http.HandlerFunc(authzServer.Discovery))
+	} else {
+		logger.Warn("OpenID Connect disabled: JWT_SIGNING_KEY must hold an asymmetric key to sign ID tokens")
+	}
+	if authzServer.DeviceFlowEnabled() {
+		srv.HandleHTTP("/oauth/device/code", http.HandlerFunc(authzServer.DeviceAuthorization))
+	}
+
+	return &App{
+		cfg:        cfg,
+		logger:     logger,
+		db:         db,
+		repos:      repos,
+		grpcServer: grpcServer,
+		srv:        srv,
+		checker:    rbacChecker,
+		changeFeed: changeFeed,
+		extAuthz:   extAuthzSrv,
+		authz:      authzServer,
+	}, nil
+}
+
+// GRPCServer returns the gRPC server, on which additional services may be registered
+// before Run.
+func (a *App) GRPCServer() *grpc.Server {
+	return a.grpcServer
+}
+
+// HandleHTTP registers an HTTP handler alongside the gateway routes. It must be called
+// before Run.
+func (a *App) HandleHTTP(pattern string, handler http.Handler) {
+	a.srv.HandleHTTP(pattern, handler)
+}
+
+// Logger returns the logger in use.
+func (a *App) Logger() *zap.Logger {
+	return a.logger
+}
+
+// DB returns the database connection.
+func (a *App) DB() *gorm.DB {
+	return a.db
+}
+
+// Repositories returns the repositories in use, including any overrides.
+func (a *App) Repositories() Repositories {
+	return a.repos
+}
+
+// Run serves gRPC, the HTTP gateway and the optional ext_authz server until ctx is done,
+// then stops them gracefully.
+func (a *App) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Apply permission cache invalidations from other instances, and prune the change feed
+	// and expired OAuth codes
+	go func() {
+		if err := a.checker.ListenForInvalidations(ctx); err != nil {
+			a.logger.Error("cache invalidation listener stopped", zap.Error(err))
+		}
+	}()
+	if a.changeFeed != nil {
+		go a.changeFeed.RunPruner(ctx, a.cfg.PermissionChangeRetention)
+	}
+	go a.authz.RunPruner(ctx)
+
+	extAuthzErr := make(chan error, 1)
+	if a.extAuthz != nil {
+		go func() {
+			if err := a.extAuthz.Start(a.cfg.ExtAuthzPort); err != nil {
+				extAuthzErr <- fmt.Errorf("ext_authz server failed: %w", err)
+				cancel()
+			}
+		}()
+	}
+
+	// Handle graceful shutdown
+	go func() {
+		<-ctx.Done()
+		a.logger.Info("shutting down")
+		if a.extAuthz != nil {
+			a.extAuthz.GracefulStop()
+		}
+		a.srv.GracefulStop()
+	}()
+
+	if err := a.srv.Start(); err != nil {
+		return err
+	}
+	select {
+	case err := <-extAuthzErr:
+		return err
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,100 @@
+package authlayer
+
+import (
+	"net/http"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+)
+
+// Option customizes how New assembles the server.
+type Option func(*options)
+
+type options struct {
+	logger         *zap.Logger
+	db             *gorm.DB
+	repos          Repositories
+	oauthProviders []OAuthProvider
+	unary          []grpc.UnaryServerInterceptor
+	stream         []grpc.StreamServerInterceptor
+	permissions    []PermissionSeed
+	methodPerms    map[string]string
+	publicMethods  []string
+	newGRPCServer  func(opts ...grpc.ServerOption) *grpc.Server
+	httpMux        *http.ServeMux
+}
+
+// WithLogger sets the logger. By default a zap production logger is used when
+// cfg.Environment is "production" and a development logger otherwise.
+func WithLogger(logger *zap.Logger) Option {
+	return func(o *options) { o.logger = logger }
+}
+
+// WithDB uses an existing database connection instead of opening cfg.DatabaseURL.
+// Migrations and seeds still run against it.
+func WithDB(db *gorm.DB) Option {
+	return func(o *options) { o.db = db }
+}
+
+// WithRepositories replaces the default GORM repositories with the non-nil fields of
+// repos. It may be given more than once; later non-nil fields win.
+func WithRepositories(repos Repositories) Option {
+	return func(o *options) { o.repos.merge(repos) }
+}
+
+// WithOAuthProvider registers an OAuth provider in addition to those configured in
+// OAUTH_PROVIDERS. A provider with the same name replaces the configured one.
+func WithOAuthProvider(provider OAuthProvider) Option {
+	return func(o *options) { o.oauthProviders = append(o.oauthProviders, provider) }
+}
+
+// WithUnaryInterceptors appends unary interceptors. They run after authentication and
+// authorization, so the context carries the caller's identity.
+func WithUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) Option {
+	return func(o *options) { o.unary = append(o.unary, interceptors...) }
+}
+
+// WithStreamInterceptors appends stream interceptors. They run after authentication and
+// authorization, so the context carries the caller's identity.
+func WithStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) Option {
+	return func(o *options) { o.stream = append(o.stream, interceptors...) }
+}
+
+// WithPermissions seeds additional permissions at startup and grants them to the named
+// system roles.
+func WithPermissions(seeds ...PermissionSeed) Option {
+	return func(o *options) { o.permissions = append(o.permissions, seeds...) }
+}
+
+// WithMethodPermissions requires a permission for each gRPC full method name, for
+// services the caller registers on GRPCServer. Like authlayer's own method permissions,
+// they are checked outside any org.
+func WithMethodPermissions(methodPerms map[string]string) Option {
+	return func(o *options) {
+		if o.methodPerms == nil {
+			o.methodPerms = make(map[string]string)
+		}
+		for method, perm := range methodPerms {
+			o.methodPerms[method] = perm
+		}
+	}
+}
+
+// WithPublicMethods lets the given gRPC full method names through without credentials.
+func WithPublicMethods(methods ...string) Option {
+	return func(o *options) { o.publicMethods = append(o.publicMethods, methods...) }
+}
+
+// WithGRPCServer creates the gRPC server with newServer, which must pass authlayer's
+// server options through to grpc.NewServer. Use it to add options such as TLS
+// credentials or keepalive settings.
+func WithGRPCServer(newServer func(opts ...grpc.ServerOption) *grpc.Server) Option {
+	return func(o *options) { o.newGRPCServer = newServer }
+}
+
+// WithServeMux mounts the gateway and authlayer's HTTP endpoints on mux instead of
+// listening on cfg.HTTPPort. Serving mux is left to the caller.
+func WithServeMux(mux *http.ServeMux) Option {
+	return func(o *options) { o.httpMux = mux }
+}
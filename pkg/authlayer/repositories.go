@@ -0,0 +1,125 @@
+package authlayer
+
+import (
+	"github.com/bernardoforcillo/authlayer/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// Repositories holds the data access layer. Fields left nil when passed to
+// WithRepositories keep the default GORM implementation.
+type Repositories struct {
+	Users               UserRepository
+	Accounts            AccountRepository
+	Sessions            SessionRepository
+	APIKeys             APIKeyRepository
+	Organizations       OrganizationRepository
+	OrganizationMembers OrganizationMemberRepository
+	Teams               TeamRepository
+	TeamMembers         TeamMemberRepository
+	Projects            ProjectRepository
+	ProjectMembers      ProjectMemberRepository
+	Roles               RoleRepository
+	Permissions         PermissionRepository
+	RolePermissions     RolePermissionRepository
+	Invitations         InvitationRepository
+	ServiceAccounts     ServiceAccountRepository
+	ServiceAccountKeys  ServiceAccountKeyRepository
+	ServiceAccountRoles ServiceAccountRoleRepository
+	RoleConstraints     RoleConstraintRepository
+	LabelRoleBindings   LabelRoleBindingRepository
+	RelationNamespaces  RelationNamespaceRepository
+	RelationTuples      RelationTupleRepository
+	EffectivePerms      EffectivePermissionRepository
+	PermissionChanges   PermissionChangeRepository
+	RBACSnapshots       RBACSnapshotRepository
+
+	OAuthClients            OAuthClientRepository
+	OAuthAuthorizationCodes OAuthAuthorizationCodeRepository
+	OAuthDeviceCodes        OAuthDeviceCodeRepository
+	OAuthConsents           OAuthConsentRepository
+	WorkloadIdentityTrusts  WorkloadIdentityTrustRepository
+
+	// Transactor spans writes that must commit together, such as a role assignment and
+	// its separation-of-duties check; custom repositories must join its transactions.
+	Transactor Transactor
+}
+
+// merge copies the non-nil fields of other into r.
+func (r *Repositories) merge(other Repositories) {
+	set(&r.Users, other.Users)
+	set(&r.Accounts, other.Accounts)
+	set(&r.Sessions, other.Sessions)
+	set(&r.APIKeys, other.APIKeys)
+	set(&r.Organizations, other.Organizations)
+	set(&r.OrganizationMembers, other.OrganizationMembers)
+	set(&r.Teams, other.Teams)
+	set(&r.TeamMembers, other.TeamMembers)
+	set(&r.Projects, other.Projects)
+	set(&r.ProjectMembers, other.ProjectMembers)
+	set(&r.Roles, other.Roles)
+	set(&r.Permissions, other.Permissions)
+	set(&r.RolePermissions, other.RolePermissions)
+	set(&r.Invitations, other.Invitations)
+	set(&r.ServiceAccounts, other.ServiceAccounts)
+	set(&r.ServiceAccountKeys, other.ServiceAccountKeys)
+	set(&r.ServiceAccountRoles, other.ServiceAccountRoles)
+	set(&r.RoleConstraints, other.RoleConstraints)
+	set(&r.LabelRoleBindings, other.LabelRoleBindings)
+	set(&r.RelationNamespaces, other.RelationNamespaces)
+	set(&r.RelationTuples, other.RelationTuples)
+	set(&r.EffectivePerms, other.EffectivePerms)
+	set(&r.PermissionChanges, other.PermissionChanges)
+	set(&r.RBACSnapshots, other.RBACSnapshots)
+	set(&r.OAuthClients, other.OAuthClients)
+	set(&r.OAuthAuthorizationCodes, other.OAuthAuthorizationCodes)
+	set(&r.OAuthDeviceCodes, other.OAuthDeviceCodes)
+	set(&r.OAuthConsents, other.OAuthConsents)
+	set(&r.WorkloadIdentityTrusts, other.WorkloadIdentityTrusts)
+	set(&r.Transactor, other.Transactor)
+}
+
+// defaultRepositories returns the GORM implementations backed by db.
+func defaultRepositories(db *gorm.DB) Repositories {
+	return Repositories{
+		Users:               repository.NewUserRepository(db),
+		Accounts:            repository.NewAccountRepository(db),
+		Sessions:            repository.NewSessionRepository(db),
+		APIKeys:             repository.NewAPIKeyRepository(db),
+		Organizations:       repository.NewOrganizationRepository(db),
+		OrganizationMembers: repository.NewOrganizationMemberRepository(db),
+		Teams:               repository.NewTeamRepository(db),
+		TeamMembers:         repository.NewTeamMemberRepository(db),
+		Projects:            repository.NewProjectRepository(db),
+		ProjectMembers:      repository.NewProjectMemberRepository(db),
+		Roles:               repository.NewRoleRepository(db),
+		Permissions:         repository.NewPermissionRepository(db),
+		RolePermissions:     repository.NewRolePermissionRepository(db),
+		Invitations:         repository.NewInvitationRepository(db),
+		ServiceAccounts:     repository.NewServiceAccountRepository(db),
+		ServiceAccountKeys:  repository.NewServiceAccountKeyRepository(db),
+		ServiceAccountRoles: repository.NewServiceAccountRoleRepository(db),
+		RoleConstraints:     repository.NewRoleConstraintRepository(db),
+		LabelRoleBindings:   repository.NewLabelRoleBindingRepository(db),
+		RelationNamespaces:  repository.NewRelationNamespaceRepository(db),
+		RelationTuples:      repository.NewRelationTupleRepository(db),
+		EffectivePerms:      repository.NewEffectivePermissionRepository(db),
+		PermissionChanges:   repository.NewPermissionChangeRepository(db),
+		RBACSnapshots:       repository.NewRBACSnapshotRepository(db),
+
+		OAuthClients:            repository.NewOAuthClientRepository(db),
+		OAuthAuthorizationCodes: repository.NewOAuthAuthorizationCodeRepository(db),
+		OAuthDeviceCodes:        repository.NewOAuthDeviceCodeRepository(db),
+		OAuthConsents:           repository.NewOAuthConsentRepository(db),
+		WorkloadIdentityTrusts:  repository.NewWorkloadIdentityTrustRepository(db),
+
+		Transactor: repository.NewTransactor(db),
+	}
+}
+
+func set[T comparable](dst *T, v T) {
+	var zero T
+	if v != zero {
+		*dst = v
+	}
+}
@@ -0,0 +1,117 @@
+package authlayer
+
+import (
+	"github.com/bernardoforcillo/authlayer/internal/config"
+	"github.com/bernardoforcillo/authlayer/internal/labels"
+	"github.com/bernardoforcillo/authlayer/internal/model"
+	"github.com/bernardoforcillo/authlayer/internal/oauth"
+	"github.com/bernardoforcillo/authlayer/internal/repository"
+	"github.com/bernardoforcillo/authlayer/migrations"
+)
+
+// The aliases below let code outside this module name the types it needs to configure
+// authlayer, implement custom repositories and add OAuth providers.
+
+// Config is the server configuration, normally loaded from the environment by LoadConfig.
+type Config = config.Config
+
+// OAuthProviderConfig configures a built-in OAuth or OIDC provider.
+type OAuthProviderConfig = config.OAuthProviderConfig
+
+// OAuthProvider is a pluggable upstream identity provider.
+type OAuthProvider = oauth.Provider
+
+// OAuthUserInfo is the normalized user information an OAuthProvider returns.
+type OAuthUserInfo = oauth.UserInfo
+
+// PermissionSeed is an additional permission created at startup and granted to system
+// roles.
+type PermissionSeed = migrations.PermissionSeed
+
+// Repository interfaces.
+type (
+	UserRepository                = repository.UserRepository
+	AccountRepository             = repository.AccountRepository
+	SessionRepository             = repository.SessionRepository
+	APIKeyRepository              = repository.APIKeyRepository
+	OrganizationRepository        = repository.OrganizationRepository
+	OrganizationMemberRepository  = repository.OrganizationMemberRepository
+	TeamRepository                = repository.TeamRepository
+	TeamMemberRepository          = repository.TeamMemberRepository
+	ProjectRepository             = repository.ProjectRepository
+	ProjectMemberRepository       = repository.ProjectMemberRepository
+	RoleRepository                = repository.RoleRepository
+	PermissionRepository          = repository.PermissionRepository
+	RolePermissionRepository      = repository.RolePermissionRepository
+	InvitationRepository          = repository.InvitationRepository
+	ServiceAccountRepository      = repository.ServiceAccountRepository
+	ServiceAccountKeyRepository   = repository.ServiceAccountKeyRepository
+	ServiceAccountRoleRepository  = repository.ServiceAccountRoleRepository
+	RoleConstraintRepository      = repository.RoleConstraintRepository
+	LabelRoleBindingRepository    = repository.LabelRoleBindingRepository
+	RelationNamespaceRepository   = repository.RelationNamespaceRepository
+	RelationTupleRepository       = repository.RelationTupleRepository
+	EffectivePermissionRepository = repository.EffectivePermissionRepository
+	PermissionChangeRepository    = repository.PermissionChangeRepository
+	RBACSnapshotRepository        = repository.RBACSnapshotRepository
+
+	OAuthClientRepository            = repository.OAuthClientRepository
+	OAuthAuthorizationCodeRepository = repository.OAuthAuthorizationCodeRepository
+	OAuthDeviceCodeRepository        = repository.OAuthDeviceCodeRepository
+	OAuthConsentRepository           = repository.OAuthConsentRepository
+	WorkloadIdentityTrustRepository  = repository.WorkloadIdentityTrustRepository
+	Transactor                       = repository.Transactor
+)
+
+// Types used in repository method signatures.
+type (
+	Pagination             = repository.Pagination
+	UserFilter             = repository.UserFilter
+	RoleSeed               = repository.RoleSeed
+	RoleGrant              = repository.RoleGrant
+	PrincipalRef           = repository.PrincipalRef
+	PermissionChangeFilter = repository.PermissionChangeFilter
+	RolePermissionName     = repository.RolePermissionName
+	RBACSnapshot           = repository.RBACSnapshot
+	LabelSelector          = labels.Selector
+	Labels                 = model.Labels
+)
+
+// Models.
+type (
+	Base                = model.Base
+	User                = model.User
+	UserStatus          = model.UserStatus
+	Account             = model.Account
+	Session             = model.Session
+	APIKey              = model.APIKey
+	Organization        = model.Organization
+	OrganizationMember  = model.OrganizationMember
+	Team                = model.Team
+	TeamMember          = model.TeamMember
+	Project             = model.Project
+	ProjectMember       = model.ProjectMember
+	Role                = model.Role
+	Permission          = model.Permission
+	RolePermission      = model.RolePermission
+	Invitation          = model.Invitation
+	InvitationStatus    = model.InvitationStatus
+	ServiceAccount      = model.ServiceAccount
+	ServiceAccountKey   = model.ServiceAccountKey
+	ServiceAccountRole  = model.ServiceAccountRole
+	RoleConstraint      = model.RoleConstraint
+	LabelRoleBinding    = model.LabelRoleBinding
+	RelationNamespace   = model.RelationNamespace
+	RelationTuple       = model.RelationTuple
+	EffectivePermission = model.EffectivePermission
+	PermissionChange    = model.PermissionChange
+	PrincipalType       = model.PrincipalType
+	ScopeType           = model.ScopeType
+
+	OAuthClient            = model.OAuthClient
+	OAuthAuthorizationCode = model.OAuthAuthorizationCode
+	OAuthDeviceCode        = model.OAuthDeviceCode
+	OAuthConsent           = model.OAuthConsent
+	StringList             = model.StringList
+	WorkloadIdentityTrust  = model.WorkloadIdentityTrust
+)
@@ -0,0 +1,173 @@
+package authlayerverify
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	defaultIntrospectionCacheTTL = 30 * time.Second
+	maxCacheEntries              = 10000
+)
+
+type introspectionResponse struct {
+	Active        bool             `json:"active"`
+	TokenType     string           `json:"token_type"`
+	Subject       string           `json:"sub"`
+	PrincipalType string           `json:"principal_type"`
+	Email         string           `json:"email"`
+	Scope         string           `json:"scope"`
+	Audience      jwt.ClaimStrings `json:"aud"`
+	Issuer        string           `json:"iss"`
+	ExpiresAt     int64            `json:"exp"`
+	Actor         *struct {
+		Subject string `json:"sub"`
+	} `json:"act"`
+}
+
+type cachedPrincipal struct {
+	principal *Principal
+	expiresAt time.Time
+}
+
+// IntrospectionVerifier verifies any authlayer credential through the /oauth/introspect
+// endpoint. Active results are cached briefly, never past the credential's expiry.
+type IntrospectionVerifier struct {
+	url        string
+	credential string
+	audience   string
+	opts       verifierOptions
+
+	mu    sync.Mutex
+	cache map[string]cachedPrincipal
+}
+
+// NewIntrospectionVerifier creates a verifier for the introspection endpoint at
+// introspectURL. credential is the Authorization header value the calling service
+// authenticates with, typically "ServiceKey <key>". Access tokens are only accepted when
+// issued for audience, as with NewJWKSVerifier; API keys and service account credentials
+// carry no audience.
+func NewIntrospectionVerifier(introspectURL, credential, audience string, opts ...VerifierOption) *IntrospectionVerifier {
+	return &IntrospectionVerifier{
+		url:        introspectURL,
+		credential: credential,
+		audience:   audience,
+		opts:       newVerifierOptions(opts),
+		cache:      make(map[string]cachedPrincipal),
+	}
+}
+
+// Verify implements Verifier.
+func (v *IntrospectionVerifier) Verify(ctx context.Context, authorization string) (*Principal, error) {
+	scheme, token, ok := splitAuthorization(authorization)
+	if !ok {
+		return nil, ErrMissingCredential
+	}
+	switch scheme {
+	case "Bearer", "ApiKey", "ServiceKey", "ServiceAssertion":
+	default:
+		return nil, ErrUnsupportedCredential
+	}
+
+	sum := sha256.Sum256([]byte(authorization))
+	key := hex.EncodeToString(sum[:])
+	if p, ok := v.cached(key); ok {
+		return p, nil
+	}
+
+	form := url.Values{"token": {token}, "token_type_hint": {scheme}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", v.credential)
+
+	resp, err := v.opts.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("authlayerverify: introspect: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("authlayerverify: introspect: unexpected status %s", resp.Status)
+	}
+
+	var result introspectionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("authlayerverify: decode introspection response: %w", err)
+	}
+	if !result.Active || !v.intendedForUs(result) {
+		return nil, ErrInvalidCredential
+	}
+
+	p := &Principal{
+		Type:   PrincipalType(result.PrincipalType),
+		ID:     result.Subject,
+		Email:  result.Email,
+		Scopes: strings.Fields(result.Scope),
+	}
+	if result.ExpiresAt != 0 {
+		p.ExpiresAt = time.Unix(result.ExpiresAt, 0)
+	}
+	if result.Actor != nil {
+		p.ActorID = result.Actor.Subject
+	}
+	v.store(key, p)
+	return p, nil
+}
+
+// intendedForUs reports whether an active credential was issued for this service. Access
+// tokens must name its audience; any credential that carries an audience must include it.
+func (v *IntrospectionVerifier) intendedForUs(result introspectionResponse) bool {
+	bearer := result.TokenType == "Bearer"
+	if v.opts.issuer != "" && (bearer || result.Issuer != "") && result.Issuer != v.opts.issuer {
+		return false
+	}
+	if !bearer && len(result.Audience) == 0 {
+		return true
+	}
+	return v.audience != "" && slices.Contains(result.Audience, v.audience)
+}
+
+func (v *IntrospectionVerifier) cached(key string) (*Principal, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	entry, ok := v.cache[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.principal, true
+}
+
+func (v *IntrospectionVerifier) store(key string, p *Principal) {
+	if v.opts.cacheTTL <= 0 {
+		return
+	}
+	now := time.Now()
+	expiresAt := now.Add(v.opts.cacheTTL)
+	if !p.ExpiresAt.IsZero() && p.ExpiresAt.Before(expiresAt) {
+		expiresAt = p.ExpiresAt
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if len(v.cache) >= maxCacheEntries {
+		for k, entry := range v.cache {
+			if now.After(entry.expiresAt) {
+				delete(v.cache, k)
+			}
+		}
+	}
+	v.cache[key] = cachedPrincipal{principal: p, expiresAt: expiresAt}
+}
@@ -0,0 +1,286 @@
+package authlayerverify
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	defaultJWKSRefresh = 10 * time.Minute
+	// minJWKSRefetch bounds how often an unknown kid can trigger a refetch.
+	minJWKSRefetch = 30 * time.Second
+)
+
+// VerifierOption configures a JWKSVerifier or IntrospectionVerifier.
+type VerifierOption func(*verifierOptions)
+
+type verifierOptions struct {
+	httpClient *http.Client
+	refresh    time.Duration
+	cacheTTL   time.Duration
+	issuer     string
+}
+
+// WithHTTPClient sets the client used to reach authlayer.
+func WithHTTPClient(client *http.Client) VerifierOption {
+	return func(o *verifierOptions) { o.httpClient = client }
+}
+
+// WithRefreshInterval sets how long fetched signing keys are used before refetching.
+func WithRefreshInterval(d time.Duration) VerifierOption {
+	return func(o *verifierOptions) { o.refresh = d }
+}
+
+// WithCacheTTL sets how long introspection results are reused. Zero disables caching.
+func WithCacheTTL(d time.Duration) VerifierOption {
+	return func(o *verifierOptions) { o.cacheTTL = d }
+}
+
+// WithIssuer requires credentials to have been issued by issuer, authlayer's
+// OAUTH_ISSUER.
+func WithIssuer(issuer string) VerifierOption {
+	return func(o *verifierOptions) { o.issuer = issuer }
+}
+
+func newVerifierOptions(opts []VerifierOption) verifierOptions {
+	o := verifierOptions{
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		refresh:    defaultJWKSRefresh,
+		cacheTTL:   defaultIntrospectionCacheTTL,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// accessClaims are the claims of an authlayer access token.
+type accessClaims struct {
+	jwt.RegisteredClaims
+	UserID    string `json:"uid"`
+	Email     string `json:"email"`
+	TokenType string `json:"type"`
+	Scope     string `json:"scope,omitempty"`
+	// Set on service account tokens
+	ServiceAccountID string `json:"sa_id,omitempty"`
+	// Set on tokens obtained by token exchange
+	Actor *struct {
+		Subject string `json:"sub"`
+	} `json:"act,omitempty"`
+}
+
+// JWKSVerifier verifies access tokens locally against authlayer's JWKS document, served at
+// /.well-known/jwks.json when authlayer signs tokens with an asymmetric key. It only handles
+// Bearer credentials.
+type JWKSVerifier struct {
+	url      string
+	audience string
+	opts     verifierOptions
+	parser   *jwt.Parser
+
+	mu          sync.Mutex
+	keys        map[string]interface{}
+	fetchedAt   time.Time
+	lastAttempt time.Time
+}
+
+// NewJWKSVerifier creates a verifier for the JWKS document at jwksURL. Only tokens issued
+// for audience, the calling service's OAuth client audience, are accepted: tokens for other
+// services and authlayer's own API are rejected. Keys are fetched on first use.
+func NewJWKSVerifier(jwksURL, audience string, opts ...VerifierOption) *JWKSVerifier {
+	o := newVerifierOptions(opts)
+	parserOpts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"RS256", "ES256", "ES384", "ES512"}),
+		jwt.WithExpirationRequired(),
+		jwt.WithAudience(audience),
+	}
+	if o.issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(o.issuer))
+	}
+	return &JWKSVerifier{
+		url:      jwksURL,
+		audience: audience,
+		opts:     o,
+		parser:   jwt.NewParser(parserOpts...),
+	}
+}
+
+// Verify implements Verifier.
+func (v *JWKSVerifier) Verify(ctx context.Context, authorization string) (*Principal, error) {
+	scheme, token, ok := splitAuthorization(authorization)
+	if !ok {
+		return nil, ErrMissingCredential
+	}
+	if scheme != "Bearer" {
+		return nil, ErrUnsupportedCredential
+	}
+	if v.audience == "" {
+		return nil, ErrInvalidCredential
+	}
+
+	var fetchErr error
+	claims := &accessClaims{}
+	_, err := v.parser.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		key, err := v.key(ctx, kid)
+		if err != nil {
+			fetchErr = err
+		}
+		return key, err
+	})
+	if fetchErr != nil {
+		return nil, fetchErr
+	}
+	if err != nil || claims.TokenType != "access" {
+		return nil, ErrInvalidCredential
+	}
+
+	p := &Principal{Type: PrincipalTypeUser, ID: claims.UserID, Email: claims.Email}
+	if claims.ServiceAccountID != "" {
+		p = &Principal{Type: PrincipalTypeServiceAccount, ID: claims.ServiceAccountID}
+	}
+	if claims.Actor != nil {
+		p.ActorID = claims.Actor.Subject
+	}
+	if p.ID == "" {
+		p.ID = claims.Subject
+	}
+	if claims.Scope != "" {
+		p.Scopes = strings.Fields(claims.Scope)
+	}
+	if claims.ExpiresAt != nil {
+		p.ExpiresAt = claims.ExpiresAt.Time
+	}
+	return p, nil
+}
+
+// key returns the verification key for kid, refetching the document when the cached one is
+// stale or does not contain it.
+func (v *JWKSVerifier) key(ctx context.Context, kid string) (interface{}, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	stale := time.Since(v.fetchedAt) > v.opts.refresh
+	key, found := v.keys[kid]
+	if found && !stale {
+		return key, nil
+	}
+	if time.Since(v.lastAttempt) < minJWKSRefetch && v.keys != nil {
+		if found {
+			return key, nil
+		}
+		return nil, ErrInvalidCredential
+	}
+
+	v.lastAttempt = time.Now()
+	keys, err := v.fetch(ctx)
+	if err != nil {
+		if found {
+			return key, nil // keep serving the stale key while authlayer is unreachable
+		}
+		return nil, err
+	}
+	v.keys, v.fetchedAt = keys, time.Now()
+	if key, ok := keys[kid]; ok {
+		return key, nil
+	}
+	return nil, ErrInvalidCredential
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (v *JWKSVerifier) fetch(ctx context.Context) (map[string]interface{}, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := v.opts.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("authlayerverify: fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("authlayerverify: fetch JWKS: unexpected status %s", resp.Status)
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("authlayerverify: decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			continue // skip keys this library cannot use rather than failing them all
+		}
+		keys[jwk.Kid] = key
+	}
+	return keys, nil
+}
+
+func (k jsonWebKey) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		point := append(append([]byte{4}, x...), y...)
+		return ecdsa.ParseUncompressedPublicKey(curve, point)
+	}
+	return nil, errors.New("unsupported key type")
+}
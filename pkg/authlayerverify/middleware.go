@@ -0,0 +1,146 @@
+package authlayerverify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultOrgHeader is the header, or lowercase gRPC metadata key, naming the org a request
+// acts in.
+const DefaultOrgHeader = "X-Org-ID"
+
+// AuthenticatorOption configures an Authenticator.
+type AuthenticatorOption func(*Authenticator)
+
+// WithOrgHeader sets the header the org is read from.
+func WithOrgHeader(name string) AuthenticatorOption {
+	return func(a *Authenticator) { a.orgHeader = name }
+}
+
+// WithPublicMethods lists full gRPC method names that skip authentication.
+func WithPublicMethods(methods ...string) AuthenticatorOption {
+	return func(a *Authenticator) {
+		for _, m := range methods {
+			a.publicMethods[m] = true
+		}
+	}
+}
+
+// Authenticator verifies the credential of every request and stores the principal and org
+// in its context.
+type Authenticator struct {
+	verifier      Verifier
+	orgHeader     string
+	publicMethods map[string]bool
+}
+
+// NewAuthenticator creates an authenticator backed by verifier.
+func NewAuthenticator(verifier Verifier, opts ...AuthenticatorOption) *Authenticator {
+	a := &Authenticator{verifier: verifier, orgHeader: DefaultOrgHeader, publicMethods: make(map[string]bool)}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// UnaryServerInterceptor returns a gRPC unary interceptor.
+func (a *Authenticator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if a.publicMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		ctx, err := a.authenticateGRPC(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a gRPC stream interceptor.
+func (a *Authenticator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if a.publicMethods[info.FullMethod] {
+			return handler(srv, ss)
+		}
+		ctx, err := a.authenticateGRPC(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (a *Authenticator) authenticateGRPC(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx, err := a.authenticate(ctx, first(md.Get("authorization")), first(md.Get(strings.ToLower(a.orgHeader))))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return ctx, nil
+}
+
+// Middleware returns net/http middleware that rejects unauthenticated requests with 401.
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := a.authenticate(r.Context(), r.Header.Get("Authorization"), r.Header.Get(a.orgHeader))
+		if err != nil {
+			if code := status.Code(grpcError(err)); code == codes.Unauthenticated {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="authlayer"`)
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+			} else {
+				http.Error(w, "authentication unavailable", http.StatusServiceUnavailable)
+			}
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (a *Authenticator) authenticate(ctx context.Context, authorization, org string) (context.Context, error) {
+	if authorization == "" {
+		return nil, ErrMissingCredential
+	}
+	p, err := a.verifier.Verify(ctx, authorization)
+	if err != nil {
+		return nil, err
+	}
+	ctx = WithPrincipal(ctx, p)
+	if org != "" {
+		ctx = WithOrg(ctx, org)
+	}
+	return ctx, nil
+}
+
+// grpcError maps verification errors to Unauthenticated and anything else, such as
+// authlayer being unreachable, to Unavailable.
+func grpcError(err error) error {
+	switch {
+	case errors.Is(err, ErrMissingCredential), errors.Is(err, ErrUnsupportedCredential), errors.Is(err, ErrInvalidCredential):
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+	return status.Error(codes.Unavailable, "credential verification unavailable")
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
@@ -0,0 +1,154 @@
+package authlayerverify
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	authlayerv1 "github.com/bernardoforcillo/authlayer/pkg/proto/authlayer/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type cachedCheck struct {
+	allowed   bool
+	expiresAt time.Time
+}
+
+// PermissionChecker checks permissions through RBACService.CheckPermission and caches the
+// answers for a short TTL, so hot paths do not call authlayer on every request.
+type PermissionChecker struct {
+	client authlayerv1.RBACServiceClient
+	ttl    time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cachedCheck
+}
+
+// NewPermissionChecker creates a checker. client must be authenticated as the calling
+// service; answers, allowed or not, are reused for ttl.
+func NewPermissionChecker(client authlayerv1.RBACServiceClient, ttl time.Duration) *PermissionChecker {
+	return &PermissionChecker{client: client, ttl: ttl, cache: make(map[string]cachedCheck)}
+}
+
+// Check reports whether the principal holds permission in orgID, or globally when orgID is
+// empty.
+func (c *PermissionChecker) Check(ctx context.Context, p *Principal, permission, orgID string) (bool, error) {
+	key := string(p.Type) + "\x00" + p.ID + "\x00" + permission + "\x00" + orgID
+	if allowed, ok := c.cached(key); ok {
+		return allowed, nil
+	}
+
+	req := &authlayerv1.CheckPermissionRequest{UserId: p.ID, PermissionName: permission}
+	if p.Type == PrincipalTypeServiceAccount {
+		req.PrincipalType = authlayerv1.PrincipalType_PRINCIPAL_TYPE_SERVICE_ACCOUNT
+	}
+	if orgID != "" {
+		req.OrgId = &orgID
+	}
+	resp, err := c.client.CheckPermission(ctx, req)
+	if err != nil {
+		return false, err
+	}
+	c.store(key, resp.Allowed)
+	return resp.Allowed, nil
+}
+
+// Require checks that the principal in ctx holds permission in the request's org. It
+// returns a gRPC status error: Unauthenticated without a principal, PermissionDenied when
+// the permission is missing and Unavailable when authlayer cannot answer.
+func (c *PermissionChecker) Require(ctx context.Context, permission string) error {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "not authenticated")
+	}
+	allowed, err := c.Check(ctx, p, permission, OrgFromContext(ctx))
+	if err != nil {
+		return status.Error(codes.Unavailable, "permission check unavailable")
+	}
+	if !allowed {
+		return status.Errorf(codes.PermissionDenied, "permission %q denied", permission)
+	}
+	return nil
+}
+
+// RequirePermission returns net/http middleware that requires permission. It must run
+// behind Authenticator.Middleware.
+func (c *PermissionChecker) RequirePermission(permission string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := c.Require(r.Context(), permission); err != nil {
+				switch status.Code(err) {
+				case codes.Unauthenticated:
+					http.Error(w, "unauthorized", http.StatusUnauthorized)
+				case codes.PermissionDenied:
+					http.Error(w, "forbidden", http.StatusForbidden)
+				default:
+					http.Error(w, "permission check unavailable", http.StatusServiceUnavailable)
+				}
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// UnaryServerInterceptor returns a gRPC interceptor that requires the permission mapped to
+// each full method name; unmapped methods pass through. It must run after the
+// Authenticator's interceptor.
+func (c *PermissionChecker) UnaryServerInterceptor(methodPerms map[string]string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if permission, ok := methodPerms[info.FullMethod]; ok {
+			if err := c.Require(ctx, permission); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of UnaryServerInterceptor.
+func (c *PermissionChecker) StreamServerInterceptor(methodPerms map[string]string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if permission, ok := methodPerms[info.FullMethod]; ok {
+			if err := c.Require(ss.Context(), permission); err != nil {
+				return err
+			}
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (c *PermissionChecker) cached(key string) (bool, bool) {
+	if c.ttl <= 0 {
+		return false, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.cache[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return false, false
+	}
+	return entry.allowed, true
+}
+
+func (c *PermissionChecker) store(key string, allowed bool) {
+	if c.ttl <= 0 {
+		return
+	}
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.cache) >= maxCacheEntries {
+		for k, entry := range c.cache {
+			if now.After(entry.expiresAt) {
+				delete(c.cache, k)
+			}
+		}
+	}
+	c.cache[key] = cachedCheck{allowed: allowed, expiresAt: now.Add(c.ttl)}
+}
@@ -0,0 +1,126 @@
+// Package authlayerverify lets Go services authenticate requests carrying authlayer
+// credentials and check authlayer permissions, without copying authlayer internals.
+//
+// A Verifier turns an Authorization header value into a Principal: JWKSVerifier checks
+// access tokens locally against the published signing keys, and IntrospectionVerifier asks
+// authlayer about any credential, including API keys and service account keys. An
+// Authenticator wraps a Verifier as gRPC interceptors or net/http middleware, and a
+// PermissionChecker answers permission questions through RBACService.CheckPermission.
+package authlayerverify
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrMissingCredential is returned when a request carries no credential.
+	ErrMissingCredential = errors.New("authlayerverify: missing credential")
+	// ErrUnsupportedCredential is returned by verifiers for authorization schemes they do
+	// not handle, so Chain can try the next one.
+	ErrUnsupportedCredential = errors.New("authlayerverify: unsupported credential")
+	// ErrInvalidCredential is returned for credentials that are malformed, expired or revoked.
+	ErrInvalidCredential = errors.New("authlayerverify: invalid credential")
+)
+
+// PrincipalType identifies the kind of authenticated principal.
+type PrincipalType string
+
+const (
+	PrincipalTypeUser           PrincipalType = "user"
+	PrincipalTypeServiceAccount PrincipalType = "service_account"
+)
+
+// Principal is the authenticated caller.
+type Principal struct {
+	Type  PrincipalType
+	ID    string
+	Email string
+	// Scopes are the scopes the credential was issued with, if any.
+	Scopes []string
+	// ExpiresAt is when the credential expires; zero when it does not or is unknown.
+	ExpiresAt time.Time
+	// ActorID is the service account acting on the user's behalf, for tokens obtained by
+	// token exchange.
+	ActorID string
+}
+
+// HasScope reports whether the principal's credential carries scope.
+func (p *Principal) HasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Verifier authenticates an Authorization header value such as "Bearer <token>".
+type Verifier interface {
+	Verify(ctx context.Context, authorization string) (*Principal, error)
+}
+
+// Chain returns a Verifier that tries each verifier in turn, moving on only when one
+// returns ErrUnsupportedCredential. A typical chain verifies access tokens with JWKS and
+// falls back to introspection for keys.
+func Chain(verifiers ...Verifier) Verifier {
+	return chain(verifiers)
+}
+
+type chain []Verifier
+
+func (c chain) Verify(ctx context.Context, authorization string) (*Principal, error) {
+	for _, v := range c {
+		p, err := v.Verify(ctx, authorization)
+		if !errors.Is(err, ErrUnsupportedCredential) {
+			return p, err
+		}
+	}
+	return nil, ErrUnsupportedCredential
+}
+
+type contextKey int
+
+const (
+	principalKey contextKey = iota
+	orgKey
+)
+
+// WithPrincipal returns ctx carrying the principal.
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey, p)
+}
+
+// PrincipalFromContext returns the principal an Authenticator stored in ctx.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey).(*Principal)
+	return p, ok && p != nil
+}
+
+// WithOrg returns ctx carrying the org the request acts in.
+func WithOrg(ctx context.Context, orgID string) context.Context {
+	return context.WithValue(ctx, orgKey, orgID)
+}
+
+// OrgFromContext returns the org the request acts in, or "" when it names none.
+func OrgFromContext(ctx context.Context) string {
+	org, _ := ctx.Value(orgKey).(string)
+	return org
+}
+
+// ScopesFromContext returns the scopes of the request's credential.
+func ScopesFromContext(ctx context.Context) []string {
+	if p, ok := PrincipalFromContext(ctx); ok {
+		return p.Scopes
+	}
+	return nil
+}
+
+// splitAuthorization splits an Authorization header value into scheme and credential.
+func splitAuthorization(authorization string) (scheme, credential string, ok bool) {
+	scheme, credential, ok = strings.Cut(strings.TrimSpace(authorization), " ")
+	credential = strings.TrimSpace(credential)
+	return scheme, credential, ok && credential != ""
+}
@@ -0,0 +1,230 @@
+// Package client is a Go SDK for the authlayer gRPC API. It wraps every service, attaches
+// credentials to each call, keeps user sessions alive by refreshing access tokens before
+// they expire, and retries idempotent calls on transient errors.
+//
+//	c, err := client.New("authlayer.internal:50051")
+//	if err != nil { ... }
+//	defer c.Close()
+//	if _, err := c.Login(ctx, email, password); err != nil { ... }
+//	orgs, err := c.Organizations.ListOrganizations(ctx, &authlayerv1.ListOrganizationsRequest{})
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"sync"
+	"time"
+
+	authlayerv1 "github.com/bernardoforcillo/authlayer/pkg/proto/authlayer/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	defaultRefreshMargin = time.Minute
+	defaultMaxRetries    = 3
+)
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithInsecure disables transport security, for local development only.
+func WithInsecure() Option {
+	return func(c *Client) { c.insecure = true }
+}
+
+// WithTLSConfig sets the TLS configuration; by default the system roots are used.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(c *Client) { c.tlsConfig = cfg }
+}
+
+// WithAPIKey authenticates every call with a user API key.
+func WithAPIKey(key string) Option {
+	return func(c *Client) { c.creds = &staticCredentials{scheme: "ApiKey", secret: key} }
+}
+
+// WithServiceKey authenticates every call with a service account key.
+func WithServiceKey(key string) Option {
+	return func(c *Client) { c.creds = &staticCredentials{scheme: "ServiceKey", secret: key} }
+}
+
+// WithServiceAccountKeyPair authenticates every call with short-lived assertions signed by
+// the private key of a service account key pair. audience is authlayer's issuer URL.
+func WithServiceAccountKeyPair(serviceAccountID, keyID, privateKeyPEM, audience string) Option {
+	return func(c *Client) {
+		c.creds = &assertionCredentials{
+			serviceAccountID: serviceAccountID,
+			keyID:            keyID,
+			privateKey:       privateKeyPEM,
+			audience:         audience,
+		}
+	}
+}
+
+// WithSignedServiceKey signs every call with a service account key created for request
+// signing, so that the key itself is never sent.
+func WithSignedServiceKey(keyID, key string) Option {
+	return func(c *Client) { c.creds = &signedCredentials{keyID: keyID, key: key} }
+}
+
+// WithBearerToken authenticates every call with a fixed access token that is never
+// refreshed.
+func WithBearerToken(token string) Option {
+	return func(c *Client) { c.creds = &staticCredentials{scheme: "Bearer", secret: token} }
+}
+
+// WithTokens resumes a saved session; its access token is refreshed as needed.
+func WithTokens(tokens *authlayerv1.TokenPair) Option {
+	return func(c *Client) { c.resume = tokens }
+}
+
+// WithTokenRefreshHandler registers a callback invoked with every rotated token pair, so
+// callers can persist the session.
+func WithTokenRefreshHandler(fn func(*authlayerv1.TokenPair)) Option {
+	return func(c *Client) { c.onRefresh = fn }
+}
+
+// WithRefreshMargin sets how long before expiry access tokens are refreshed.
+func WithRefreshMargin(d time.Duration) Option {
+	return func(c *Client) { c.refreshMargin = d }
+}
+
+// WithMaxRetries sets how many times idempotent calls are retried on transient errors.
+func WithMaxRetries(n int) Option {
+	return func(c *Client) { c.maxRetries = n }
+}
+
+// WithDialOptions appends gRPC dial options.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(c *Client) { c.dialOptions = append(c.dialOptions, opts...) }
+}
+
+// Client is a connection to authlayer with a stub for each service.
+type Client struct {
+	Auth            authlayerv1.AuthServiceClient
+	Users           authlayerv1.UserServiceClient
+	Organizations   authlayerv1.OrganizationServiceClient
+	Teams           authlayerv1.TeamServiceClient
+	RBAC            authlayerv1.RBACServiceClient
+	APIKeys         authlayerv1.APIKeyServiceClient
+	ServiceAccounts authlayerv1.ServiceAccountServiceClient
+	Relations       authlayerv1.RelationServiceClient
+	Projects        authlayerv1.ProjectServiceClient
+	OAuth           authlayerv1.OAuthServiceClient
+
+	conn          *grpc.ClientConn
+	insecure      bool
+	tlsConfig     *tls.Config
+	resume        *authlayerv1.TokenPair
+	onRefresh     func(*authlayerv1.TokenPair)
+	refreshMargin time.Duration
+	maxRetries    int
+	dialOptions   []grpc.DialOption
+
+	mu      sync.RWMutex
+	creds   credentials.PerRPCCredentials
+	session *Session
+}
+
+// New creates a client for the authlayer gRPC endpoint at target.
+func New(target string, opts ...Option) (*Client, error) {
+	c := &Client{refreshMargin: defaultRefreshMargin, maxRetries: defaultMaxRetries}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	transport := credentials.NewTLS(c.tlsConfig)
+	if c.insecure {
+		transport = insecure.NewCredentials()
+	}
+	dialOptions := append([]grpc.DialOption{
+		grpc.WithTransportCredentials(transport),
+		grpc.WithPerRPCCredentials(&perRPCCredentials{client: c}),
+		grpc.WithChainUnaryInterceptor(c.unaryInterceptor, c.signingInterceptor),
+	}, c.dialOptions...)
+
+	conn, err := grpc.NewClient(target, dialOptions...)
+	if err != nil {
+		return nil, err
+	}
+	c.conn = conn
+	c.Auth = authlayerv1.NewAuthServiceClient(conn)
+	c.Users = authlayerv1.NewUserServiceClient(conn)
+	c.Organizations = authlayerv1.NewOrganizationServiceClient(conn)
+	c.Teams = authlayerv1.NewTeamServiceClient(conn)
+	c.RBAC = authlayerv1.NewRBACServiceClient(conn)
+	c.APIKeys = authlayerv1.NewAPIKeyServiceClient(conn)
+	c.ServiceAccounts = authlayerv1.NewServiceAccountServiceClient(conn)
+	c.Relations = authlayerv1.NewRelationServiceClient(conn)
+	c.Projects = authlayerv1.NewProjectServiceClient(conn)
+	c.OAuth = authlayerv1.NewOAuthServiceClient(conn)
+
+	if c.resume != nil {
+		c.startSession(c.resume)
+	}
+	return c, nil
+}
+
+// Conn returns the underlying connection.
+func (c *Client) Conn() *grpc.ClientConn {
+	return c.conn
+}
+
+// Close closes the connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Login signs in with email and password and authenticates later calls with the session.
+func (c *Client) Login(ctx context.Context, email, password string) (*authlayerv1.LoginResponse, error) {
+	resp, err := c.Auth.Login(ctx, &authlayerv1.LoginRequest{Email: email, Password: password})
+	if err != nil {
+		return nil, err
+	}
+	c.startSession(resp.Tokens)
+	return resp, nil
+}
+
+// Logout revokes the session's refresh token and drops the session.
+func (c *Client) Logout(ctx context.Context) error {
+	c.mu.Lock()
+	session := c.session
+	c.session, c.creds = nil, nil
+	c.mu.Unlock()
+
+	if session == nil {
+		return nil
+	}
+	tokens := session.clear()
+	if tokens == nil {
+		return nil
+	}
+	_, err := c.Auth.Logout(ctx, &authlayerv1.LogoutRequest{RefreshToken: tokens.RefreshToken})
+	return err
+}
+
+// Session returns the current user session, or nil when the client uses static
+// credentials or is logged out.
+func (c *Client) Session() *Session {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.session
+}
+
+func (c *Client) startSession(tokens *authlayerv1.TokenPair) {
+	session := &Session{auth: c.Auth, margin: c.refreshMargin, onRefresh: c.onRefresh}
+	session.set(tokens)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.session = session
+	c.creds = &sessionCredentials{session: session}
+}
+
+func (c *Client) credentials() credentials.PerRPCCredentials {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.creds
+}
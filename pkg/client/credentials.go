@@ -0,0 +1,298 @@
+package client
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	authlayerv1 "github.com/bernardoforcillo/authlayer/pkg/proto/authlayer/v1"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrNotLoggedIn is returned for authenticated calls made before a session exists.
+	ErrNotLoggedIn = errors.New("authlayer client: not logged in")
+	// ErrSessionExpired is returned once the refresh token is no longer accepted; the user
+	// must log in again.
+	ErrSessionExpired = errors.New("authlayer client: session expired")
+	// ErrSessionRevoked is returned when authlayer detected reuse of a rotated refresh
+	// token and revoked every session of the user. The session cannot be recovered.
+	ErrSessionRevoked = errors.New("authlayer client: session revoked after refresh token reuse")
+)
+
+// publicMethods are sent without credentials, as the server does not require them.
+var publicMethods = map[string]bool{
+	"/authlayer.v1.AuthService/Register":             true,
+	"/authlayer.v1.AuthService/Login":                true,
+	"/authlayer.v1.AuthService/RefreshToken":         true,
+	"/authlayer.v1.AuthService/GetOAuthURL":          true,
+	"/authlayer.v1.AuthService/OAuthCallback":        true,
+	"/authlayer.v1.AuthService/VerifyEmail":          true,
+	"/authlayer.v1.AuthService/RequestPasswordReset": true,
+	"/authlayer.v1.AuthService/ResetPassword":        true,
+	"/authlayer.v1.APIKeyService/ValidateAPIKey":     true,
+}
+
+// staticCredentials send a fixed authorization header: "Bearer", "ApiKey" or "ServiceKey".
+type staticCredentials struct {
+	scheme string
+	secret string
+}
+
+func (c *staticCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": c.scheme + " " + c.secret}, nil
+}
+
+// RequireTransportSecurity is decided by perRPCCredentials, which wraps every credential.
+func (c *staticCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+// assertionLifetime is how long a signed assertion is accepted. Each is used once, so it
+// only needs to cover the trip to the server.
+const assertionLifetime = time.Minute
+
+// assertionCredentials sign a service account assertion with the private key of one of its
+// key pairs for every call, as the server accepts each assertion's jti only once.
+type assertionCredentials struct {
+	serviceAccountID string
+	keyID            string
+	privateKey       string
+	audience         string
+}
+
+func (c *assertionCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	now := time.Now()
+	assertion, err := c.sign(jwt.RegisteredClaims{
+		Issuer:    c.serviceAccountID,
+		Subject:   c.serviceAccountID,
+		Audience:  jwt.ClaimStrings{c.audience},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(assertionLifetime)),
+		ID:        rand.Text(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"authorization": "ServiceAssertion " + assertion}, nil
+}
+
+func (c *assertionCredentials) sign(claims jwt.RegisteredClaims) (string, error) {
+	var (
+		method jwt.SigningMethod
+		key    interface{}
+	)
+	if ecKey, err := jwt.ParseECPrivateKeyFromPEM([]byte(c.privateKey)); err == nil {
+		key = ecKey
+		switch ecKey.Curve.Params().BitSize {
+		case 256:
+			method = jwt.SigningMethodES256
+		case 384:
+			method = jwt.SigningMethodES384
+		default:
+			method = jwt.SigningMethodES512
+		}
+	} else if rsaKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(c.privateKey)); err == nil {
+		key, method = rsaKey, jwt.SigningMethodRS256
+	} else {
+		return "", errors.New("authlayer client: service account private key is neither ECDSA nor RSA")
+	}
+
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = c.keyID
+	return token.SignedString(key)
+}
+
+func (c *assertionCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+// signedCredentials sign each call with a service account key, in the format the server's
+// AuthInterceptor verifies: an HMAC-SHA256 over the method, path, timestamp, nonce and hash
+// of the request as sent on the wire. Every attempt gets a fresh nonce, as the server
+// rejects a nonce seen before.
+type signedCredentials struct {
+	keyID string
+	key   string
+}
+
+// requestBodyKey carries the encoding signingInterceptor sends to signedCredentials.
+type requestBodyKey struct{}
+
+func (c *signedCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	info, ok := credentials.RequestInfoFromContext(ctx)
+	if !ok {
+		return nil, errors.New("authlayer client: signed requests need the request method")
+	}
+	// Streams are signed with an empty body, as their messages are sent after the headers
+	body, _ := ctx.Value(requestBodyKey{}).([]byte)
+
+	nonceBytes := make([]byte, 18)
+	if _, err := rand.Read(nonceBytes); err != nil {
+		return nil, err
+	}
+	nonce := base64.RawURLEncoding.EncodeToString(nonceBytes)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	bodyHash := sha256.Sum256(body)
+	stringToSign := strings.Join([]string{
+		"AUTHLAYER-HMAC-SHA256",
+		"POST",
+		info.Method,
+		timestamp,
+		nonce,
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")
+
+	mac := hmac.New(sha256.New, []byte(c.key))
+	mac.Write([]byte(stringToSign))
+	signature := base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+	return map[string]string{
+		"authorization": "ServiceSignature keyId=" + c.keyID + ",timestamp=" + timestamp + ",nonce=" + nonce + ",signature=" + signature,
+	}, nil
+}
+
+func (c *signedCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+// Session holds a user's access and refresh tokens and refreshes the access token shortly
+// before it expires. Refreshes are serialized: authlayer rotates refresh tokens and treats
+// a second use of the same one as theft, so two concurrent refreshes would revoke the
+// session.
+type Session struct {
+	auth      authlayerv1.AuthServiceClient
+	margin    time.Duration
+	onRefresh func(*authlayerv1.TokenPair)
+
+	mu     sync.Mutex
+	tokens *authlayerv1.TokenPair
+	err    error
+}
+
+// Tokens returns the current token pair, or nil when logged out.
+func (s *Session) Tokens() *authlayerv1.TokenPair {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tokens
+}
+
+// Err returns ErrSessionExpired or ErrSessionRevoked once the session has ended.
+func (s *Session) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+func (s *Session) set(tokens *authlayerv1.TokenPair) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens, s.err = tokens, nil
+}
+
+func (s *Session) clear() *authlayerv1.TokenPair {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tokens := s.tokens
+	s.tokens = nil
+	return tokens
+}
+
+// accessToken returns a valid access token, refreshing it when it expires within the
+// margin or when it is the token the server just rejected.
+func (s *Session) accessToken(ctx context.Context, rejected string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return "", s.err
+	}
+	if s.tokens == nil {
+		return "", ErrNotLoggedIn
+	}
+	expiresAt := s.tokens.GetAccessTokenExpiresAt().AsTime()
+	if s.tokens.AccessToken != rejected && time.Until(expiresAt) > s.margin {
+		return s.tokens.AccessToken, nil
+	}
+
+	resp, err := s.auth.RefreshToken(ctx, &authlayerv1.RefreshTokenRequest{RefreshToken: s.tokens.RefreshToken})
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			s.tokens = nil
+			s.err = ErrSessionExpired
+			if strings.Contains(status.Convert(err).Message(), "reuse") {
+				s.err = ErrSessionRevoked
+			}
+			return "", s.err
+		}
+		// authlayer is unreachable: keep using the access token while it is still valid.
+		if s.tokens.AccessToken != rejected && time.Now().Before(expiresAt) {
+			return s.tokens.AccessToken, nil
+		}
+		return "", err
+	}
+
+	s.tokens = resp.Tokens
+	if s.onRefresh != nil {
+		s.onRefresh(resp.Tokens)
+	}
+	return s.tokens.AccessToken, nil
+}
+
+// current returns the access token that the next call will present.
+func (s *Session) current() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tokens == nil {
+		return ""
+	}
+	return s.tokens.AccessToken
+}
+
+type sessionCredentials struct {
+	session *Session
+}
+
+func (c *sessionCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	token, err := c.session.accessToken(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"authorization": "Bearer " + token}, nil
+}
+
+func (c *sessionCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+// perRPCCredentials delegates to the client's current credentials, which Login and Logout
+// replace, and leaves public methods unauthenticated.
+type perRPCCredentials struct {
+	client *Client
+}
+
+func (c *perRPCCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	if info, ok := credentials.RequestInfoFromContext(ctx); ok && publicMethods[info.Method] {
+		return nil, nil
+	}
+	creds := c.client.credentials()
+	if creds == nil {
+		return nil, nil
+	}
+	return creds.GetRequestMetadata(ctx, uri...)
+}
+
+func (c *perRPCCredentials) RequireTransportSecurity() bool {
+	return !c.client.insecure
+}
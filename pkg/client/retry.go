@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	protocodec "google.golang.org/grpc/encoding/proto"
+	"google.golang.org/grpc/mem"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	retryBaseDelay = 100 * time.Millisecond
+	retryMaxDelay  = 2 * time.Second
+)
+
+// idempotentPrefixes name the read-only methods that are safe to send more than once.
+var idempotentPrefixes = []string{"Get", "List", "Check", "Expand", "Read", "Validate"}
+
+func isIdempotent(fullMethod string) bool {
+	name := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	for _, prefix := range idempotentPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// unaryInterceptor retries idempotent calls on Unavailable and ResourceExhausted with
+// jittered exponential backoff. Independently, a call rejected as Unauthenticated is sent
+// once more after refreshing the session's access token: the server rejected it before
+// running the handler, so repeating it is safe for any method.
+func (c *Client) unaryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	retries := 0
+	if isIdempotent(method) {
+		retries = c.maxRetries
+	}
+	// Public methods carry no session token. Skipping the session for them also matters
+	// because the session's own RefreshToken call runs while it holds its lock.
+	session := c.Session()
+	if publicMethods[method] {
+		session = nil
+	}
+	refreshed := false
+
+	for attempt := 0; ; {
+		sent := ""
+		if session != nil {
+			sent = session.current()
+		}
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil {
+			return nil
+		}
+
+		switch status.Code(err) {
+		case codes.Unauthenticated:
+			if refreshed || session == nil || sent == "" {
+				return err
+			}
+			refreshed = true
+			if _, rerr := session.accessToken(ctx, sent); rerr != nil {
+				return err
+			}
+			continue
+		case codes.Unavailable, codes.ResourceExhausted:
+			if attempt >= retries {
+				return err
+			}
+		default:
+			return err
+		}
+
+		delay := retryBaseDelay << attempt
+		if delay > retryMaxDelay {
+			delay = retryMaxDelay
+		}
+		delay = delay/2 + rand.N(delay/2+1)
+		attempt++
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// signingInterceptor encodes the request once, hands the encoding to signedCredentials to
+// sign and sends that same encoding, as the server verifies the body as it arrives.
+func (c *Client) signingInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if _, ok := c.credentials().(*signedCredentials); ok && !publicMethods[method] {
+		if msg, ok := req.(proto.Message); ok {
+			body, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+			if err != nil {
+				return err
+			}
+			ctx = context.WithValue(ctx, requestBodyKey{}, body)
+			opts = append(opts, grpc.ForceCodecV2(signedCodec{CodecV2: encoding.GetCodecV2(protocodec.Name), req: req, body: body}))
+		}
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// signedCodec is the protobuf codec, sending the signed encoding for the signed request.
+type signedCodec struct {
+	encoding.CodecV2
+	req  interface{}
+	body []byte
+}
+
+func (c signedCodec) Marshal(v any) (mem.BufferSlice, error) {
+	if v == c.req {
+		return mem.BufferSlice{mem.SliceBuffer(c.body)}, nil
+	}
+	return c.CodecV2.Marshal(v)
+}
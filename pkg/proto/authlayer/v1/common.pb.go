@@ -74,6 +74,59 @@ func (UserStatus) EnumDescriptor() ([]byte, []int) {
 	return file_authlayer_v1_common_proto_rawDescGZIP(), []int{0}
 }
 
+// PrincipalType identifies the kind of identity a role binding applies to.
+type PrincipalType int32
+
+const (
+	PrincipalType_PRINCIPAL_TYPE_UNSPECIFIED     PrincipalType = 0
+	PrincipalType_PRINCIPAL_TYPE_USER            PrincipalType = 1
+	PrincipalType_PRINCIPAL_TYPE_SERVICE_ACCOUNT PrincipalType = 2
+	PrincipalType_PRINCIPAL_TYPE_TEAM            PrincipalType = 3
+)
+
+// Enum value maps for PrincipalType.
+var (
+	PrincipalType_name = map[int32]string{
+		0: "PRINCIPAL_TYPE_UNSPECIFIED",
+		1: "PRINCIPAL_TYPE_USER",
+		2: "PRINCIPAL_TYPE_SERVICE_ACCOUNT",
+		3: "PRINCIPAL_TYPE_TEAM",
+	}
+	PrincipalType_value = map[string]int32{
+		"PRINCIPAL_TYPE_UNSPECIFIED":     0,
+		"PRINCIPAL_TYPE_USER":            1,
+		"PRINCIPAL_TYPE_SERVICE_ACCOUNT": 2,
+		"PRINCIPAL_TYPE_TEAM":            3,
+	}
+)
+
+func (x PrincipalType) Enum() *PrincipalType {
+	p := new(PrincipalType)
+	*p = x
+	return p
+}
+
+func (x PrincipalType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PrincipalType) Descriptor() protoreflect.EnumDescriptor {
+	return file_authlayer_v1_common_proto_enumTypes[1].Descriptor()
+}
+
+func (PrincipalType) Type() protoreflect.EnumType {
+	return &file_authlayer_v1_common_proto_enumTypes[1]
+}
+
+func (x PrincipalType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PrincipalType.Descriptor instead.
+func (PrincipalType) EnumDescriptor() ([]byte, []int) {
+	return file_authlayer_v1_common_proto_rawDescGZIP(), []int{1}
+}
+
 type PaginationRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
@@ -188,6 +241,7 @@ type UserInfo struct {
 	Status        UserStatus             `protobuf:"varint,6,opt,name=status,proto3,enum=authlayer.v1.UserStatus" json:"status,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,9,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -278,6 +332,105 @@ func (x *UserInfo) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *UserInfo) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+// LabelSet wraps a label map so update requests can tell "replace labels"
+// apart from "leave labels unchanged".
+type LabelSet struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Labels        map[string]string      `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LabelSet) Reset() {
+	*x = LabelSet{}
+	mi := &file_authlayer_v1_common_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LabelSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabelSet) ProtoMessage() {}
+
+func (x *LabelSet) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_common_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabelSet.ProtoReflect.Descriptor instead.
+func (*LabelSet) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_common_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LabelSet) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+// StringList wraps a list so update requests can tell "replace the list" apart from
+// "leave it unchanged".
+type StringList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []string               `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StringList) Reset() {
+	*x = StringList{}
+	mi := &file_authlayer_v1_common_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StringList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StringList) ProtoMessage() {}
+
+func (x *StringList) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_common_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StringList.ProtoReflect.Descriptor instead.
+func (*StringList) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_common_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StringList) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
 type MemberInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -292,7 +445,7 @@ type MemberInfo struct {
 
 func (x *MemberInfo) Reset() {
 	*x = MemberInfo{}
-	mi := &file_authlayer_v1_common_proto_msgTypes[3]
+	mi := &file_authlayer_v1_common_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -304,7 +457,7 @@ func (x *MemberInfo) String() string {
 func (*MemberInfo) ProtoMessage() {}
 
 func (x *MemberInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_authlayer_v1_common_proto_msgTypes[3]
+	mi := &file_authlayer_v1_common_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -317,7 +470,7 @@ func (x *MemberInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MemberInfo.ProtoReflect.Descriptor instead.
 func (*MemberInfo) Descriptor() ([]byte, []int) {
-	return file_authlayer_v1_common_proto_rawDescGZIP(), []int{3}
+	return file_authlayer_v1_common_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *MemberInfo) GetUserId() string {
@@ -374,7 +527,7 @@ const file_authlayer_v1_common_proto_rawDesc = "" +
 	"\x12PaginationResponse\x12&\n" +
 	"\x0fnext_page_token\x18\x01 \x01(\tR\rnextPageToken\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
-	"totalCount\"\xbb\x02\n" +
+	"totalCount\"\xb2\x03\n" +
 	"\bUserInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05email\x18\x02 \x01(\tR\x05email\x12\x12\n" +
@@ -385,8 +538,20 @@ const file_authlayer_v1_common_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\t\n" +
-	"\a_avatar\"\xbe\x01\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12:\n" +
+	"\x06labels\x18\t \x03(\v2\".authlayer.v1.UserInfo.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\t\n" +
+	"\a_avatar\"\x81\x01\n" +
+	"\bLabelSet\x12:\n" +
+	"\x06labels\x18\x01 \x03(\v2\".authlayer.v1.LabelSet.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"$\n" +
+	"\n" +
+	"StringList\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\tR\x06values\"\xbe\x01\n" +
 	"\n" +
 	"MemberInfo\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
@@ -400,7 +565,12 @@ const file_authlayer_v1_common_proto_rawDesc = "" +
 	"\x17USER_STATUS_UNSPECIFIED\x10\x00\x12\x16\n" +
 	"\x12USER_STATUS_ACTIVE\x10\x01\x12\x18\n" +
 	"\x14USER_STATUS_INACTIVE\x10\x02\x12\x16\n" +
-	"\x12USER_STATUS_BANNED\x10\x03BJZHgithub.com/bernardoforcillo/authlayer/pkg/proto/authlayer/v1;authlayerv1b\x06proto3"
+	"\x12USER_STATUS_BANNED\x10\x03*\x85\x01\n" +
+	"\rPrincipalType\x12\x1e\n" +
+	"\x1aPRINCIPAL_TYPE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13PRINCIPAL_TYPE_USER\x10\x01\x12\"\n" +
+	"\x1ePRINCIPAL_TYPE_SERVICE_ACCOUNT\x10\x02\x12\x17\n" +
+	"\x13PRINCIPAL_TYPE_TEAM\x10\x03BJZHgithub.com/bernardoforcillo/authlayer/pkg/proto/authlayer/v1;authlayerv1b\x06proto3"
 
 var (
 	file_authlayer_v1_common_proto_rawDescOnce sync.Once
@@ -414,26 +584,33 @@ func file_authlayer_v1_common_proto_rawDescGZIP() []byte {
 	return file_authlayer_v1_common_proto_rawDescData
 }
 
-var file_authlayer_v1_common_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_authlayer_v1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_authlayer_v1_common_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_authlayer_v1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_authlayer_v1_common_proto_goTypes = []any{
 	(UserStatus)(0),               // 0: authlayer.v1.UserStatus
-	(*PaginationRequest)(nil),     // 1: authlayer.v1.PaginationRequest
-	(*PaginationResponse)(nil),    // 2: authlayer.v1.PaginationResponse
-	(*UserInfo)(nil),              // 3: authlayer.v1.UserInfo
-	(*MemberInfo)(nil),            // 4: authlayer.v1.MemberInfo
-	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+	(PrincipalType)(0),            // 1: authlayer.v1.PrincipalType
+	(*PaginationRequest)(nil),     // 2: authlayer.v1.PaginationRequest
+	(*PaginationResponse)(nil),    // 3: authlayer.v1.PaginationResponse
+	(*UserInfo)(nil),              // 4: authlayer.v1.UserInfo
+	(*LabelSet)(nil),              // 5: authlayer.v1.LabelSet
+	(*StringList)(nil),            // 6: authlayer.v1.StringList
+	(*MemberInfo)(nil),            // 7: authlayer.v1.MemberInfo
+	nil,                           // 8: authlayer.v1.UserInfo.LabelsEntry
+	nil,                           // 9: authlayer.v1.LabelSet.LabelsEntry
+	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
 }
 var file_authlayer_v1_common_proto_depIdxs = []int32{
-	0, // 0: authlayer.v1.UserInfo.status:type_name -> authlayer.v1.UserStatus
-	5, // 1: authlayer.v1.UserInfo.created_at:type_name -> google.protobuf.Timestamp
-	5, // 2: authlayer.v1.UserInfo.updated_at:type_name -> google.protobuf.Timestamp
-	5, // 3: authlayer.v1.MemberInfo.joined_at:type_name -> google.protobuf.Timestamp
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	0,  // 0: authlayer.v1.UserInfo.status:type_name -> authlayer.v1.UserStatus
+	10, // 1: authlayer.v1.UserInfo.created_at:type_name -> google.protobuf.Timestamp
+	10, // 2: authlayer.v1.UserInfo.updated_at:type_name -> google.protobuf.Timestamp
+	8,  // 3: authlayer.v1.UserInfo.labels:type_name -> authlayer.v1.UserInfo.LabelsEntry
+	9,  // 4: authlayer.v1.LabelSet.labels:type_name -> authlayer.v1.LabelSet.LabelsEntry
+	10, // 5: authlayer.v1.MemberInfo.joined_at:type_name -> google.protobuf.Timestamp
+	6,  // [6:6] is the sub-list for method output_type
+	6,  // [6:6] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_authlayer_v1_common_proto_init() }
@@ -447,8 +624,8 @@ func file_authlayer_v1_common_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_authlayer_v1_common_proto_rawDesc), len(file_authlayer_v1_common_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   4,
+			NumEnums:      2,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
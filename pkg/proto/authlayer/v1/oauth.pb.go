@@ -0,0 +1,1686 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: authlayer/v1/oauth.proto
+
+package authlayerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type OAuthClientInfo struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ClientId     string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Name         string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	RedirectUris []string               `protobuf:"bytes,3,rep,name=redirect_uris,json=redirectUris,proto3" json:"redirect_uris,omitempty"`
+	Scopes       []string               `protobuf:"bytes,4,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// The aud claim of the client's access tokens; the client ID when empty.
+	Audience string `protobuf:"bytes,5,opt,name=audience,proto3" json:"audience,omitempty"`
+	// Public clients (browser and mobile apps) have no secret and must use PKCE.
+	Public bool `protobuf:"varint,6,opt,name=public,proto3" json:"public,omitempty"`
+	// Trusted first-party clients skip the consent screen.
+	Trusted       bool                   `protobuf:"varint,7,opt,name=trusted,proto3" json:"trusted,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,8,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	OrgId         string                 `protobuf:"bytes,11,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OAuthClientInfo) Reset() {
+	*x = OAuthClientInfo{}
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OAuthClientInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OAuthClientInfo) ProtoMessage() {}
+
+func (x *OAuthClientInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OAuthClientInfo.ProtoReflect.Descriptor instead.
+func (*OAuthClientInfo) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_oauth_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OAuthClientInfo) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *OAuthClientInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OAuthClientInfo) GetRedirectUris() []string {
+	if x != nil {
+		return x.RedirectUris
+	}
+	return nil
+}
+
+func (x *OAuthClientInfo) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *OAuthClientInfo) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+func (x *OAuthClientInfo) GetPublic() bool {
+	if x != nil {
+		return x.Public
+	}
+	return false
+}
+
+func (x *OAuthClientInfo) GetTrusted() bool {
+	if x != nil {
+		return x.Trusted
+	}
+	return false
+}
+
+func (x *OAuthClientInfo) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *OAuthClientInfo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *OAuthClientInfo) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *OAuthClientInfo) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+type OAuthConsentInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientId      string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ClientName    string                 `protobuf:"bytes,2,opt,name=client_name,json=clientName,proto3" json:"client_name,omitempty"`
+	Scopes        []string               `protobuf:"bytes,3,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OAuthConsentInfo) Reset() {
+	*x = OAuthConsentInfo{}
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OAuthConsentInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OAuthConsentInfo) ProtoMessage() {}
+
+func (x *OAuthConsentInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OAuthConsentInfo.ProtoReflect.Descriptor instead.
+func (*OAuthConsentInfo) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_oauth_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OAuthConsentInfo) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *OAuthConsentInfo) GetClientName() string {
+	if x != nil {
+		return x.ClientName
+	}
+	return ""
+}
+
+func (x *OAuthConsentInfo) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *OAuthConsentInfo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *OAuthConsentInfo) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type DeviceAuthorizationInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserCode      string                 `protobuf:"bytes,1,opt,name=user_code,json=userCode,proto3" json:"user_code,omitempty"`
+	ClientId      string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ClientName    string                 `protobuf:"bytes,3,opt,name=client_name,json=clientName,proto3" json:"client_name,omitempty"`
+	Scopes        []string               `protobuf:"bytes,4,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeviceAuthorizationInfo) Reset() {
+	*x = DeviceAuthorizationInfo{}
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceAuthorizationInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceAuthorizationInfo) ProtoMessage() {}
+
+func (x *DeviceAuthorizationInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceAuthorizationInfo.ProtoReflect.Descriptor instead.
+func (*DeviceAuthorizationInfo) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_oauth_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DeviceAuthorizationInfo) GetUserCode() string {
+	if x != nil {
+		return x.UserCode
+	}
+	return ""
+}
+
+func (x *DeviceAuthorizationInfo) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *DeviceAuthorizationInfo) GetClientName() string {
+	if x != nil {
+		return x.ClientName
+	}
+	return ""
+}
+
+func (x *DeviceAuthorizationInfo) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *DeviceAuthorizationInfo) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type CreateOAuthClientRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Name         string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	RedirectUris []string               `protobuf:"bytes,2,rep,name=redirect_uris,json=redirectUris,proto3" json:"redirect_uris,omitempty"`
+	Scopes       []string               `protobuf:"bytes,3,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// Must not be authlayer's own API audience, its issuer.
+	Audience string `protobuf:"bytes,4,opt,name=audience,proto3" json:"audience,omitempty"`
+	Public   bool   `protobuf:"varint,5,opt,name=public,proto3" json:"public,omitempty"`
+	// Requires the platform-wide oauth_client:trust permission.
+	Trusted       bool   `protobuf:"varint,6,opt,name=trusted,proto3" json:"trusted,omitempty"`
+	OrgId         string `protobuf:"bytes,7,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOAuthClientRequest) Reset() {
+	*x = CreateOAuthClientRequest{}
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOAuthClientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOAuthClientRequest) ProtoMessage() {}
+
+func (x *CreateOAuthClientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOAuthClientRequest.ProtoReflect.Descriptor instead.
+func (*CreateOAuthClientRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_oauth_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateOAuthClientRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateOAuthClientRequest) GetRedirectUris() []string {
+	if x != nil {
+		return x.RedirectUris
+	}
+	return nil
+}
+
+func (x *CreateOAuthClientRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *CreateOAuthClientRequest) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+func (x *CreateOAuthClientRequest) GetPublic() bool {
+	if x != nil {
+		return x.Public
+	}
+	return false
+}
+
+func (x *CreateOAuthClientRequest) GetTrusted() bool {
+	if x != nil {
+		return x.Trusted
+	}
+	return false
+}
+
+func (x *CreateOAuthClientRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+type CreateOAuthClientResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Client *OAuthClientInfo       `protobuf:"bytes,1,opt,name=client,proto3" json:"client,omitempty"`
+	// Only returned here, and empty for public clients.
+	ClientSecret  string `protobuf:"bytes,2,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOAuthClientResponse) Reset() {
+	*x = CreateOAuthClientResponse{}
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOAuthClientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOAuthClientResponse) ProtoMessage() {}
+
+func (x *CreateOAuthClientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOAuthClientResponse.ProtoReflect.Descriptor instead.
+func (*CreateOAuthClientResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_oauth_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateOAuthClientResponse) GetClient() *OAuthClientInfo {
+	if x != nil {
+		return x.Client
+	}
+	return nil
+}
+
+func (x *CreateOAuthClientResponse) GetClientSecret() string {
+	if x != nil {
+		return x.ClientSecret
+	}
+	return ""
+}
+
+type GetOAuthClientRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientId      string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOAuthClientRequest) Reset() {
+	*x = GetOAuthClientRequest{}
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOAuthClientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOAuthClientRequest) ProtoMessage() {}
+
+func (x *GetOAuthClientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOAuthClientRequest.ProtoReflect.Descriptor instead.
+func (*GetOAuthClientRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_oauth_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetOAuthClientRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+type GetOAuthClientResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Client        *OAuthClientInfo       `protobuf:"bytes,1,opt,name=client,proto3" json:"client,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOAuthClientResponse) Reset() {
+	*x = GetOAuthClientResponse{}
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOAuthClientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOAuthClientResponse) ProtoMessage() {}
+
+func (x *GetOAuthClientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOAuthClientResponse.ProtoReflect.Descriptor instead.
+func (*GetOAuthClientResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_oauth_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetOAuthClientResponse) GetClient() *OAuthClientInfo {
+	if x != nil {
+		return x.Client
+	}
+	return nil
+}
+
+type ListOAuthClientsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pagination    *PaginationRequest     `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	OrgId         string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOAuthClientsRequest) Reset() {
+	*x = ListOAuthClientsRequest{}
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOAuthClientsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOAuthClientsRequest) ProtoMessage() {}
+
+func (x *ListOAuthClientsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOAuthClientsRequest.ProtoReflect.Descriptor instead.
+func (*ListOAuthClientsRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_oauth_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListOAuthClientsRequest) GetPagination() *PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+func (x *ListOAuthClientsRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+type ListOAuthClientsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Clients       []*OAuthClientInfo     `protobuf:"bytes,1,rep,name=clients,proto3" json:"clients,omitempty"`
+	Pagination    *PaginationResponse    `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOAuthClientsResponse) Reset() {
+	*x = ListOAuthClientsResponse{}
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOAuthClientsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOAuthClientsResponse) ProtoMessage() {}
+
+func (x *ListOAuthClientsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOAuthClientsResponse.ProtoReflect.Descriptor instead.
+func (*ListOAuthClientsResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_oauth_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListOAuthClientsResponse) GetClients() []*OAuthClientInfo {
+	if x != nil {
+		return x.Clients
+	}
+	return nil
+}
+
+func (x *ListOAuthClientsResponse) GetPagination() *PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type UpdateOAuthClientRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ClientId string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Name     *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// Replace the list when set.
+	RedirectUris *StringList `protobuf:"bytes,3,opt,name=redirect_uris,json=redirectUris,proto3,oneof" json:"redirect_uris,omitempty"`
+	Scopes       *StringList `protobuf:"bytes,4,opt,name=scopes,proto3,oneof" json:"scopes,omitempty"`
+	// Must not be authlayer's own API audience, its issuer.
+	Audience *string `protobuf:"bytes,5,opt,name=audience,proto3,oneof" json:"audience,omitempty"`
+	// Requires the platform-wide oauth_client:trust permission.
+	Trusted       *bool `protobuf:"varint,6,opt,name=trusted,proto3,oneof" json:"trusted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOAuthClientRequest) Reset() {
+	*x = UpdateOAuthClientRequest{}
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOAuthClientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOAuthClientRequest) ProtoMessage() {}
+
+func (x *UpdateOAuthClientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOAuthClientRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOAuthClientRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_oauth_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateOAuthClientRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *UpdateOAuthClientRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateOAuthClientRequest) GetRedirectUris() *StringList {
+	if x != nil {
+		return x.RedirectUris
+	}
+	return nil
+}
+
+func (x *UpdateOAuthClientRequest) GetScopes() *StringList {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *UpdateOAuthClientRequest) GetAudience() string {
+	if x != nil && x.Audience != nil {
+		return *x.Audience
+	}
+	return ""
+}
+
+func (x *UpdateOAuthClientRequest) GetTrusted() bool {
+	if x != nil && x.Trusted != nil {
+		return *x.Trusted
+	}
+	return false
+}
+
+type UpdateOAuthClientResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Client        *OAuthClientInfo       `protobuf:"bytes,1,opt,name=client,proto3" json:"client,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOAuthClientResponse) Reset() {
+	*x = UpdateOAuthClientResponse{}
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOAuthClientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOAuthClientResponse) ProtoMessage() {}
+
+func (x *UpdateOAuthClientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOAuthClientResponse.ProtoReflect.Descriptor instead.
+func (*UpdateOAuthClientResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_oauth_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateOAuthClientResponse) GetClient() *OAuthClientInfo {
+	if x != nil {
+		return x.Client
+	}
+	return nil
+}
+
+type DeleteOAuthClientRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientId      string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOAuthClientRequest) Reset() {
+	*x = DeleteOAuthClientRequest{}
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOAuthClientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOAuthClientRequest) ProtoMessage() {}
+
+func (x *DeleteOAuthClientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOAuthClientRequest.ProtoReflect.Descriptor instead.
+func (*DeleteOAuthClientRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_oauth_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteOAuthClientRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+type DeleteOAuthClientResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOAuthClientResponse) Reset() {
+	*x = DeleteOAuthClientResponse{}
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOAuthClientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOAuthClientResponse) ProtoMessage() {}
+
+func (x *DeleteOAuthClientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOAuthClientResponse.ProtoReflect.Descriptor instead.
+func (*DeleteOAuthClientResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_oauth_proto_rawDescGZIP(), []int{12}
+}
+
+type RotateOAuthClientSecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientId      string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateOAuthClientSecretRequest) Reset() {
+	*x = RotateOAuthClientSecretRequest{}
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateOAuthClientSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateOAuthClientSecretRequest) ProtoMessage() {}
+
+func (x *RotateOAuthClientSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateOAuthClientSecretRequest.ProtoReflect.Descriptor instead.
+func (*RotateOAuthClientSecretRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_oauth_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RotateOAuthClientSecretRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+type RotateOAuthClientSecretResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientSecret  string                 `protobuf:"bytes,1,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateOAuthClientSecretResponse) Reset() {
+	*x = RotateOAuthClientSecretResponse{}
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateOAuthClientSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateOAuthClientSecretResponse) ProtoMessage() {}
+
+func (x *RotateOAuthClientSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateOAuthClientSecretResponse.ProtoReflect.Descriptor instead.
+func (*RotateOAuthClientSecretResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_oauth_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RotateOAuthClientSecretResponse) GetClientSecret() string {
+	if x != nil {
+		return x.ClientSecret
+	}
+	return ""
+}
+
+type GrantOAuthConsentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientId      string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Scopes        []string               `protobuf:"bytes,2,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantOAuthConsentRequest) Reset() {
+	*x = GrantOAuthConsentRequest{}
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantOAuthConsentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantOAuthConsentRequest) ProtoMessage() {}
+
+func (x *GrantOAuthConsentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantOAuthConsentRequest.ProtoReflect.Descriptor instead.
+func (*GrantOAuthConsentRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_oauth_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GrantOAuthConsentRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *GrantOAuthConsentRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+type GrantOAuthConsentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Consent       *OAuthConsentInfo      `protobuf:"bytes,1,opt,name=consent,proto3" json:"consent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantOAuthConsentResponse) Reset() {
+	*x = GrantOAuthConsentResponse{}
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantOAuthConsentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantOAuthConsentResponse) ProtoMessage() {}
+
+func (x *GrantOAuthConsentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantOAuthConsentResponse.ProtoReflect.Descriptor instead.
+func (*GrantOAuthConsentResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_oauth_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GrantOAuthConsentResponse) GetConsent() *OAuthConsentInfo {
+	if x != nil {
+		return x.Consent
+	}
+	return nil
+}
+
+type ListOAuthConsentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOAuthConsentsRequest) Reset() {
+	*x = ListOAuthConsentsRequest{}
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOAuthConsentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOAuthConsentsRequest) ProtoMessage() {}
+
+func (x *ListOAuthConsentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOAuthConsentsRequest.ProtoReflect.Descriptor instead.
+func (*ListOAuthConsentsRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_oauth_proto_rawDescGZIP(), []int{17}
+}
+
+type ListOAuthConsentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Consents      []*OAuthConsentInfo    `protobuf:"bytes,1,rep,name=consents,proto3" json:"consents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOAuthConsentsResponse) Reset() {
+	*x = ListOAuthConsentsResponse{}
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOAuthConsentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOAuthConsentsResponse) ProtoMessage() {}
+
+func (x *ListOAuthConsentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOAuthConsentsResponse.ProtoReflect.Descriptor instead.
+func (*ListOAuthConsentsResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_oauth_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListOAuthConsentsResponse) GetConsents() []*OAuthConsentInfo {
+	if x != nil {
+		return x.Consents
+	}
+	return nil
+}
+
+type RevokeOAuthConsentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientId      string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeOAuthConsentRequest) Reset() {
+	*x = RevokeOAuthConsentRequest{}
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeOAuthConsentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeOAuthConsentRequest) ProtoMessage() {}
+
+func (x *RevokeOAuthConsentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeOAuthConsentRequest.ProtoReflect.Descriptor instead.
+func (*RevokeOAuthConsentRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_oauth_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RevokeOAuthConsentRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+type RevokeOAuthConsentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeOAuthConsentResponse) Reset() {
+	*x = RevokeOAuthConsentResponse{}
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeOAuthConsentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeOAuthConsentResponse) ProtoMessage() {}
+
+func (x *RevokeOAuthConsentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeOAuthConsentResponse.ProtoReflect.Descriptor instead.
+func (*RevokeOAuthConsentResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_oauth_proto_rawDescGZIP(), []int{20}
+}
+
+type GetDeviceAuthorizationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// As typed by the user; case and dashes are ignored.
+	UserCode      string `protobuf:"bytes,1,opt,name=user_code,json=userCode,proto3" json:"user_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeviceAuthorizationRequest) Reset() {
+	*x = GetDeviceAuthorizationRequest{}
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeviceAuthorizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeviceAuthorizationRequest) ProtoMessage() {}
+
+func (x *GetDeviceAuthorizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeviceAuthorizationRequest.ProtoReflect.Descriptor instead.
+func (*GetDeviceAuthorizationRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_oauth_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetDeviceAuthorizationRequest) GetUserCode() string {
+	if x != nil {
+		return x.UserCode
+	}
+	return ""
+}
+
+type GetDeviceAuthorizationResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Authorization *DeviceAuthorizationInfo `protobuf:"bytes,1,opt,name=authorization,proto3" json:"authorization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeviceAuthorizationResponse) Reset() {
+	*x = GetDeviceAuthorizationResponse{}
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeviceAuthorizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeviceAuthorizationResponse) ProtoMessage() {}
+
+func (x *GetDeviceAuthorizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeviceAuthorizationResponse.ProtoReflect.Descriptor instead.
+func (*GetDeviceAuthorizationResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_oauth_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetDeviceAuthorizationResponse) GetAuthorization() *DeviceAuthorizationInfo {
+	if x != nil {
+		return x.Authorization
+	}
+	return nil
+}
+
+type ApproveDeviceAuthorizationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserCode      string                 `protobuf:"bytes,1,opt,name=user_code,json=userCode,proto3" json:"user_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveDeviceAuthorizationRequest) Reset() {
+	*x = ApproveDeviceAuthorizationRequest{}
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveDeviceAuthorizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveDeviceAuthorizationRequest) ProtoMessage() {}
+
+func (x *ApproveDeviceAuthorizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveDeviceAuthorizationRequest.ProtoReflect.Descriptor instead.
+func (*ApproveDeviceAuthorizationRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_oauth_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ApproveDeviceAuthorizationRequest) GetUserCode() string {
+	if x != nil {
+		return x.UserCode
+	}
+	return ""
+}
+
+type ApproveDeviceAuthorizationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveDeviceAuthorizationResponse) Reset() {
+	*x = ApproveDeviceAuthorizationResponse{}
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveDeviceAuthorizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveDeviceAuthorizationResponse) ProtoMessage() {}
+
+func (x *ApproveDeviceAuthorizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveDeviceAuthorizationResponse.ProtoReflect.Descriptor instead.
+func (*ApproveDeviceAuthorizationResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_oauth_proto_rawDescGZIP(), []int{24}
+}
+
+type DenyDeviceAuthorizationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserCode      string                 `protobuf:"bytes,1,opt,name=user_code,json=userCode,proto3" json:"user_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DenyDeviceAuthorizationRequest) Reset() {
+	*x = DenyDeviceAuthorizationRequest{}
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DenyDeviceAuthorizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DenyDeviceAuthorizationRequest) ProtoMessage() {}
+
+func (x *DenyDeviceAuthorizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DenyDeviceAuthorizationRequest.ProtoReflect.Descriptor instead.
+func (*DenyDeviceAuthorizationRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_oauth_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DenyDeviceAuthorizationRequest) GetUserCode() string {
+	if x != nil {
+		return x.UserCode
+	}
+	return ""
+}
+
+type DenyDeviceAuthorizationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DenyDeviceAuthorizationResponse) Reset() {
+	*x = DenyDeviceAuthorizationResponse{}
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DenyDeviceAuthorizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DenyDeviceAuthorizationResponse) ProtoMessage() {}
+
+func (x *DenyDeviceAuthorizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_oauth_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DenyDeviceAuthorizationResponse.ProtoReflect.Descriptor instead.
+func (*DenyDeviceAuthorizationResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_oauth_proto_rawDescGZIP(), []int{26}
+}
+
+var File_authlayer_v1_oauth_proto protoreflect.FileDescriptor
+
+const file_authlayer_v1_oauth_proto_rawDesc = "" +
+	"\n" +
+	"\x18authlayer/v1/oauth.proto\x12\fauthlayer.v1\x1a\x19authlayer/v1/common.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf9\x02\n" +
+	"\x0fOAuthClientInfo\x12\x1b\n" +
+	"\tclient_id\x18\x01 \x01(\tR\bclientId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12#\n" +
+	"\rredirect_uris\x18\x03 \x03(\tR\fredirectUris\x12\x16\n" +
+	"\x06scopes\x18\x04 \x03(\tR\x06scopes\x12\x1a\n" +
+	"\baudience\x18\x05 \x01(\tR\baudience\x12\x16\n" +
+	"\x06public\x18\x06 \x01(\bR\x06public\x12\x18\n" +
+	"\atrusted\x18\a \x01(\bR\atrusted\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\b \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x15\n" +
+	"\x06org_id\x18\v \x01(\tR\x05orgId\"\xde\x01\n" +
+	"\x10OAuthConsentInfo\x12\x1b\n" +
+	"\tclient_id\x18\x01 \x01(\tR\bclientId\x12\x1f\n" +
+	"\vclient_name\x18\x02 \x01(\tR\n" +
+	"clientName\x12\x16\n" +
+	"\x06scopes\x18\x03 \x03(\tR\x06scopes\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xc7\x01\n" +
+	"\x17DeviceAuthorizationInfo\x12\x1b\n" +
+	"\tuser_code\x18\x01 \x01(\tR\buserCode\x12\x1b\n" +
+	"\tclient_id\x18\x02 \x01(\tR\bclientId\x12\x1f\n" +
+	"\vclient_name\x18\x03 \x01(\tR\n" +
+	"clientName\x12\x16\n" +
+	"\x06scopes\x18\x04 \x03(\tR\x06scopes\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\xd0\x01\n" +
+	"\x18CreateOAuthClientRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12#\n" +
+	"\rredirect_uris\x18\x02 \x03(\tR\fredirectUris\x12\x16\n" +
+	"\x06scopes\x18\x03 \x03(\tR\x06scopes\x12\x1a\n" +
+	"\baudience\x18\x04 \x01(\tR\baudience\x12\x16\n" +
+	"\x06public\x18\x05 \x01(\bR\x06public\x12\x18\n" +
+	"\atrusted\x18\x06 \x01(\bR\atrusted\x12\x15\n" +
+	"\x06org_id\x18\a \x01(\tR\x05orgId\"w\n" +
+	"\x19CreateOAuthClientResponse\x125\n" +
+	"\x06client\x18\x01 \x01(\v2\x1d.authlayer.v1.OAuthClientInfoR\x06client\x12#\n" +
+	"\rclient_secret\x18\x02 \x01(\tR\fclientSecret\"4\n" +
+	"\x15GetOAuthClientRequest\x12\x1b\n" +
+	"\tclient_id\x18\x01 \x01(\tR\bclientId\"O\n" +
+	"\x16GetOAuthClientResponse\x125\n" +
+	"\x06client\x18\x01 \x01(\v2\x1d.authlayer.v1.OAuthClientInfoR\x06client\"q\n" +
+	"\x17ListOAuthClientsRequest\x12?\n" +
+	"\n" +
+	"pagination\x18\x01 \x01(\v2\x1f.authlayer.v1.PaginationRequestR\n" +
+	"pagination\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\"\x95\x01\n" +
+	"\x18ListOAuthClientsResponse\x127\n" +
+	"\aclients\x18\x01 \x03(\v2\x1d.authlayer.v1.OAuthClientInfoR\aclients\x12@\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2 .authlayer.v1.PaginationResponseR\n" +
+	"pagination\"\xca\x02\n" +
+	"\x18UpdateOAuthClientRequest\x12\x1b\n" +
+	"\tclient_id\x18\x01 \x01(\tR\bclientId\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12B\n" +
+	"\rredirect_uris\x18\x03 \x01(\v2\x18.authlayer.v1.StringListH\x01R\fredirectUris\x88\x01\x01\x125\n" +
+	"\x06scopes\x18\x04 \x01(\v2\x18.authlayer.v1.StringListH\x02R\x06scopes\x88\x01\x01\x12\x1f\n" +
+	"\baudience\x18\x05 \x01(\tH\x03R\baudience\x88\x01\x01\x12\x1d\n" +
+	"\atrusted\x18\x06 \x01(\bH\x04R\atrusted\x88\x01\x01B\a\n" +
+	"\x05_nameB\x10\n" +
+	"\x0e_redirect_urisB\t\n" +
+	"\a_scopesB\v\n" +
+	"\t_audienceB\n" +
+	"\n" +
+	"\b_trusted\"R\n" +
+	"\x19UpdateOAuthClientResponse\x125\n" +
+	"\x06client\x18\x01 \x01(\v2\x1d.authlayer.v1.OAuthClientInfoR\x06client\"7\n" +
+	"\x18DeleteOAuthClientRequest\x12\x1b\n" +
+	"\tclient_id\x18\x01 \x01(\tR\bclientId\"\x1b\n" +
+	"\x19DeleteOAuthClientResponse\"=\n" +
+	"\x1eRotateOAuthClientSecretRequest\x12\x1b\n" +
+	"\tclient_id\x18\x01 \x01(\tR\bclientId\"F\n" +
+	"\x1fRotateOAuthClientSecretResponse\x12#\n" +
+	"\rclient_secret\x18\x01 \x01(\tR\fclientSecret\"O\n" +
+	"\x18GrantOAuthConsentRequest\x12\x1b\n" +
+	"\tclient_id\x18\x01 \x01(\tR\bclientId\x12\x16\n" +
+	"\x06scopes\x18\x02 \x03(\tR\x06scopes\"U\n" +
+	"\x19GrantOAuthConsentResponse\x128\n" +
+	"\aconsent\x18\x01 \x01(\v2\x1e.authlayer.v1.OAuthConsentInfoR\aconsent\"\x1a\n" +
+	"\x18ListOAuthConsentsRequest\"W\n" +
+	"\x19ListOAuthConsentsResponse\x12:\n" +
+	"\bconsents\x18\x01 \x03(\v2\x1e.authlayer.v1.OAuthConsentInfoR\bconsents\"8\n" +
+	"\x19RevokeOAuthConsentRequest\x12\x1b\n" +
+	"\tclient_id\x18\x01 \x01(\tR\bclientId\"\x1c\n" +
+	"\x1aRevokeOAuthConsentResponse\"<\n" +
+	"\x1dGetDeviceAuthorizationRequest\x12\x1b\n" +
+	"\tuser_code\x18\x01 \x01(\tR\buserCode\"m\n" +
+	"\x1eGetDeviceAuthorizationResponse\x12K\n" +
+	"\rauthorization\x18\x01 \x01(\v2%.authlayer.v1.DeviceAuthorizationInfoR\rauthorization\"@\n" +
+	"!ApproveDeviceAuthorizationRequest\x12\x1b\n" +
+	"\tuser_code\x18\x01 \x01(\tR\buserCode\"$\n" +
+	"\"ApproveDeviceAuthorizationResponse\"=\n" +
+	"\x1eDenyDeviceAuthorizationRequest\x12\x1b\n" +
+	"\tuser_code\x18\x01 \x01(\tR\buserCode\"!\n" +
+	"\x1fDenyDeviceAuthorizationResponse2\x9b\n" +
+	"\n" +
+	"\fOAuthService\x12d\n" +
+	"\x11CreateOAuthClient\x12&.authlayer.v1.CreateOAuthClientRequest\x1a'.authlayer.v1.CreateOAuthClientResponse\x12[\n" +
+	"\x0eGetOAuthClient\x12#.authlayer.v1.GetOAuthClientRequest\x1a$.authlayer.v1.GetOAuthClientResponse\x12a\n" +
+	"\x10ListOAuthClients\x12%.authlayer.v1.ListOAuthClientsRequest\x1a&.authlayer.v1.ListOAuthClientsResponse\x12d\n" +
+	"\x11UpdateOAuthClient\x12&.authlayer.v1.UpdateOAuthClientRequest\x1a'.authlayer.v1.UpdateOAuthClientResponse\x12d\n" +
+	"\x11DeleteOAuthClient\x12&.authlayer.v1.DeleteOAuthClientRequest\x1a'.authlayer.v1.DeleteOAuthClientResponse\x12v\n" +
+	"\x17RotateOAuthClientSecret\x12,.authlayer.v1.RotateOAuthClientSecretRequest\x1a-.authlayer.v1.RotateOAuthClientSecretResponse\x12d\n" +
+	"\x11GrantOAuthConsent\x12&.authlayer.v1.GrantOAuthConsentRequest\x1a'.authlayer.v1.GrantOAuthConsentResponse\x12d\n" +
+	"\x11ListOAuthConsents\x12&.authlayer.v1.ListOAuthConsentsRequest\x1a'.authlayer.v1.ListOAuthConsentsResponse\x12g\n" +
+	"\x12RevokeOAuthConsent\x12'.authlayer.v1.RevokeOAuthConsentRequest\x1a(.authlayer.v1.RevokeOAuthConsentResponse\x12s\n" +
+	"\x16GetDeviceAuthorization\x12+.authlayer.v1.GetDeviceAuthorizationRequest\x1a,.authlayer.v1.GetDeviceAuthorizationResponse\x12\x7f\n" +
+	"\x1aApproveDeviceAuthorization\x12/.authlayer.v1.ApproveDeviceAuthorizationRequest\x1a0.authlayer.v1.ApproveDeviceAuthorizationResponse\x12v\n" +
+	"\x17DenyDeviceAuthorization\x12,.authlayer.v1.DenyDeviceAuthorizationRequest\x1a-.authlayer.v1.DenyDeviceAuthorizationResponseBJZHgithub.com/bernardoforcillo/authlayer/pkg/proto/authlayer/v1;authlayerv1b\x06proto3"
+
+var (
+	file_authlayer_v1_oauth_proto_rawDescOnce sync.Once
+	file_authlayer_v1_oauth_proto_rawDescData []byte
+)
+
+func file_authlayer_v1_oauth_proto_rawDescGZIP() []byte {
+	file_authlayer_v1_oauth_proto_rawDescOnce.Do(func() {
+		file_authlayer_v1_oauth_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_authlayer_v1_oauth_proto_rawDesc), len(file_authlayer_v1_oauth_proto_rawDesc)))
+	})
+	return file_authlayer_v1_oauth_proto_rawDescData
+}
+
+var file_authlayer_v1_oauth_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_authlayer_v1_oauth_proto_goTypes = []any{
+	(*OAuthClientInfo)(nil),                    // 0: authlayer.v1.OAuthClientInfo
+	(*OAuthConsentInfo)(nil),                   // 1: authlayer.v1.OAuthConsentInfo
+	(*DeviceAuthorizationInfo)(nil),            // 2: authlayer.v1.DeviceAuthorizationInfo
+	(*CreateOAuthClientRequest)(nil),           // 3: authlayer.v1.CreateOAuthClientRequest
+	(*CreateOAuthClientResponse)(nil),          // 4: authlayer.v1.CreateOAuthClientResponse
+	(*GetOAuthClientRequest)(nil),              // 5: authlayer.v1.GetOAuthClientRequest
+	(*GetOAuthClientResponse)(nil),             // 6: authlayer.v1.GetOAuthClientResponse
+	(*ListOAuthClientsRequest)(nil),            // 7: authlayer.v1.ListOAuthClientsRequest
+	(*ListOAuthClientsResponse)(nil),           // 8: authlayer.v1.ListOAuthClientsResponse
+	(*UpdateOAuthClientRequest)(nil),           // 9: authlayer.v1.UpdateOAuthClientRequest
+	(*UpdateOAuthClientResponse)(nil),          // 10: authlayer.v1.UpdateOAuthClientResponse
+	(*DeleteOAuthClientRequest)(nil),           // 11: authlayer.v1.DeleteOAuthClientRequest
+	(*DeleteOAuthClientResponse)(nil),          // 12: authlayer.v1.DeleteOAuthClientResponse
+	(*RotateOAuthClientSecretRequest)(nil),     // 13: authlayer.v1.RotateOAuthClientSecretRequest
+	(*RotateOAuthClientSecretResponse)(nil),    // 14: authlayer.v1.RotateOAuthClientSecretResponse
+	(*GrantOAuthConsentRequest)(nil),           // 15: authlayer.v1.GrantOAuthConsentRequest
+	(*GrantOAuthConsentResponse)(nil),          // 16: authlayer.v1.GrantOAuthConsentResponse
+	(*ListOAuthConsentsRequest)(nil),           // 17: authlayer.v1.ListOAuthConsentsRequest
+	(*ListOAuthConsentsResponse)(nil),          // 18: authlayer.v1.ListOAuthConsentsResponse
+	(*RevokeOAuthConsentRequest)(nil),          // 19: authlayer.v1.RevokeOAuthConsentRequest
+	(*RevokeOAuthConsentResponse)(nil),         // 20: authlayer.v1.RevokeOAuthConsentResponse
+	(*GetDeviceAuthorizationRequest)(nil),      // 21: authlayer.v1.GetDeviceAuthorizationRequest
+	(*GetDeviceAuthorizationResponse)(nil),     // 22: authlayer.v1.GetDeviceAuthorizationResponse
+	(*ApproveDeviceAuthorizationRequest)(nil),  // 23: authlayer.v1.ApproveDeviceAuthorizationRequest
+	(*ApproveDeviceAuthorizationResponse)(nil), // 24: authlayer.v1.ApproveDeviceAuthorizationResponse
+	(*DenyDeviceAuthorizationRequest)(nil),     // 25: authlayer.v1.DenyDeviceAuthorizationRequest
+	(*DenyDeviceAuthorizationResponse)(nil),    // 26: authlayer.v1.DenyDeviceAuthorizationResponse
+	(*timestamppb.Timestamp)(nil),              // 27: google.protobuf.Timestamp
+	(*PaginationRequest)(nil),                  // 28: authlayer.v1.PaginationRequest
+	(*PaginationResponse)(nil),                 // 29: authlayer.v1.PaginationResponse
+	(*StringList)(nil),                         // 30: authlayer.v1.StringList
+}
+var file_authlayer_v1_oauth_proto_depIdxs = []int32{
+	27, // 0: authlayer.v1.OAuthClientInfo.created_at:type_name -> google.protobuf.Timestamp
+	27, // 1: authlayer.v1.OAuthClientInfo.updated_at:type_name -> google.protobuf.Timestamp
+	27, // 2: authlayer.v1.OAuthConsentInfo.created_at:type_name -> google.protobuf.Timestamp
+	27, // 3: authlayer.v1.OAuthConsentInfo.updated_at:type_name -> google.protobuf.Timestamp
+	27, // 4: authlayer.v1.DeviceAuthorizationInfo.expires_at:type_name -> google.protobuf.Timestamp
+	0,  // 5: authlayer.v1.CreateOAuthClientResponse.client:type_name -> authlayer.v1.OAuthClientInfo
+	0,  // 6: authlayer.v1.GetOAuthClientResponse.client:type_name -> authlayer.v1.OAuthClientInfo
+	28, // 7: authlayer.v1.ListOAuthClientsRequest.pagination:type_name -> authlayer.v1.PaginationRequest
+	0,  // 8: authlayer.v1.ListOAuthClientsResponse.clients:type_name -> authlayer.v1.OAuthClientInfo
+	29, // 9: authlayer.v1.ListOAuthClientsResponse.pagination:type_name -> authlayer.v1.PaginationResponse
+	30, // 10: authlayer.v1.UpdateOAuthClientRequest.redirect_uris:type_name -> authlayer.v1.StringList
+	30, // 11: authlayer.v1.UpdateOAuthClientRequest.scopes:type_name -> authlayer.v1.StringList
+	0,  // 12: authlayer.v1.UpdateOAuthClientResponse.client:type_name -> authlayer.v1.OAuthClientInfo
+	1,  // 13: authlayer.v1.GrantOAuthConsentResponse.consent:type_name -> authlayer.v1.OAuthConsentInfo
+	1,  // 14: authlayer.v1.ListOAuthConsentsResponse.consents:type_name -> authlayer.v1.OAuthConsentInfo
+	2,  // 15: authlayer.v1.GetDeviceAuthorizationResponse.authorization:type_name -> authlayer.v1.DeviceAuthorizationInfo
+	3,  // 16: authlayer.v1.OAuthService.CreateOAuthClient:input_type -> authlayer.v1.CreateOAuthClientRequest
+	5,  // 17: authlayer.v1.OAuthService.GetOAuthClient:input_type -> authlayer.v1.GetOAuthClientRequest
+	7,  // 18: authlayer.v1.OAuthService.ListOAuthClients:input_type -> authlayer.v1.ListOAuthClientsRequest
+	9,  // 19: authlayer.v1.OAuthService.UpdateOAuthClient:input_type -> authlayer.v1.UpdateOAuthClientRequest
+	11, // 20: authlayer.v1.OAuthService.DeleteOAuthClient:input_type -> authlayer.v1.DeleteOAuthClientRequest
+	13, // 21: authlayer.v1.OAuthService.RotateOAuthClientSecret:input_type -> authlayer.v1.RotateOAuthClientSecretRequest
+	15, // 22: authlayer.v1.OAuthService.GrantOAuthConsent:input_type -> authlayer.v1.GrantOAuthConsentRequest
+	17, // 23: authlayer.v1.OAuthService.ListOAuthConsents:input_type -> authlayer.v1.ListOAuthConsentsRequest
+	19, // 24: authlayer.v1.OAuthService.RevokeOAuthConsent:input_type -> authlayer.v1.RevokeOAuthConsentRequest
+	21, // 25: authlayer.v1.OAuthService.GetDeviceAuthorization:input_type -> authlayer.v1.GetDeviceAuthorizationRequest
+	23, // 26: authlayer.v1.OAuthService.ApproveDeviceAuthorization:input_type -> authlayer.v1.ApproveDeviceAuthorizationRequest
+	25, // 27: authlayer.v1.OAuthService.DenyDeviceAuthorization:input_type -> authlayer.v1.DenyDeviceAuthorizationRequest
+	4,  // 28: authlayer.v1.OAuthService.CreateOAuthClient:output_type -> authlayer.v1.CreateOAuthClientResponse
+	6,  // 29: authlayer.v1.OAuthService.GetOAuthClient:output_type -> authlayer.v1.GetOAuthClientResponse
+	8,  // 30: authlayer.v1.OAuthService.ListOAuthClients:output_type -> authlayer.v1.ListOAuthClientsResponse
+	10, // 31: authlayer.v1.OAuthService.UpdateOAuthClient:output_type -> authlayer.v1.UpdateOAuthClientResponse
+	12, // 32: authlayer.v1.OAuthService.DeleteOAuthClient:output_type -> authlayer.v1.DeleteOAuthClientResponse
+	14, // 33: authlayer.v1.OAuthService.RotateOAuthClientSecret:output_type -> authlayer.v1.RotateOAuthClientSecretResponse
+	16, // 34: authlayer.v1.OAuthService.GrantOAuthConsent:output_type -> authlayer.v1.GrantOAuthConsentResponse
+	18, // 35: authlayer.v1.OAuthService.ListOAuthConsents:output_type -> authlayer.v1.ListOAuthConsentsResponse
+	20, // 36: authlayer.v1.OAuthService.RevokeOAuthConsent:output_type -> authlayer.v1.RevokeOAuthConsentResponse
+	22, // 37: authlayer.v1.OAuthService.GetDeviceAuthorization:output_type -> authlayer.v1.GetDeviceAuthorizationResponse
+	24, // 38: authlayer.v1.OAuthService.ApproveDeviceAuthorization:output_type -> authlayer.v1.ApproveDeviceAuthorizationResponse
+	26, // 39: authlayer.v1.OAuthService.DenyDeviceAuthorization:output_type -> authlayer.v1.DenyDeviceAuthorizationResponse
+	28, // [28:40] is the sub-list for method output_type
+	16, // [16:28] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_authlayer_v1_oauth_proto_init() }
+func file_authlayer_v1_oauth_proto_init() {
+	if File_authlayer_v1_oauth_proto != nil {
+		return
+	}
+	file_authlayer_v1_common_proto_init()
+	file_authlayer_v1_oauth_proto_msgTypes[9].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_authlayer_v1_oauth_proto_rawDesc), len(file_authlayer_v1_oauth_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   27,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_authlayer_v1_oauth_proto_goTypes,
+		DependencyIndexes: file_authlayer_v1_oauth_proto_depIdxs,
+		MessageInfos:      file_authlayer_v1_oauth_proto_msgTypes,
+	}.Build()
+	File_authlayer_v1_oauth_proto = out.File
+	file_authlayer_v1_oauth_proto_goTypes = nil
+	file_authlayer_v1_oauth_proto_depIdxs = nil
+}
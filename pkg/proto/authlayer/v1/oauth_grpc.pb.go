@@ -0,0 +1,555 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.1
+// - protoc             (unknown)
+// source: authlayer/v1/oauth.proto
+
+package authlayerv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	OAuthService_CreateOAuthClient_FullMethodName          = "/authlayer.v1.OAuthService/CreateOAuthClient"
+	OAuthService_GetOAuthClient_FullMethodName             = "/authlayer.v1.OAuthService/GetOAuthClient"
+	OAuthService_ListOAuthClients_FullMethodName           = "/authlayer.v1.OAuthService/ListOAuthClients"
+	OAuthService_UpdateOAuthClient_FullMethodName          = "/authlayer.v1.OAuthService/UpdateOAuthClient"
+	OAuthService_DeleteOAuthClient_FullMethodName          = "/authlayer.v1.OAuthService/DeleteOAuthClient"
+	OAuthService_RotateOAuthClientSecret_FullMethodName    = "/authlayer.v1.OAuthService/RotateOAuthClientSecret"
+	OAuthService_GrantOAuthConsent_FullMethodName          = "/authlayer.v1.OAuthService/GrantOAuthConsent"
+	OAuthService_ListOAuthConsents_FullMethodName          = "/authlayer.v1.OAuthService/ListOAuthConsents"
+	OAuthService_RevokeOAuthConsent_FullMethodName         = "/authlayer.v1.OAuthService/RevokeOAuthConsent"
+	OAuthService_GetDeviceAuthorization_FullMethodName     = "/authlayer.v1.OAuthService/GetDeviceAuthorization"
+	OAuthService_ApproveDeviceAuthorization_FullMethodName = "/authlayer.v1.OAuthService/ApproveDeviceAuthorization"
+	OAuthService_DenyDeviceAuthorization_FullMethodName    = "/authlayer.v1.OAuthService/DenyDeviceAuthorization"
+)
+
+// OAuthServiceClient is the client API for OAuthService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// OAuthService manages the applications that sign users in through authlayer's OAuth 2.0
+// authorization server (/oauth/authorize and /oauth/token), and the consents users grant
+// them. Each client belongs to the organization that registered it and is managed there.
+type OAuthServiceClient interface {
+	CreateOAuthClient(ctx context.Context, in *CreateOAuthClientRequest, opts ...grpc.CallOption) (*CreateOAuthClientResponse, error)
+	GetOAuthClient(ctx context.Context, in *GetOAuthClientRequest, opts ...grpc.CallOption) (*GetOAuthClientResponse, error)
+	ListOAuthClients(ctx context.Context, in *ListOAuthClientsRequest, opts ...grpc.CallOption) (*ListOAuthClientsResponse, error)
+	UpdateOAuthClient(ctx context.Context, in *UpdateOAuthClientRequest, opts ...grpc.CallOption) (*UpdateOAuthClientResponse, error)
+	DeleteOAuthClient(ctx context.Context, in *DeleteOAuthClientRequest, opts ...grpc.CallOption) (*DeleteOAuthClientResponse, error)
+	RotateOAuthClientSecret(ctx context.Context, in *RotateOAuthClientSecretRequest, opts ...grpc.CallOption) (*RotateOAuthClientSecretResponse, error)
+	// Consents of the calling user. A consent page calls GrantOAuthConsent once the user
+	// approves a client, then sends the user back to the authorize endpoint.
+	GrantOAuthConsent(ctx context.Context, in *GrantOAuthConsentRequest, opts ...grpc.CallOption) (*GrantOAuthConsentResponse, error)
+	ListOAuthConsents(ctx context.Context, in *ListOAuthConsentsRequest, opts ...grpc.CallOption) (*ListOAuthConsentsResponse, error)
+	RevokeOAuthConsent(ctx context.Context, in *RevokeOAuthConsentRequest, opts ...grpc.CallOption) (*RevokeOAuthConsentResponse, error)
+	// Device authorization (RFC 8628). The verification page looks up the code the user
+	// typed, shows the client and scopes, and approves or denies it as the calling user.
+	GetDeviceAuthorization(ctx context.Context, in *GetDeviceAuthorizationRequest, opts ...grpc.CallOption) (*GetDeviceAuthorizationResponse, error)
+	ApproveDeviceAuthorization(ctx context.Context, in *ApproveDeviceAuthorizationRequest, opts ...grpc.CallOption) (*ApproveDeviceAuthorizationResponse, error)
+	DenyDeviceAuthorization(ctx context.Context, in *DenyDeviceAuthorizationRequest, opts ...grpc.CallOption) (*DenyDeviceAuthorizationResponse, error)
+}
+
+type oAuthServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOAuthServiceClient(cc grpc.ClientConnInterface) OAuthServiceClient {
+	return &oAuthServiceClient{cc}
+}
+
+func (c *oAuthServiceClient) CreateOAuthClient(ctx context.Context, in *CreateOAuthClientRequest, opts ...grpc.CallOption) (*CreateOAuthClientResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateOAuthClientResponse)
+	err := c.cc.Invoke(ctx, OAuthService_CreateOAuthClient_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oAuthServiceClient) GetOAuthClient(ctx context.Context, in *GetOAuthClientRequest, opts ...grpc.CallOption) (*GetOAuthClientResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOAuthClientResponse)
+	err := c.cc.Invoke(ctx, OAuthService_GetOAuthClient_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oAuthServiceClient) ListOAuthClients(ctx context.Context, in *ListOAuthClientsRequest, opts ...grpc.CallOption) (*ListOAuthClientsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOAuthClientsResponse)
+	err := c.cc.Invoke(ctx, OAuthService_ListOAuthClients_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oAuthServiceClient) UpdateOAuthClient(ctx context.Context, in *UpdateOAuthClientRequest, opts ...grpc.CallOption) (*UpdateOAuthClientResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateOAuthClientResponse)
+	err := c.cc.Invoke(ctx, OAuthService_UpdateOAuthClient_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oAuthServiceClient) DeleteOAuthClient(ctx context.Context, in *DeleteOAuthClientRequest, opts ...grpc.CallOption) (*DeleteOAuthClientResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteOAuthClientResponse)
+	err := c.cc.Invoke(ctx, OAuthService_DeleteOAuthClient_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oAuthServiceClient) RotateOAuthClientSecret(ctx context.Context, in *RotateOAuthClientSecretRequest, opts ...grpc.CallOption) (*RotateOAuthClientSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RotateOAuthClientSecretResponse)
+	err := c.cc.Invoke(ctx, OAuthService_RotateOAuthClientSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oAuthServiceClient) GrantOAuthConsent(ctx context.Context, in *GrantOAuthConsentRequest, opts ...grpc.CallOption) (*GrantOAuthConsentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GrantOAuthConsentResponse)
+	err := c.cc.Invoke(ctx, OAuthService_GrantOAuthConsent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oAuthServiceClient) ListOAuthConsents(ctx context.Context, in *ListOAuthConsentsRequest, opts ...grpc.CallOption) (*ListOAuthConsentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOAuthConsentsResponse)
+	err := c.cc.Invoke(ctx, OAuthService_ListOAuthConsents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oAuthServiceClient) RevokeOAuthConsent(ctx context.Context, in *RevokeOAuthConsentRequest, opts ...grpc.CallOption) (*RevokeOAuthConsentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeOAuthConsentResponse)
+	err := c.cc.Invoke(ctx, OAuthService_RevokeOAuthConsent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oAuthServiceClient) GetDeviceAuthorization(ctx context.Context, in *GetDeviceAuthorizationRequest, opts ...grpc.CallOption) (*GetDeviceAuthorizationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDeviceAuthorizationResponse)
+	err := c.cc.Invoke(ctx, OAuthService_GetDeviceAuthorization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oAuthServiceClient) ApproveDeviceAuthorization(ctx context.Context, in *ApproveDeviceAuthorizationRequest, opts ...grpc.CallOption) (*ApproveDeviceAuthorizationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproveDeviceAuthorizationResponse)
+	err := c.cc.Invoke(ctx, OAuthService_ApproveDeviceAuthorization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oAuthServiceClient) DenyDeviceAuthorization(ctx context.Context, in *DenyDeviceAuthorizationRequest, opts ...grpc.CallOption) (*DenyDeviceAuthorizationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DenyDeviceAuthorizationResponse)
+	err := c.cc.Invoke(ctx, OAuthService_DenyDeviceAuthorization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OAuthServiceServer is the server API for OAuthService service.
+// All implementations must embed UnimplementedOAuthServiceServer
+// for forward compatibility.
+//
+// OAuthService manages the applications that sign users in through authlayer's OAuth 2.0
+// authorization server (/oauth/authorize and /oauth/token), and the consents users grant
+// them. Each client belongs to the organization that registered it and is managed there.
+type OAuthServiceServer interface {
+	CreateOAuthClient(context.Context, *CreateOAuthClientRequest) (*CreateOAuthClientResponse, error)
+	GetOAuthClient(context.Context, *GetOAuthClientRequest) (*GetOAuthClientResponse, error)
+	ListOAuthClients(context.Context, *ListOAuthClientsRequest) (*ListOAuthClientsResponse, error)
+	UpdateOAuthClient(context.Context, *UpdateOAuthClientRequest) (*UpdateOAuthClientResponse, error)
+	DeleteOAuthClient(context.Context, *DeleteOAuthClientRequest) (*DeleteOAuthClientResponse, error)
+	RotateOAuthClientSecret(context.Context, *RotateOAuthClientSecretRequest) (*RotateOAuthClientSecretResponse, error)
+	// Consents of the calling user. A consent page calls GrantOAuthConsent once the user
+	// approves a client, then sends the user back to the authorize endpoint.
+	GrantOAuthConsent(context.Context, *GrantOAuthConsentRequest) (*GrantOAuthConsentResponse, error)
+	ListOAuthConsents(context.Context, *ListOAuthConsentsRequest) (*ListOAuthConsentsResponse, error)
+	RevokeOAuthConsent(context.Context, *RevokeOAuthConsentRequest) (*RevokeOAuthConsentResponse, error)
+	// Device authorization (RFC 8628). The verification page looks up the code the user
+	// typed, shows the client and scopes, and approves or denies it as the calling user.
+	GetDeviceAuthorization(context.Context, *GetDeviceAuthorizationRequest) (*GetDeviceAuthorizationResponse, error)
+	ApproveDeviceAuthorization(context.Context, *ApproveDeviceAuthorizationRequest) (*ApproveDeviceAuthorizationResponse, error)
+	DenyDeviceAuthorization(context.Context, *DenyDeviceAuthorizationRequest) (*DenyDeviceAuthorizationResponse, error)
+	mustEmbedUnimplementedOAuthServiceServer()
+}
+
+// UnimplementedOAuthServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedOAuthServiceServer struct{}
+
+func (UnimplementedOAuthServiceServer) CreateOAuthClient(context.Context, *CreateOAuthClientRequest) (*CreateOAuthClientResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateOAuthClient not implemented")
+}
+func (UnimplementedOAuthServiceServer) GetOAuthClient(context.Context, *GetOAuthClientRequest) (*GetOAuthClientResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetOAuthClient not implemented")
+}
+func (UnimplementedOAuthServiceServer) ListOAuthClients(context.Context, *ListOAuthClientsRequest) (*ListOAuthClientsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListOAuthClients not implemented")
+}
+func (UnimplementedOAuthServiceServer) UpdateOAuthClient(context.Context, *UpdateOAuthClientRequest) (*UpdateOAuthClientResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateOAuthClient not implemented")
+}
+func (UnimplementedOAuthServiceServer) DeleteOAuthClient(context.Context, *DeleteOAuthClientRequest) (*DeleteOAuthClientResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteOAuthClient not implemented")
+}
+func (UnimplementedOAuthServiceServer) RotateOAuthClientSecret(context.Context, *RotateOAuthClientSecretRequest) (*RotateOAuthClientSecretResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RotateOAuthClientSecret not implemented")
+}
+func (UnimplementedOAuthServiceServer) GrantOAuthConsent(context.Context, *GrantOAuthConsentRequest) (*GrantOAuthConsentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GrantOAuthConsent not implemented")
+}
+func (UnimplementedOAuthServiceServer) ListOAuthConsents(context.Context, *ListOAuthConsentsRequest) (*ListOAuthConsentsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListOAuthConsents not implemented")
+}
+func (UnimplementedOAuthServiceServer) RevokeOAuthConsent(context.Context, *RevokeOAuthConsentRequest) (*RevokeOAuthConsentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RevokeOAuthConsent not implemented")
+}
+func (UnimplementedOAuthServiceServer) GetDeviceAuthorization(context.Context, *GetDeviceAuthorizationRequest) (*GetDeviceAuthorizationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDeviceAuthorization not implemented")
+}
+func (UnimplementedOAuthServiceServer) ApproveDeviceAuthorization(context.Context, *ApproveDeviceAuthorizationRequest) (*ApproveDeviceAuthorizationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ApproveDeviceAuthorization not implemented")
+}
+func (UnimplementedOAuthServiceServer) DenyDeviceAuthorization(context.Context, *DenyDeviceAuthorizationRequest) (*DenyDeviceAuthorizationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DenyDeviceAuthorization not implemented")
+}
+func (UnimplementedOAuthServiceServer) mustEmbedUnimplementedOAuthServiceServer() {}
+func (UnimplementedOAuthServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeOAuthServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OAuthServiceServer will
+// result in compilation errors.
+type UnsafeOAuthServiceServer interface {
+	mustEmbedUnimplementedOAuthServiceServer()
+}
+
+func RegisterOAuthServiceServer(s grpc.ServiceRegistrar, srv OAuthServiceServer) {
+	// If the following call panics, it indicates UnimplementedOAuthServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&OAuthService_ServiceDesc, srv)
+}
+
+func _OAuthService_CreateOAuthClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOAuthClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OAuthServiceServer).CreateOAuthClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OAuthService_CreateOAuthClient_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OAuthServiceServer).CreateOAuthClient(ctx, req.(*CreateOAuthClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OAuthService_GetOAuthClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOAuthClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OAuthServiceServer).GetOAuthClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OAuthService_GetOAuthClient_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OAuthServiceServer).GetOAuthClient(ctx, req.(*GetOAuthClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OAuthService_ListOAuthClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOAuthClientsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OAuthServiceServer).ListOAuthClients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OAuthService_ListOAuthClients_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OAuthServiceServer).ListOAuthClients(ctx, req.(*ListOAuthClientsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OAuthService_UpdateOAuthClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateOAuthClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OAuthServiceServer).UpdateOAuthClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OAuthService_UpdateOAuthClient_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OAuthServiceServer).UpdateOAuthClient(ctx, req.(*UpdateOAuthClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OAuthService_DeleteOAuthClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteOAuthClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OAuthServiceServer).DeleteOAuthClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OAuthService_DeleteOAuthClient_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OAuthServiceServer).DeleteOAuthClient(ctx, req.(*DeleteOAuthClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OAuthService_RotateOAuthClientSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateOAuthClientSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OAuthServiceServer).RotateOAuthClientSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OAuthService_RotateOAuthClientSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OAuthServiceServer).RotateOAuthClientSecret(ctx, req.(*RotateOAuthClientSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OAuthService_GrantOAuthConsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantOAuthConsentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OAuthServiceServer).GrantOAuthConsent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OAuthService_GrantOAuthConsent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OAuthServiceServer).GrantOAuthConsent(ctx, req.(*GrantOAuthConsentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OAuthService_ListOAuthConsents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOAuthConsentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OAuthServiceServer).ListOAuthConsents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OAuthService_ListOAuthConsents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OAuthServiceServer).ListOAuthConsents(ctx, req.(*ListOAuthConsentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OAuthService_RevokeOAuthConsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeOAuthConsentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OAuthServiceServer).RevokeOAuthConsent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OAuthService_RevokeOAuthConsent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OAuthServiceServer).RevokeOAuthConsent(ctx, req.(*RevokeOAuthConsentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OAuthService_GetDeviceAuthorization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceAuthorizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OAuthServiceServer).GetDeviceAuthorization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OAuthService_GetDeviceAuthorization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OAuthServiceServer).GetDeviceAuthorization(ctx, req.(*GetDeviceAuthorizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OAuthService_ApproveDeviceAuthorization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveDeviceAuthorizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OAuthServiceServer).ApproveDeviceAuthorization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OAuthService_ApproveDeviceAuthorization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OAuthServiceServer).ApproveDeviceAuthorization(ctx, req.(*ApproveDeviceAuthorizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OAuthService_DenyDeviceAuthorization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DenyDeviceAuthorizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OAuthServiceServer).DenyDeviceAuthorization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OAuthService_DenyDeviceAuthorization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OAuthServiceServer).DenyDeviceAuthorization(ctx, req.(*DenyDeviceAuthorizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OAuthService_ServiceDesc is the grpc.ServiceDesc for OAuthService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OAuthService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "authlayer.v1.OAuthService",
+	HandlerType: (*OAuthServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateOAuthClient",
+			Handler:    _OAuthService_CreateOAuthClient_Handler,
+		},
+		{
+			MethodName: "GetOAuthClient",
+			Handler:    _OAuthService_GetOAuthClient_Handler,
+		},
+		{
+			MethodName: "ListOAuthClients",
+			Handler:    _OAuthService_ListOAuthClients_Handler,
+		},
+		{
+			MethodName: "UpdateOAuthClient",
+			Handler:    _OAuthService_UpdateOAuthClient_Handler,
+		},
+		{
+			MethodName: "DeleteOAuthClient",
+			Handler:    _OAuthService_DeleteOAuthClient_Handler,
+		},
+		{
+			MethodName: "RotateOAuthClientSecret",
+			Handler:    _OAuthService_RotateOAuthClientSecret_Handler,
+		},
+		{
+			MethodName: "GrantOAuthConsent",
+			Handler:    _OAuthService_GrantOAuthConsent_Handler,
+		},
+		{
+			MethodName: "ListOAuthConsents",
+			Handler:    _OAuthService_ListOAuthConsents_Handler,
+		},
+		{
+			MethodName: "RevokeOAuthConsent",
+			Handler:    _OAuthService_RevokeOAuthConsent_Handler,
+		},
+		{
+			MethodName: "GetDeviceAuthorization",
+			Handler:    _OAuthService_GetDeviceAuthorization_Handler,
+		},
+		{
+			MethodName: "ApproveDeviceAuthorization",
+			Handler:    _OAuthService_ApproveDeviceAuthorization_Handler,
+		},
+		{
+			MethodName: "DenyDeviceAuthorization",
+			Handler:    _OAuthService_DenyDeviceAuthorization_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "authlayer/v1/oauth.proto",
+}
@@ -23,14 +23,17 @@ const (
 )
 
 type OrganizationInfo struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Slug          string                 `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"`
-	OwnerId       string                 `protobuf:"bytes,4,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Slug        string                 `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"`
+	OwnerId     string                 `protobuf:"bytes,4,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ParentOrgId *string                `protobuf:"bytes,6,opt,name=parent_org_id,json=parentOrgId,proto3,oneof" json:"parent_org_id,omitempty"`
+	// When true, roles held in the parent organization also apply in this one.
+	InheritParentRoles bool `protobuf:"varint,7,opt,name=inherit_parent_roles,json=inheritParentRoles,proto3" json:"inherit_parent_roles,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *OrganizationInfo) Reset() {
@@ -98,6 +101,20 @@ func (x *OrganizationInfo) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *OrganizationInfo) GetParentOrgId() string {
+	if x != nil && x.ParentOrgId != nil {
+		return *x.ParentOrgId
+	}
+	return ""
+}
+
+func (x *OrganizationInfo) GetInheritParentRoles() bool {
+	if x != nil {
+		return x.InheritParentRoles
+	}
+	return false
+}
+
 type CreateOrganizationRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -1080,18 +1097,469 @@ func (*UpdateOrgMemberRoleResponse) Descriptor() ([]byte, []int) {
 	return file_authlayer_v1_organization_proto_rawDescGZIP(), []int{20}
 }
 
+type AttachChildOrganizationRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	ParentOrgId        string                 `protobuf:"bytes,1,opt,name=parent_org_id,json=parentOrgId,proto3" json:"parent_org_id,omitempty"`
+	ChildOrgId         string                 `protobuf:"bytes,2,opt,name=child_org_id,json=childOrgId,proto3" json:"child_org_id,omitempty"`
+	InheritParentRoles bool                   `protobuf:"varint,3,opt,name=inherit_parent_roles,json=inheritParentRoles,proto3" json:"inherit_parent_roles,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AttachChildOrganizationRequest) Reset() {
+	*x = AttachChildOrganizationRequest{}
+	mi := &file_authlayer_v1_organization_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachChildOrganizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachChildOrganizationRequest) ProtoMessage() {}
+
+func (x *AttachChildOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_organization_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachChildOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*AttachChildOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_organization_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *AttachChildOrganizationRequest) GetParentOrgId() string {
+	if x != nil {
+		return x.ParentOrgId
+	}
+	return ""
+}
+
+func (x *AttachChildOrganizationRequest) GetChildOrgId() string {
+	if x != nil {
+		return x.ChildOrgId
+	}
+	return ""
+}
+
+func (x *AttachChildOrganizationRequest) GetInheritParentRoles() bool {
+	if x != nil {
+		return x.InheritParentRoles
+	}
+	return false
+}
+
+type AttachChildOrganizationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Organization  *OrganizationInfo      `protobuf:"bytes,1,opt,name=organization,proto3" json:"organization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachChildOrganizationResponse) Reset() {
+	*x = AttachChildOrganizationResponse{}
+	mi := &file_authlayer_v1_organization_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachChildOrganizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachChildOrganizationResponse) ProtoMessage() {}
+
+func (x *AttachChildOrganizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_organization_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachChildOrganizationResponse.ProtoReflect.Descriptor instead.
+func (*AttachChildOrganizationResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_organization_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AttachChildOrganizationResponse) GetOrganization() *OrganizationInfo {
+	if x != nil {
+		return x.Organization
+	}
+	return nil
+}
+
+type DetachChildOrganizationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ParentOrgId   string                 `protobuf:"bytes,1,opt,name=parent_org_id,json=parentOrgId,proto3" json:"parent_org_id,omitempty"`
+	ChildOrgId    string                 `protobuf:"bytes,2,opt,name=child_org_id,json=childOrgId,proto3" json:"child_org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DetachChildOrganizationRequest) Reset() {
+	*x = DetachChildOrganizationRequest{}
+	mi := &file_authlayer_v1_organization_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetachChildOrganizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetachChildOrganizationRequest) ProtoMessage() {}
+
+func (x *DetachChildOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_organization_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetachChildOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*DetachChildOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_organization_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *DetachChildOrganizationRequest) GetParentOrgId() string {
+	if x != nil {
+		return x.ParentOrgId
+	}
+	return ""
+}
+
+func (x *DetachChildOrganizationRequest) GetChildOrgId() string {
+	if x != nil {
+		return x.ChildOrgId
+	}
+	return ""
+}
+
+type DetachChildOrganizationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DetachChildOrganizationResponse) Reset() {
+	*x = DetachChildOrganizationResponse{}
+	mi := &file_authlayer_v1_organization_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetachChildOrganizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetachChildOrganizationResponse) ProtoMessage() {}
+
+func (x *DetachChildOrganizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_organization_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetachChildOrganizationResponse.ProtoReflect.Descriptor instead.
+func (*DetachChildOrganizationResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_organization_proto_rawDescGZIP(), []int{24}
+}
+
+type ListDescendantsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDescendantsRequest) Reset() {
+	*x = ListDescendantsRequest{}
+	mi := &file_authlayer_v1_organization_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDescendantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDescendantsRequest) ProtoMessage() {}
+
+func (x *ListDescendantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_organization_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDescendantsRequest.ProtoReflect.Descriptor instead.
+func (*ListDescendantsRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_organization_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListDescendantsRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+// ListDescendantsResponse lists every organization below org_id. Each entry
+// carries its parent_org_id so callers can rebuild the tree.
+type ListDescendantsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Organizations []*OrganizationInfo    `protobuf:"bytes,1,rep,name=organizations,proto3" json:"organizations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDescendantsResponse) Reset() {
+	*x = ListDescendantsResponse{}
+	mi := &file_authlayer_v1_organization_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDescendantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDescendantsResponse) ProtoMessage() {}
+
+func (x *ListDescendantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_organization_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDescendantsResponse.ProtoReflect.Descriptor instead.
+func (*ListDescendantsResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_organization_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListDescendantsResponse) GetOrganizations() []*OrganizationInfo {
+	if x != nil {
+		return x.Organizations
+	}
+	return nil
+}
+
+type ListTreeMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Pagination    *PaginationRequest     `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTreeMembersRequest) Reset() {
+	*x = ListTreeMembersRequest{}
+	mi := &file_authlayer_v1_organization_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTreeMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTreeMembersRequest) ProtoMessage() {}
+
+func (x *ListTreeMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_organization_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTreeMembersRequest.ProtoReflect.Descriptor instead.
+func (*ListTreeMembersRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_organization_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListTreeMembersRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *ListTreeMembersRequest) GetPagination() *PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type TreeMemberInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Member        *MemberInfo            `protobuf:"bytes,2,opt,name=member,proto3" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TreeMemberInfo) Reset() {
+	*x = TreeMemberInfo{}
+	mi := &file_authlayer_v1_organization_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TreeMemberInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TreeMemberInfo) ProtoMessage() {}
+
+func (x *TreeMemberInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_organization_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TreeMemberInfo.ProtoReflect.Descriptor instead.
+func (*TreeMemberInfo) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_organization_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *TreeMemberInfo) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *TreeMemberInfo) GetMember() *MemberInfo {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+// ListTreeMembersResponse aggregates the memberships of org_id and all its descendants.
+type ListTreeMembersResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Members    []*TreeMemberInfo      `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	Pagination *PaginationResponse    `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	// Number of distinct users across the whole tree.
+	DistinctUserCount int32 `protobuf:"varint,3,opt,name=distinct_user_count,json=distinctUserCount,proto3" json:"distinct_user_count,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ListTreeMembersResponse) Reset() {
+	*x = ListTreeMembersResponse{}
+	mi := &file_authlayer_v1_organization_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTreeMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTreeMembersResponse) ProtoMessage() {}
+
+func (x *ListTreeMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_organization_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTreeMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListTreeMembersResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_organization_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListTreeMembersResponse) GetMembers() []*TreeMemberInfo {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *ListTreeMembersResponse) GetPagination() *PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+func (x *ListTreeMembersResponse) GetDistinctUserCount() int32 {
+	if x != nil {
+		return x.DistinctUserCount
+	}
+	return 0
+}
+
 var File_authlayer_v1_organization_proto protoreflect.FileDescriptor
 
 const file_authlayer_v1_organization_proto_rawDesc = "" +
 	"\n" +
-	"\x1fauthlayer/v1/organization.proto\x12\fauthlayer.v1\x1a\x19authlayer/v1/common.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa0\x01\n" +
+	"\x1fauthlayer/v1/organization.proto\x12\fauthlayer.v1\x1a\x19authlayer/v1/common.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8d\x02\n" +
 	"\x10OrganizationInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
 	"\x04slug\x18\x03 \x01(\tR\x04slug\x12\x19\n" +
 	"\bowner_id\x18\x04 \x01(\tR\aownerId\x129\n" +
 	"\n" +
-	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"C\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12'\n" +
+	"\rparent_org_id\x18\x06 \x01(\tH\x00R\vparentOrgId\x88\x01\x01\x120\n" +
+	"\x14inherit_parent_roles\x18\a \x01(\bR\x12inheritParentRolesB\x10\n" +
+	"\x0e_parent_org_id\"C\n" +
 	"\x19CreateOrganizationRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
 	"\x04slug\x18\x02 \x01(\tR\x04slug\"`\n" +
@@ -1152,7 +1620,37 @@ const file_authlayer_v1_organization_proto_rawDesc = "" +
 	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x17\n" +
 	"\arole_id\x18\x03 \x01(\tR\x06roleId\"\x1d\n" +
-	"\x1bUpdateOrgMemberRoleResponse2\xf0\a\n" +
+	"\x1bUpdateOrgMemberRoleResponse\"\x98\x01\n" +
+	"\x1eAttachChildOrganizationRequest\x12\"\n" +
+	"\rparent_org_id\x18\x01 \x01(\tR\vparentOrgId\x12 \n" +
+	"\fchild_org_id\x18\x02 \x01(\tR\n" +
+	"childOrgId\x120\n" +
+	"\x14inherit_parent_roles\x18\x03 \x01(\bR\x12inheritParentRoles\"e\n" +
+	"\x1fAttachChildOrganizationResponse\x12B\n" +
+	"\forganization\x18\x01 \x01(\v2\x1e.authlayer.v1.OrganizationInfoR\forganization\"f\n" +
+	"\x1eDetachChildOrganizationRequest\x12\"\n" +
+	"\rparent_org_id\x18\x01 \x01(\tR\vparentOrgId\x12 \n" +
+	"\fchild_org_id\x18\x02 \x01(\tR\n" +
+	"childOrgId\"!\n" +
+	"\x1fDetachChildOrganizationResponse\"/\n" +
+	"\x16ListDescendantsRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\"_\n" +
+	"\x17ListDescendantsResponse\x12D\n" +
+	"\rorganizations\x18\x01 \x03(\v2\x1e.authlayer.v1.OrganizationInfoR\rorganizations\"p\n" +
+	"\x16ListTreeMembersRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12?\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1f.authlayer.v1.PaginationRequestR\n" +
+	"pagination\"Y\n" +
+	"\x0eTreeMemberInfo\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x120\n" +
+	"\x06member\x18\x02 \x01(\v2\x18.authlayer.v1.MemberInfoR\x06member\"\xc3\x01\n" +
+	"\x17ListTreeMembersResponse\x126\n" +
+	"\amembers\x18\x01 \x03(\v2\x1c.authlayer.v1.TreeMemberInfoR\amembers\x12@\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2 .authlayer.v1.PaginationResponseR\n" +
+	"pagination\x12.\n" +
+	"\x13distinct_user_count\x18\x03 \x01(\x05R\x11distinctUserCount2\xa0\v\n" +
 	"\x13OrganizationService\x12g\n" +
 	"\x12CreateOrganization\x12'.authlayer.v1.CreateOrganizationRequest\x1a(.authlayer.v1.CreateOrganizationResponse\x12^\n" +
 	"\x0fGetOrganization\x12$.authlayer.v1.GetOrganizationRequest\x1a%.authlayer.v1.GetOrganizationResponse\x12g\n" +
@@ -1163,7 +1661,11 @@ const file_authlayer_v1_organization_proto_rawDesc = "" +
 	"\fInviteMember\x12!.authlayer.v1.InviteMemberRequest\x1a\".authlayer.v1.InviteMemberResponse\x12a\n" +
 	"\x10AcceptInvitation\x12%.authlayer.v1.AcceptInvitationRequest\x1a&.authlayer.v1.AcceptInvitationResponse\x12[\n" +
 	"\fRemoveMember\x12$.authlayer.v1.RemoveOrgMemberRequest\x1a%.authlayer.v1.RemoveOrgMemberResponse\x12g\n" +
-	"\x10UpdateMemberRole\x12(.authlayer.v1.UpdateOrgMemberRoleRequest\x1a).authlayer.v1.UpdateOrgMemberRoleResponseBJZHgithub.com/bernardoforcillo/authlayer/pkg/proto/authlayer/v1;authlayerv1b\x06proto3"
+	"\x10UpdateMemberRole\x12(.authlayer.v1.UpdateOrgMemberRoleRequest\x1a).authlayer.v1.UpdateOrgMemberRoleResponse\x12v\n" +
+	"\x17AttachChildOrganization\x12,.authlayer.v1.AttachChildOrganizationRequest\x1a-.authlayer.v1.AttachChildOrganizationResponse\x12v\n" +
+	"\x17DetachChildOrganization\x12,.authlayer.v1.DetachChildOrganizationRequest\x1a-.authlayer.v1.DetachChildOrganizationResponse\x12^\n" +
+	"\x0fListDescendants\x12$.authlayer.v1.ListDescendantsRequest\x1a%.authlayer.v1.ListDescendantsResponse\x12^\n" +
+	"\x0fListTreeMembers\x12$.authlayer.v1.ListTreeMembersRequest\x1a%.authlayer.v1.ListTreeMembersResponseBJZHgithub.com/bernardoforcillo/authlayer/pkg/proto/authlayer/v1;authlayerv1b\x06proto3"
 
 var (
 	file_authlayer_v1_organization_proto_rawDescOnce sync.Once
@@ -1177,71 +1679,94 @@ func file_authlayer_v1_organization_proto_rawDescGZIP() []byte {
 	return file_authlayer_v1_organization_proto_rawDescData
 }
 
-var file_authlayer_v1_organization_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_authlayer_v1_organization_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
 var file_authlayer_v1_organization_proto_goTypes = []any{
-	(*OrganizationInfo)(nil),            // 0: authlayer.v1.OrganizationInfo
-	(*CreateOrganizationRequest)(nil),   // 1: authlayer.v1.CreateOrganizationRequest
-	(*CreateOrganizationResponse)(nil),  // 2: authlayer.v1.CreateOrganizationResponse
-	(*GetOrganizationRequest)(nil),      // 3: authlayer.v1.GetOrganizationRequest
-	(*GetOrganizationResponse)(nil),     // 4: authlayer.v1.GetOrganizationResponse
-	(*UpdateOrganizationRequest)(nil),   // 5: authlayer.v1.UpdateOrganizationRequest
-	(*UpdateOrganizationResponse)(nil),  // 6: authlayer.v1.UpdateOrganizationResponse
-	(*DeleteOrganizationRequest)(nil),   // 7: authlayer.v1.DeleteOrganizationRequest
-	(*DeleteOrganizationResponse)(nil),  // 8: authlayer.v1.DeleteOrganizationResponse
-	(*ListOrganizationsRequest)(nil),    // 9: authlayer.v1.ListOrganizationsRequest
-	(*ListOrganizationsResponse)(nil),   // 10: authlayer.v1.ListOrganizationsResponse
-	(*ListOrgMembersRequest)(nil),       // 11: authlayer.v1.ListOrgMembersRequest
-	(*ListOrgMembersResponse)(nil),      // 12: authlayer.v1.ListOrgMembersResponse
-	(*InviteMemberRequest)(nil),         // 13: authlayer.v1.InviteMemberRequest
-	(*InviteMemberResponse)(nil),        // 14: authlayer.v1.InviteMemberResponse
-	(*AcceptInvitationRequest)(nil),     // 15: authlayer.v1.AcceptInvitationRequest
-	(*AcceptInvitationResponse)(nil),    // 16: authlayer.v1.AcceptInvitationResponse
-	(*RemoveOrgMemberRequest)(nil),      // 17: authlayer.v1.RemoveOrgMemberRequest
-	(*RemoveOrgMemberResponse)(nil),     // 18: authlayer.v1.RemoveOrgMemberResponse
-	(*UpdateOrgMemberRoleRequest)(nil),  // 19: authlayer.v1.UpdateOrgMemberRoleRequest
-	(*UpdateOrgMemberRoleResponse)(nil), // 20: authlayer.v1.UpdateOrgMemberRoleResponse
-	(*timestamppb.Timestamp)(nil),       // 21: google.protobuf.Timestamp
-	(*PaginationRequest)(nil),           // 22: authlayer.v1.PaginationRequest
-	(*PaginationResponse)(nil),          // 23: authlayer.v1.PaginationResponse
-	(*MemberInfo)(nil),                  // 24: authlayer.v1.MemberInfo
+	(*OrganizationInfo)(nil),                // 0: authlayer.v1.OrganizationInfo
+	(*CreateOrganizationRequest)(nil),       // 1: authlayer.v1.CreateOrganizationRequest
+	(*CreateOrganizationResponse)(nil),      // 2: authlayer.v1.CreateOrganizationResponse
+	(*GetOrganizationRequest)(nil),          // 3: authlayer.v1.GetOrganizationRequest
+	(*GetOrganizationResponse)(nil),         // 4: authlayer.v1.GetOrganizationResponse
+	(*UpdateOrganizationRequest)(nil),       // 5: authlayer.v1.UpdateOrganizationRequest
+	(*UpdateOrganizationResponse)(nil),      // 6: authlayer.v1.UpdateOrganizationResponse
+	(*DeleteOrganizationRequest)(nil),       // 7: authlayer.v1.DeleteOrganizationRequest
+	(*DeleteOrganizationResponse)(nil),      // 8: authlayer.v1.DeleteOrganizationResponse
+	(*ListOrganizationsRequest)(nil),        // 9: authlayer.v1.ListOrganizationsRequest
+	(*ListOrganizationsResponse)(nil),       // 10: authlayer.v1.ListOrganizationsResponse
+	(*ListOrgMembersRequest)(nil),           // 11: authlayer.v1.ListOrgMembersRequest
+	(*ListOrgMembersResponse)(nil),          // 12: authlayer.v1.ListOrgMembersResponse
+	(*InviteMemberRequest)(nil),             // 13: authlayer.v1.InviteMemberRequest
+	(*InviteMemberResponse)(nil),            // 14: authlayer.v1.InviteMemberResponse
+	(*AcceptInvitationRequest)(nil),         // 15: authlayer.v1.AcceptInvitationRequest
+	(*AcceptInvitationResponse)(nil),        // 16: authlayer.v1.AcceptInvitationResponse
+	(*RemoveOrgMemberRequest)(nil),          // 17: authlayer.v1.RemoveOrgMemberRequest
+	(*RemoveOrgMemberResponse)(nil),         // 18: authlayer.v1.RemoveOrgMemberResponse
+	(*UpdateOrgMemberRoleRequest)(nil),      // 19: authlayer.v1.UpdateOrgMemberRoleRequest
+	(*UpdateOrgMemberRoleResponse)(nil),     // 20: authlayer.v1.UpdateOrgMemberRoleResponse
+	(*AttachChildOrganizationRequest)(nil),  // 21: authlayer.v1.AttachChildOrganizationRequest
+	(*AttachChildOrganizationResponse)(nil), // 22: authlayer.v1.AttachChildOrganizationResponse
+	(*DetachChildOrganizationRequest)(nil),  // 23: authlayer.v1.DetachChildOrganizationRequest
+	(*DetachChildOrganizationResponse)(nil), // 24: authlayer.v1.DetachChildOrganizationResponse
+	(*ListDescendantsRequest)(nil),          // 25: authlayer.v1.ListDescendantsRequest
+	(*ListDescendantsResponse)(nil),         // 26: authlayer.v1.ListDescendantsResponse
+	(*ListTreeMembersRequest)(nil),          // 27: authlayer.v1.ListTreeMembersRequest
+	(*TreeMemberInfo)(nil),                  // 28: authlayer.v1.TreeMemberInfo
+	(*ListTreeMembersResponse)(nil),         // 29: authlayer.v1.ListTreeMembersResponse
+	(*timestamppb.Timestamp)(nil),           // 30: google.protobuf.Timestamp
+	(*PaginationRequest)(nil),               // 31: authlayer.v1.PaginationRequest
+	(*PaginationResponse)(nil),              // 32: authlayer.v1.PaginationResponse
+	(*MemberInfo)(nil),                      // 33: authlayer.v1.MemberInfo
 }
 var file_authlayer_v1_organization_proto_depIdxs = []int32{
-	21, // 0: authlayer.v1.OrganizationInfo.created_at:type_name -> google.protobuf.Timestamp
+	30, // 0: authlayer.v1.OrganizationInfo.created_at:type_name -> google.protobuf.Timestamp
 	0,  // 1: authlayer.v1.CreateOrganizationResponse.organization:type_name -> authlayer.v1.OrganizationInfo
 	0,  // 2: authlayer.v1.GetOrganizationResponse.organization:type_name -> authlayer.v1.OrganizationInfo
 	0,  // 3: authlayer.v1.UpdateOrganizationResponse.organization:type_name -> authlayer.v1.OrganizationInfo
-	22, // 4: authlayer.v1.ListOrganizationsRequest.pagination:type_name -> authlayer.v1.PaginationRequest
+	31, // 4: authlayer.v1.ListOrganizationsRequest.pagination:type_name -> authlayer.v1.PaginationRequest
 	0,  // 5: authlayer.v1.ListOrganizationsResponse.organizations:type_name -> authlayer.v1.OrganizationInfo
-	23, // 6: authlayer.v1.ListOrganizationsResponse.pagination:type_name -> authlayer.v1.PaginationResponse
-	22, // 7: authlayer.v1.ListOrgMembersRequest.pagination:type_name -> authlayer.v1.PaginationRequest
-	24, // 8: authlayer.v1.ListOrgMembersResponse.members:type_name -> authlayer.v1.MemberInfo
-	23, // 9: authlayer.v1.ListOrgMembersResponse.pagination:type_name -> authlayer.v1.PaginationResponse
+	32, // 6: authlayer.v1.ListOrganizationsResponse.pagination:type_name -> authlayer.v1.PaginationResponse
+	31, // 7: authlayer.v1.ListOrgMembersRequest.pagination:type_name -> authlayer.v1.PaginationRequest
+	33, // 8: authlayer.v1.ListOrgMembersResponse.members:type_name -> authlayer.v1.MemberInfo
+	32, // 9: authlayer.v1.ListOrgMembersResponse.pagination:type_name -> authlayer.v1.PaginationResponse
 	0,  // 10: authlayer.v1.AcceptInvitationResponse.organization:type_name -> authlayer.v1.OrganizationInfo
-	1,  // 11: authlayer.v1.OrganizationService.CreateOrganization:input_type -> authlayer.v1.CreateOrganizationRequest
-	3,  // 12: authlayer.v1.OrganizationService.GetOrganization:input_type -> authlayer.v1.GetOrganizationRequest
-	5,  // 13: authlayer.v1.OrganizationService.UpdateOrganization:input_type -> authlayer.v1.UpdateOrganizationRequest
-	7,  // 14: authlayer.v1.OrganizationService.DeleteOrganization:input_type -> authlayer.v1.DeleteOrganizationRequest
-	9,  // 15: authlayer.v1.OrganizationService.ListOrganizations:input_type -> authlayer.v1.ListOrganizationsRequest
-	11, // 16: authlayer.v1.OrganizationService.ListMembers:input_type -> authlayer.v1.ListOrgMembersRequest
-	13, // 17: authlayer.v1.OrganizationService.InviteMember:input_type -> authlayer.v1.InviteMemberRequest
-	15, // 18: authlayer.v1.OrganizationService.AcceptInvitation:input_type -> authlayer.v1.AcceptInvitationRequest
-	17, // 19: authlayer.v1.OrganizationService.RemoveMember:input_type -> authlayer.v1.RemoveOrgMemberRequest
-	19, // 20: authlayer.v1.OrganizationService.UpdateMemberRole:input_type -> authlayer.v1.UpdateOrgMemberRoleRequest
-	2,  // 21: authlayer.v1.OrganizationService.CreateOrganization:output_type -> authlayer.v1.CreateOrganizationResponse
-	4,  // 22: authlayer.v1.OrganizationService.GetOrganization:output_type -> authlayer.v1.GetOrganizationResponse
-	6,  // 23: authlayer.v1.OrganizationService.UpdateOrganization:output_type -> authlayer.v1.UpdateOrganizationResponse
-	8,  // 24: authlayer.v1.OrganizationService.DeleteOrganization:output_type -> authlayer.v1.DeleteOrganizationResponse
-	10, // 25: authlayer.v1.OrganizationService.ListOrganizations:output_type -> authlayer.v1.ListOrganizationsResponse
-	12, // 26: authlayer.v1.OrganizationService.ListMembers:output_type -> authlayer.v1.ListOrgMembersResponse
-	14, // 27: authlayer.v1.OrganizationService.InviteMember:output_type -> authlayer.v1.InviteMemberResponse
-	16, // 28: authlayer.v1.OrganizationService.AcceptInvitation:output_type -> authlayer.v1.AcceptInvitationResponse
-	18, // 29: authlayer.v1.OrganizationService.RemoveMember:output_type -> authlayer.v1.RemoveOrgMemberResponse
-	20, // 30: authlayer.v1.OrganizationService.UpdateMemberRole:output_type -> authlayer.v1.UpdateOrgMemberRoleResponse
-	21, // [21:31] is the sub-list for method output_type
-	11, // [11:21] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	0,  // 11: authlayer.v1.AttachChildOrganizationResponse.organization:type_name -> authlayer.v1.OrganizationInfo
+	0,  // 12: authlayer.v1.ListDescendantsResponse.organizations:type_name -> authlayer.v1.OrganizationInfo
+	31, // 13: authlayer.v1.ListTreeMembersRequest.pagination:type_name -> authlayer.v1.PaginationRequest
+	33, // 14: authlayer.v1.TreeMemberInfo.member:type_name -> authlayer.v1.MemberInfo
+	28, // 15: authlayer.v1.ListTreeMembersResponse.members:type_name -> authlayer.v1.TreeMemberInfo
+	32, // 16: authlayer.v1.ListTreeMembersResponse.pagination:type_name -> authlayer.v1.PaginationResponse
+	1,  // 17: authlayer.v1.OrganizationService.CreateOrganization:input_type -> authlayer.v1.CreateOrganizationRequest
+	3,  // 18: authlayer.v1.OrganizationService.GetOrganization:input_type -> authlayer.v1.GetOrganizationRequest
+	5,  // 19: authlayer.v1.OrganizationService.UpdateOrganization:input_type -> authlayer.v1.UpdateOrganizationRequest
+	7,  // 20: authlayer.v1.OrganizationService.DeleteOrganization:input_type -> authlayer.v1.DeleteOrganizationRequest
+	9,  // 21: authlayer.v1.OrganizationService.ListOrganizations:input_type -> authlayer.v1.ListOrganizationsRequest
+	11, // 22: authlayer.v1.OrganizationService.ListMembers:input_type -> authlayer.v1.ListOrgMembersRequest
+	13, // 23: authlayer.v1.OrganizationService.InviteMember:input_type -> authlayer.v1.InviteMemberRequest
+	15, // 24: authlayer.v1.OrganizationService.AcceptInvitation:input_type -> authlayer.v1.AcceptInvitationRequest
+	17, // 25: authlayer.v1.OrganizationService.RemoveMember:input_type -> authlayer.v1.RemoveOrgMemberRequest
+	19, // 26: authlayer.v1.OrganizationService.UpdateMemberRole:input_type -> authlayer.v1.UpdateOrgMemberRoleRequest
+	21, // 27: authlayer.v1.OrganizationService.AttachChildOrganization:input_type -> authlayer.v1.AttachChildOrganizationRequest
+	23, // 28: authlayer.v1.OrganizationService.DetachChildOrganization:input_type -> authlayer.v1.DetachChildOrganizationRequest
+	25, // 29: authlayer.v1.OrganizationService.ListDescendants:input_type -> authlayer.v1.ListDescendantsRequest
+	27, // 30: authlayer.v1.OrganizationService.ListTreeMembers:input_type -> authlayer.v1.ListTreeMembersRequest
+	2,  // 31: authlayer.v1.OrganizationService.CreateOrganization:output_type -> authlayer.v1.CreateOrganizationResponse
+	4,  // 32: authlayer.v1.OrganizationService.GetOrganization:output_type -> authlayer.v1.GetOrganizationResponse
+	6,  // 33: authlayer.v1.OrganizationService.UpdateOrganization:output_type -> authlayer.v1.UpdateOrganizationResponse
+	8,  // 34: authlayer.v1.OrganizationService.DeleteOrganization:output_type -> authlayer.v1.DeleteOrganizationResponse
+	10, // 35: authlayer.v1.OrganizationService.ListOrganizations:output_type -> authlayer.v1.ListOrganizationsResponse
+	12, // 36: authlayer.v1.OrganizationService.ListMembers:output_type -> authlayer.v1.ListOrgMembersResponse
+	14, // 37: authlayer.v1.OrganizationService.InviteMember:output_type -> authlayer.v1.InviteMemberResponse
+	16, // 38: authlayer.v1.OrganizationService.AcceptInvitation:output_type -> authlayer.v1.AcceptInvitationResponse
+	18, // 39: authlayer.v1.OrganizationService.RemoveMember:output_type -> authlayer.v1.RemoveOrgMemberResponse
+	20, // 40: authlayer.v1.OrganizationService.UpdateMemberRole:output_type -> authlayer.v1.UpdateOrgMemberRoleResponse
+	22, // 41: authlayer.v1.OrganizationService.AttachChildOrganization:output_type -> authlayer.v1.AttachChildOrganizationResponse
+	24, // 42: authlayer.v1.OrganizationService.DetachChildOrganization:output_type -> authlayer.v1.DetachChildOrganizationResponse
+	26, // 43: authlayer.v1.OrganizationService.ListDescendants:output_type -> authlayer.v1.ListDescendantsResponse
+	29, // 44: authlayer.v1.OrganizationService.ListTreeMembers:output_type -> authlayer.v1.ListTreeMembersResponse
+	31, // [31:45] is the sub-list for method output_type
+	17, // [17:31] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
 }
 
 func init() { file_authlayer_v1_organization_proto_init() }
@@ -1250,6 +1775,7 @@ func file_authlayer_v1_organization_proto_init() {
 		return
 	}
 	file_authlayer_v1_common_proto_init()
+	file_authlayer_v1_organization_proto_msgTypes[0].OneofWrappers = []any{}
 	file_authlayer_v1_organization_proto_msgTypes[3].OneofWrappers = []any{
 		(*GetOrganizationRequest_Id)(nil),
 		(*GetOrganizationRequest_Slug)(nil),
@@ -1261,7 +1787,7 @@ func file_authlayer_v1_organization_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_authlayer_v1_organization_proto_rawDesc), len(file_authlayer_v1_organization_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   21,
+			NumMessages:   30,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,16 +19,20 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	OrganizationService_CreateOrganization_FullMethodName = "/authlayer.v1.OrganizationService/CreateOrganization"
-	OrganizationService_GetOrganization_FullMethodName    = "/authlayer.v1.OrganizationService/GetOrganization"
-	OrganizationService_UpdateOrganization_FullMethodName = "/authlayer.v1.OrganizationService/UpdateOrganization"
-	OrganizationService_DeleteOrganization_FullMethodName = "/authlayer.v1.OrganizationService/DeleteOrganization"
-	OrganizationService_ListOrganizations_FullMethodName  = "/authlayer.v1.OrganizationService/ListOrganizations"
-	OrganizationService_ListMembers_FullMethodName        = "/authlayer.v1.OrganizationService/ListMembers"
-	OrganizationService_InviteMember_FullMethodName       = "/authlayer.v1.OrganizationService/InviteMember"
-	OrganizationService_AcceptInvitation_FullMethodName   = "/authlayer.v1.OrganizationService/AcceptInvitation"
-	OrganizationService_RemoveMember_FullMethodName       = "/authlayer.v1.OrganizationService/RemoveMember"
-	OrganizationService_UpdateMemberRole_FullMethodName   = "/authlayer.v1.OrganizationService/UpdateMemberRole"
+	OrganizationService_CreateOrganization_FullMethodName      = "/authlayer.v1.OrganizationService/CreateOrganization"
+	OrganizationService_GetOrganization_FullMethodName         = "/authlayer.v1.OrganizationService/GetOrganization"
+	OrganizationService_UpdateOrganization_FullMethodName      = "/authlayer.v1.OrganizationService/UpdateOrganization"
+	OrganizationService_DeleteOrganization_FullMethodName      = "/authlayer.v1.OrganizationService/DeleteOrganization"
+	OrganizationService_ListOrganizations_FullMethodName       = "/authlayer.v1.OrganizationService/ListOrganizations"
+	OrganizationService_ListMembers_FullMethodName             = "/authlayer.v1.OrganizationService/ListMembers"
+	OrganizationService_InviteMember_FullMethodName            = "/authlayer.v1.OrganizationService/InviteMember"
+	OrganizationService_AcceptInvitation_FullMethodName        = "/authlayer.v1.OrganizationService/AcceptInvitation"
+	OrganizationService_RemoveMember_FullMethodName            = "/authlayer.v1.OrganizationService/RemoveMember"
+	OrganizationService_UpdateMemberRole_FullMethodName        = "/authlayer.v1.OrganizationService/UpdateMemberRole"
+	OrganizationService_AttachChildOrganization_FullMethodName = "/authlayer.v1.OrganizationService/AttachChildOrganization"
+	OrganizationService_DetachChildOrganization_FullMethodName = "/authlayer.v1.OrganizationService/DetachChildOrganization"
+	OrganizationService_ListDescendants_FullMethodName         = "/authlayer.v1.OrganizationService/ListDescendants"
+	OrganizationService_ListTreeMembers_FullMethodName         = "/authlayer.v1.OrganizationService/ListTreeMembers"
 )
 
 // OrganizationServiceClient is the client API for OrganizationService service.
@@ -45,6 +49,11 @@ type OrganizationServiceClient interface {
 	AcceptInvitation(ctx context.Context, in *AcceptInvitationRequest, opts ...grpc.CallOption) (*AcceptInvitationResponse, error)
 	RemoveMember(ctx context.Context, in *RemoveOrgMemberRequest, opts ...grpc.CallOption) (*RemoveOrgMemberResponse, error)
 	UpdateMemberRole(ctx context.Context, in *UpdateOrgMemberRoleRequest, opts ...grpc.CallOption) (*UpdateOrgMemberRoleResponse, error)
+	// Organization hierarchy
+	AttachChildOrganization(ctx context.Context, in *AttachChildOrganizationRequest, opts ...grpc.CallOption) (*AttachChildOrganizationResponse, error)
+	DetachChildOrganization(ctx context.Context, in *DetachChildOrganizationRequest, opts ...grpc.CallOption) (*DetachChildOrganizationResponse, error)
+	ListDescendants(ctx context.Context, in *ListDescendantsRequest, opts ...grpc.CallOption) (*ListDescendantsResponse, error)
+	ListTreeMembers(ctx context.Context, in *ListTreeMembersRequest, opts ...grpc.CallOption) (*ListTreeMembersResponse, error)
 }
 
 type organizationServiceClient struct {
@@ -155,6 +164,46 @@ func (c *organizationServiceClient) UpdateMemberRole(ctx context.Context, in *Up
 	return out, nil
 }
 
+func (c *organizationServiceClient) AttachChildOrganization(ctx context.Context, in *AttachChildOrganizationRequest, opts ...grpc.CallOption) (*AttachChildOrganizationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AttachChildOrganizationResponse)
+	err := c.cc.Invoke(ctx, OrganizationService_AttachChildOrganization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationServiceClient) DetachChildOrganization(ctx context.Context, in *DetachChildOrganizationRequest, opts ...grpc.CallOption) (*DetachChildOrganizationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DetachChildOrganizationResponse)
+	err := c.cc.Invoke(ctx, OrganizationService_DetachChildOrganization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationServiceClient) ListDescendants(ctx context.Context, in *ListDescendantsRequest, opts ...grpc.CallOption) (*ListDescendantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDescendantsResponse)
+	err := c.cc.Invoke(ctx, OrganizationService_ListDescendants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationServiceClient) ListTreeMembers(ctx context.Context, in *ListTreeMembersRequest, opts ...grpc.CallOption) (*ListTreeMembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTreeMembersResponse)
+	err := c.cc.Invoke(ctx, OrganizationService_ListTreeMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrganizationServiceServer is the server API for OrganizationService service.
 // All implementations must embed UnimplementedOrganizationServiceServer
 // for forward compatibility.
@@ -169,6 +218,11 @@ type OrganizationServiceServer interface {
 	AcceptInvitation(context.Context, *AcceptInvitationRequest) (*AcceptInvitationResponse, error)
 	RemoveMember(context.Context, *RemoveOrgMemberRequest) (*RemoveOrgMemberResponse, error)
 	UpdateMemberRole(context.Context, *UpdateOrgMemberRoleRequest) (*UpdateOrgMemberRoleResponse, error)
+	// Organization hierarchy
+	AttachChildOrganization(context.Context, *AttachChildOrganizationRequest) (*AttachChildOrganizationResponse, error)
+	DetachChildOrganization(context.Context, *DetachChildOrganizationRequest) (*DetachChildOrganizationResponse, error)
+	ListDescendants(context.Context, *ListDescendantsRequest) (*ListDescendantsResponse, error)
+	ListTreeMembers(context.Context, *ListTreeMembersRequest) (*ListTreeMembersResponse, error)
 	mustEmbedUnimplementedOrganizationServiceServer()
 }
 
@@ -209,6 +263,18 @@ func (UnimplementedOrganizationServiceServer) RemoveMember(context.Context, *Rem
 func (UnimplementedOrganizationServiceServer) UpdateMemberRole(context.Context, *UpdateOrgMemberRoleRequest) (*UpdateOrgMemberRoleResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateMemberRole not implemented")
 }
+func (UnimplementedOrganizationServiceServer) AttachChildOrganization(context.Context, *AttachChildOrganizationRequest) (*AttachChildOrganizationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AttachChildOrganization not implemented")
+}
+func (UnimplementedOrganizationServiceServer) DetachChildOrganization(context.Context, *DetachChildOrganizationRequest) (*DetachChildOrganizationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DetachChildOrganization not implemented")
+}
+func (UnimplementedOrganizationServiceServer) ListDescendants(context.Context, *ListDescendantsRequest) (*ListDescendantsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDescendants not implemented")
+}
+func (UnimplementedOrganizationServiceServer) ListTreeMembers(context.Context, *ListTreeMembersRequest) (*ListTreeMembersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTreeMembers not implemented")
+}
 func (UnimplementedOrganizationServiceServer) mustEmbedUnimplementedOrganizationServiceServer() {}
 func (UnimplementedOrganizationServiceServer) testEmbeddedByValue()                             {}
 
@@ -410,6 +476,78 @@ func _OrganizationService_UpdateMemberRole_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrganizationService_AttachChildOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttachChildOrganizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).AttachChildOrganization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_AttachChildOrganization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).AttachChildOrganization(ctx, req.(*AttachChildOrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizationService_DetachChildOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DetachChildOrganizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).DetachChildOrganization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_DetachChildOrganization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).DetachChildOrganization(ctx, req.(*DetachChildOrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizationService_ListDescendants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDescendantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).ListDescendants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_ListDescendants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).ListDescendants(ctx, req.(*ListDescendantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizationService_ListTreeMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTreeMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).ListTreeMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_ListTreeMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).ListTreeMembers(ctx, req.(*ListTreeMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OrganizationService_ServiceDesc is the grpc.ServiceDesc for OrganizationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -457,6 +595,22 @@ var OrganizationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateMemberRole",
 			Handler:    _OrganizationService_UpdateMemberRole_Handler,
 		},
+		{
+			MethodName: "AttachChildOrganization",
+			Handler:    _OrganizationService_AttachChildOrganization_Handler,
+		},
+		{
+			MethodName: "DetachChildOrganization",
+			Handler:    _OrganizationService_DetachChildOrganization_Handler,
+		},
+		{
+			MethodName: "ListDescendants",
+			Handler:    _OrganizationService_ListDescendants_Handler,
+		},
+		{
+			MethodName: "ListTreeMembers",
+			Handler:    _OrganizationService_ListTreeMembers_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "authlayer/v1/organization.proto",
@@ -9,6 +9,7 @@ package authlayerv1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -29,6 +30,7 @@ type RoleInfo struct {
 	OrgId         *string                `protobuf:"bytes,4,opt,name=org_id,json=orgId,proto3,oneof" json:"org_id,omitempty"`
 	ParentRoleId  *string                `protobuf:"bytes,5,opt,name=parent_role_id,json=parentRoleId,proto3,oneof" json:"parent_role_id,omitempty"`
 	Permissions   []*PermissionInfo      `protobuf:"bytes,6,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,7,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -105,6 +107,13 @@ func (x *RoleInfo) GetPermissions() []*PermissionInfo {
 	return nil
 }
 
+func (x *RoleInfo) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
 type PermissionInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -171,6 +180,7 @@ type CreateRoleRequest struct {
 	Description   *string                `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`
 	OrgId         *string                `protobuf:"bytes,3,opt,name=org_id,json=orgId,proto3,oneof" json:"org_id,omitempty"`
 	ParentRoleId  *string                `protobuf:"bytes,4,opt,name=parent_role_id,json=parentRoleId,proto3,oneof" json:"parent_role_id,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -233,6 +243,13 @@ func (x *CreateRoleRequest) GetParentRoleId() string {
 	return ""
 }
 
+func (x *CreateRoleRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
 type CreateRoleResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Role          *RoleInfo              `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
@@ -374,11 +391,13 @@ func (x *GetRoleResponse) GetInheritedPermissions() []*PermissionInfo {
 }
 
 type UpdateRoleRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RoleId        string                 `protobuf:"bytes,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
-	Name          *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
-	Description   *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
-	ParentRoleId  *string                `protobuf:"bytes,4,opt,name=parent_role_id,json=parentRoleId,proto3,oneof" json:"parent_role_id,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	RoleId       string                 `protobuf:"bytes,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	Name         *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Description  *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	ParentRoleId *string                `protobuf:"bytes,4,opt,name=parent_role_id,json=parentRoleId,proto3,oneof" json:"parent_role_id,omitempty"`
+	// Replaces all labels when set.
+	Labels        *LabelSet `protobuf:"bytes,5,opt,name=labels,proto3,oneof" json:"labels,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -441,6 +460,13 @@ func (x *UpdateRoleRequest) GetParentRoleId() string {
 	return ""
 }
 
+func (x *UpdateRoleRequest) GetLabels() *LabelSet {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
 type UpdateRoleResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Role          *RoleInfo              `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
@@ -569,6 +595,7 @@ type ListRolesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrgId         *string                `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3,oneof" json:"org_id,omitempty"`
 	Pagination    *PaginationRequest     `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	LabelSelector *string                `protobuf:"bytes,3,opt,name=label_selector,json=labelSelector,proto3,oneof" json:"label_selector,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -617,6 +644,13 @@ func (x *ListRolesRequest) GetPagination() *PaginationRequest {
 	return nil
 }
 
+func (x *ListRolesRequest) GetLabelSelector() string {
+	if x != nil && x.LabelSelector != nil {
+		return *x.LabelSelector
+	}
+	return ""
+}
+
 type ListRolesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Roles         []*RoleInfo            `protobuf:"bytes,1,rep,name=roles,proto3" json:"roles,omitempty"`
@@ -1311,8 +1345,13 @@ type CheckPermissionRequest struct {
 	PermissionName string                 `protobuf:"bytes,2,opt,name=permission_name,json=permissionName,proto3" json:"permission_name,omitempty"`
 	OrgId          *string                `protobuf:"bytes,3,opt,name=org_id,json=orgId,proto3,oneof" json:"org_id,omitempty"`
 	TeamId         *string                `protobuf:"bytes,4,opt,name=team_id,json=teamId,proto3,oneof" json:"team_id,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// When set, the check includes project grants on top of the project's org roles.
+	ProjectId *string `protobuf:"bytes,5,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	// Defaults to a user check. For PRINCIPAL_TYPE_SERVICE_ACCOUNT, user_id holds the
+	// service account ID.
+	PrincipalType PrincipalType `protobuf:"varint,6,opt,name=principal_type,json=principalType,proto3,enum=authlayer.v1.PrincipalType" json:"principal_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CheckPermissionRequest) Reset() {
@@ -1373,6 +1412,20 @@ func (x *CheckPermissionRequest) GetTeamId() string {
 	return ""
 }
 
+func (x *CheckPermissionRequest) GetProjectId() string {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return ""
+}
+
+func (x *CheckPermissionRequest) GetPrincipalType() PrincipalType {
+	if x != nil {
+		return x.PrincipalType
+	}
+	return PrincipalType_PRINCIPAL_TYPE_UNSPECIFIED
+}
+
 type CheckPermissionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Allowed       bool                   `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`
@@ -1521,121 +1574,1347 @@ func (x *GetUserPermissionsResponse) GetPermissions() []*PermissionInfo {
 	return nil
 }
 
-var File_authlayer_v1_rbac_proto protoreflect.FileDescriptor
+// RoleConstraintInfo describes a set of mutually exclusive roles.
+// If org_id is unset, the constraint applies to every organization.
+type RoleConstraintInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	OrgId         *string                `protobuf:"bytes,4,opt,name=org_id,json=orgId,proto3,oneof" json:"org_id,omitempty"`
+	Roles         []*RoleInfo            `protobuf:"bytes,5,rep,name=roles,proto3" json:"roles,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_authlayer_v1_rbac_proto_rawDesc = "" +
-	"\n" +
-	"\x17authlayer/v1/rbac.proto\x12\fauthlayer.v1\x1a\x19authlayer/v1/common.proto\"\x8a\x02\n" +
-	"\bRoleInfo\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
-	"\vdescription\x18\x03 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\x1a\n" +
-	"\x06org_id\x18\x04 \x01(\tH\x01R\x05orgId\x88\x01\x01\x12)\n" +
-	"\x0eparent_role_id\x18\x05 \x01(\tH\x02R\fparentRoleId\x88\x01\x01\x12>\n" +
-	"\vpermissions\x18\x06 \x03(\v2\x1c.authlayer.v1.PermissionInfoR\vpermissionsB\x0e\n" +
-	"\f_descriptionB\t\n" +
-	"\a_org_idB\x11\n" +
-	"\x0f_parent_role_id\"k\n" +
-	"\x0ePermissionInfo\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
-	"\vdescription\x18\x03 \x01(\tH\x00R\vdescription\x88\x01\x01B\x0e\n" +
-	"\f_description\"\xc3\x01\n" +
-	"\x11CreateRoleRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12%\n" +
-	"\vdescription\x18\x02 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\x1a\n" +
-	"\x06org_id\x18\x03 \x01(\tH\x01R\x05orgId\x88\x01\x01\x12)\n" +
-	"\x0eparent_role_id\x18\x04 \x01(\tH\x02R\fparentRoleId\x88\x01\x01B\x0e\n" +
-	"\f_descriptionB\t\n" +
-	"\a_org_idB\x11\n" +
-	"\x0f_parent_role_id\"@\n" +
-	"\x12CreateRoleResponse\x12*\n" +
-	"\x04role\x18\x01 \x01(\v2\x16.authlayer.v1.RoleInfoR\x04role\")\n" +
-	"\x0eGetRoleRequest\x12\x17\n" +
-	"\arole_id\x18\x01 \x01(\tR\x06roleId\"\x90\x01\n" +
-	"\x0fGetRoleResponse\x12*\n" +
-	"\x04role\x18\x01 \x01(\v2\x16.authlayer.v1.RoleInfoR\x04role\x12Q\n" +
-	"\x15inherited_permissions\x18\x02 \x03(\v2\x1c.authlayer.v1.PermissionInfoR\x14inheritedPermissions\"\xc3\x01\n" +
-	"\x11UpdateRoleRequest\x12\x17\n" +
-	"\arole_id\x18\x01 \x01(\tR\x06roleId\x12\x17\n" +
-	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
-	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12)\n" +
-	"\x0eparent_role_id\x18\x04 \x01(\tH\x02R\fparentRoleId\x88\x01\x01B\a\n" +
-	"\x05_nameB\x0e\n" +
-	"\f_descriptionB\x11\n" +
-	"\x0f_parent_role_id\"@\n" +
-	"\x12UpdateRoleResponse\x12*\n" +
-	"\x04role\x18\x01 \x01(\v2\x16.authlayer.v1.RoleInfoR\x04role\",\n" +
-	"\x11DeleteRoleRequest\x12\x17\n" +
-	"\arole_id\x18\x01 \x01(\tR\x06roleId\"\x14\n" +
-	"\x12DeleteRoleResponse\"z\n" +
-	"\x10ListRolesRequest\x12\x1a\n" +
-	"\x06org_id\x18\x01 \x01(\tH\x00R\x05orgId\x88\x01\x01\x12?\n" +
-	"\n" +
-	"pagination\x18\x02 \x01(\v2\x1f.authlayer.v1.PaginationRequestR\n" +
-	"paginationB\t\n" +
-	"\a_org_id\"\x83\x01\n" +
-	"\x11ListRolesResponse\x12,\n" +
-	"\x05roles\x18\x01 \x03(\v2\x16.authlayer.v1.RoleInfoR\x05roles\x12@\n" +
-	"\n" +
-	"pagination\x18\x02 \x01(\v2 .authlayer.v1.PaginationResponseR\n" +
-	"pagination\"\x82\x01\n" +
-	"\x11AssignRoleRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
-	"\arole_id\x18\x02 \x01(\tR\x06roleId\x12\x17\n" +
-	"\x06org_id\x18\x03 \x01(\tH\x00R\x05orgId\x12\x19\n" +
-	"\ateam_id\x18\x04 \x01(\tH\x00R\x06teamIdB\a\n" +
-	"\x05scope\"\x14\n" +
-	"\x12AssignRoleResponse\"\x82\x01\n" +
-	"\x11RevokeRoleRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
-	"\arole_id\x18\x02 \x01(\tR\x06roleId\x12\x17\n" +
-	"\x06org_id\x18\x03 \x01(\tH\x00R\x05orgId\x12\x19\n" +
-	"\ateam_id\x18\x04 \x01(\tH\x00R\x06teamIdB\a\n" +
-	"\x05scope\"\x14\n" +
-	"\x12RevokeRoleResponse\"d\n" +
-	"\x17CreatePermissionRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12%\n" +
-	"\vdescription\x18\x02 \x01(\tH\x00R\vdescription\x88\x01\x01B\x0e\n" +
-	"\f_description\"X\n" +
-	"\x18CreatePermissionResponse\x12<\n" +
-	"\n" +
-	"permission\x18\x01 \x01(\v2\x1c.authlayer.v1.PermissionInfoR\n" +
-	"permission\"Y\n" +
-	"\x16ListPermissionsRequest\x12?\n" +
-	"\n" +
-	"pagination\x18\x01 \x01(\v2\x1f.authlayer.v1.PaginationRequestR\n" +
-	"pagination\"\x9b\x01\n" +
-	"\x17ListPermissionsResponse\x12>\n" +
-	"\vpermissions\x18\x01 \x03(\v2\x1c.authlayer.v1.PermissionInfoR\vpermissions\x12@\n" +
-	"\n" +
-	"pagination\x18\x02 \x01(\v2 .authlayer.v1.PaginationResponseR\n" +
-	"pagination\"W\n" +
-	"\x17AssignPermissionRequest\x12\x17\n" +
-	"\arole_id\x18\x01 \x01(\tR\x06roleId\x12#\n" +
-	"\rpermission_id\x18\x02 \x01(\tR\fpermissionId\"\x1a\n" +
-	"\x18AssignPermissionResponse\"W\n" +
-	"\x17RevokePermissionRequest\x12\x17\n" +
-	"\arole_id\x18\x01 \x01(\tR\x06roleId\x12#\n" +
-	"\rpermission_id\x18\x02 \x01(\tR\fpermissionId\"\x1a\n" +
-	"\x18RevokePermissionResponse\"\xab\x01\n" +
-	"\x16CheckPermissionRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12'\n" +
-	"\x0fpermission_name\x18\x02 \x01(\tR\x0epermissionName\x12\x1a\n" +
-	"\x06org_id\x18\x03 \x01(\tH\x00R\x05orgId\x88\x01\x01\x12\x1c\n" +
-	"\ateam_id\x18\x04 \x01(\tH\x01R\x06teamId\x88\x01\x01B\t\n" +
-	"\a_org_idB\n" +
-	"\n" +
-	"\b_team_id\"V\n" +
-	"\x17CheckPermissionResponse\x12\x18\n" +
-	"\aallowed\x18\x01 \x01(\bR\aallowed\x12!\n" +
-	"\fmatched_role\x18\x02 \x01(\tR\vmatchedRole\"[\n" +
-	"\x19GetUserPermissionsRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
-	"\x06org_id\x18\x02 \x01(\tH\x00R\x05orgId\x88\x01\x01B\t\n" +
-	"\a_org_id\"\\\n" +
-	"\x1aGetUserPermissionsResponse\x12>\n" +
-	"\vpermissions\x18\x01 \x03(\v2\x1c.authlayer.v1.PermissionInfoR\vpermissions2\x8a\t\n" +
+func (x *RoleConstraintInfo) Reset() {
+	*x = RoleConstraintInfo{}
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoleConstraintInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoleConstraintInfo) ProtoMessage() {}
+
+func (x *RoleConstraintInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoleConstraintInfo.ProtoReflect.Descriptor instead.
+func (*RoleConstraintInfo) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_rbac_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RoleConstraintInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RoleConstraintInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RoleConstraintInfo) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *RoleConstraintInfo) GetOrgId() string {
+	if x != nil && x.OrgId != nil {
+		return *x.OrgId
+	}
+	return ""
+}
+
+func (x *RoleConstraintInfo) GetRoles() []*RoleInfo {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *RoleConstraintInfo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreateRoleConstraintRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   *string                `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	OrgId         *string                `protobuf:"bytes,3,opt,name=org_id,json=orgId,proto3,oneof" json:"org_id,omitempty"`
+	RoleIds       []string               `protobuf:"bytes,4,rep,name=role_ids,json=roleIds,proto3" json:"role_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRoleConstraintRequest) Reset() {
+	*x = CreateRoleConstraintRequest{}
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRoleConstraintRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRoleConstraintRequest) ProtoMessage() {}
+
+func (x *CreateRoleConstraintRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRoleConstraintRequest.ProtoReflect.Descriptor instead.
+func (*CreateRoleConstraintRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_rbac_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CreateRoleConstraintRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateRoleConstraintRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *CreateRoleConstraintRequest) GetOrgId() string {
+	if x != nil && x.OrgId != nil {
+		return *x.OrgId
+	}
+	return ""
+}
+
+func (x *CreateRoleConstraintRequest) GetRoleIds() []string {
+	if x != nil {
+		return x.RoleIds
+	}
+	return nil
+}
+
+type CreateRoleConstraintResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Constraint    *RoleConstraintInfo    `protobuf:"bytes,1,opt,name=constraint,proto3" json:"constraint,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRoleConstraintResponse) Reset() {
+	*x = CreateRoleConstraintResponse{}
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRoleConstraintResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRoleConstraintResponse) ProtoMessage() {}
+
+func (x *CreateRoleConstraintResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRoleConstraintResponse.ProtoReflect.Descriptor instead.
+func (*CreateRoleConstraintResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_rbac_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CreateRoleConstraintResponse) GetConstraint() *RoleConstraintInfo {
+	if x != nil {
+		return x.Constraint
+	}
+	return nil
+}
+
+type ListRoleConstraintsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         *string                `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3,oneof" json:"org_id,omitempty"`
+	Pagination    *PaginationRequest     `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoleConstraintsRequest) Reset() {
+	*x = ListRoleConstraintsRequest{}
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoleConstraintsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoleConstraintsRequest) ProtoMessage() {}
+
+func (x *ListRoleConstraintsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoleConstraintsRequest.ProtoReflect.Descriptor instead.
+func (*ListRoleConstraintsRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_rbac_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListRoleConstraintsRequest) GetOrgId() string {
+	if x != nil && x.OrgId != nil {
+		return *x.OrgId
+	}
+	return ""
+}
+
+func (x *ListRoleConstraintsRequest) GetPagination() *PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ListRoleConstraintsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Constraints   []*RoleConstraintInfo  `protobuf:"bytes,1,rep,name=constraints,proto3" json:"constraints,omitempty"`
+	Pagination    *PaginationResponse    `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoleConstraintsResponse) Reset() {
+	*x = ListRoleConstraintsResponse{}
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoleConstraintsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoleConstraintsResponse) ProtoMessage() {}
+
+func (x *ListRoleConstraintsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoleConstraintsResponse.ProtoReflect.Descriptor instead.
+func (*ListRoleConstraintsResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_rbac_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListRoleConstraintsResponse) GetConstraints() []*RoleConstraintInfo {
+	if x != nil {
+		return x.Constraints
+	}
+	return nil
+}
+
+func (x *ListRoleConstraintsResponse) GetPagination() *PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type DeleteRoleConstraintRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConstraintId  string                 `protobuf:"bytes,1,opt,name=constraint_id,json=constraintId,proto3" json:"constraint_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRoleConstraintRequest) Reset() {
+	*x = DeleteRoleConstraintRequest{}
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRoleConstraintRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRoleConstraintRequest) ProtoMessage() {}
+
+func (x *DeleteRoleConstraintRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRoleConstraintRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRoleConstraintRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_rbac_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DeleteRoleConstraintRequest) GetConstraintId() string {
+	if x != nil {
+		return x.ConstraintId
+	}
+	return ""
+}
+
+type DeleteRoleConstraintResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRoleConstraintResponse) Reset() {
+	*x = DeleteRoleConstraintResponse{}
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRoleConstraintResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRoleConstraintResponse) ProtoMessage() {}
+
+func (x *DeleteRoleConstraintResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRoleConstraintResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRoleConstraintResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_rbac_proto_rawDescGZIP(), []int{34}
+}
+
+type ListConstraintViolationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConstraintViolationsRequest) Reset() {
+	*x = ListConstraintViolationsRequest{}
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConstraintViolationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConstraintViolationsRequest) ProtoMessage() {}
+
+func (x *ListConstraintViolationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConstraintViolationsRequest.ProtoReflect.Descriptor instead.
+func (*ListConstraintViolationsRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_rbac_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListConstraintViolationsRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+// ConstraintViolation reports a principal that currently holds more than one
+// role of a constraint, e.g. because the constraint was created after the roles were granted.
+type ConstraintViolation struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConstraintId   string                 `protobuf:"bytes,1,opt,name=constraint_id,json=constraintId,proto3" json:"constraint_id,omitempty"`
+	ConstraintName string                 `protobuf:"bytes,2,opt,name=constraint_name,json=constraintName,proto3" json:"constraint_name,omitempty"`
+	PrincipalType  PrincipalType          `protobuf:"varint,3,opt,name=principal_type,json=principalType,proto3,enum=authlayer.v1.PrincipalType" json:"principal_type,omitempty"`
+	PrincipalId    string                 `protobuf:"bytes,4,opt,name=principal_id,json=principalId,proto3" json:"principal_id,omitempty"`
+	RoleIds        []string               `protobuf:"bytes,5,rep,name=role_ids,json=roleIds,proto3" json:"role_ids,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ConstraintViolation) Reset() {
+	*x = ConstraintViolation{}
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConstraintViolation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConstraintViolation) ProtoMessage() {}
+
+func (x *ConstraintViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConstraintViolation.ProtoReflect.Descriptor instead.
+func (*ConstraintViolation) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_rbac_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ConstraintViolation) GetConstraintId() string {
+	if x != nil {
+		return x.ConstraintId
+	}
+	return ""
+}
+
+func (x *ConstraintViolation) GetConstraintName() string {
+	if x != nil {
+		return x.ConstraintName
+	}
+	return ""
+}
+
+func (x *ConstraintViolation) GetPrincipalType() PrincipalType {
+	if x != nil {
+		return x.PrincipalType
+	}
+	return PrincipalType_PRINCIPAL_TYPE_UNSPECIFIED
+}
+
+func (x *ConstraintViolation) GetPrincipalId() string {
+	if x != nil {
+		return x.PrincipalId
+	}
+	return ""
+}
+
+func (x *ConstraintViolation) GetRoleIds() []string {
+	if x != nil {
+		return x.RoleIds
+	}
+	return nil
+}
+
+type ListConstraintViolationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Violations    []*ConstraintViolation `protobuf:"bytes,1,rep,name=violations,proto3" json:"violations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConstraintViolationsResponse) Reset() {
+	*x = ListConstraintViolationsResponse{}
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConstraintViolationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConstraintViolationsResponse) ProtoMessage() {}
+
+func (x *ListConstraintViolationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConstraintViolationsResponse.ProtoReflect.Descriptor instead.
+func (*ListConstraintViolationsResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_rbac_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListConstraintViolationsResponse) GetViolations() []*ConstraintViolation {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+// LabelRoleBinding grants role_id in org_id to every principal of principal_type
+// whose labels match selector. Users must be members of the org; for teams, the
+// role applies to the members of every matching team.
+type LabelRoleBindingInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrgId         string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	RoleId        string                 `protobuf:"bytes,3,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	PrincipalType PrincipalType          `protobuf:"varint,4,opt,name=principal_type,json=principalType,proto3,enum=authlayer.v1.PrincipalType" json:"principal_type,omitempty"`
+	Selector      string                 `protobuf:"bytes,5,opt,name=selector,proto3" json:"selector,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LabelRoleBindingInfo) Reset() {
+	*x = LabelRoleBindingInfo{}
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LabelRoleBindingInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabelRoleBindingInfo) ProtoMessage() {}
+
+func (x *LabelRoleBindingInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabelRoleBindingInfo.ProtoReflect.Descriptor instead.
+func (*LabelRoleBindingInfo) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_rbac_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *LabelRoleBindingInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LabelRoleBindingInfo) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *LabelRoleBindingInfo) GetRoleId() string {
+	if x != nil {
+		return x.RoleId
+	}
+	return ""
+}
+
+func (x *LabelRoleBindingInfo) GetPrincipalType() PrincipalType {
+	if x != nil {
+		return x.PrincipalType
+	}
+	return PrincipalType_PRINCIPAL_TYPE_UNSPECIFIED
+}
+
+func (x *LabelRoleBindingInfo) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *LabelRoleBindingInfo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreateLabelRoleBindingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	RoleId        string                 `protobuf:"bytes,2,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	PrincipalType PrincipalType          `protobuf:"varint,3,opt,name=principal_type,json=principalType,proto3,enum=authlayer.v1.PrincipalType" json:"principal_type,omitempty"`
+	Selector      string                 `protobuf:"bytes,4,opt,name=selector,proto3" json:"selector,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateLabelRoleBindingRequest) Reset() {
+	*x = CreateLabelRoleBindingRequest{}
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLabelRoleBindingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLabelRoleBindingRequest) ProtoMessage() {}
+
+func (x *CreateLabelRoleBindingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLabelRoleBindingRequest.ProtoReflect.Descriptor instead.
+func (*CreateLabelRoleBindingRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_rbac_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *CreateLabelRoleBindingRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *CreateLabelRoleBindingRequest) GetRoleId() string {
+	if x != nil {
+		return x.RoleId
+	}
+	return ""
+}
+
+func (x *CreateLabelRoleBindingRequest) GetPrincipalType() PrincipalType {
+	if x != nil {
+		return x.PrincipalType
+	}
+	return PrincipalType_PRINCIPAL_TYPE_UNSPECIFIED
+}
+
+func (x *CreateLabelRoleBindingRequest) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+type CreateLabelRoleBindingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Binding       *LabelRoleBindingInfo  `protobuf:"bytes,1,opt,name=binding,proto3" json:"binding,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateLabelRoleBindingResponse) Reset() {
+	*x = CreateLabelRoleBindingResponse{}
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLabelRoleBindingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLabelRoleBindingResponse) ProtoMessage() {}
+
+func (x *CreateLabelRoleBindingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLabelRoleBindingResponse.ProtoReflect.Descriptor instead.
+func (*CreateLabelRoleBindingResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_rbac_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CreateLabelRoleBindingResponse) GetBinding() *LabelRoleBindingInfo {
+	if x != nil {
+		return x.Binding
+	}
+	return nil
+}
+
+type ListLabelRoleBindingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Pagination    *PaginationRequest     `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLabelRoleBindingsRequest) Reset() {
+	*x = ListLabelRoleBindingsRequest{}
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLabelRoleBindingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLabelRoleBindingsRequest) ProtoMessage() {}
+
+func (x *ListLabelRoleBindingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLabelRoleBindingsRequest.ProtoReflect.Descriptor instead.
+func (*ListLabelRoleBindingsRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_rbac_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListLabelRoleBindingsRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *ListLabelRoleBindingsRequest) GetPagination() *PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ListLabelRoleBindingsResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Bindings      []*LabelRoleBindingInfo `protobuf:"bytes,1,rep,name=bindings,proto3" json:"bindings,omitempty"`
+	Pagination    *PaginationResponse     `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLabelRoleBindingsResponse) Reset() {
+	*x = ListLabelRoleBindingsResponse{}
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLabelRoleBindingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLabelRoleBindingsResponse) ProtoMessage() {}
+
+func (x *ListLabelRoleBindingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLabelRoleBindingsResponse.ProtoReflect.Descriptor instead.
+func (*ListLabelRoleBindingsResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_rbac_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListLabelRoleBindingsResponse) GetBindings() []*LabelRoleBindingInfo {
+	if x != nil {
+		return x.Bindings
+	}
+	return nil
+}
+
+func (x *ListLabelRoleBindingsResponse) GetPagination() *PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type DeleteLabelRoleBindingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BindingId     string                 `protobuf:"bytes,1,opt,name=binding_id,json=bindingId,proto3" json:"binding_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLabelRoleBindingRequest) Reset() {
+	*x = DeleteLabelRoleBindingRequest{}
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLabelRoleBindingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLabelRoleBindingRequest) ProtoMessage() {}
+
+func (x *DeleteLabelRoleBindingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLabelRoleBindingRequest.ProtoReflect.Descriptor instead.
+func (*DeleteLabelRoleBindingRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_rbac_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *DeleteLabelRoleBindingRequest) GetBindingId() string {
+	if x != nil {
+		return x.BindingId
+	}
+	return ""
+}
+
+type DeleteLabelRoleBindingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLabelRoleBindingResponse) Reset() {
+	*x = DeleteLabelRoleBindingResponse{}
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLabelRoleBindingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLabelRoleBindingResponse) ProtoMessage() {}
+
+func (x *DeleteLabelRoleBindingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLabelRoleBindingResponse.ProtoReflect.Descriptor instead.
+func (*DeleteLabelRoleBindingResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_rbac_proto_rawDescGZIP(), []int{44}
+}
+
+// Filters are optional; an empty filter matches every principal or scope. Only servers
+// running with MATERIALIZED_PERMISSIONS=true record changes; others reject the request
+// with FAILED_PRECONDITION.
+type WatchPermissionChangesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Replay the changes made after this revision. When unset, only changes made from now
+	// on are sent.
+	FromRevision  *int64        `protobuf:"varint,1,opt,name=from_revision,json=fromRevision,proto3,oneof" json:"from_revision,omitempty"`
+	PrincipalType PrincipalType `protobuf:"varint,2,opt,name=principal_type,json=principalType,proto3,enum=authlayer.v1.PrincipalType" json:"principal_type,omitempty"`
+	PrincipalId   string        `protobuf:"bytes,3,opt,name=principal_id,json=principalId,proto3" json:"principal_id,omitempty"`
+	// "global", "org" or "project".
+	ScopeType     string `protobuf:"bytes,4,opt,name=scope_type,json=scopeType,proto3" json:"scope_type,omitempty"`
+	ScopeId       string `protobuf:"bytes,5,opt,name=scope_id,json=scopeId,proto3" json:"scope_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchPermissionChangesRequest) Reset() {
+	*x = WatchPermissionChangesRequest{}
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchPermissionChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchPermissionChangesRequest) ProtoMessage() {}
+
+func (x *WatchPermissionChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchPermissionChangesRequest.ProtoReflect.Descriptor instead.
+func (*WatchPermissionChangesRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_rbac_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *WatchPermissionChangesRequest) GetFromRevision() int64 {
+	if x != nil && x.FromRevision != nil {
+		return *x.FromRevision
+	}
+	return 0
+}
+
+func (x *WatchPermissionChangesRequest) GetPrincipalType() PrincipalType {
+	if x != nil {
+		return x.PrincipalType
+	}
+	return PrincipalType_PRINCIPAL_TYPE_UNSPECIFIED
+}
+
+func (x *WatchPermissionChangesRequest) GetPrincipalId() string {
+	if x != nil {
+		return x.PrincipalId
+	}
+	return ""
+}
+
+func (x *WatchPermissionChangesRequest) GetScopeType() string {
+	if x != nil {
+		return x.ScopeType
+	}
+	return ""
+}
+
+func (x *WatchPermissionChangesRequest) GetScopeId() string {
+	if x != nil {
+		return x.ScopeId
+	}
+	return ""
+}
+
+// PermissionChangeEvent reports the permissions a principal gained or lost in a scope.
+// Revisions increase strictly; resume a stream from the last revision received.
+type PermissionChangeEvent struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Revision           int64                  `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	PrincipalType      PrincipalType          `protobuf:"varint,2,opt,name=principal_type,json=principalType,proto3,enum=authlayer.v1.PrincipalType" json:"principal_type,omitempty"`
+	PrincipalId        string                 `protobuf:"bytes,3,opt,name=principal_id,json=principalId,proto3" json:"principal_id,omitempty"`
+	ScopeType          string                 `protobuf:"bytes,4,opt,name=scope_type,json=scopeType,proto3" json:"scope_type,omitempty"`
+	ScopeId            string                 `protobuf:"bytes,5,opt,name=scope_id,json=scopeId,proto3" json:"scope_id,omitempty"`
+	AddedPermissions   []string               `protobuf:"bytes,6,rep,name=added_permissions,json=addedPermissions,proto3" json:"added_permissions,omitempty"`
+	RemovedPermissions []string               `protobuf:"bytes,7,rep,name=removed_permissions,json=removedPermissions,proto3" json:"removed_permissions,omitempty"`
+	ChangedAt          *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *PermissionChangeEvent) Reset() {
+	*x = PermissionChangeEvent{}
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PermissionChangeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PermissionChangeEvent) ProtoMessage() {}
+
+func (x *PermissionChangeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_rbac_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PermissionChangeEvent.ProtoReflect.Descriptor instead.
+func (*PermissionChangeEvent) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_rbac_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *PermissionChangeEvent) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *PermissionChangeEvent) GetPrincipalType() PrincipalType {
+	if x != nil {
+		return x.PrincipalType
+	}
+	return PrincipalType_PRINCIPAL_TYPE_UNSPECIFIED
+}
+
+func (x *PermissionChangeEvent) GetPrincipalId() string {
+	if x != nil {
+		return x.PrincipalId
+	}
+	return ""
+}
+
+func (x *PermissionChangeEvent) GetScopeType() string {
+	if x != nil {
+		return x.ScopeType
+	}
+	return ""
+}
+
+func (x *PermissionChangeEvent) GetScopeId() string {
+	if x != nil {
+		return x.ScopeId
+	}
+	return ""
+}
+
+func (x *PermissionChangeEvent) GetAddedPermissions() []string {
+	if x != nil {
+		return x.AddedPermissions
+	}
+	return nil
+}
+
+func (x *PermissionChangeEvent) GetRemovedPermissions() []string {
+	if x != nil {
+		return x.RemovedPermissions
+	}
+	return nil
+}
+
+func (x *PermissionChangeEvent) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+var File_authlayer_v1_rbac_proto protoreflect.FileDescriptor
+
+const file_authlayer_v1_rbac_proto_rawDesc = "" +
+	"\n" +
+	"\x17authlayer/v1/rbac.proto\x12\fauthlayer.v1\x1a\x19authlayer/v1/common.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x81\x03\n" +
+	"\bRoleInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\x1a\n" +
+	"\x06org_id\x18\x04 \x01(\tH\x01R\x05orgId\x88\x01\x01\x12)\n" +
+	"\x0eparent_role_id\x18\x05 \x01(\tH\x02R\fparentRoleId\x88\x01\x01\x12>\n" +
+	"\vpermissions\x18\x06 \x03(\v2\x1c.authlayer.v1.PermissionInfoR\vpermissions\x12:\n" +
+	"\x06labels\x18\a \x03(\v2\".authlayer.v1.RoleInfo.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x0e\n" +
+	"\f_descriptionB\t\n" +
+	"\a_org_idB\x11\n" +
+	"\x0f_parent_role_id\"k\n" +
+	"\x0ePermissionInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x00R\vdescription\x88\x01\x01B\x0e\n" +
+	"\f_description\"\xc3\x02\n" +
+	"\x11CreateRoleRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12%\n" +
+	"\vdescription\x18\x02 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\x1a\n" +
+	"\x06org_id\x18\x03 \x01(\tH\x01R\x05orgId\x88\x01\x01\x12)\n" +
+	"\x0eparent_role_id\x18\x04 \x01(\tH\x02R\fparentRoleId\x88\x01\x01\x12C\n" +
+	"\x06labels\x18\x05 \x03(\v2+.authlayer.v1.CreateRoleRequest.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x0e\n" +
+	"\f_descriptionB\t\n" +
+	"\a_org_idB\x11\n" +
+	"\x0f_parent_role_id\"@\n" +
+	"\x12CreateRoleResponse\x12*\n" +
+	"\x04role\x18\x01 \x01(\v2\x16.authlayer.v1.RoleInfoR\x04role\")\n" +
+	"\x0eGetRoleRequest\x12\x17\n" +
+	"\arole_id\x18\x01 \x01(\tR\x06roleId\"\x90\x01\n" +
+	"\x0fGetRoleResponse\x12*\n" +
+	"\x04role\x18\x01 \x01(\v2\x16.authlayer.v1.RoleInfoR\x04role\x12Q\n" +
+	"\x15inherited_permissions\x18\x02 \x03(\v2\x1c.authlayer.v1.PermissionInfoR\x14inheritedPermissions\"\x83\x02\n" +
+	"\x11UpdateRoleRequest\x12\x17\n" +
+	"\arole_id\x18\x01 \x01(\tR\x06roleId\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12)\n" +
+	"\x0eparent_role_id\x18\x04 \x01(\tH\x02R\fparentRoleId\x88\x01\x01\x123\n" +
+	"\x06labels\x18\x05 \x01(\v2\x16.authlayer.v1.LabelSetH\x03R\x06labels\x88\x01\x01B\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_descriptionB\x11\n" +
+	"\x0f_parent_role_idB\t\n" +
+	"\a_labels\"@\n" +
+	"\x12UpdateRoleResponse\x12*\n" +
+	"\x04role\x18\x01 \x01(\v2\x16.authlayer.v1.RoleInfoR\x04role\",\n" +
+	"\x11DeleteRoleRequest\x12\x17\n" +
+	"\arole_id\x18\x01 \x01(\tR\x06roleId\"\x14\n" +
+	"\x12DeleteRoleResponse\"\xb9\x01\n" +
+	"\x10ListRolesRequest\x12\x1a\n" +
+	"\x06org_id\x18\x01 \x01(\tH\x00R\x05orgId\x88\x01\x01\x12?\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1f.authlayer.v1.PaginationRequestR\n" +
+	"pagination\x12*\n" +
+	"\x0elabel_selector\x18\x03 \x01(\tH\x01R\rlabelSelector\x88\x01\x01B\t\n" +
+	"\a_org_idB\x11\n" +
+	"\x0f_label_selector\"\x83\x01\n" +
+	"\x11ListRolesResponse\x12,\n" +
+	"\x05roles\x18\x01 \x03(\v2\x16.authlayer.v1.RoleInfoR\x05roles\x12@\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2 .authlayer.v1.PaginationResponseR\n" +
+	"pagination\"\x82\x01\n" +
+	"\x11AssignRoleRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\arole_id\x18\x02 \x01(\tR\x06roleId\x12\x17\n" +
+	"\x06org_id\x18\x03 \x01(\tH\x00R\x05orgId\x12\x19\n" +
+	"\ateam_id\x18\x04 \x01(\tH\x00R\x06teamIdB\a\n" +
+	"\x05scope\"\x14\n" +
+	"\x12AssignRoleResponse\"\x82\x01\n" +
+	"\x11RevokeRoleRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\arole_id\x18\x02 \x01(\tR\x06roleId\x12\x17\n" +
+	"\x06org_id\x18\x03 \x01(\tH\x00R\x05orgId\x12\x19\n" +
+	"\ateam_id\x18\x04 \x01(\tH\x00R\x06teamIdB\a\n" +
+	"\x05scope\"\x14\n" +
+	"\x12RevokeRoleResponse\"d\n" +
+	"\x17CreatePermissionRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12%\n" +
+	"\vdescription\x18\x02 \x01(\tH\x00R\vdescription\x88\x01\x01B\x0e\n" +
+	"\f_description\"X\n" +
+	"\x18CreatePermissionResponse\x12<\n" +
+	"\n" +
+	"permission\x18\x01 \x01(\v2\x1c.authlayer.v1.PermissionInfoR\n" +
+	"permission\"Y\n" +
+	"\x16ListPermissionsRequest\x12?\n" +
+	"\n" +
+	"pagination\x18\x01 \x01(\v2\x1f.authlayer.v1.PaginationRequestR\n" +
+	"pagination\"\x9b\x01\n" +
+	"\x17ListPermissionsResponse\x12>\n" +
+	"\vpermissions\x18\x01 \x03(\v2\x1c.authlayer.v1.PermissionInfoR\vpermissions\x12@\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2 .authlayer.v1.PaginationResponseR\n" +
+	"pagination\"W\n" +
+	"\x17AssignPermissionRequest\x12\x17\n" +
+	"\arole_id\x18\x01 \x01(\tR\x06roleId\x12#\n" +
+	"\rpermission_id\x18\x02 \x01(\tR\fpermissionId\"\x1a\n" +
+	"\x18AssignPermissionResponse\"W\n" +
+	"\x17RevokePermissionRequest\x12\x17\n" +
+	"\arole_id\x18\x01 \x01(\tR\x06roleId\x12#\n" +
+	"\rpermission_id\x18\x02 \x01(\tR\fpermissionId\"\x1a\n" +
+	"\x18RevokePermissionResponse\"\xa2\x02\n" +
+	"\x16CheckPermissionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12'\n" +
+	"\x0fpermission_name\x18\x02 \x01(\tR\x0epermissionName\x12\x1a\n" +
+	"\x06org_id\x18\x03 \x01(\tH\x00R\x05orgId\x88\x01\x01\x12\x1c\n" +
+	"\ateam_id\x18\x04 \x01(\tH\x01R\x06teamId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"project_id\x18\x05 \x01(\tH\x02R\tprojectId\x88\x01\x01\x12B\n" +
+	"\x0eprincipal_type\x18\x06 \x01(\x0e2\x1b.authlayer.v1.PrincipalTypeR\rprincipalTypeB\t\n" +
+	"\a_org_idB\n" +
+	"\n" +
+	"\b_team_idB\r\n" +
+	"\v_project_id\"V\n" +
+	"\x17CheckPermissionResponse\x12\x18\n" +
+	"\aallowed\x18\x01 \x01(\bR\aallowed\x12!\n" +
+	"\fmatched_role\x18\x02 \x01(\tR\vmatchedRole\"[\n" +
+	"\x19GetUserPermissionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\x06org_id\x18\x02 \x01(\tH\x00R\x05orgId\x88\x01\x01B\t\n" +
+	"\a_org_id\"\\\n" +
+	"\x1aGetUserPermissionsResponse\x12>\n" +
+	"\vpermissions\x18\x01 \x03(\v2\x1c.authlayer.v1.PermissionInfoR\vpermissions\"\xff\x01\n" +
+	"\x12RoleConstraintInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\x1a\n" +
+	"\x06org_id\x18\x04 \x01(\tH\x01R\x05orgId\x88\x01\x01\x12,\n" +
+	"\x05roles\x18\x05 \x03(\v2\x16.authlayer.v1.RoleInfoR\x05roles\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\x0e\n" +
+	"\f_descriptionB\t\n" +
+	"\a_org_id\"\xaa\x01\n" +
+	"\x1bCreateRoleConstraintRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12%\n" +
+	"\vdescription\x18\x02 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\x1a\n" +
+	"\x06org_id\x18\x03 \x01(\tH\x01R\x05orgId\x88\x01\x01\x12\x19\n" +
+	"\brole_ids\x18\x04 \x03(\tR\aroleIdsB\x0e\n" +
+	"\f_descriptionB\t\n" +
+	"\a_org_id\"`\n" +
+	"\x1cCreateRoleConstraintResponse\x12@\n" +
+	"\n" +
+	"constraint\x18\x01 \x01(\v2 .authlayer.v1.RoleConstraintInfoR\n" +
+	"constraint\"\x84\x01\n" +
+	"\x1aListRoleConstraintsRequest\x12\x1a\n" +
+	"\x06org_id\x18\x01 \x01(\tH\x00R\x05orgId\x88\x01\x01\x12?\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1f.authlayer.v1.PaginationRequestR\n" +
+	"paginationB\t\n" +
+	"\a_org_id\"\xa3\x01\n" +
+	"\x1bListRoleConstraintsResponse\x12B\n" +
+	"\vconstraints\x18\x01 \x03(\v2 .authlayer.v1.RoleConstraintInfoR\vconstraints\x12@\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2 .authlayer.v1.PaginationResponseR\n" +
+	"pagination\"B\n" +
+	"\x1bDeleteRoleConstraintRequest\x12#\n" +
+	"\rconstraint_id\x18\x01 \x01(\tR\fconstraintId\"\x1e\n" +
+	"\x1cDeleteRoleConstraintResponse\"8\n" +
+	"\x1fListConstraintViolationsRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\"\xe5\x01\n" +
+	"\x13ConstraintViolation\x12#\n" +
+	"\rconstraint_id\x18\x01 \x01(\tR\fconstraintId\x12'\n" +
+	"\x0fconstraint_name\x18\x02 \x01(\tR\x0econstraintName\x12B\n" +
+	"\x0eprincipal_type\x18\x03 \x01(\x0e2\x1b.authlayer.v1.PrincipalTypeR\rprincipalType\x12!\n" +
+	"\fprincipal_id\x18\x04 \x01(\tR\vprincipalId\x12\x19\n" +
+	"\brole_ids\x18\x05 \x03(\tR\aroleIds\"e\n" +
+	" ListConstraintViolationsResponse\x12A\n" +
+	"\n" +
+	"violations\x18\x01 \x03(\v2!.authlayer.v1.ConstraintViolationR\n" +
+	"violations\"\xf1\x01\n" +
+	"\x14LabelRoleBindingInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x17\n" +
+	"\arole_id\x18\x03 \x01(\tR\x06roleId\x12B\n" +
+	"\x0eprincipal_type\x18\x04 \x01(\x0e2\x1b.authlayer.v1.PrincipalTypeR\rprincipalType\x12\x1a\n" +
+	"\bselector\x18\x05 \x01(\tR\bselector\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xaf\x01\n" +
+	"\x1dCreateLabelRoleBindingRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x17\n" +
+	"\arole_id\x18\x02 \x01(\tR\x06roleId\x12B\n" +
+	"\x0eprincipal_type\x18\x03 \x01(\x0e2\x1b.authlayer.v1.PrincipalTypeR\rprincipalType\x12\x1a\n" +
+	"\bselector\x18\x04 \x01(\tR\bselector\"^\n" +
+	"\x1eCreateLabelRoleBindingResponse\x12<\n" +
+	"\abinding\x18\x01 \x01(\v2\".authlayer.v1.LabelRoleBindingInfoR\abinding\"v\n" +
+	"\x1cListLabelRoleBindingsRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12?\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1f.authlayer.v1.PaginationRequestR\n" +
+	"pagination\"\xa1\x01\n" +
+	"\x1dListLabelRoleBindingsResponse\x12>\n" +
+	"\bbindings\x18\x01 \x03(\v2\".authlayer.v1.LabelRoleBindingInfoR\bbindings\x12@\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2 .authlayer.v1.PaginationResponseR\n" +
+	"pagination\">\n" +
+	"\x1dDeleteLabelRoleBindingRequest\x12\x1d\n" +
+	"\n" +
+	"binding_id\x18\x01 \x01(\tR\tbindingId\" \n" +
+	"\x1eDeleteLabelRoleBindingResponse\"\xfc\x01\n" +
+	"\x1dWatchPermissionChangesRequest\x12(\n" +
+	"\rfrom_revision\x18\x01 \x01(\x03H\x00R\ffromRevision\x88\x01\x01\x12B\n" +
+	"\x0eprincipal_type\x18\x02 \x01(\x0e2\x1b.authlayer.v1.PrincipalTypeR\rprincipalType\x12!\n" +
+	"\fprincipal_id\x18\x03 \x01(\tR\vprincipalId\x12\x1d\n" +
+	"\n" +
+	"scope_type\x18\x04 \x01(\tR\tscopeType\x12\x19\n" +
+	"\bscope_id\x18\x05 \x01(\tR\ascopeIdB\x10\n" +
+	"\x0e_from_revision\"\xed\x02\n" +
+	"\x15PermissionChangeEvent\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\x03R\brevision\x12B\n" +
+	"\x0eprincipal_type\x18\x02 \x01(\x0e2\x1b.authlayer.v1.PrincipalTypeR\rprincipalType\x12!\n" +
+	"\fprincipal_id\x18\x03 \x01(\tR\vprincipalId\x12\x1d\n" +
+	"\n" +
+	"scope_type\x18\x04 \x01(\tR\tscopeType\x12\x19\n" +
+	"\bscope_id\x18\x05 \x01(\tR\ascopeId\x12+\n" +
+	"\x11added_permissions\x18\x06 \x03(\tR\x10addedPermissions\x12/\n" +
+	"\x13removed_permissions\x18\a \x03(\tR\x12removedPermissions\x129\n" +
+	"\n" +
+	"changed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tchangedAt2\x99\x10\n" +
 	"\vRBACService\x12O\n" +
 	"\n" +
 	"CreateRole\x12\x1f.authlayer.v1.CreateRoleRequest\x1a .authlayer.v1.CreateRoleResponse\x12F\n" +
@@ -1654,7 +2933,15 @@ const file_authlayer_v1_rbac_proto_rawDesc = "" +
 	"\x10AssignPermission\x12%.authlayer.v1.AssignPermissionRequest\x1a&.authlayer.v1.AssignPermissionResponse\x12a\n" +
 	"\x10RevokePermission\x12%.authlayer.v1.RevokePermissionRequest\x1a&.authlayer.v1.RevokePermissionResponse\x12^\n" +
 	"\x0fCheckPermission\x12$.authlayer.v1.CheckPermissionRequest\x1a%.authlayer.v1.CheckPermissionResponse\x12g\n" +
-	"\x12GetUserPermissions\x12'.authlayer.v1.GetUserPermissionsRequest\x1a(.authlayer.v1.GetUserPermissionsResponseBJZHgithub.com/bernardoforcillo/authlayer/pkg/proto/authlayer/v1;authlayerv1b\x06proto3"
+	"\x12GetUserPermissions\x12'.authlayer.v1.GetUserPermissionsRequest\x1a(.authlayer.v1.GetUserPermissionsResponse\x12m\n" +
+	"\x14CreateRoleConstraint\x12).authlayer.v1.CreateRoleConstraintRequest\x1a*.authlayer.v1.CreateRoleConstraintResponse\x12j\n" +
+	"\x13ListRoleConstraints\x12(.authlayer.v1.ListRoleConstraintsRequest\x1a).authlayer.v1.ListRoleConstraintsResponse\x12m\n" +
+	"\x14DeleteRoleConstraint\x12).authlayer.v1.DeleteRoleConstraintRequest\x1a*.authlayer.v1.DeleteRoleConstraintResponse\x12y\n" +
+	"\x18ListConstraintViolations\x12-.authlayer.v1.ListConstraintViolationsRequest\x1a..authlayer.v1.ListConstraintViolationsResponse\x12s\n" +
+	"\x16CreateLabelRoleBinding\x12+.authlayer.v1.CreateLabelRoleBindingRequest\x1a,.authlayer.v1.CreateLabelRoleBindingResponse\x12p\n" +
+	"\x15ListLabelRoleBindings\x12*.authlayer.v1.ListLabelRoleBindingsRequest\x1a+.authlayer.v1.ListLabelRoleBindingsResponse\x12s\n" +
+	"\x16DeleteLabelRoleBinding\x12+.authlayer.v1.DeleteLabelRoleBindingRequest\x1a,.authlayer.v1.DeleteLabelRoleBindingResponse\x12l\n" +
+	"\x16WatchPermissionChanges\x12+.authlayer.v1.WatchPermissionChangesRequest\x1a#.authlayer.v1.PermissionChangeEvent0\x01BJZHgithub.com/bernardoforcillo/authlayer/pkg/proto/authlayer/v1;authlayerv1b\x06proto3"
 
 var (
 	file_authlayer_v1_rbac_proto_rawDescOnce sync.Once
@@ -1668,84 +2955,146 @@ func file_authlayer_v1_rbac_proto_rawDescGZIP() []byte {
 	return file_authlayer_v1_rbac_proto_rawDescData
 }
 
-var file_authlayer_v1_rbac_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
+var file_authlayer_v1_rbac_proto_msgTypes = make([]protoimpl.MessageInfo, 49)
 var file_authlayer_v1_rbac_proto_goTypes = []any{
-	(*RoleInfo)(nil),                   // 0: authlayer.v1.RoleInfo
-	(*PermissionInfo)(nil),             // 1: authlayer.v1.PermissionInfo
-	(*CreateRoleRequest)(nil),          // 2: authlayer.v1.CreateRoleRequest
-	(*CreateRoleResponse)(nil),         // 3: authlayer.v1.CreateRoleResponse
-	(*GetRoleRequest)(nil),             // 4: authlayer.v1.GetRoleRequest
-	(*GetRoleResponse)(nil),            // 5: authlayer.v1.GetRoleResponse
-	(*UpdateRoleRequest)(nil),          // 6: authlayer.v1.UpdateRoleRequest
-	(*UpdateRoleResponse)(nil),         // 7: authlayer.v1.UpdateRoleResponse
-	(*DeleteRoleRequest)(nil),          // 8: authlayer.v1.DeleteRoleRequest
-	(*DeleteRoleResponse)(nil),         // 9: authlayer.v1.DeleteRoleResponse
-	(*ListRolesRequest)(nil),           // 10: authlayer.v1.ListRolesRequest
-	(*ListRolesResponse)(nil),          // 11: authlayer.v1.ListRolesResponse
-	(*AssignRoleRequest)(nil),          // 12: authlayer.v1.AssignRoleRequest
-	(*AssignRoleResponse)(nil),         // 13: authlayer.v1.AssignRoleResponse
-	(*RevokeRoleRequest)(nil),          // 14: authlayer.v1.RevokeRoleRequest
-	(*RevokeRoleResponse)(nil),         // 15: authlayer.v1.RevokeRoleResponse
-	(*CreatePermissionRequest)(nil),    // 16: authlayer.v1.CreatePermissionRequest
-	(*CreatePermissionResponse)(nil),   // 17: authlayer.v1.CreatePermissionResponse
-	(*ListPermissionsRequest)(nil),     // 18: authlayer.v1.ListPermissionsRequest
-	(*ListPermissionsResponse)(nil),    // 19: authlayer.v1.ListPermissionsResponse
-	(*AssignPermissionRequest)(nil),    // 20: authlayer.v1.AssignPermissionRequest
-	(*AssignPermissionResponse)(nil),   // 21: authlayer.v1.AssignPermissionResponse
-	(*RevokePermissionRequest)(nil),    // 22: authlayer.v1.RevokePermissionRequest
-	(*RevokePermissionResponse)(nil),   // 23: authlayer.v1.RevokePermissionResponse
-	(*CheckPermissionRequest)(nil),     // 24: authlayer.v1.CheckPermissionRequest
-	(*CheckPermissionResponse)(nil),    // 25: authlayer.v1.CheckPermissionResponse
-	(*GetUserPermissionsRequest)(nil),  // 26: authlayer.v1.GetUserPermissionsRequest
-	(*GetUserPermissionsResponse)(nil), // 27: authlayer.v1.GetUserPermissionsResponse
-	(*PaginationRequest)(nil),          // 28: authlayer.v1.PaginationRequest
-	(*PaginationResponse)(nil),         // 29: authlayer.v1.PaginationResponse
+	(*RoleInfo)(nil),                         // 0: authlayer.v1.RoleInfo
+	(*PermissionInfo)(nil),                   // 1: authlayer.v1.PermissionInfo
+	(*CreateRoleRequest)(nil),                // 2: authlayer.v1.CreateRoleRequest
+	(*CreateRoleResponse)(nil),               // 3: authlayer.v1.CreateRoleResponse
+	(*GetRoleRequest)(nil),                   // 4: authlayer.v1.GetRoleRequest
+	(*GetRoleResponse)(nil),                  // 5: authlayer.v1.GetRoleResponse
+	(*UpdateRoleRequest)(nil),                // 6: authlayer.v1.UpdateRoleRequest
+	(*UpdateRoleResponse)(nil),               // 7: authlayer.v1.UpdateRoleResponse
+	(*DeleteRoleRequest)(nil),                // 8: authlayer.v1.DeleteRoleRequest
+	(*DeleteRoleResponse)(nil),               // 9: authlayer.v1.DeleteRoleResponse
+	(*ListRolesRequest)(nil),                 // 10: authlayer.v1.ListRolesRequest
+	(*ListRolesResponse)(nil),                // 11: authlayer.v1.ListRolesResponse
+	(*AssignRoleRequest)(nil),                // 12: authlayer.v1.AssignRoleRequest
+	(*AssignRoleResponse)(nil),               // 13: authlayer.v1.AssignRoleResponse
+	(*RevokeRoleRequest)(nil),                // 14: authlayer.v1.RevokeRoleRequest
+	(*RevokeRoleResponse)(nil),               // 15: authlayer.v1.RevokeRoleResponse
+	(*CreatePermissionRequest)(nil),          // 16: authlayer.v1.CreatePermissionRequest
+	(*CreatePermissionResponse)(nil),         // 17: authlayer.v1.CreatePermissionResponse
+	(*ListPermissionsRequest)(nil),           // 18: authlayer.v1.ListPermissionsRequest
+	(*ListPermissionsResponse)(nil),          // 19: authlayer.v1.ListPermissionsResponse
+	(*AssignPermissionRequest)(nil),          // 20: authlayer.v1.AssignPermissionRequest
+	(*AssignPermissionResponse)(nil),         // 21: authlayer.v1.AssignPermissionResponse
+	(*RevokePermissionRequest)(nil),          // 22: authlayer.v1.RevokePermissionRequest
+	(*RevokePermissionResponse)(nil),         // 23: authlayer.v1.RevokePermissionResponse
+	(*CheckPermissionRequest)(nil),           // 24: authlayer.v1.CheckPermissionRequest
+	(*CheckPermissionResponse)(nil),          // 25: authlayer.v1.CheckPermissionResponse
+	(*GetUserPermissionsRequest)(nil),        // 26: authlayer.v1.GetUserPermissionsRequest
+	(*GetUserPermissionsResponse)(nil),       // 27: authlayer.v1.GetUserPermissionsResponse
+	(*RoleConstraintInfo)(nil),               // 28: authlayer.v1.RoleConstraintInfo
+	(*CreateRoleConstraintRequest)(nil),      // 29: authlayer.v1.CreateRoleConstraintRequest
+	(*CreateRoleConstraintResponse)(nil),     // 30: authlayer.v1.CreateRoleConstraintResponse
+	(*ListRoleConstraintsRequest)(nil),       // 31: authlayer.v1.ListRoleConstraintsRequest
+	(*ListRoleConstraintsResponse)(nil),      // 32: authlayer.v1.ListRoleConstraintsResponse
+	(*DeleteRoleConstraintRequest)(nil),      // 33: authlayer.v1.DeleteRoleConstraintRequest
+	(*DeleteRoleConstraintResponse)(nil),     // 34: authlayer.v1.DeleteRoleConstraintResponse
+	(*ListConstraintViolationsRequest)(nil),  // 35: authlayer.v1.ListConstraintViolationsRequest
+	(*ConstraintViolation)(nil),              // 36: authlayer.v1.ConstraintViolation
+	(*ListConstraintViolationsResponse)(nil), // 37: authlayer.v1.ListConstraintViolationsResponse
+	(*LabelRoleBindingInfo)(nil),             // 38: authlayer.v1.LabelRoleBindingInfo
+	(*CreateLabelRoleBindingRequest)(nil),    // 39: authlayer.v1.CreateLabelRoleBindingRequest
+	(*CreateLabelRoleBindingResponse)(nil),   // 40: authlayer.v1.CreateLabelRoleBindingResponse
+	(*ListLabelRoleBindingsRequest)(nil),     // 41: authlayer.v1.ListLabelRoleBindingsRequest
+	(*ListLabelRoleBindingsResponse)(nil),    // 42: authlayer.v1.ListLabelRoleBindingsResponse
+	(*DeleteLabelRoleBindingRequest)(nil),    // 43: authlayer.v1.DeleteLabelRoleBindingRequest
+	(*DeleteLabelRoleBindingResponse)(nil),   // 44: authlayer.v1.DeleteLabelRoleBindingResponse
+	(*WatchPermissionChangesRequest)(nil),    // 45: authlayer.v1.WatchPermissionChangesRequest
+	(*PermissionChangeEvent)(nil),            // 46: authlayer.v1.PermissionChangeEvent
+	nil,                                      // 47: authlayer.v1.RoleInfo.LabelsEntry
+	nil,                                      // 48: authlayer.v1.CreateRoleRequest.LabelsEntry
+	(*LabelSet)(nil),                         // 49: authlayer.v1.LabelSet
+	(*PaginationRequest)(nil),                // 50: authlayer.v1.PaginationRequest
+	(*PaginationResponse)(nil),               // 51: authlayer.v1.PaginationResponse
+	(PrincipalType)(0),                       // 52: authlayer.v1.PrincipalType
+	(*timestamppb.Timestamp)(nil),            // 53: google.protobuf.Timestamp
 }
 var file_authlayer_v1_rbac_proto_depIdxs = []int32{
 	1,  // 0: authlayer.v1.RoleInfo.permissions:type_name -> authlayer.v1.PermissionInfo
-	0,  // 1: authlayer.v1.CreateRoleResponse.role:type_name -> authlayer.v1.RoleInfo
-	0,  // 2: authlayer.v1.GetRoleResponse.role:type_name -> authlayer.v1.RoleInfo
-	1,  // 3: authlayer.v1.GetRoleResponse.inherited_permissions:type_name -> authlayer.v1.PermissionInfo
-	0,  // 4: authlayer.v1.UpdateRoleResponse.role:type_name -> authlayer.v1.RoleInfo
-	28, // 5: authlayer.v1.ListRolesRequest.pagination:type_name -> authlayer.v1.PaginationRequest
-	0,  // 6: authlayer.v1.ListRolesResponse.roles:type_name -> authlayer.v1.RoleInfo
-	29, // 7: authlayer.v1.ListRolesResponse.pagination:type_name -> authlayer.v1.PaginationResponse
-	1,  // 8: authlayer.v1.CreatePermissionResponse.permission:type_name -> authlayer.v1.PermissionInfo
-	28, // 9: authlayer.v1.ListPermissionsRequest.pagination:type_name -> authlayer.v1.PaginationRequest
-	1,  // 10: authlayer.v1.ListPermissionsResponse.permissions:type_name -> authlayer.v1.PermissionInfo
-	29, // 11: authlayer.v1.ListPermissionsResponse.pagination:type_name -> authlayer.v1.PaginationResponse
-	1,  // 12: authlayer.v1.GetUserPermissionsResponse.permissions:type_name -> authlayer.v1.PermissionInfo
-	2,  // 13: authlayer.v1.RBACService.CreateRole:input_type -> authlayer.v1.CreateRoleRequest
-	4,  // 14: authlayer.v1.RBACService.GetRole:input_type -> authlayer.v1.GetRoleRequest
-	6,  // 15: authlayer.v1.RBACService.UpdateRole:input_type -> authlayer.v1.UpdateRoleRequest
-	8,  // 16: authlayer.v1.RBACService.DeleteRole:input_type -> authlayer.v1.DeleteRoleRequest
-	10, // 17: authlayer.v1.RBACService.ListRoles:input_type -> authlayer.v1.ListRolesRequest
-	12, // 18: authlayer.v1.RBACService.AssignRole:input_type -> authlayer.v1.AssignRoleRequest
-	14, // 19: authlayer.v1.RBACService.RevokeRole:input_type -> authlayer.v1.RevokeRoleRequest
-	16, // 20: authlayer.v1.RBACService.CreatePermission:input_type -> authlayer.v1.CreatePermissionRequest
-	18, // 21: authlayer.v1.RBACService.ListPermissions:input_type -> authlayer.v1.ListPermissionsRequest
-	20, // 22: authlayer.v1.RBACService.AssignPermission:input_type -> authlayer.v1.AssignPermissionRequest
-	22, // 23: authlayer.v1.RBACService.RevokePermission:input_type -> authlayer.v1.RevokePermissionRequest
-	24, // 24: authlayer.v1.RBACService.CheckPermission:input_type -> authlayer.v1.CheckPermissionRequest
-	26, // 25: authlayer.v1.RBACService.GetUserPermissions:input_type -> authlayer.v1.GetUserPermissionsRequest
-	3,  // 26: authlayer.v1.RBACService.CreateRole:output_type -> authlayer.v1.CreateRoleResponse
-	5,  // 27: authlayer.v1.RBACService.GetRole:output_type -> authlayer.v1.GetRoleResponse
-	7,  // 28: authlayer.v1.RBACService.UpdateRole:output_type -> authlayer.v1.UpdateRoleResponse
-	9,  // 29: authlayer.v1.RBACService.DeleteRole:output_type -> authlayer.v1.DeleteRoleResponse
-	11, // 30: authlayer.v1.RBACService.ListRoles:output_type -> authlayer.v1.ListRolesResponse
-	13, // 31: authlayer.v1.RBACService.AssignRole:output_type -> authlayer.v1.AssignRoleResponse
-	15, // 32: authlayer.v1.RBACService.RevokeRole:output_type -> authlayer.v1.RevokeRoleResponse
-	17, // 33: authlayer.v1.RBACService.CreatePermission:output_type -> authlayer.v1.CreatePermissionResponse
-	19, // 34: authlayer.v1.RBACService.ListPermissions:output_type -> authlayer.v1.ListPermissionsResponse
-	21, // 35: authlayer.v1.RBACService.AssignPermission:output_type -> authlayer.v1.AssignPermissionResponse
-	23, // 36: authlayer.v1.RBACService.RevokePermission:output_type -> authlayer.v1.RevokePermissionResponse
-	25, // 37: authlayer.v1.RBACService.CheckPermission:output_type -> authlayer.v1.CheckPermissionResponse
-	27, // 38: authlayer.v1.RBACService.GetUserPermissions:output_type -> authlayer.v1.GetUserPermissionsResponse
-	26, // [26:39] is the sub-list for method output_type
-	13, // [13:26] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	47, // 1: authlayer.v1.RoleInfo.labels:type_name -> authlayer.v1.RoleInfo.LabelsEntry
+	48, // 2: authlayer.v1.CreateRoleRequest.labels:type_name -> authlayer.v1.CreateRoleRequest.LabelsEntry
+	0,  // 3: authlayer.v1.CreateRoleResponse.role:type_name -> authlayer.v1.RoleInfo
+	0,  // 4: authlayer.v1.GetRoleResponse.role:type_name -> authlayer.v1.RoleInfo
+	1,  // 5: authlayer.v1.GetRoleResponse.inherited_permissions:type_name -> authlayer.v1.PermissionInfo
+	49, // 6: authlayer.v1.UpdateRoleRequest.labels:type_name -> authlayer.v1.LabelSet
+	0,  // 7: authlayer.v1.UpdateRoleResponse.role:type_name -> authlayer.v1.RoleInfo
+	50, // 8: authlayer.v1.ListRolesRequest.pagination:type_name -> authlayer.v1.PaginationRequest
+	0,  // 9: authlayer.v1.ListRolesResponse.roles:type_name -> authlayer.v1.RoleInfo
+	51, // 10: authlayer.v1.ListRolesResponse.pagination:type_name -> authlayer.v1.PaginationResponse
+	1,  // 11: authlayer.v1.CreatePermissionResponse.permission:type_name -> authlayer.v1.PermissionInfo
+	50, // 12: authlayer.v1.ListPermissionsRequest.pagination:type_name -> authlayer.v1.PaginationRequest
+	1,  // 13: authlayer.v1.ListPermissionsResponse.permissions:type_name -> authlayer.v1.PermissionInfo
+	51, // 14: authlayer.v1.ListPermissionsResponse.pagination:type_name -> authlayer.v1.PaginationResponse
+	52, // 15: authlayer.v1.CheckPermissionRequest.principal_type:type_name -> authlayer.v1.PrincipalType
+	1,  // 16: authlayer.v1.GetUserPermissionsResponse.permissions:type_name -> authlayer.v1.PermissionInfo
+	0,  // 17: authlayer.v1.RoleConstraintInfo.roles:type_name -> authlayer.v1.RoleInfo
+	53, // 18: authlayer.v1.RoleConstraintInfo.created_at:type_name -> google.protobuf.Timestamp
+	28, // 19: authlayer.v1.CreateRoleConstraintResponse.constraint:type_name -> authlayer.v1.RoleConstraintInfo
+	50, // 20: authlayer.v1.ListRoleConstraintsRequest.pagination:type_name -> authlayer.v1.PaginationRequest
+	28, // 21: authlayer.v1.ListRoleConstraintsResponse.constraints:type_name -> authlayer.v1.RoleConstraintInfo
+	51, // 22: authlayer.v1.ListRoleConstraintsResponse.pagination:type_name -> authlayer.v1.PaginationResponse
+	52, // 23: authlayer.v1.ConstraintViolation.principal_type:type_name -> authlayer.v1.PrincipalType
+	36, // 24: authlayer.v1.ListConstraintViolationsResponse.violations:type_name -> authlayer.v1.ConstraintViolation
+	52, // 25: authlayer.v1.LabelRoleBindingInfo.principal_type:type_name -> authlayer.v1.PrincipalType
+	53, // 26: authlayer.v1.LabelRoleBindingInfo.created_at:type_name -> google.protobuf.Timestamp
+	52, // 27: authlayer.v1.CreateLabelRoleBindingRequest.principal_type:type_name -> authlayer.v1.PrincipalType
+	38, // 28: authlayer.v1.CreateLabelRoleBindingResponse.binding:type_name -> authlayer.v1.LabelRoleBindingInfo
+	50, // 29: authlayer.v1.ListLabelRoleBindingsRequest.pagination:type_name -> authlayer.v1.PaginationRequest
+	38, // 30: authlayer.v1.ListLabelRoleBindingsResponse.bindings:type_name -> authlayer.v1.LabelRoleBindingInfo
+	51, // 31: authlayer.v1.ListLabelRoleBindingsResponse.pagination:type_name -> authlayer.v1.PaginationResponse
+	52, // 32: authlayer.v1.WatchPermissionChangesRequest.principal_type:type_name -> authlayer.v1.PrincipalType
+	52, // 33: authlayer.v1.PermissionChangeEvent.principal_type:type_name -> authlayer.v1.PrincipalType
+	53, // 34: authlayer.v1.PermissionChangeEvent.changed_at:type_name -> google.protobuf.Timestamp
+	2,  // 35: authlayer.v1.RBACService.CreateRole:input_type -> authlayer.v1.CreateRoleRequest
+	4,  // 36: authlayer.v1.RBACService.GetRole:input_type -> authlayer.v1.GetRoleRequest
+	6,  // 37: authlayer.v1.RBACService.UpdateRole:input_type -> authlayer.v1.UpdateRoleRequest
+	8,  // 38: authlayer.v1.RBACService.DeleteRole:input_type -> authlayer.v1.DeleteRoleRequest
+	10, // 39: authlayer.v1.RBACService.ListRoles:input_type -> authlayer.v1.ListRolesRequest
+	12, // 40: authlayer.v1.RBACService.AssignRole:input_type -> authlayer.v1.AssignRoleRequest
+	14, // 41: authlayer.v1.RBACService.RevokeRole:input_type -> authlayer.v1.RevokeRoleRequest
+	16, // 42: authlayer.v1.RBACService.CreatePermission:input_type -> authlayer.v1.CreatePermissionRequest
+	18, // 43: authlayer.v1.RBACService.ListPermissions:input_type -> authlayer.v1.ListPermissionsRequest
+	20, // 44: authlayer.v1.RBACService.AssignPermission:input_type -> authlayer.v1.AssignPermissionRequest
+	22, // 45: authlayer.v1.RBACService.RevokePermission:input_type -> authlayer.v1.RevokePermissionRequest
+	24, // 46: authlayer.v1.RBACService.CheckPermission:input_type -> authlayer.v1.CheckPermissionRequest
+	26, // 47: authlayer.v1.RBACService.GetUserPermissions:input_type -> authlayer.v1.GetUserPermissionsRequest
+	29, // 48: authlayer.v1.RBACService.CreateRoleConstraint:input_type -> authlayer.v1.CreateRoleConstraintRequest
+	31, // 49: authlayer.v1.RBACService.ListRoleConstraints:input_type -> authlayer.v1.ListRoleConstraintsRequest
+	33, // 50: authlayer.v1.RBACService.DeleteRoleConstraint:input_type -> authlayer.v1.DeleteRoleConstraintRequest
+	35, // 51: authlayer.v1.RBACService.ListConstraintViolations:input_type -> authlayer.v1.ListConstraintViolationsRequest
+	39, // 52: authlayer.v1.RBACService.CreateLabelRoleBinding:input_type -> authlayer.v1.CreateLabelRoleBindingRequest
+	41, // 53: authlayer.v1.RBACService.ListLabelRoleBindings:input_type -> authlayer.v1.ListLabelRoleBindingsRequest
+	43, // 54: authlayer.v1.RBACService.DeleteLabelRoleBinding:input_type -> authlayer.v1.DeleteLabelRoleBindingRequest
+	45, // 55: authlayer.v1.RBACService.WatchPermissionChanges:input_type -> authlayer.v1.WatchPermissionChangesRequest
+	3,  // 56: authlayer.v1.RBACService.CreateRole:output_type -> authlayer.v1.CreateRoleResponse
+	5,  // 57: authlayer.v1.RBACService.GetRole:output_type -> authlayer.v1.GetRoleResponse
+	7,  // 58: authlayer.v1.RBACService.UpdateRole:output_type -> authlayer.v1.UpdateRoleResponse
+	9,  // 59: authlayer.v1.RBACService.DeleteRole:output_type -> authlayer.v1.DeleteRoleResponse
+	11, // 60: authlayer.v1.RBACService.ListRoles:output_type -> authlayer.v1.ListRolesResponse
+	13, // 61: authlayer.v1.RBACService.AssignRole:output_type -> authlayer.v1.AssignRoleResponse
+	15, // 62: authlayer.v1.RBACService.RevokeRole:output_type -> authlayer.v1.RevokeRoleResponse
+	17, // 63: authlayer.v1.RBACService.CreatePermission:output_type -> authlayer.v1.CreatePermissionResponse
+	19, // 64: authlayer.v1.RBACService.ListPermissions:output_type -> authlayer.v1.ListPermissionsResponse
+	21, // 65: authlayer.v1.RBACService.AssignPermission:output_type -> authlayer.v1.AssignPermissionResponse
+	23, // 66: authlayer.v1.RBACService.RevokePermission:output_type -> authlayer.v1.RevokePermissionResponse
+	25, // 67: authlayer.v1.RBACService.CheckPermission:output_type -> authlayer.v1.CheckPermissionResponse
+	27, // 68: authlayer.v1.RBACService.GetUserPermissions:output_type -> authlayer.v1.GetUserPermissionsResponse
+	30, // 69: authlayer.v1.RBACService.CreateRoleConstraint:output_type -> authlayer.v1.CreateRoleConstraintResponse
+	32, // 70: authlayer.v1.RBACService.ListRoleConstraints:output_type -> authlayer.v1.ListRoleConstraintsResponse
+	34, // 71: authlayer.v1.RBACService.DeleteRoleConstraint:output_type -> authlayer.v1.DeleteRoleConstraintResponse
+	37, // 72: authlayer.v1.RBACService.ListConstraintViolations:output_type -> authlayer.v1.ListConstraintViolationsResponse
+	40, // 73: authlayer.v1.RBACService.CreateLabelRoleBinding:output_type -> authlayer.v1.CreateLabelRoleBindingResponse
+	42, // 74: authlayer.v1.RBACService.ListLabelRoleBindings:output_type -> authlayer.v1.ListLabelRoleBindingsResponse
+	44, // 75: authlayer.v1.RBACService.DeleteLabelRoleBinding:output_type -> authlayer.v1.DeleteLabelRoleBindingResponse
+	46, // 76: authlayer.v1.RBACService.WatchPermissionChanges:output_type -> authlayer.v1.PermissionChangeEvent
+	56, // [56:77] is the sub-list for method output_type
+	35, // [35:56] is the sub-list for method input_type
+	35, // [35:35] is the sub-list for extension type_name
+	35, // [35:35] is the sub-list for extension extendee
+	0,  // [0:35] is the sub-list for field type_name
 }
 
 func init() { file_authlayer_v1_rbac_proto_init() }
@@ -1770,13 +3119,17 @@ func file_authlayer_v1_rbac_proto_init() {
 	file_authlayer_v1_rbac_proto_msgTypes[16].OneofWrappers = []any{}
 	file_authlayer_v1_rbac_proto_msgTypes[24].OneofWrappers = []any{}
 	file_authlayer_v1_rbac_proto_msgTypes[26].OneofWrappers = []any{}
+	file_authlayer_v1_rbac_proto_msgTypes[28].OneofWrappers = []any{}
+	file_authlayer_v1_rbac_proto_msgTypes[29].OneofWrappers = []any{}
+	file_authlayer_v1_rbac_proto_msgTypes[31].OneofWrappers = []any{}
+	file_authlayer_v1_rbac_proto_msgTypes[45].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_authlayer_v1_rbac_proto_rawDesc), len(file_authlayer_v1_rbac_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   28,
+			NumMessages:   49,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,19 +19,27 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	RBACService_CreateRole_FullMethodName         = "/authlayer.v1.RBACService/CreateRole"
-	RBACService_GetRole_FullMethodName            = "/authlayer.v1.RBACService/GetRole"
-	RBACService_UpdateRole_FullMethodName         = "/authlayer.v1.RBACService/UpdateRole"
-	RBACService_DeleteRole_FullMethodName         = "/authlayer.v1.RBACService/DeleteRole"
-	RBACService_ListRoles_FullMethodName          = "/authlayer.v1.RBACService/ListRoles"
-	RBACService_AssignRole_FullMethodName         = "/authlayer.v1.RBACService/AssignRole"
-	RBACService_RevokeRole_FullMethodName         = "/authlayer.v1.RBACService/RevokeRole"
-	RBACService_CreatePermission_FullMethodName   = "/authlayer.v1.RBACService/CreatePermission"
-	RBACService_ListPermissions_FullMethodName    = "/authlayer.v1.RBACService/ListPermissions"
-	RBACService_AssignPermission_FullMethodName   = "/authlayer.v1.RBACService/AssignPermission"
-	RBACService_RevokePermission_FullMethodName   = "/authlayer.v1.RBACService/RevokePermission"
-	RBACService_CheckPermission_FullMethodName    = "/authlayer.v1.RBACService/CheckPermission"
-	RBACService_GetUserPermissions_FullMethodName = "/authlayer.v1.RBACService/GetUserPermissions"
+	RBACService_CreateRole_FullMethodName               = "/authlayer.v1.RBACService/CreateRole"
+	RBACService_GetRole_FullMethodName                  = "/authlayer.v1.RBACService/GetRole"
+	RBACService_UpdateRole_FullMethodName               = "/authlayer.v1.RBACService/UpdateRole"
+	RBACService_DeleteRole_FullMethodName               = "/authlayer.v1.RBACService/DeleteRole"
+	RBACService_ListRoles_FullMethodName                = "/authlayer.v1.RBACService/ListRoles"
+	RBACService_AssignRole_FullMethodName               = "/authlayer.v1.RBACService/AssignRole"
+	RBACService_RevokeRole_FullMethodName               = "/authlayer.v1.RBACService/RevokeRole"
+	RBACService_CreatePermission_FullMethodName         = "/authlayer.v1.RBACService/CreatePermission"
+	RBACService_ListPermissions_FullMethodName          = "/authlayer.v1.RBACService/ListPermissions"
+	RBACService_AssignPermission_FullMethodName         = "/authlayer.v1.RBACService/AssignPermission"
+	RBACService_RevokePermission_FullMethodName         = "/authlayer.v1.RBACService/RevokePermission"
+	RBACService_CheckPermission_FullMethodName          = "/authlayer.v1.RBACService/CheckPermission"
+	RBACService_GetUserPermissions_FullMethodName       = "/authlayer.v1.RBACService/GetUserPermissions"
+	RBACService_CreateRoleConstraint_FullMethodName     = "/authlayer.v1.RBACService/CreateRoleConstraint"
+	RBACService_ListRoleConstraints_FullMethodName      = "/authlayer.v1.RBACService/ListRoleConstraints"
+	RBACService_DeleteRoleConstraint_FullMethodName     = "/authlayer.v1.RBACService/DeleteRoleConstraint"
+	RBACService_ListConstraintViolations_FullMethodName = "/authlayer.v1.RBACService/ListConstraintViolations"
+	RBACService_CreateLabelRoleBinding_FullMethodName   = "/authlayer.v1.RBACService/CreateLabelRoleBinding"
+	RBACService_ListLabelRoleBindings_FullMethodName    = "/authlayer.v1.RBACService/ListLabelRoleBindings"
+	RBACService_DeleteLabelRoleBinding_FullMethodName   = "/authlayer.v1.RBACService/DeleteLabelRoleBinding"
+	RBACService_WatchPermissionChanges_FullMethodName   = "/authlayer.v1.RBACService/WatchPermissionChanges"
 )
 
 // RBACServiceClient is the client API for RBACService service.
@@ -51,6 +59,19 @@ type RBACServiceClient interface {
 	RevokePermission(ctx context.Context, in *RevokePermissionRequest, opts ...grpc.CallOption) (*RevokePermissionResponse, error)
 	CheckPermission(ctx context.Context, in *CheckPermissionRequest, opts ...grpc.CallOption) (*CheckPermissionResponse, error)
 	GetUserPermissions(ctx context.Context, in *GetUserPermissionsRequest, opts ...grpc.CallOption) (*GetUserPermissionsResponse, error)
+	// Separation-of-duties constraints: sets of roles no single principal may hold together.
+	CreateRoleConstraint(ctx context.Context, in *CreateRoleConstraintRequest, opts ...grpc.CallOption) (*CreateRoleConstraintResponse, error)
+	ListRoleConstraints(ctx context.Context, in *ListRoleConstraintsRequest, opts ...grpc.CallOption) (*ListRoleConstraintsResponse, error)
+	DeleteRoleConstraint(ctx context.Context, in *DeleteRoleConstraintRequest, opts ...grpc.CallOption) (*DeleteRoleConstraintResponse, error)
+	ListConstraintViolations(ctx context.Context, in *ListConstraintViolationsRequest, opts ...grpc.CallOption) (*ListConstraintViolationsResponse, error)
+	// Label role bindings: grant a role in an org to every principal whose labels match a selector.
+	CreateLabelRoleBinding(ctx context.Context, in *CreateLabelRoleBindingRequest, opts ...grpc.CallOption) (*CreateLabelRoleBindingResponse, error)
+	ListLabelRoleBindings(ctx context.Context, in *ListLabelRoleBindingsRequest, opts ...grpc.CallOption) (*ListLabelRoleBindingsResponse, error)
+	DeleteLabelRoleBinding(ctx context.Context, in *DeleteLabelRoleBindingRequest, opts ...grpc.CallOption) (*DeleteLabelRoleBindingResponse, error)
+	// Permission change feed: streams changes to effective permissions, resumable by revision.
+	// Changes are recorded while materializing effective permissions, so the call fails with
+	// FAILED_PRECONDITION unless the server runs with MATERIALIZED_PERMISSIONS=true.
+	WatchPermissionChanges(ctx context.Context, in *WatchPermissionChangesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PermissionChangeEvent], error)
 }
 
 type rBACServiceClient struct {
@@ -191,6 +212,95 @@ func (c *rBACServiceClient) GetUserPermissions(ctx context.Context, in *GetUserP
 	return out, nil
 }
 
+func (c *rBACServiceClient) CreateRoleConstraint(ctx context.Context, in *CreateRoleConstraintRequest, opts ...grpc.CallOption) (*CreateRoleConstraintResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateRoleConstraintResponse)
+	err := c.cc.Invoke(ctx, RBACService_CreateRoleConstraint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rBACServiceClient) ListRoleConstraints(ctx context.Context, in *ListRoleConstraintsRequest, opts ...grpc.CallOption) (*ListRoleConstraintsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRoleConstraintsResponse)
+	err := c.cc.Invoke(ctx, RBACService_ListRoleConstraints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rBACServiceClient) DeleteRoleConstraint(ctx context.Context, in *DeleteRoleConstraintRequest, opts ...grpc.CallOption) (*DeleteRoleConstraintResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteRoleConstraintResponse)
+	err := c.cc.Invoke(ctx, RBACService_DeleteRoleConstraint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rBACServiceClient) ListConstraintViolations(ctx context.Context, in *ListConstraintViolationsRequest, opts ...grpc.CallOption) (*ListConstraintViolationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListConstraintViolationsResponse)
+	err := c.cc.Invoke(ctx, RBACService_ListConstraintViolations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rBACServiceClient) CreateLabelRoleBinding(ctx context.Context, in *CreateLabelRoleBindingRequest, opts ...grpc.CallOption) (*CreateLabelRoleBindingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateLabelRoleBindingResponse)
+	err := c.cc.Invoke(ctx, RBACService_CreateLabelRoleBinding_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rBACServiceClient) ListLabelRoleBindings(ctx context.Context, in *ListLabelRoleBindingsRequest, opts ...grpc.CallOption) (*ListLabelRoleBindingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLabelRoleBindingsResponse)
+	err := c.cc.Invoke(ctx, RBACService_ListLabelRoleBindings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rBACServiceClient) DeleteLabelRoleBinding(ctx context.Context, in *DeleteLabelRoleBindingRequest, opts ...grpc.CallOption) (*DeleteLabelRoleBindingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteLabelRoleBindingResponse)
+	err := c.cc.Invoke(ctx, RBACService_DeleteLabelRoleBinding_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rBACServiceClient) WatchPermissionChanges(ctx context.Context, in *WatchPermissionChangesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PermissionChangeEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RBACService_ServiceDesc.Streams[0], RBACService_WatchPermissionChanges_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchPermissionChangesRequest, PermissionChangeEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RBACService_WatchPermissionChangesClient = grpc.ServerStreamingClient[PermissionChangeEvent]
+
 // RBACServiceServer is the server API for RBACService service.
 // All implementations must embed UnimplementedRBACServiceServer
 // for forward compatibility.
@@ -208,6 +318,19 @@ type RBACServiceServer interface {
 	RevokePermission(context.Context, *RevokePermissionRequest) (*RevokePermissionResponse, error)
 	CheckPermission(context.Context, *CheckPermissionRequest) (*CheckPermissionResponse, error)
 	GetUserPermissions(context.Context, *GetUserPermissionsRequest) (*GetUserPermissionsResponse, error)
+	// Separation-of-duties constraints: sets of roles no single principal may hold together.
+	CreateRoleConstraint(context.Context, *CreateRoleConstraintRequest) (*CreateRoleConstraintResponse, error)
+	ListRoleConstraints(context.Context, *ListRoleConstraintsRequest) (*ListRoleConstraintsResponse, error)
+	DeleteRoleConstraint(context.Context, *DeleteRoleConstraintRequest) (*DeleteRoleConstraintResponse, error)
+	ListConstraintViolations(context.Context, *ListConstraintViolationsRequest) (*ListConstraintViolationsResponse, error)
+	// Label role bindings: grant a role in an org to every principal whose labels match a selector.
+	CreateLabelRoleBinding(context.Context, *CreateLabelRoleBindingRequest) (*CreateLabelRoleBindingResponse, error)
+	ListLabelRoleBindings(context.Context, *ListLabelRoleBindingsRequest) (*ListLabelRoleBindingsResponse, error)
+	DeleteLabelRoleBinding(context.Context, *DeleteLabelRoleBindingRequest) (*DeleteLabelRoleBindingResponse, error)
+	// Permission change feed: streams changes to effective permissions, resumable by revision.
+	// Changes are recorded while materializing effective permissions, so the call fails with
+	// FAILED_PRECONDITION unless the server runs with MATERIALIZED_PERMISSIONS=true.
+	WatchPermissionChanges(*WatchPermissionChangesRequest, grpc.ServerStreamingServer[PermissionChangeEvent]) error
 	mustEmbedUnimplementedRBACServiceServer()
 }
 
@@ -257,6 +380,30 @@ func (UnimplementedRBACServiceServer) CheckPermission(context.Context, *CheckPer
 func (UnimplementedRBACServiceServer) GetUserPermissions(context.Context, *GetUserPermissionsRequest) (*GetUserPermissionsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetUserPermissions not implemented")
 }
+func (UnimplementedRBACServiceServer) CreateRoleConstraint(context.Context, *CreateRoleConstraintRequest) (*CreateRoleConstraintResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateRoleConstraint not implemented")
+}
+func (UnimplementedRBACServiceServer) ListRoleConstraints(context.Context, *ListRoleConstraintsRequest) (*ListRoleConstraintsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListRoleConstraints not implemented")
+}
+func (UnimplementedRBACServiceServer) DeleteRoleConstraint(context.Context, *DeleteRoleConstraintRequest) (*DeleteRoleConstraintResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteRoleConstraint not implemented")
+}
+func (UnimplementedRBACServiceServer) ListConstraintViolations(context.Context, *ListConstraintViolationsRequest) (*ListConstraintViolationsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListConstraintViolations not implemented")
+}
+func (UnimplementedRBACServiceServer) CreateLabelRoleBinding(context.Context, *CreateLabelRoleBindingRequest) (*CreateLabelRoleBindingResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateLabelRoleBinding not implemented")
+}
+func (UnimplementedRBACServiceServer) ListLabelRoleBindings(context.Context, *ListLabelRoleBindingsRequest) (*ListLabelRoleBindingsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListLabelRoleBindings not implemented")
+}
+func (UnimplementedRBACServiceServer) DeleteLabelRoleBinding(context.Context, *DeleteLabelRoleBindingRequest) (*DeleteLabelRoleBindingResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteLabelRoleBinding not implemented")
+}
+func (UnimplementedRBACServiceServer) WatchPermissionChanges(*WatchPermissionChangesRequest, grpc.ServerStreamingServer[PermissionChangeEvent]) error {
+	return status.Error(codes.Unimplemented, "method WatchPermissionChanges not implemented")
+}
 func (UnimplementedRBACServiceServer) mustEmbedUnimplementedRBACServiceServer() {}
 func (UnimplementedRBACServiceServer) testEmbeddedByValue()                     {}
 
@@ -512,6 +659,143 @@ func _RBACService_GetUserPermissions_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RBACService_CreateRoleConstraint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRoleConstraintRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RBACServiceServer).CreateRoleConstraint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RBACService_CreateRoleConstraint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RBACServiceServer).CreateRoleConstraint(ctx, req.(*CreateRoleConstraintRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RBACService_ListRoleConstraints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoleConstraintsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RBACServiceServer).ListRoleConstraints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RBACService_ListRoleConstraints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RBACServiceServer).ListRoleConstraints(ctx, req.(*ListRoleConstraintsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RBACService_DeleteRoleConstraint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRoleConstraintRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RBACServiceServer).DeleteRoleConstraint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RBACService_DeleteRoleConstraint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RBACServiceServer).DeleteRoleConstraint(ctx, req.(*DeleteRoleConstraintRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RBACService_ListConstraintViolations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConstraintViolationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RBACServiceServer).ListConstraintViolations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RBACService_ListConstraintViolations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RBACServiceServer).ListConstraintViolations(ctx, req.(*ListConstraintViolationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RBACService_CreateLabelRoleBinding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateLabelRoleBindingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RBACServiceServer).CreateLabelRoleBinding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RBACService_CreateLabelRoleBinding_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RBACServiceServer).CreateLabelRoleBinding(ctx, req.(*CreateLabelRoleBindingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RBACService_ListLabelRoleBindings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLabelRoleBindingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RBACServiceServer).ListLabelRoleBindings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RBACService_ListLabelRoleBindings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RBACServiceServer).ListLabelRoleBindings(ctx, req.(*ListLabelRoleBindingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RBACService_DeleteLabelRoleBinding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteLabelRoleBindingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RBACServiceServer).DeleteLabelRoleBinding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RBACService_DeleteLabelRoleBinding_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RBACServiceServer).DeleteLabelRoleBinding(ctx, req.(*DeleteLabelRoleBindingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RBACService_WatchPermissionChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchPermissionChangesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RBACServiceServer).WatchPermissionChanges(m, &grpc.GenericServerStream[WatchPermissionChangesRequest, PermissionChangeEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RBACService_WatchPermissionChangesServer = grpc.ServerStreamingServer[PermissionChangeEvent]
+
 // RBACService_ServiceDesc is the grpc.ServiceDesc for RBACService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -571,7 +855,41 @@ var RBACService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetUserPermissions",
 			Handler:    _RBACService_GetUserPermissions_Handler,
 		},
+		{
+			MethodName: "CreateRoleConstraint",
+			Handler:    _RBACService_CreateRoleConstraint_Handler,
+		},
+		{
+			MethodName: "ListRoleConstraints",
+			Handler:    _RBACService_ListRoleConstraints_Handler,
+		},
+		{
+			MethodName: "DeleteRoleConstraint",
+			Handler:    _RBACService_DeleteRoleConstraint_Handler,
+		},
+		{
+			MethodName: "ListConstraintViolations",
+			Handler:    _RBACService_ListConstraintViolations_Handler,
+		},
+		{
+			MethodName: "CreateLabelRoleBinding",
+			Handler:    _RBACService_CreateLabelRoleBinding_Handler,
+		},
+		{
+			MethodName: "ListLabelRoleBindings",
+			Handler:    _RBACService_ListLabelRoleBindings_Handler,
+		},
+		{
+			MethodName: "DeleteLabelRoleBinding",
+			Handler:    _RBACService_DeleteLabelRoleBinding_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchPermissionChanges",
+			Handler:       _RBACService_WatchPermissionChanges_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "authlayer/v1/rbac.proto",
 }
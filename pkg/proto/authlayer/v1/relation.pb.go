@@ -0,0 +1,1353 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: authlayer/v1/relation.proto
+
+package authlayerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// TupleToUserset grants a relation to the subjects of computed_userset on every
+// object referenced through tupleset, e.g. "viewer of the parent folder".
+type TupleToUserset struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Tupleset        string                 `protobuf:"bytes,1,opt,name=tupleset,proto3" json:"tupleset,omitempty"`
+	ComputedUserset string                 `protobuf:"bytes,2,opt,name=computed_userset,json=computedUserset,proto3" json:"computed_userset,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TupleToUserset) Reset() {
+	*x = TupleToUserset{}
+	mi := &file_authlayer_v1_relation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TupleToUserset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TupleToUserset) ProtoMessage() {}
+
+func (x *TupleToUserset) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_relation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TupleToUserset.ProtoReflect.Descriptor instead.
+func (*TupleToUserset) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_relation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TupleToUserset) GetTupleset() string {
+	if x != nil {
+		return x.Tupleset
+	}
+	return ""
+}
+
+func (x *TupleToUserset) GetComputedUserset() string {
+	if x != nil {
+		return x.ComputedUserset
+	}
+	return ""
+}
+
+// RelationDefinition declares a relation. Subjects written directly as tuples always
+// hold the relation; computed_usersets and tuple_to_usersets add rewrite rules whose
+// subjects are unioned in.
+type RelationDefinition struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Name             string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ComputedUsersets []string               `protobuf:"bytes,2,rep,name=computed_usersets,json=computedUsersets,proto3" json:"computed_usersets,omitempty"`
+	TupleToUsersets  []*TupleToUserset      `protobuf:"bytes,3,rep,name=tuple_to_usersets,json=tupleToUsersets,proto3" json:"tuple_to_usersets,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RelationDefinition) Reset() {
+	*x = RelationDefinition{}
+	mi := &file_authlayer_v1_relation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelationDefinition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelationDefinition) ProtoMessage() {}
+
+func (x *RelationDefinition) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_relation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelationDefinition.ProtoReflect.Descriptor instead.
+func (*RelationDefinition) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_relation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RelationDefinition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RelationDefinition) GetComputedUsersets() []string {
+	if x != nil {
+		return x.ComputedUsersets
+	}
+	return nil
+}
+
+func (x *RelationDefinition) GetTupleToUsersets() []*TupleToUserset {
+	if x != nil {
+		return x.TupleToUsersets
+	}
+	return nil
+}
+
+type NamespaceDefinition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Relations     []*RelationDefinition  `protobuf:"bytes,2,rep,name=relations,proto3" json:"relations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NamespaceDefinition) Reset() {
+	*x = NamespaceDefinition{}
+	mi := &file_authlayer_v1_relation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NamespaceDefinition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NamespaceDefinition) ProtoMessage() {}
+
+func (x *NamespaceDefinition) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_relation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NamespaceDefinition.ProtoReflect.Descriptor instead.
+func (*NamespaceDefinition) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_relation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *NamespaceDefinition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NamespaceDefinition) GetRelations() []*RelationDefinition {
+	if x != nil {
+		return x.Relations
+	}
+	return nil
+}
+
+type ObjectRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	ObjectId      string                 `protobuf:"bytes,2,opt,name=object_id,json=objectId,proto3" json:"object_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ObjectRef) Reset() {
+	*x = ObjectRef{}
+	mi := &file_authlayer_v1_relation_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObjectRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObjectRef) ProtoMessage() {}
+
+func (x *ObjectRef) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_relation_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObjectRef.ProtoReflect.Descriptor instead.
+func (*ObjectRef) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_relation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ObjectRef) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ObjectRef) GetObjectId() string {
+	if x != nil {
+		return x.ObjectId
+	}
+	return ""
+}
+
+// SubjectRef is either a concrete subject ("user:42") or, when relation is set,
+// a userset ("team:7#member").
+type SubjectRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	ObjectId      string                 `protobuf:"bytes,2,opt,name=object_id,json=objectId,proto3" json:"object_id,omitempty"`
+	Relation      *string                `protobuf:"bytes,3,opt,name=relation,proto3,oneof" json:"relation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubjectRef) Reset() {
+	*x = SubjectRef{}
+	mi := &file_authlayer_v1_relation_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubjectRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubjectRef) ProtoMessage() {}
+
+func (x *SubjectRef) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_relation_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubjectRef.ProtoReflect.Descriptor instead.
+func (*SubjectRef) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_relation_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SubjectRef) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *SubjectRef) GetObjectId() string {
+	if x != nil {
+		return x.ObjectId
+	}
+	return ""
+}
+
+func (x *SubjectRef) GetRelation() string {
+	if x != nil && x.Relation != nil {
+		return *x.Relation
+	}
+	return ""
+}
+
+type RelationTuple struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Object        *ObjectRef             `protobuf:"bytes,1,opt,name=object,proto3" json:"object,omitempty"`
+	Relation      string                 `protobuf:"bytes,2,opt,name=relation,proto3" json:"relation,omitempty"`
+	Subject       *SubjectRef            `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelationTuple) Reset() {
+	*x = RelationTuple{}
+	mi := &file_authlayer_v1_relation_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelationTuple) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelationTuple) ProtoMessage() {}
+
+func (x *RelationTuple) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_relation_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelationTuple.ProtoReflect.Descriptor instead.
+func (*RelationTuple) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_relation_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RelationTuple) GetObject() *ObjectRef {
+	if x != nil {
+		return x.Object
+	}
+	return nil
+}
+
+func (x *RelationTuple) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *RelationTuple) GetSubject() *SubjectRef {
+	if x != nil {
+		return x.Subject
+	}
+	return nil
+}
+
+type WriteNamespaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     *NamespaceDefinition   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteNamespaceRequest) Reset() {
+	*x = WriteNamespaceRequest{}
+	mi := &file_authlayer_v1_relation_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteNamespaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteNamespaceRequest) ProtoMessage() {}
+
+func (x *WriteNamespaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_relation_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteNamespaceRequest.ProtoReflect.Descriptor instead.
+func (*WriteNamespaceRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_relation_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *WriteNamespaceRequest) GetNamespace() *NamespaceDefinition {
+	if x != nil {
+		return x.Namespace
+	}
+	return nil
+}
+
+type WriteNamespaceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     *NamespaceDefinition   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteNamespaceResponse) Reset() {
+	*x = WriteNamespaceResponse{}
+	mi := &file_authlayer_v1_relation_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteNamespaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteNamespaceResponse) ProtoMessage() {}
+
+func (x *WriteNamespaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_relation_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteNamespaceResponse.ProtoReflect.Descriptor instead.
+func (*WriteNamespaceResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_relation_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *WriteNamespaceResponse) GetNamespace() *NamespaceDefinition {
+	if x != nil {
+		return x.Namespace
+	}
+	return nil
+}
+
+type ListNamespacesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pagination    *PaginationRequest     `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNamespacesRequest) Reset() {
+	*x = ListNamespacesRequest{}
+	mi := &file_authlayer_v1_relation_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNamespacesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNamespacesRequest) ProtoMessage() {}
+
+func (x *ListNamespacesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_relation_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNamespacesRequest.ProtoReflect.Descriptor instead.
+func (*ListNamespacesRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_relation_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListNamespacesRequest) GetPagination() *PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ListNamespacesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespaces    []*NamespaceDefinition `protobuf:"bytes,1,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+	Pagination    *PaginationResponse    `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNamespacesResponse) Reset() {
+	*x = ListNamespacesResponse{}
+	mi := &file_authlayer_v1_relation_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNamespacesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNamespacesResponse) ProtoMessage() {}
+
+func (x *ListNamespacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_relation_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNamespacesResponse.ProtoReflect.Descriptor instead.
+func (*ListNamespacesResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_relation_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListNamespacesResponse) GetNamespaces() []*NamespaceDefinition {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+func (x *ListNamespacesResponse) GetPagination() *PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+// WriteTuplesRequest applies all writes and deletes atomically.
+type WriteTuplesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Writes        []*RelationTuple       `protobuf:"bytes,1,rep,name=writes,proto3" json:"writes,omitempty"`
+	Deletes       []*RelationTuple       `protobuf:"bytes,2,rep,name=deletes,proto3" json:"deletes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteTuplesRequest) Reset() {
+	*x = WriteTuplesRequest{}
+	mi := &file_authlayer_v1_relation_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteTuplesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteTuplesRequest) ProtoMessage() {}
+
+func (x *WriteTuplesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_relation_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteTuplesRequest.ProtoReflect.Descriptor instead.
+func (*WriteTuplesRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_relation_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WriteTuplesRequest) GetWrites() []*RelationTuple {
+	if x != nil {
+		return x.Writes
+	}
+	return nil
+}
+
+func (x *WriteTuplesRequest) GetDeletes() []*RelationTuple {
+	if x != nil {
+		return x.Deletes
+	}
+	return nil
+}
+
+type WriteTuplesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteTuplesResponse) Reset() {
+	*x = WriteTuplesResponse{}
+	mi := &file_authlayer_v1_relation_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteTuplesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteTuplesResponse) ProtoMessage() {}
+
+func (x *WriteTuplesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_relation_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteTuplesResponse.ProtoReflect.Descriptor instead.
+func (*WriteTuplesResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_relation_proto_rawDescGZIP(), []int{11}
+}
+
+type ReadTuplesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Object        *ObjectRef             `protobuf:"bytes,1,opt,name=object,proto3" json:"object,omitempty"`
+	Relation      *string                `protobuf:"bytes,2,opt,name=relation,proto3,oneof" json:"relation,omitempty"`
+	Pagination    *PaginationRequest     `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadTuplesRequest) Reset() {
+	*x = ReadTuplesRequest{}
+	mi := &file_authlayer_v1_relation_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadTuplesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadTuplesRequest) ProtoMessage() {}
+
+func (x *ReadTuplesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_relation_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadTuplesRequest.ProtoReflect.Descriptor instead.
+func (*ReadTuplesRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_relation_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ReadTuplesRequest) GetObject() *ObjectRef {
+	if x != nil {
+		return x.Object
+	}
+	return nil
+}
+
+func (x *ReadTuplesRequest) GetRelation() string {
+	if x != nil && x.Relation != nil {
+		return *x.Relation
+	}
+	return ""
+}
+
+func (x *ReadTuplesRequest) GetPagination() *PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ReadTuplesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tuples        []*RelationTuple       `protobuf:"bytes,1,rep,name=tuples,proto3" json:"tuples,omitempty"`
+	Pagination    *PaginationResponse    `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadTuplesResponse) Reset() {
+	*x = ReadTuplesResponse{}
+	mi := &file_authlayer_v1_relation_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadTuplesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadTuplesResponse) ProtoMessage() {}
+
+func (x *ReadTuplesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_relation_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadTuplesResponse.ProtoReflect.Descriptor instead.
+func (*ReadTuplesResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_relation_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ReadTuplesResponse) GetTuples() []*RelationTuple {
+	if x != nil {
+		return x.Tuples
+	}
+	return nil
+}
+
+func (x *ReadTuplesResponse) GetPagination() *PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type CheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Object        *ObjectRef             `protobuf:"bytes,1,opt,name=object,proto3" json:"object,omitempty"`
+	Relation      string                 `protobuf:"bytes,2,opt,name=relation,proto3" json:"relation,omitempty"`
+	Subject       *SubjectRef            `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckRequest) Reset() {
+	*x = CheckRequest{}
+	mi := &file_authlayer_v1_relation_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckRequest) ProtoMessage() {}
+
+func (x *CheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_relation_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckRequest.ProtoReflect.Descriptor instead.
+func (*CheckRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_relation_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CheckRequest) GetObject() *ObjectRef {
+	if x != nil {
+		return x.Object
+	}
+	return nil
+}
+
+func (x *CheckRequest) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *CheckRequest) GetSubject() *SubjectRef {
+	if x != nil {
+		return x.Subject
+	}
+	return nil
+}
+
+type CheckResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Allowed       bool                   `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckResponse) Reset() {
+	*x = CheckResponse{}
+	mi := &file_authlayer_v1_relation_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckResponse) ProtoMessage() {}
+
+func (x *CheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_relation_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckResponse.ProtoReflect.Descriptor instead.
+func (*CheckResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_relation_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CheckResponse) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+type ExpandRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Object        *ObjectRef             `protobuf:"bytes,1,opt,name=object,proto3" json:"object,omitempty"`
+	Relation      string                 `protobuf:"bytes,2,opt,name=relation,proto3" json:"relation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpandRequest) Reset() {
+	*x = ExpandRequest{}
+	mi := &file_authlayer_v1_relation_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpandRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpandRequest) ProtoMessage() {}
+
+func (x *ExpandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_relation_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpandRequest.ProtoReflect.Descriptor instead.
+func (*ExpandRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_relation_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ExpandRequest) GetObject() *ObjectRef {
+	if x != nil {
+		return x.Object
+	}
+	return nil
+}
+
+func (x *ExpandRequest) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+// UsersetTree is the expansion of object#relation. subjects holds the direct
+// subjects; usersets among them can be expanded with further Expand calls.
+// children holds the expansions of the relation's rewrite rules.
+type UsersetTree struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Object        *ObjectRef             `protobuf:"bytes,1,opt,name=object,proto3" json:"object,omitempty"`
+	Relation      string                 `protobuf:"bytes,2,opt,name=relation,proto3" json:"relation,omitempty"`
+	Subjects      []*SubjectRef          `protobuf:"bytes,3,rep,name=subjects,proto3" json:"subjects,omitempty"`
+	Children      []*UsersetTree         `protobuf:"bytes,4,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UsersetTree) Reset() {
+	*x = UsersetTree{}
+	mi := &file_authlayer_v1_relation_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsersetTree) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsersetTree) ProtoMessage() {}
+
+func (x *UsersetTree) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_relation_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsersetTree.ProtoReflect.Descriptor instead.
+func (*UsersetTree) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_relation_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UsersetTree) GetObject() *ObjectRef {
+	if x != nil {
+		return x.Object
+	}
+	return nil
+}
+
+func (x *UsersetTree) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *UsersetTree) GetSubjects() []*SubjectRef {
+	if x != nil {
+		return x.Subjects
+	}
+	return nil
+}
+
+func (x *UsersetTree) GetChildren() []*UsersetTree {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type ExpandResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tree          *UsersetTree           `protobuf:"bytes,1,opt,name=tree,proto3" json:"tree,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpandResponse) Reset() {
+	*x = ExpandResponse{}
+	mi := &file_authlayer_v1_relation_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpandResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpandResponse) ProtoMessage() {}
+
+func (x *ExpandResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_relation_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpandResponse.ProtoReflect.Descriptor instead.
+func (*ExpandResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_relation_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ExpandResponse) GetTree() *UsersetTree {
+	if x != nil {
+		return x.Tree
+	}
+	return nil
+}
+
+type ListObjectsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Namespace string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Relation  string                 `protobuf:"bytes,2,opt,name=relation,proto3" json:"relation,omitempty"`
+	Subject   *SubjectRef            `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	// Each page evaluates at most 1000 candidate objects, so a page may hold fewer objects
+	// than page_size, or none, while next_page_token is still set.
+	Pagination    *PaginationRequest `protobuf:"bytes,4,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListObjectsRequest) Reset() {
+	*x = ListObjectsRequest{}
+	mi := &file_authlayer_v1_relation_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListObjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListObjectsRequest) ProtoMessage() {}
+
+func (x *ListObjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_relation_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListObjectsRequest.ProtoReflect.Descriptor instead.
+func (*ListObjectsRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_relation_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListObjectsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ListObjectsRequest) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *ListObjectsRequest) GetSubject() *SubjectRef {
+	if x != nil {
+		return x.Subject
+	}
+	return nil
+}
+
+func (x *ListObjectsRequest) GetPagination() *PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ListObjectsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ObjectIds     []string               `protobuf:"bytes,1,rep,name=object_ids,json=objectIds,proto3" json:"object_ids,omitempty"`
+	Pagination    *PaginationResponse    `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListObjectsResponse) Reset() {
+	*x = ListObjectsResponse{}
+	mi := &file_authlayer_v1_relation_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListObjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListObjectsResponse) ProtoMessage() {}
+
+func (x *ListObjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_relation_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListObjectsResponse.ProtoReflect.Descriptor instead.
+func (*ListObjectsResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_relation_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListObjectsResponse) GetObjectIds() []string {
+	if x != nil {
+		return x.ObjectIds
+	}
+	return nil
+}
+
+func (x *ListObjectsResponse) GetPagination() *PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+var File_authlayer_v1_relation_proto protoreflect.FileDescriptor
+
+const file_authlayer_v1_relation_proto_rawDesc = "" +
+	"\n" +
+	"\x1bauthlayer/v1/relation.proto\x12\fauthlayer.v1\x1a\x19authlayer/v1/common.proto\"W\n" +
+	"\x0eTupleToUserset\x12\x1a\n" +
+	"\btupleset\x18\x01 \x01(\tR\btupleset\x12)\n" +
+	"\x10computed_userset\x18\x02 \x01(\tR\x0fcomputedUserset\"\x9f\x01\n" +
+	"\x12RelationDefinition\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12+\n" +
+	"\x11computed_usersets\x18\x02 \x03(\tR\x10computedUsersets\x12H\n" +
+	"\x11tuple_to_usersets\x18\x03 \x03(\v2\x1c.authlayer.v1.TupleToUsersetR\x0ftupleToUsersets\"i\n" +
+	"\x13NamespaceDefinition\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12>\n" +
+	"\trelations\x18\x02 \x03(\v2 .authlayer.v1.RelationDefinitionR\trelations\"F\n" +
+	"\tObjectRef\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1b\n" +
+	"\tobject_id\x18\x02 \x01(\tR\bobjectId\"u\n" +
+	"\n" +
+	"SubjectRef\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1b\n" +
+	"\tobject_id\x18\x02 \x01(\tR\bobjectId\x12\x1f\n" +
+	"\brelation\x18\x03 \x01(\tH\x00R\brelation\x88\x01\x01B\v\n" +
+	"\t_relation\"\x90\x01\n" +
+	"\rRelationTuple\x12/\n" +
+	"\x06object\x18\x01 \x01(\v2\x17.authlayer.v1.ObjectRefR\x06object\x12\x1a\n" +
+	"\brelation\x18\x02 \x01(\tR\brelation\x122\n" +
+	"\asubject\x18\x03 \x01(\v2\x18.authlayer.v1.SubjectRefR\asubject\"X\n" +
+	"\x15WriteNamespaceRequest\x12?\n" +
+	"\tnamespace\x18\x01 \x01(\v2!.authlayer.v1.NamespaceDefinitionR\tnamespace\"Y\n" +
+	"\x16WriteNamespaceResponse\x12?\n" +
+	"\tnamespace\x18\x01 \x01(\v2!.authlayer.v1.NamespaceDefinitionR\tnamespace\"X\n" +
+	"\x15ListNamespacesRequest\x12?\n" +
+	"\n" +
+	"pagination\x18\x01 \x01(\v2\x1f.authlayer.v1.PaginationRequestR\n" +
+	"pagination\"\x9d\x01\n" +
+	"\x16ListNamespacesResponse\x12A\n" +
+	"\n" +
+	"namespaces\x18\x01 \x03(\v2!.authlayer.v1.NamespaceDefinitionR\n" +
+	"namespaces\x12@\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2 .authlayer.v1.PaginationResponseR\n" +
+	"pagination\"\x80\x01\n" +
+	"\x12WriteTuplesRequest\x123\n" +
+	"\x06writes\x18\x01 \x03(\v2\x1b.authlayer.v1.RelationTupleR\x06writes\x125\n" +
+	"\adeletes\x18\x02 \x03(\v2\x1b.authlayer.v1.RelationTupleR\adeletes\"\x15\n" +
+	"\x13WriteTuplesResponse\"\xb3\x01\n" +
+	"\x11ReadTuplesRequest\x12/\n" +
+	"\x06object\x18\x01 \x01(\v2\x17.authlayer.v1.ObjectRefR\x06object\x12\x1f\n" +
+	"\brelation\x18\x02 \x01(\tH\x00R\brelation\x88\x01\x01\x12?\n" +
+	"\n" +
+	"pagination\x18\x03 \x01(\v2\x1f.authlayer.v1.PaginationRequestR\n" +
+	"paginationB\v\n" +
+	"\t_relation\"\x8b\x01\n" +
+	"\x12ReadTuplesResponse\x123\n" +
+	"\x06tuples\x18\x01 \x03(\v2\x1b.authlayer.v1.RelationTupleR\x06tuples\x12@\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2 .authlayer.v1.PaginationResponseR\n" +
+	"pagination\"\x8f\x01\n" +
+	"\fCheckRequest\x12/\n" +
+	"\x06object\x18\x01 \x01(\v2\x17.authlayer.v1.ObjectRefR\x06object\x12\x1a\n" +
+	"\brelation\x18\x02 \x01(\tR\brelation\x122\n" +
+	"\asubject\x18\x03 \x01(\v2\x18.authlayer.v1.SubjectRefR\asubject\")\n" +
+	"\rCheckResponse\x12\x18\n" +
+	"\aallowed\x18\x01 \x01(\bR\aallowed\"\\\n" +
+	"\rExpandRequest\x12/\n" +
+	"\x06object\x18\x01 \x01(\v2\x17.authlayer.v1.ObjectRefR\x06object\x12\x1a\n" +
+	"\brelation\x18\x02 \x01(\tR\brelation\"\xc7\x01\n" +
+	"\vUsersetTree\x12/\n" +
+	"\x06object\x18\x01 \x01(\v2\x17.authlayer.v1.ObjectRefR\x06object\x12\x1a\n" +
+	"\brelation\x18\x02 \x01(\tR\brelation\x124\n" +
+	"\bsubjects\x18\x03 \x03(\v2\x18.authlayer.v1.SubjectRefR\bsubjects\x125\n" +
+	"\bchildren\x18\x04 \x03(\v2\x19.authlayer.v1.UsersetTreeR\bchildren\"?\n" +
+	"\x0eExpandResponse\x12-\n" +
+	"\x04tree\x18\x01 \x01(\v2\x19.authlayer.v1.UsersetTreeR\x04tree\"\xc3\x01\n" +
+	"\x12ListObjectsRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1a\n" +
+	"\brelation\x18\x02 \x01(\tR\brelation\x122\n" +
+	"\asubject\x18\x03 \x01(\v2\x18.authlayer.v1.SubjectRefR\asubject\x12?\n" +
+	"\n" +
+	"pagination\x18\x04 \x01(\v2\x1f.authlayer.v1.PaginationRequestR\n" +
+	"pagination\"v\n" +
+	"\x13ListObjectsResponse\x12\x1d\n" +
+	"\n" +
+	"object_ids\x18\x01 \x03(\tR\tobjectIds\x12@\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2 .authlayer.v1.PaginationResponseR\n" +
+	"pagination2\xcb\x04\n" +
+	"\x0fRelationService\x12[\n" +
+	"\x0eWriteNamespace\x12#.authlayer.v1.WriteNamespaceRequest\x1a$.authlayer.v1.WriteNamespaceResponse\x12[\n" +
+	"\x0eListNamespaces\x12#.authlayer.v1.ListNamespacesRequest\x1a$.authlayer.v1.ListNamespacesResponse\x12R\n" +
+	"\vWriteTuples\x12 .authlayer.v1.WriteTuplesRequest\x1a!.authlayer.v1.WriteTuplesResponse\x12O\n" +
+	"\n" +
+	"ReadTuples\x12\x1f.authlayer.v1.ReadTuplesRequest\x1a .authlayer.v1.ReadTuplesResponse\x12@\n" +
+	"\x05Check\x12\x1a.authlayer.v1.CheckRequest\x1a\x1b.authlayer.v1.CheckResponse\x12C\n" +
+	"\x06Expand\x12\x1b.authlayer.v1.ExpandRequest\x1a\x1c.authlayer.v1.ExpandResponse\x12R\n" +
+	"\vListObjects\x12 .authlayer.v1.ListObjectsRequest\x1a!.authlayer.v1.ListObjectsResponseBJZHgithub.com/bernardoforcillo/authlayer/pkg/proto/authlayer/v1;authlayerv1b\x06proto3"
+
+var (
+	file_authlayer_v1_relation_proto_rawDescOnce sync.Once
+	file_authlayer_v1_relation_proto_rawDescData []byte
+)
+
+func file_authlayer_v1_relation_proto_rawDescGZIP() []byte {
+	file_authlayer_v1_relation_proto_rawDescOnce.Do(func() {
+		file_authlayer_v1_relation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_authlayer_v1_relation_proto_rawDesc), len(file_authlayer_v1_relation_proto_rawDesc)))
+	})
+	return file_authlayer_v1_relation_proto_rawDescData
+}
+
+var file_authlayer_v1_relation_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_authlayer_v1_relation_proto_goTypes = []any{
+	(*TupleToUserset)(nil),         // 0: authlayer.v1.TupleToUserset
+	(*RelationDefinition)(nil),     // 1: authlayer.v1.RelationDefinition
+	(*NamespaceDefinition)(nil),    // 2: authlayer.v1.NamespaceDefinition
+	(*ObjectRef)(nil),              // 3: authlayer.v1.ObjectRef
+	(*SubjectRef)(nil),             // 4: authlayer.v1.SubjectRef
+	(*RelationTuple)(nil),          // 5: authlayer.v1.RelationTuple
+	(*WriteNamespaceRequest)(nil),  // 6: authlayer.v1.WriteNamespaceRequest
+	(*WriteNamespaceResponse)(nil), // 7: authlayer.v1.WriteNamespaceResponse
+	(*ListNamespacesRequest)(nil),  // 8: authlayer.v1.ListNamespacesRequest
+	(*ListNamespacesResponse)(nil), // 9: authlayer.v1.ListNamespacesResponse
+	(*WriteTuplesRequest)(nil),     // 10: authlayer.v1.WriteTuplesRequest
+	(*WriteTuplesResponse)(nil),    // 11: authlayer.v1.WriteTuplesResponse
+	(*ReadTuplesRequest)(nil),      // 12: authlayer.v1.ReadTuplesRequest
+	(*ReadTuplesResponse)(nil),     // 13: authlayer.v1.ReadTuplesResponse
+	(*CheckRequest)(nil),           // 14: authlayer.v1.CheckRequest
+	(*CheckResponse)(nil),          // 15: authlayer.v1.CheckResponse
+	(*ExpandRequest)(nil),          // 16: authlayer.v1.ExpandRequest
+	(*UsersetTree)(nil),            // 17: authlayer.v1.UsersetTree
+	(*ExpandResponse)(nil),         // 18: authlayer.v1.ExpandResponse
+	(*ListObjectsRequest)(nil),     // 19: authlayer.v1.ListObjectsRequest
+	(*ListObjectsResponse)(nil),    // 20: authlayer.v1.ListObjectsResponse
+	(*PaginationRequest)(nil),      // 21: authlayer.v1.PaginationRequest
+	(*PaginationResponse)(nil),     // 22: authlayer.v1.PaginationResponse
+}
+var file_authlayer_v1_relation_proto_depIdxs = []int32{
+	0,  // 0: authlayer.v1.RelationDefinition.tuple_to_usersets:type_name -> authlayer.v1.TupleToUserset
+	1,  // 1: authlayer.v1.NamespaceDefinition.relations:type_name -> authlayer.v1.RelationDefinition
+	3,  // 2: authlayer.v1.RelationTuple.object:type_name -> authlayer.v1.ObjectRef
+	4,  // 3: authlayer.v1.RelationTuple.subject:type_name -> authlayer.v1.SubjectRef
+	2,  // 4: authlayer.v1.WriteNamespaceRequest.namespace:type_name -> authlayer.v1.NamespaceDefinition
+	2,  // 5: authlayer.v1.WriteNamespaceResponse.namespace:type_name -> authlayer.v1.NamespaceDefinition
+	21, // 6: authlayer.v1.ListNamespacesRequest.pagination:type_name -> authlayer.v1.PaginationRequest
+	2,  // 7: authlayer.v1.ListNamespacesResponse.namespaces:type_name -> authlayer.v1.NamespaceDefinition
+	22, // 8: authlayer.v1.ListNamespacesResponse.pagination:type_name -> authlayer.v1.PaginationResponse
+	5,  // 9: authlayer.v1.WriteTuplesRequest.writes:type_name -> authlayer.v1.RelationTuple
+	5,  // 10: authlayer.v1.WriteTuplesRequest.deletes:type_name -> authlayer.v1.RelationTuple
+	3,  // 11: authlayer.v1.ReadTuplesRequest.object:type_name -> authlayer.v1.ObjectRef
+	21, // 12: authlayer.v1.ReadTuplesRequest.pagination:type_name -> authlayer.v1.PaginationRequest
+	5,  // 13: authlayer.v1.ReadTuplesResponse.tuples:type_name -> authlayer.v1.RelationTuple
+	22, // 14: authlayer.v1.ReadTuplesResponse.pagination:type_name -> authlayer.v1.PaginationResponse
+	3,  // 15: authlayer.v1.CheckRequest.object:type_name -> authlayer.v1.ObjectRef
+	4,  // 16: authlayer.v1.CheckRequest.subject:type_name -> authlayer.v1.SubjectRef
+	3,  // 17: authlayer.v1.ExpandRequest.object:type_name -> authlayer.v1.ObjectRef
+	3,  // 18: authlayer.v1.UsersetTree.object:type_name -> authlayer.v1.ObjectRef
+	4,  // 19: authlayer.v1.UsersetTree.subjects:type_name -> authlayer.v1.SubjectRef
+	17, // 20: authlayer.v1.UsersetTree.children:type_name -> authlayer.v1.UsersetTree
+	17, // 21: authlayer.v1.ExpandResponse.tree:type_name -> authlayer.v1.UsersetTree
+	4,  // 22: authlayer.v1.ListObjectsRequest.subject:type_name -> authlayer.v1.SubjectRef
+	21, // 23: authlayer.v1.ListObjectsRequest.pagination:type_name -> authlayer.v1.PaginationRequest
+	22, // 24: authlayer.v1.ListObjectsResponse.pagination:type_name -> authlayer.v1.PaginationResponse
+	6,  // 25: authlayer.v1.RelationService.WriteNamespace:input_type -> authlayer.v1.WriteNamespaceRequest
+	8,  // 26: authlayer.v1.RelationService.ListNamespaces:input_type -> authlayer.v1.ListNamespacesRequest
+	10, // 27: authlayer.v1.RelationService.WriteTuples:input_type -> authlayer.v1.WriteTuplesRequest
+	12, // 28: authlayer.v1.RelationService.ReadTuples:input_type -> authlayer.v1.ReadTuplesRequest
+	14, // 29: authlayer.v1.RelationService.Check:input_type -> authlayer.v1.CheckRequest
+	16, // 30: authlayer.v1.RelationService.Expand:input_type -> authlayer.v1.ExpandRequest
+	19, // 31: authlayer.v1.RelationService.ListObjects:input_type -> authlayer.v1.ListObjectsRequest
+	7,  // 32: authlayer.v1.RelationService.WriteNamespace:output_type -> authlayer.v1.WriteNamespaceResponse
+	9,  // 33: authlayer.v1.RelationService.ListNamespaces:output_type -> authlayer.v1.ListNamespacesResponse
+	11, // 34: authlayer.v1.RelationService.WriteTuples:output_type -> authlayer.v1.WriteTuplesResponse
+	13, // 35: authlayer.v1.RelationService.ReadTuples:output_type -> authlayer.v1.ReadTuplesResponse
+	15, // 36: authlayer.v1.RelationService.Check:output_type -> authlayer.v1.CheckResponse
+	18, // 37: authlayer.v1.RelationService.Expand:output_type -> authlayer.v1.ExpandResponse
+	20, // 38: authlayer.v1.RelationService.ListObjects:output_type -> authlayer.v1.ListObjectsResponse
+	32, // [32:39] is the sub-list for method output_type
+	25, // [25:32] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
+}
+
+func init() { file_authlayer_v1_relation_proto_init() }
+func file_authlayer_v1_relation_proto_init() {
+	if File_authlayer_v1_relation_proto != nil {
+		return
+	}
+	file_authlayer_v1_common_proto_init()
+	file_authlayer_v1_relation_proto_msgTypes[4].OneofWrappers = []any{}
+	file_authlayer_v1_relation_proto_msgTypes[12].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_authlayer_v1_relation_proto_rawDesc), len(file_authlayer_v1_relation_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   21,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_authlayer_v1_relation_proto_goTypes,
+		DependencyIndexes: file_authlayer_v1_relation_proto_depIdxs,
+		MessageInfos:      file_authlayer_v1_relation_proto_msgTypes,
+	}.Build()
+	File_authlayer_v1_relation_proto = out.File
+	file_authlayer_v1_relation_proto_goTypes = nil
+	file_authlayer_v1_relation_proto_depIdxs = nil
+}
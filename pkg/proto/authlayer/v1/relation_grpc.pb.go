@@ -0,0 +1,365 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.1
+// - protoc             (unknown)
+// source: authlayer/v1/relation.proto
+
+package authlayerv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RelationService_WriteNamespace_FullMethodName = "/authlayer.v1.RelationService/WriteNamespace"
+	RelationService_ListNamespaces_FullMethodName = "/authlayer.v1.RelationService/ListNamespaces"
+	RelationService_WriteTuples_FullMethodName    = "/authlayer.v1.RelationService/WriteTuples"
+	RelationService_ReadTuples_FullMethodName     = "/authlayer.v1.RelationService/ReadTuples"
+	RelationService_Check_FullMethodName          = "/authlayer.v1.RelationService/Check"
+	RelationService_Expand_FullMethodName         = "/authlayer.v1.RelationService/Expand"
+	RelationService_ListObjects_FullMethodName    = "/authlayer.v1.RelationService/ListObjects"
+)
+
+// RelationServiceClient is the client API for RelationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// RelationService provides relationship-based access control (Zanzibar-style) for
+// objects owned by downstream services. Access is expressed as relation tuples
+// ("document:readme#editor@user:42") interpreted through per-namespace schemas.
+//
+// The built-in namespaces "user", "service_account", "team" and "organization"
+// refer to existing authlayer identities. "team:<id>#member" and
+// "organization:<id>#member" are resolved from team and organization memberships.
+type RelationServiceClient interface {
+	WriteNamespace(ctx context.Context, in *WriteNamespaceRequest, opts ...grpc.CallOption) (*WriteNamespaceResponse, error)
+	ListNamespaces(ctx context.Context, in *ListNamespacesRequest, opts ...grpc.CallOption) (*ListNamespacesResponse, error)
+	WriteTuples(ctx context.Context, in *WriteTuplesRequest, opts ...grpc.CallOption) (*WriteTuplesResponse, error)
+	ReadTuples(ctx context.Context, in *ReadTuplesRequest, opts ...grpc.CallOption) (*ReadTuplesResponse, error)
+	Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error)
+	Expand(ctx context.Context, in *ExpandRequest, opts ...grpc.CallOption) (*ExpandResponse, error)
+	ListObjects(ctx context.Context, in *ListObjectsRequest, opts ...grpc.CallOption) (*ListObjectsResponse, error)
+}
+
+type relationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRelationServiceClient(cc grpc.ClientConnInterface) RelationServiceClient {
+	return &relationServiceClient{cc}
+}
+
+func (c *relationServiceClient) WriteNamespace(ctx context.Context, in *WriteNamespaceRequest, opts ...grpc.CallOption) (*WriteNamespaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WriteNamespaceResponse)
+	err := c.cc.Invoke(ctx, RelationService_WriteNamespace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relationServiceClient) ListNamespaces(ctx context.Context, in *ListNamespacesRequest, opts ...grpc.CallOption) (*ListNamespacesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNamespacesResponse)
+	err := c.cc.Invoke(ctx, RelationService_ListNamespaces_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relationServiceClient) WriteTuples(ctx context.Context, in *WriteTuplesRequest, opts ...grpc.CallOption) (*WriteTuplesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WriteTuplesResponse)
+	err := c.cc.Invoke(ctx, RelationService_WriteTuples_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relationServiceClient) ReadTuples(ctx context.Context, in *ReadTuplesRequest, opts ...grpc.CallOption) (*ReadTuplesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReadTuplesResponse)
+	err := c.cc.Invoke(ctx, RelationService_ReadTuples_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relationServiceClient) Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckResponse)
+	err := c.cc.Invoke(ctx, RelationService_Check_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relationServiceClient) Expand(ctx context.Context, in *ExpandRequest, opts ...grpc.CallOption) (*ExpandResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExpandResponse)
+	err := c.cc.Invoke(ctx, RelationService_Expand_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relationServiceClient) ListObjects(ctx context.Context, in *ListObjectsRequest, opts ...grpc.CallOption) (*ListObjectsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListObjectsResponse)
+	err := c.cc.Invoke(ctx, RelationService_ListObjects_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RelationServiceServer is the server API for RelationService service.
+// All implementations must embed UnimplementedRelationServiceServer
+// for forward compatibility.
+//
+// RelationService provides relationship-based access control (Zanzibar-style) for
+// objects owned by downstream services. Access is expressed as relation tuples
+// ("document:readme#editor@user:42") interpreted through per-namespace schemas.
+//
+// The built-in namespaces "user", "service_account", "team" and "organization"
+// refer to existing authlayer identities. "team:<id>#member" and
+// "organization:<id>#member" are resolved from team and organization memberships.
+type RelationServiceServer interface {
+	WriteNamespace(context.Context, *WriteNamespaceRequest) (*WriteNamespaceResponse, error)
+	ListNamespaces(context.Context, *ListNamespacesRequest) (*ListNamespacesResponse, error)
+	WriteTuples(context.Context, *WriteTuplesRequest) (*WriteTuplesResponse, error)
+	ReadTuples(context.Context, *ReadTuplesRequest) (*ReadTuplesResponse, error)
+	Check(context.Context, *CheckRequest) (*CheckResponse, error)
+	Expand(context.Context, *ExpandRequest) (*ExpandResponse, error)
+	ListObjects(context.Context, *ListObjectsRequest) (*ListObjectsResponse, error)
+	mustEmbedUnimplementedRelationServiceServer()
+}
+
+// UnimplementedRelationServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRelationServiceServer struct{}
+
+func (UnimplementedRelationServiceServer) WriteNamespace(context.Context, *WriteNamespaceRequest) (*WriteNamespaceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method WriteNamespace not implemented")
+}
+func (UnimplementedRelationServiceServer) ListNamespaces(context.Context, *ListNamespacesRequest) (*ListNamespacesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListNamespaces not implemented")
+}
+func (UnimplementedRelationServiceServer) WriteTuples(context.Context, *WriteTuplesRequest) (*WriteTuplesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method WriteTuples not implemented")
+}
+func (UnimplementedRelationServiceServer) ReadTuples(context.Context, *ReadTuplesRequest) (*ReadTuplesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReadTuples not implemented")
+}
+func (UnimplementedRelationServiceServer) Check(context.Context, *CheckRequest) (*CheckResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Check not implemented")
+}
+func (UnimplementedRelationServiceServer) Expand(context.Context, *ExpandRequest) (*ExpandResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Expand not implemented")
+}
+func (UnimplementedRelationServiceServer) ListObjects(context.Context, *ListObjectsRequest) (*ListObjectsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListObjects not implemented")
+}
+func (UnimplementedRelationServiceServer) mustEmbedUnimplementedRelationServiceServer() {}
+func (UnimplementedRelationServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeRelationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RelationServiceServer will
+// result in compilation errors.
+type UnsafeRelationServiceServer interface {
+	mustEmbedUnimplementedRelationServiceServer()
+}
+
+func RegisterRelationServiceServer(s grpc.ServiceRegistrar, srv RelationServiceServer) {
+	// If the following call panics, it indicates UnimplementedRelationServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RelationService_ServiceDesc, srv)
+}
+
+func _RelationService_WriteNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteNamespaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationServiceServer).WriteNamespace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RelationService_WriteNamespace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationServiceServer).WriteNamespace(ctx, req.(*WriteNamespaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelationService_ListNamespaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNamespacesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationServiceServer).ListNamespaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RelationService_ListNamespaces_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationServiceServer).ListNamespaces(ctx, req.(*ListNamespacesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelationService_WriteTuples_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteTuplesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationServiceServer).WriteTuples(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RelationService_WriteTuples_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationServiceServer).WriteTuples(ctx, req.(*WriteTuplesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelationService_ReadTuples_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadTuplesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationServiceServer).ReadTuples(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RelationService_ReadTuples_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationServiceServer).ReadTuples(ctx, req.(*ReadTuplesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelationService_Check_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationServiceServer).Check(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RelationService_Check_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationServiceServer).Check(ctx, req.(*CheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelationService_Expand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExpandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationServiceServer).Expand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RelationService_Expand_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationServiceServer).Expand(ctx, req.(*ExpandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelationService_ListObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListObjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationServiceServer).ListObjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RelationService_ListObjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationServiceServer).ListObjects(ctx, req.(*ListObjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RelationService_ServiceDesc is the grpc.ServiceDesc for RelationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RelationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "authlayer.v1.RelationService",
+	HandlerType: (*RelationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "WriteNamespace",
+			Handler:    _RelationService_WriteNamespace_Handler,
+		},
+		{
+			MethodName: "ListNamespaces",
+			Handler:    _RelationService_ListNamespaces_Handler,
+		},
+		{
+			MethodName: "WriteTuples",
+			Handler:    _RelationService_WriteTuples_Handler,
+		},
+		{
+			MethodName: "ReadTuples",
+			Handler:    _RelationService_ReadTuples_Handler,
+		},
+		{
+			MethodName: "Check",
+			Handler:    _RelationService_Check_Handler,
+		},
+		{
+			MethodName: "Expand",
+			Handler:    _RelationService_Expand_Handler,
+		},
+		{
+			MethodName: "ListObjects",
+			Handler:    _RelationService_ListObjects_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "authlayer/v1/relation.proto",
+}
@@ -71,6 +71,57 @@ func (ServiceAccountStatus) EnumDescriptor() ([]byte, []int) {
 	return file_authlayer_v1_service_account_proto_rawDescGZIP(), []int{0}
 }
 
+type ServiceAccountKeyType int32
+
+const (
+	ServiceAccountKeyType_SERVICE_ACCOUNT_KEY_TYPE_UNSPECIFIED ServiceAccountKeyType = 0
+	// A random secret, presented with the ServiceKey scheme.
+	ServiceAccountKeyType_SERVICE_ACCOUNT_KEY_TYPE_SECRET ServiceAccountKeyType = 1
+	// A public key verifying JWT assertions the service account signs with its private key.
+	ServiceAccountKeyType_SERVICE_ACCOUNT_KEY_TYPE_PUBLIC_KEY ServiceAccountKeyType = 2
+)
+
+// Enum value maps for ServiceAccountKeyType.
+var (
+	ServiceAccountKeyType_name = map[int32]string{
+		0: "SERVICE_ACCOUNT_KEY_TYPE_UNSPECIFIED",
+		1: "SERVICE_ACCOUNT_KEY_TYPE_SECRET",
+		2: "SERVICE_ACCOUNT_KEY_TYPE_PUBLIC_KEY",
+	}
+	ServiceAccountKeyType_value = map[string]int32{
+		"SERVICE_ACCOUNT_KEY_TYPE_UNSPECIFIED": 0,
+		"SERVICE_ACCOUNT_KEY_TYPE_SECRET":      1,
+		"SERVICE_ACCOUNT_KEY_TYPE_PUBLIC_KEY":  2,
+	}
+)
+
+func (x ServiceAccountKeyType) Enum() *ServiceAccountKeyType {
+	p := new(ServiceAccountKeyType)
+	*p = x
+	return p
+}
+
+func (x ServiceAccountKeyType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ServiceAccountKeyType) Descriptor() protoreflect.EnumDescriptor {
+	return file_authlayer_v1_service_account_proto_enumTypes[1].Descriptor()
+}
+
+func (ServiceAccountKeyType) Type() protoreflect.EnumType {
+	return &file_authlayer_v1_service_account_proto_enumTypes[1]
+}
+
+func (x ServiceAccountKeyType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ServiceAccountKeyType.Descriptor instead.
+func (ServiceAccountKeyType) EnumDescriptor() ([]byte, []int) {
+	return file_authlayer_v1_service_account_proto_rawDescGZIP(), []int{1}
+}
+
 type ServiceAccountInfo struct {
 	state               protoimpl.MessageState `protogen:"open.v1"`
 	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -83,8 +134,11 @@ type ServiceAccountInfo struct {
 	UpdatedAt           *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	LastAuthenticatedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=last_authenticated_at,json=lastAuthenticatedAt,proto3,oneof" json:"last_authenticated_at,omitempty"`
 	Roles               []*RoleInfo            `protobuf:"bytes,10,rep,name=roles,proto3" json:"roles,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+	Labels              map[string]string      `protobuf:"bytes,11,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Scopes the service account may request for its access tokens.
+	Scopes        []string `protobuf:"bytes,12,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ServiceAccountInfo) Reset() {
@@ -187,6 +241,20 @@ func (x *ServiceAccountInfo) GetRoles() []*RoleInfo {
 	return nil
 }
 
+func (x *ServiceAccountInfo) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ServiceAccountInfo) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
 type ServiceAccountKeyInfo struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -197,8 +265,15 @@ type ServiceAccountKeyInfo struct {
 	ExpiresAt        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3,oneof" json:"expires_at,omitempty"`
 	LastUsedAt       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_used_at,json=lastUsedAt,proto3,oneof" json:"last_used_at,omitempty"`
 	Revoked          bool                   `protobuf:"varint,8,opt,name=revoked,proto3" json:"revoked,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	KeyType          ServiceAccountKeyType  `protobuf:"varint,9,opt,name=key_type,json=keyType,proto3,enum=authlayer.v1.ServiceAccountKeyType" json:"key_type,omitempty"`
+	// PEM encoded public key and the JWT algorithm assertions are signed with, for public keys.
+	PublicKey string `protobuf:"bytes,10,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Algorithm string `protobuf:"bytes,11,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	// Whether the secret can sign requests with the ServiceSignature scheme and HS256
+	// assertions.
+	RequestSigning bool `protobuf:"varint,12,opt,name=request_signing,json=requestSigning,proto3" json:"request_signing,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ServiceAccountKeyInfo) Reset() {
@@ -287,11 +362,42 @@ func (x *ServiceAccountKeyInfo) GetRevoked() bool {
 	return false
 }
 
+func (x *ServiceAccountKeyInfo) GetKeyType() ServiceAccountKeyType {
+	if x != nil {
+		return x.KeyType
+	}
+	return ServiceAccountKeyType_SERVICE_ACCOUNT_KEY_TYPE_UNSPECIFIED
+}
+
+func (x *ServiceAccountKeyInfo) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
+func (x *ServiceAccountKeyInfo) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *ServiceAccountKeyInfo) GetRequestSigning() bool {
+	if x != nil {
+		return x.RequestSigning
+	}
+	return false
+}
+
 type CreateServiceAccountRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	DisplayName   string                 `protobuf:"bytes,1,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	OrgId         string                 `protobuf:"bytes,3,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	DisplayName string                 `protobuf:"bytes,1,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	OrgId       string                 `protobuf:"bytes,3,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Labels      map[string]string      `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Scopes the service account may request for its access tokens; none when empty.
+	Scopes        []string `protobuf:"bytes,5,rep,name=scopes,proto3" json:"scopes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -347,6 +453,20 @@ func (x *CreateServiceAccountRequest) GetOrgId() string {
 	return ""
 }
 
+func (x *CreateServiceAccountRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *CreateServiceAccountRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
 type CreateServiceAccountResponse struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	ServiceAccount *ServiceAccountInfo    `protobuf:"bytes,1,opt,name=service_account,json=serviceAccount,proto3" json:"service_account,omitempty"`
@@ -485,8 +605,12 @@ type UpdateServiceAccountRequest struct {
 	DisplayName      *string                `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3,oneof" json:"display_name,omitempty"`
 	Description      *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
 	Status           *ServiceAccountStatus  `protobuf:"varint,4,opt,name=status,proto3,enum=authlayer.v1.ServiceAccountStatus,oneof" json:"status,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// Replaces all labels when set.
+	Labels *LabelSet `protobuf:"bytes,5,opt,name=labels,proto3,oneof" json:"labels,omitempty"`
+	// Replaces the allowed scopes when set.
+	Scopes        *StringList `protobuf:"bytes,6,opt,name=scopes,proto3,oneof" json:"scopes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateServiceAccountRequest) Reset() {
@@ -547,6 +671,20 @@ func (x *UpdateServiceAccountRequest) GetStatus() ServiceAccountStatus {
 	return ServiceAccountStatus_SERVICE_ACCOUNT_STATUS_UNSPECIFIED
 }
 
+func (x *UpdateServiceAccountRequest) GetLabels() *LabelSet {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *UpdateServiceAccountRequest) GetScopes() *StringList {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
 type UpdateServiceAccountResponse struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	ServiceAccount *ServiceAccountInfo    `protobuf:"bytes,1,opt,name=service_account,json=serviceAccount,proto3" json:"service_account,omitempty"`
@@ -675,6 +813,7 @@ type ListServiceAccountsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
 	Pagination    *PaginationRequest     `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	LabelSelector *string                `protobuf:"bytes,3,opt,name=label_selector,json=labelSelector,proto3,oneof" json:"label_selector,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -723,6 +862,13 @@ func (x *ListServiceAccountsRequest) GetPagination() *PaginationRequest {
 	return nil
 }
 
+func (x *ListServiceAccountsRequest) GetLabelSelector() string {
+	if x != nil && x.LabelSelector != nil {
+		return *x.LabelSelector
+	}
+	return ""
+}
+
 type ListServiceAccountsResponse struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	ServiceAccounts []*ServiceAccountInfo  `protobuf:"bytes,1,rep,name=service_accounts,json=serviceAccounts,proto3" json:"service_accounts,omitempty"`
@@ -775,13 +921,31 @@ func (x *ListServiceAccountsResponse) GetPagination() *PaginationResponse {
 	return nil
 }
 
+// Creates a random secret by default. Set public_key to register a key pair held by the
+// service account, or generate_key_pair to have one generated.
 type CreateServiceAccountKeyRequest struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	ServiceAccountId string                 `protobuf:"bytes,1,opt,name=service_account_id,json=serviceAccountId,proto3" json:"service_account_id,omitempty"`
 	Name             string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	ExpiresAt        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3,oneof" json:"expires_at,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// PEM encoded (PKIX or PKCS#1) or JWK RSA or ECDSA public key.
+	PublicKey       *string `protobuf:"bytes,4,opt,name=public_key,json=publicKey,proto3,oneof" json:"public_key,omitempty"`
+	GenerateKeyPair bool    `protobuf:"varint,5,opt,name=generate_key_pair,json=generateKeyPair,proto3" json:"generate_key_pair,omitempty"`
+	// Keep the secret, encrypted, so that it can sign requests and HS256 assertions instead
+	// of being sent with them. Requires the server to be configured with a service key
+	// encryption key.
+	//
+	// A signed request carries the header
+	//   Authorization: ServiceSignature keyId=<key id>,timestamp=<unix seconds>,nonce=<16 to 128 characters>,signature=<signature>
+	// where the signature is the unpadded base64url HMAC-SHA256, keyed with the secret, of
+	//   AUTHLAYER-HMAC-SHA256\n<method>\n<path>\n<timestamp>\n<nonce>\n<hex SHA-256 of the body>
+	// The body is hashed exactly as sent. gRPC calls sign POST, the full method name, such as
+	// /authlayer.v1.UserService/GetUser, and the serialized request message as it goes on
+	// the wire; streams sign an empty body. HTTP gateway calls sign their HTTP method, path
+	// and query as in the request line, and raw body. Each nonce is accepted once.
+	RequestSigning bool `protobuf:"varint,6,opt,name=request_signing,json=requestSigning,proto3" json:"request_signing,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *CreateServiceAccountKeyRequest) Reset() {
@@ -835,10 +999,35 @@ func (x *CreateServiceAccountKeyRequest) GetExpiresAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *CreateServiceAccountKeyRequest) GetPublicKey() string {
+	if x != nil && x.PublicKey != nil {
+		return *x.PublicKey
+	}
+	return ""
+}
+
+func (x *CreateServiceAccountKeyRequest) GetGenerateKeyPair() bool {
+	if x != nil {
+		return x.GenerateKeyPair
+	}
+	return false
+}
+
+func (x *CreateServiceAccountKeyRequest) GetRequestSigning() bool {
+	if x != nil {
+		return x.RequestSigning
+	}
+	return false
+}
+
 type CreateServiceAccountKeyResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	KeyInfo       *ServiceAccountKeyInfo `protobuf:"bytes,1,opt,name=key_info,json=keyInfo,proto3" json:"key_info,omitempty"`
-	PlainTextKey  string                 `protobuf:"bytes,2,opt,name=plain_text_key,json=plainTextKey,proto3" json:"plain_text_key,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	KeyInfo *ServiceAccountKeyInfo `protobuf:"bytes,1,opt,name=key_info,json=keyInfo,proto3" json:"key_info,omitempty"`
+	// Set for secrets. It is shown only once.
+	PlainTextKey string `protobuf:"bytes,2,opt,name=plain_text_key,json=plainTextKey,proto3" json:"plain_text_key,omitempty"`
+	// PEM encoded private key, set for generated key pairs. It is shown only once and
+	// authlayer does not keep it.
+	PrivateKey    string `protobuf:"bytes,3,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -887,6 +1076,13 @@ func (x *CreateServiceAccountKeyResponse) GetPlainTextKey() string {
 	return ""
 }
 
+func (x *CreateServiceAccountKeyResponse) GetPrivateKey() string {
+	if x != nil {
+		return x.PrivateKey
+	}
+	return ""
+}
+
 type RevokeServiceAccountKeyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	KeyId         string                 `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
@@ -1263,11 +1459,405 @@ func (*RevokeServiceAccountRoleResponse) Descriptor() ([]byte, []int) {
 	return file_authlayer_v1_service_account_proto_rawDescGZIP(), []int{21}
 }
 
+type WorkloadIdentityTrustInfo struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ServiceAccountId string                 `protobuf:"bytes,2,opt,name=service_account_id,json=serviceAccountId,proto3" json:"service_account_id,omitempty"`
+	Name             string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	IssuerUrl        string                 `protobuf:"bytes,4,opt,name=issuer_url,json=issuerUrl,proto3" json:"issuer_url,omitempty"`
+	Audience         string                 `protobuf:"bytes,5,opt,name=audience,proto3" json:"audience,omitempty"`
+	Conditions       map[string]string      `protobuf:"bytes,6,rep,name=conditions,proto3" json:"conditions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastUsedAt       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_used_at,json=lastUsedAt,proto3,oneof" json:"last_used_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *WorkloadIdentityTrustInfo) Reset() {
+	*x = WorkloadIdentityTrustInfo{}
+	mi := &file_authlayer_v1_service_account_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkloadIdentityTrustInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkloadIdentityTrustInfo) ProtoMessage() {}
+
+func (x *WorkloadIdentityTrustInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_service_account_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkloadIdentityTrustInfo.ProtoReflect.Descriptor instead.
+func (*WorkloadIdentityTrustInfo) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_service_account_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *WorkloadIdentityTrustInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WorkloadIdentityTrustInfo) GetServiceAccountId() string {
+	if x != nil {
+		return x.ServiceAccountId
+	}
+	return ""
+}
+
+func (x *WorkloadIdentityTrustInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WorkloadIdentityTrustInfo) GetIssuerUrl() string {
+	if x != nil {
+		return x.IssuerUrl
+	}
+	return ""
+}
+
+func (x *WorkloadIdentityTrustInfo) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+func (x *WorkloadIdentityTrustInfo) GetConditions() map[string]string {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
+}
+
+func (x *WorkloadIdentityTrustInfo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *WorkloadIdentityTrustInfo) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+type CreateWorkloadIdentityTrustRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ServiceAccountId string                 `protobuf:"bytes,1,opt,name=service_account_id,json=serviceAccountId,proto3" json:"service_account_id,omitempty"`
+	Name             string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// OIDC issuer of the external tokens, e.g. https://token.actions.githubusercontent.com.
+	// Its signing keys are found through OIDC discovery.
+	IssuerUrl string `protobuf:"bytes,3,opt,name=issuer_url,json=issuerUrl,proto3" json:"issuer_url,omitempty"`
+	// Audience the external tokens must be issued for.
+	Audience string `protobuf:"bytes,4,opt,name=audience,proto3" json:"audience,omitempty"`
+	// Claims the external tokens must carry, e.g. repository or
+	// kubernetes.io.namespace. A value ending in * matches claims starting with the rest.
+	// At least one condition is required.
+	Conditions    map[string]string `protobuf:"bytes,5,rep,name=conditions,proto3" json:"conditions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWorkloadIdentityTrustRequest) Reset() {
+	*x = CreateWorkloadIdentityTrustRequest{}
+	mi := &file_authlayer_v1_service_account_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWorkloadIdentityTrustRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWorkloadIdentityTrustRequest) ProtoMessage() {}
+
+func (x *CreateWorkloadIdentityTrustRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_service_account_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWorkloadIdentityTrustRequest.ProtoReflect.Descriptor instead.
+func (*CreateWorkloadIdentityTrustRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_service_account_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CreateWorkloadIdentityTrustRequest) GetServiceAccountId() string {
+	if x != nil {
+		return x.ServiceAccountId
+	}
+	return ""
+}
+
+func (x *CreateWorkloadIdentityTrustRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateWorkloadIdentityTrustRequest) GetIssuerUrl() string {
+	if x != nil {
+		return x.IssuerUrl
+	}
+	return ""
+}
+
+func (x *CreateWorkloadIdentityTrustRequest) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+func (x *CreateWorkloadIdentityTrustRequest) GetConditions() map[string]string {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
+}
+
+type CreateWorkloadIdentityTrustResponse struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Trust         *WorkloadIdentityTrustInfo `protobuf:"bytes,1,opt,name=trust,proto3" json:"trust,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWorkloadIdentityTrustResponse) Reset() {
+	*x = CreateWorkloadIdentityTrustResponse{}
+	mi := &file_authlayer_v1_service_account_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWorkloadIdentityTrustResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWorkloadIdentityTrustResponse) ProtoMessage() {}
+
+func (x *CreateWorkloadIdentityTrustResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_service_account_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWorkloadIdentityTrustResponse.ProtoReflect.Descriptor instead.
+func (*CreateWorkloadIdentityTrustResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_service_account_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CreateWorkloadIdentityTrustResponse) GetTrust() *WorkloadIdentityTrustInfo {
+	if x != nil {
+		return x.Trust
+	}
+	return nil
+}
+
+type ListWorkloadIdentityTrustsRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ServiceAccountId string                 `protobuf:"bytes,1,opt,name=service_account_id,json=serviceAccountId,proto3" json:"service_account_id,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListWorkloadIdentityTrustsRequest) Reset() {
+	*x = ListWorkloadIdentityTrustsRequest{}
+	mi := &file_authlayer_v1_service_account_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkloadIdentityTrustsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkloadIdentityTrustsRequest) ProtoMessage() {}
+
+func (x *ListWorkloadIdentityTrustsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_service_account_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkloadIdentityTrustsRequest.ProtoReflect.Descriptor instead.
+func (*ListWorkloadIdentityTrustsRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_service_account_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListWorkloadIdentityTrustsRequest) GetServiceAccountId() string {
+	if x != nil {
+		return x.ServiceAccountId
+	}
+	return ""
+}
+
+type ListWorkloadIdentityTrustsResponse struct {
+	state         protoimpl.MessageState       `protogen:"open.v1"`
+	Trusts        []*WorkloadIdentityTrustInfo `protobuf:"bytes,1,rep,name=trusts,proto3" json:"trusts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkloadIdentityTrustsResponse) Reset() {
+	*x = ListWorkloadIdentityTrustsResponse{}
+	mi := &file_authlayer_v1_service_account_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkloadIdentityTrustsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkloadIdentityTrustsResponse) ProtoMessage() {}
+
+func (x *ListWorkloadIdentityTrustsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_service_account_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkloadIdentityTrustsResponse.ProtoReflect.Descriptor instead.
+func (*ListWorkloadIdentityTrustsResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_service_account_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListWorkloadIdentityTrustsResponse) GetTrusts() []*WorkloadIdentityTrustInfo {
+	if x != nil {
+		return x.Trusts
+	}
+	return nil
+}
+
+type DeleteWorkloadIdentityTrustRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrustId       string                 `protobuf:"bytes,1,opt,name=trust_id,json=trustId,proto3" json:"trust_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWorkloadIdentityTrustRequest) Reset() {
+	*x = DeleteWorkloadIdentityTrustRequest{}
+	mi := &file_authlayer_v1_service_account_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWorkloadIdentityTrustRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWorkloadIdentityTrustRequest) ProtoMessage() {}
+
+func (x *DeleteWorkloadIdentityTrustRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_service_account_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWorkloadIdentityTrustRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWorkloadIdentityTrustRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_service_account_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DeleteWorkloadIdentityTrustRequest) GetTrustId() string {
+	if x != nil {
+		return x.TrustId
+	}
+	return ""
+}
+
+type DeleteWorkloadIdentityTrustResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWorkloadIdentityTrustResponse) Reset() {
+	*x = DeleteWorkloadIdentityTrustResponse{}
+	mi := &file_authlayer_v1_service_account_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWorkloadIdentityTrustResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWorkloadIdentityTrustResponse) ProtoMessage() {}
+
+func (x *DeleteWorkloadIdentityTrustResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_service_account_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWorkloadIdentityTrustResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWorkloadIdentityTrustResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_service_account_proto_rawDescGZIP(), []int{28}
+}
+
 var File_authlayer_v1_service_account_proto protoreflect.FileDescriptor
 
 const file_authlayer_v1_service_account_proto_rawDesc = "" +
 	"\n" +
-	"\"authlayer/v1/service_account.proto\x12\fauthlayer.v1\x1a\x19authlayer/v1/common.proto\x1a\x17authlayer/v1/rbac.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xee\x03\n" +
+	"\"authlayer/v1/service_account.proto\x12\fauthlayer.v1\x1a\x19authlayer/v1/common.proto\x1a\x17authlayer/v1/rbac.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x87\x05\n" +
 	"\x12ServiceAccountInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
 	"\fdisplay_name\x18\x02 \x01(\tR\vdisplayName\x12 \n" +
@@ -1282,8 +1872,13 @@ const file_authlayer_v1_service_account_proto_rawDesc = "" +
 	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12S\n" +
 	"\x15last_authenticated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x13lastAuthenticatedAt\x88\x01\x01\x12,\n" +
 	"\x05roles\x18\n" +
-	" \x03(\v2\x16.authlayer.v1.RoleInfoR\x05rolesB\x18\n" +
-	"\x16_last_authenticated_at\"\x80\x03\n" +
+	" \x03(\v2\x16.authlayer.v1.RoleInfoR\x05roles\x12D\n" +
+	"\x06labels\x18\v \x03(\v2,.authlayer.v1.ServiceAccountInfo.LabelsEntryR\x06labels\x12\x16\n" +
+	"\x06scopes\x18\f \x03(\tR\x06scopes\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x18\n" +
+	"\x16_last_authenticated_at\"\xa6\x04\n" +
 	"\x15ServiceAccountKeyInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12,\n" +
 	"\x12service_account_id\x18\x02 \x01(\tR\x10serviceAccountId\x12\x1d\n" +
@@ -1296,51 +1891,75 @@ const file_authlayer_v1_service_account_proto_rawDesc = "" +
 	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\texpiresAt\x88\x01\x01\x12A\n" +
 	"\flast_used_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampH\x01R\n" +
 	"lastUsedAt\x88\x01\x01\x12\x18\n" +
-	"\arevoked\x18\b \x01(\bR\arevokedB\r\n" +
+	"\arevoked\x18\b \x01(\bR\arevoked\x12>\n" +
+	"\bkey_type\x18\t \x01(\x0e2#.authlayer.v1.ServiceAccountKeyTypeR\akeyType\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\n" +
+	" \x01(\tR\tpublicKey\x12\x1c\n" +
+	"\talgorithm\x18\v \x01(\tR\talgorithm\x12'\n" +
+	"\x0frequest_signing\x18\f \x01(\bR\x0erequestSigningB\r\n" +
 	"\v_expires_atB\x0f\n" +
-	"\r_last_used_at\"y\n" +
+	"\r_last_used_at\"\x9b\x02\n" +
 	"\x1bCreateServiceAccountRequest\x12!\n" +
 	"\fdisplay_name\x18\x01 \x01(\tR\vdisplayName\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x15\n" +
-	"\x06org_id\x18\x03 \x01(\tR\x05orgId\"i\n" +
+	"\x06org_id\x18\x03 \x01(\tR\x05orgId\x12M\n" +
+	"\x06labels\x18\x04 \x03(\v25.authlayer.v1.CreateServiceAccountRequest.LabelsEntryR\x06labels\x12\x16\n" +
+	"\x06scopes\x18\x05 \x03(\tR\x06scopes\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"i\n" +
 	"\x1cCreateServiceAccountResponse\x12I\n" +
 	"\x0fservice_account\x18\x01 \x01(\v2 .authlayer.v1.ServiceAccountInfoR\x0eserviceAccount\"H\n" +
 	"\x18GetServiceAccountRequest\x12,\n" +
 	"\x12service_account_id\x18\x01 \x01(\tR\x10serviceAccountId\"f\n" +
 	"\x19GetServiceAccountResponse\x12I\n" +
-	"\x0fservice_account\x18\x01 \x01(\v2 .authlayer.v1.ServiceAccountInfoR\x0eserviceAccount\"\x87\x02\n" +
+	"\x0fservice_account\x18\x01 \x01(\v2 .authlayer.v1.ServiceAccountInfoR\x0eserviceAccount\"\x89\x03\n" +
 	"\x1bUpdateServiceAccountRequest\x12,\n" +
 	"\x12service_account_id\x18\x01 \x01(\tR\x10serviceAccountId\x12&\n" +
 	"\fdisplay_name\x18\x02 \x01(\tH\x00R\vdisplayName\x88\x01\x01\x12%\n" +
 	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12?\n" +
-	"\x06status\x18\x04 \x01(\x0e2\".authlayer.v1.ServiceAccountStatusH\x02R\x06status\x88\x01\x01B\x0f\n" +
+	"\x06status\x18\x04 \x01(\x0e2\".authlayer.v1.ServiceAccountStatusH\x02R\x06status\x88\x01\x01\x123\n" +
+	"\x06labels\x18\x05 \x01(\v2\x16.authlayer.v1.LabelSetH\x03R\x06labels\x88\x01\x01\x125\n" +
+	"\x06scopes\x18\x06 \x01(\v2\x18.authlayer.v1.StringListH\x04R\x06scopes\x88\x01\x01B\x0f\n" +
 	"\r_display_nameB\x0e\n" +
 	"\f_descriptionB\t\n" +
-	"\a_status\"i\n" +
+	"\a_statusB\t\n" +
+	"\a_labelsB\t\n" +
+	"\a_scopes\"i\n" +
 	"\x1cUpdateServiceAccountResponse\x12I\n" +
 	"\x0fservice_account\x18\x01 \x01(\v2 .authlayer.v1.ServiceAccountInfoR\x0eserviceAccount\"K\n" +
 	"\x1bDeleteServiceAccountRequest\x12,\n" +
 	"\x12service_account_id\x18\x01 \x01(\tR\x10serviceAccountId\"\x1e\n" +
-	"\x1cDeleteServiceAccountResponse\"t\n" +
+	"\x1cDeleteServiceAccountResponse\"\xb3\x01\n" +
 	"\x1aListServiceAccountsRequest\x12\x15\n" +
 	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12?\n" +
 	"\n" +
 	"pagination\x18\x02 \x01(\v2\x1f.authlayer.v1.PaginationRequestR\n" +
-	"pagination\"\xac\x01\n" +
+	"pagination\x12*\n" +
+	"\x0elabel_selector\x18\x03 \x01(\tH\x00R\rlabelSelector\x88\x01\x01B\x11\n" +
+	"\x0f_label_selector\"\xac\x01\n" +
 	"\x1bListServiceAccountsResponse\x12K\n" +
 	"\x10service_accounts\x18\x01 \x03(\v2 .authlayer.v1.ServiceAccountInfoR\x0fserviceAccounts\x12@\n" +
 	"\n" +
 	"pagination\x18\x02 \x01(\v2 .authlayer.v1.PaginationResponseR\n" +
-	"pagination\"\xb1\x01\n" +
+	"pagination\"\xb9\x02\n" +
 	"\x1eCreateServiceAccountKeyRequest\x12,\n" +
 	"\x12service_account_id\x18\x01 \x01(\tR\x10serviceAccountId\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12>\n" +
 	"\n" +
-	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\texpiresAt\x88\x01\x01B\r\n" +
-	"\v_expires_at\"\x87\x01\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\texpiresAt\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"public_key\x18\x04 \x01(\tH\x01R\tpublicKey\x88\x01\x01\x12*\n" +
+	"\x11generate_key_pair\x18\x05 \x01(\bR\x0fgenerateKeyPair\x12'\n" +
+	"\x0frequest_signing\x18\x06 \x01(\bR\x0erequestSigningB\r\n" +
+	"\v_expires_atB\r\n" +
+	"\v_public_key\"\xa8\x01\n" +
 	"\x1fCreateServiceAccountKeyResponse\x12>\n" +
 	"\bkey_info\x18\x01 \x01(\v2#.authlayer.v1.ServiceAccountKeyInfoR\akeyInfo\x12$\n" +
-	"\x0eplain_text_key\x18\x02 \x01(\tR\fplainTextKey\"7\n" +
+	"\x0eplain_text_key\x18\x02 \x01(\tR\fplainTextKey\x12\x1f\n" +
+	"\vprivate_key\x18\x03 \x01(\tR\n" +
+	"privateKey\"7\n" +
 	"\x1eRevokeServiceAccountKeyRequest\x12\x15\n" +
 	"\x06key_id\x18\x01 \x01(\tR\x05keyId\"!\n" +
 	"\x1fRevokeServiceAccountKeyResponse\"\x8e\x01\n" +
@@ -1363,11 +1982,54 @@ const file_authlayer_v1_service_account_proto_rawDesc = "" +
 	"\x12service_account_id\x18\x01 \x01(\tR\x10serviceAccountId\x12\x17\n" +
 	"\arole_id\x18\x02 \x01(\tR\x06roleId\x12\x15\n" +
 	"\x06org_id\x18\x03 \x01(\tR\x05orgId\"\"\n" +
-	" RevokeServiceAccountRoleResponse*\x86\x01\n" +
+	" RevokeServiceAccountRoleResponse\"\xcf\x03\n" +
+	"\x19WorkloadIdentityTrustInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12,\n" +
+	"\x12service_account_id\x18\x02 \x01(\tR\x10serviceAccountId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"issuer_url\x18\x04 \x01(\tR\tissuerUrl\x12\x1a\n" +
+	"\baudience\x18\x05 \x01(\tR\baudience\x12W\n" +
+	"\n" +
+	"conditions\x18\x06 \x03(\v27.authlayer.v1.WorkloadIdentityTrustInfo.ConditionsEntryR\n" +
+	"conditions\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12A\n" +
+	"\flast_used_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampH\x00R\n" +
+	"lastUsedAt\x88\x01\x01\x1a=\n" +
+	"\x0fConditionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x0f\n" +
+	"\r_last_used_at\"\xc2\x02\n" +
+	"\"CreateWorkloadIdentityTrustRequest\x12,\n" +
+	"\x12service_account_id\x18\x01 \x01(\tR\x10serviceAccountId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"issuer_url\x18\x03 \x01(\tR\tissuerUrl\x12\x1a\n" +
+	"\baudience\x18\x04 \x01(\tR\baudience\x12`\n" +
+	"\n" +
+	"conditions\x18\x05 \x03(\v2@.authlayer.v1.CreateWorkloadIdentityTrustRequest.ConditionsEntryR\n" +
+	"conditions\x1a=\n" +
+	"\x0fConditionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"d\n" +
+	"#CreateWorkloadIdentityTrustResponse\x12=\n" +
+	"\x05trust\x18\x01 \x01(\v2'.authlayer.v1.WorkloadIdentityTrustInfoR\x05trust\"Q\n" +
+	"!ListWorkloadIdentityTrustsRequest\x12,\n" +
+	"\x12service_account_id\x18\x01 \x01(\tR\x10serviceAccountId\"e\n" +
+	"\"ListWorkloadIdentityTrustsResponse\x12?\n" +
+	"\x06trusts\x18\x01 \x03(\v2'.authlayer.v1.WorkloadIdentityTrustInfoR\x06trusts\"?\n" +
+	"\"DeleteWorkloadIdentityTrustRequest\x12\x19\n" +
+	"\btrust_id\x18\x01 \x01(\tR\atrustId\"%\n" +
+	"#DeleteWorkloadIdentityTrustResponse*\x86\x01\n" +
 	"\x14ServiceAccountStatus\x12&\n" +
 	"\"SERVICE_ACCOUNT_STATUS_UNSPECIFIED\x10\x00\x12!\n" +
 	"\x1dSERVICE_ACCOUNT_STATUS_ACTIVE\x10\x01\x12#\n" +
-	"\x1fSERVICE_ACCOUNT_STATUS_DISABLED\x10\x022\xf5\b\n" +
+	"\x1fSERVICE_ACCOUNT_STATUS_DISABLED\x10\x02*\x8f\x01\n" +
+	"\x15ServiceAccountKeyType\x12(\n" +
+	"$SERVICE_ACCOUNT_KEY_TYPE_UNSPECIFIED\x10\x00\x12#\n" +
+	"\x1fSERVICE_ACCOUNT_KEY_TYPE_SECRET\x10\x01\x12'\n" +
+	"#SERVICE_ACCOUNT_KEY_TYPE_PUBLIC_KEY\x10\x022\x80\f\n" +
 	"\x15ServiceAccountService\x12m\n" +
 	"\x14CreateServiceAccount\x12).authlayer.v1.CreateServiceAccountRequest\x1a*.authlayer.v1.CreateServiceAccountResponse\x12d\n" +
 	"\x11GetServiceAccount\x12&.authlayer.v1.GetServiceAccountRequest\x1a'.authlayer.v1.GetServiceAccountResponse\x12m\n" +
@@ -1380,7 +2042,10 @@ const file_authlayer_v1_service_account_proto_rawDesc = "" +
 	"\n" +
 	"AssignRole\x12-.authlayer.v1.AssignServiceAccountRoleRequest\x1a..authlayer.v1.AssignServiceAccountRoleResponse\x12k\n" +
 	"\n" +
-	"RevokeRole\x12-.authlayer.v1.RevokeServiceAccountRoleRequest\x1a..authlayer.v1.RevokeServiceAccountRoleResponseBJZHgithub.com/bernardoforcillo/authlayer/pkg/proto/authlayer/v1;authlayerv1b\x06proto3"
+	"RevokeRole\x12-.authlayer.v1.RevokeServiceAccountRoleRequest\x1a..authlayer.v1.RevokeServiceAccountRoleResponse\x12\x82\x01\n" +
+	"\x1bCreateWorkloadIdentityTrust\x120.authlayer.v1.CreateWorkloadIdentityTrustRequest\x1a1.authlayer.v1.CreateWorkloadIdentityTrustResponse\x12\x7f\n" +
+	"\x1aListWorkloadIdentityTrusts\x12/.authlayer.v1.ListWorkloadIdentityTrustsRequest\x1a0.authlayer.v1.ListWorkloadIdentityTrustsResponse\x12\x82\x01\n" +
+	"\x1bDeleteWorkloadIdentityTrust\x120.authlayer.v1.DeleteWorkloadIdentityTrustRequest\x1a1.authlayer.v1.DeleteWorkloadIdentityTrustResponseBJZHgithub.com/bernardoforcillo/authlayer/pkg/proto/authlayer/v1;authlayerv1b\x06proto3"
 
 var (
 	file_authlayer_v1_service_account_proto_rawDescOnce sync.Once
@@ -1394,83 +2059,114 @@ func file_authlayer_v1_service_account_proto_rawDescGZIP() []byte {
 	return file_authlayer_v1_service_account_proto_rawDescData
 }
 
-var file_authlayer_v1_service_account_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_authlayer_v1_service_account_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_authlayer_v1_service_account_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_authlayer_v1_service_account_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_authlayer_v1_service_account_proto_goTypes = []any{
-	(ServiceAccountStatus)(0),                // 0: authlayer.v1.ServiceAccountStatus
-	(*ServiceAccountInfo)(nil),               // 1: authlayer.v1.ServiceAccountInfo
-	(*ServiceAccountKeyInfo)(nil),            // 2: authlayer.v1.ServiceAccountKeyInfo
-	(*CreateServiceAccountRequest)(nil),      // 3: authlayer.v1.CreateServiceAccountRequest
-	(*CreateServiceAccountResponse)(nil),     // 4: authlayer.v1.CreateServiceAccountResponse
-	(*GetServiceAccountRequest)(nil),         // 5: authlayer.v1.GetServiceAccountRequest
-	(*GetServiceAccountResponse)(nil),        // 6: authlayer.v1.GetServiceAccountResponse
-	(*UpdateServiceAccountRequest)(nil),      // 7: authlayer.v1.UpdateServiceAccountRequest
-	(*UpdateServiceAccountResponse)(nil),     // 8: authlayer.v1.UpdateServiceAccountResponse
-	(*DeleteServiceAccountRequest)(nil),      // 9: authlayer.v1.DeleteServiceAccountRequest
-	(*DeleteServiceAccountResponse)(nil),     // 10: authlayer.v1.DeleteServiceAccountResponse
-	(*ListServiceAccountsRequest)(nil),       // 11: authlayer.v1.ListServiceAccountsRequest
-	(*ListServiceAccountsResponse)(nil),      // 12: authlayer.v1.ListServiceAccountsResponse
-	(*CreateServiceAccountKeyRequest)(nil),   // 13: authlayer.v1.CreateServiceAccountKeyRequest
-	(*CreateServiceAccountKeyResponse)(nil),  // 14: authlayer.v1.CreateServiceAccountKeyResponse
-	(*RevokeServiceAccountKeyRequest)(nil),   // 15: authlayer.v1.RevokeServiceAccountKeyRequest
-	(*RevokeServiceAccountKeyResponse)(nil),  // 16: authlayer.v1.RevokeServiceAccountKeyResponse
-	(*ListServiceAccountKeysRequest)(nil),    // 17: authlayer.v1.ListServiceAccountKeysRequest
-	(*ListServiceAccountKeysResponse)(nil),   // 18: authlayer.v1.ListServiceAccountKeysResponse
-	(*AssignServiceAccountRoleRequest)(nil),  // 19: authlayer.v1.AssignServiceAccountRoleRequest
-	(*AssignServiceAccountRoleResponse)(nil), // 20: authlayer.v1.AssignServiceAccountRoleResponse
-	(*RevokeServiceAccountRoleRequest)(nil),  // 21: authlayer.v1.RevokeServiceAccountRoleRequest
-	(*RevokeServiceAccountRoleResponse)(nil), // 22: authlayer.v1.RevokeServiceAccountRoleResponse
-	(*timestamppb.Timestamp)(nil),            // 23: google.protobuf.Timestamp
-	(*RoleInfo)(nil),                         // 24: authlayer.v1.RoleInfo
-	(*PaginationRequest)(nil),                // 25: authlayer.v1.PaginationRequest
-	(*PaginationResponse)(nil),               // 26: authlayer.v1.PaginationResponse
+	(ServiceAccountStatus)(0),                   // 0: authlayer.v1.ServiceAccountStatus
+	(ServiceAccountKeyType)(0),                  // 1: authlayer.v1.ServiceAccountKeyType
+	(*ServiceAccountInfo)(nil),                  // 2: authlayer.v1.ServiceAccountInfo
+	(*ServiceAccountKeyInfo)(nil),               // 3: authlayer.v1.ServiceAccountKeyInfo
+	(*CreateServiceAccountRequest)(nil),         // 4: authlayer.v1.CreateServiceAccountRequest
+	(*CreateServiceAccountResponse)(nil),        // 5: authlayer.v1.CreateServiceAccountResponse
+	(*GetServiceAccountRequest)(nil),            // 6: authlayer.v1.GetServiceAccountRequest
+	(*GetServiceAccountResponse)(nil),           // 7: authlayer.v1.GetServiceAccountResponse
+	(*UpdateServiceAccountRequest)(nil),         // 8: authlayer.v1.UpdateServiceAccountRequest
+	(*UpdateServiceAccountResponse)(nil),        // 9: authlayer.v1.UpdateServiceAccountResponse
+	(*DeleteServiceAccountRequest)(nil),         // 10: authlayer.v1.DeleteServiceAccountRequest
+	(*DeleteServiceAccountResponse)(nil),        // 11: authlayer.v1.DeleteServiceAccountResponse
+	(*ListServiceAccountsRequest)(nil),          // 12: authlayer.v1.ListServiceAccountsRequest
+	(*ListServiceAccountsResponse)(nil),         // 13: authlayer.v1.ListServiceAccountsResponse
+	(*CreateServiceAccountKeyRequest)(nil),      // 14: authlayer.v1.CreateServiceAccountKeyRequest
+	(*CreateServiceAccountKeyResponse)(nil),     // 15: authlayer.v1.CreateServiceAccountKeyResponse
+	(*RevokeServiceAccountKeyRequest)(nil),      // 16: authlayer.v1.RevokeServiceAccountKeyRequest
+	(*RevokeServiceAccountKeyResponse)(nil),     // 17: authlayer.v1.RevokeServiceAccountKeyResponse
+	(*ListServiceAccountKeysRequest)(nil),       // 18: authlayer.v1.ListServiceAccountKeysRequest
+	(*ListServiceAccountKeysResponse)(nil),      // 19: authlayer.v1.ListServiceAccountKeysResponse
+	(*AssignServiceAccountRoleRequest)(nil),     // 20: authlayer.v1.AssignServiceAccountRoleRequest
+	(*AssignServiceAccountRoleResponse)(nil),    // 21: authlayer.v1.AssignServiceAccountRoleResponse
+	(*RevokeServiceAccountRoleRequest)(nil),     // 22: authlayer.v1.RevokeServiceAccountRoleRequest
+	(*RevokeServiceAccountRoleResponse)(nil),    // 23: authlayer.v1.RevokeServiceAccountRoleResponse
+	(*WorkloadIdentityTrustInfo)(nil),           // 24: authlayer.v1.WorkloadIdentityTrustInfo
+	(*CreateWorkloadIdentityTrustRequest)(nil),  // 25: authlayer.v1.CreateWorkloadIdentityTrustRequest
+	(*CreateWorkloadIdentityTrustResponse)(nil), // 26: authlayer.v1.CreateWorkloadIdentityTrustResponse
+	(*ListWorkloadIdentityTrustsRequest)(nil),   // 27: authlayer.v1.ListWorkloadIdentityTrustsRequest
+	(*ListWorkloadIdentityTrustsResponse)(nil),  // 28: authlayer.v1.ListWorkloadIdentityTrustsResponse
+	(*DeleteWorkloadIdentityTrustRequest)(nil),  // 29: authlayer.v1.DeleteWorkloadIdentityTrustRequest
+	(*DeleteWorkloadIdentityTrustResponse)(nil), // 30: authlayer.v1.DeleteWorkloadIdentityTrustResponse
+	nil,                           // 31: authlayer.v1.ServiceAccountInfo.LabelsEntry
+	nil,                           // 32: authlayer.v1.CreateServiceAccountRequest.LabelsEntry
+	nil,                           // 33: authlayer.v1.WorkloadIdentityTrustInfo.ConditionsEntry
+	nil,                           // 34: authlayer.v1.CreateWorkloadIdentityTrustRequest.ConditionsEntry
+	(*timestamppb.Timestamp)(nil), // 35: google.protobuf.Timestamp
+	(*RoleInfo)(nil),              // 36: authlayer.v1.RoleInfo
+	(*LabelSet)(nil),              // 37: authlayer.v1.LabelSet
+	(*StringList)(nil),            // 38: authlayer.v1.StringList
+	(*PaginationRequest)(nil),     // 39: authlayer.v1.PaginationRequest
+	(*PaginationResponse)(nil),    // 40: authlayer.v1.PaginationResponse
 }
 var file_authlayer_v1_service_account_proto_depIdxs = []int32{
 	0,  // 0: authlayer.v1.ServiceAccountInfo.status:type_name -> authlayer.v1.ServiceAccountStatus
-	23, // 1: authlayer.v1.ServiceAccountInfo.created_at:type_name -> google.protobuf.Timestamp
-	23, // 2: authlayer.v1.ServiceAccountInfo.updated_at:type_name -> google.protobuf.Timestamp
-	23, // 3: authlayer.v1.ServiceAccountInfo.last_authenticated_at:type_name -> google.protobuf.Timestamp
-	24, // 4: authlayer.v1.ServiceAccountInfo.roles:type_name -> authlayer.v1.RoleInfo
-	23, // 5: authlayer.v1.ServiceAccountKeyInfo.created_at:type_name -> google.protobuf.Timestamp
-	23, // 6: authlayer.v1.ServiceAccountKeyInfo.expires_at:type_name -> google.protobuf.Timestamp
-	23, // 7: authlayer.v1.ServiceAccountKeyInfo.last_used_at:type_name -> google.protobuf.Timestamp
-	1,  // 8: authlayer.v1.CreateServiceAccountResponse.service_account:type_name -> authlayer.v1.ServiceAccountInfo
-	1,  // 9: authlayer.v1.GetServiceAccountResponse.service_account:type_name -> authlayer.v1.ServiceAccountInfo
-	0,  // 10: authlayer.v1.UpdateServiceAccountRequest.status:type_name -> authlayer.v1.ServiceAccountStatus
-	1,  // 11: authlayer.v1.UpdateServiceAccountResponse.service_account:type_name -> authlayer.v1.ServiceAccountInfo
-	25, // 12: authlayer.v1.ListServiceAccountsRequest.pagination:type_name -> authlayer.v1.PaginationRequest
-	1,  // 13: authlayer.v1.ListServiceAccountsResponse.service_accounts:type_name -> authlayer.v1.ServiceAccountInfo
-	26, // 14: authlayer.v1.ListServiceAccountsResponse.pagination:type_name -> authlayer.v1.PaginationResponse
-	23, // 15: authlayer.v1.CreateServiceAccountKeyRequest.expires_at:type_name -> google.protobuf.Timestamp
-	2,  // 16: authlayer.v1.CreateServiceAccountKeyResponse.key_info:type_name -> authlayer.v1.ServiceAccountKeyInfo
-	25, // 17: authlayer.v1.ListServiceAccountKeysRequest.pagination:type_name -> authlayer.v1.PaginationRequest
-	2,  // 18: authlayer.v1.ListServiceAccountKeysResponse.keys:type_name -> authlayer.v1.ServiceAccountKeyInfo
-	26, // 19: authlayer.v1.ListServiceAccountKeysResponse.pagination:type_name -> authlayer.v1.PaginationResponse
-	3,  // 20: authlayer.v1.ServiceAccountService.CreateServiceAccount:input_type -> authlayer.v1.CreateServiceAccountRequest
-	5,  // 21: authlayer.v1.ServiceAccountService.GetServiceAccount:input_type -> authlayer.v1.GetServiceAccountRequest
-	7,  // 22: authlayer.v1.ServiceAccountService.UpdateServiceAccount:input_type -> authlayer.v1.UpdateServiceAccountRequest
-	9,  // 23: authlayer.v1.ServiceAccountService.DeleteServiceAccount:input_type -> authlayer.v1.DeleteServiceAccountRequest
-	11, // 24: authlayer.v1.ServiceAccountService.ListServiceAccounts:input_type -> authlayer.v1.ListServiceAccountsRequest
-	13, // 25: authlayer.v1.ServiceAccountService.CreateServiceAccountKey:input_type -> authlayer.v1.CreateServiceAccountKeyRequest
-	15, // 26: authlayer.v1.ServiceAccountService.RevokeServiceAccountKey:input_type -> authlayer.v1.RevokeServiceAccountKeyRequest
-	17, // 27: authlayer.v1.ServiceAccountService.ListServiceAccountKeys:input_type -> authlayer.v1.ListServiceAccountKeysRequest
-	19, // 28: authlayer.v1.ServiceAccountService.AssignRole:input_type -> authlayer.v1.AssignServiceAccountRoleRequest
-	21, // 29: authlayer.v1.ServiceAccountService.RevokeRole:input_type -> authlayer.v1.RevokeServiceAccountRoleRequest
-	4,  // 30: authlayer.v1.ServiceAccountService.CreateServiceAccount:output_type -> authlayer.v1.CreateServiceAccountResponse
-	6,  // 31: authlayer.v1.ServiceAccountService.GetServiceAccount:output_type -> authlayer.v1.GetServiceAccountResponse
-	8,  // 32: authlayer.v1.ServiceAccountService.UpdateServiceAccount:output_type -> authlayer.v1.UpdateServiceAccountResponse
-	10, // 33: authlayer.v1.ServiceAccountService.DeleteServiceAccount:output_type -> authlayer.v1.DeleteServiceAccountResponse
-	12, // 34: authlayer.v1.ServiceAccountService.ListServiceAccounts:output_type -> authlayer.v1.ListServiceAccountsResponse
-	14, // 35: authlayer.v1.ServiceAccountService.CreateServiceAccountKey:output_type -> authlayer.v1.CreateServiceAccountKeyResponse
-	16, // 36: authlayer.v1.ServiceAccountService.RevokeServiceAccountKey:output_type -> authlayer.v1.RevokeServiceAccountKeyResponse
-	18, // 37: authlayer.v1.ServiceAccountService.ListServiceAccountKeys:output_type -> authlayer.v1.ListServiceAccountKeysResponse
-	20, // 38: authlayer.v1.ServiceAccountService.AssignRole:output_type -> authlayer.v1.AssignServiceAccountRoleResponse
-	22, // 39: authlayer.v1.ServiceAccountService.RevokeRole:output_type -> authlayer.v1.RevokeServiceAccountRoleResponse
-	30, // [30:40] is the sub-list for method output_type
-	20, // [20:30] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	35, // 1: authlayer.v1.ServiceAccountInfo.created_at:type_name -> google.protobuf.Timestamp
+	35, // 2: authlayer.v1.ServiceAccountInfo.updated_at:type_name -> google.protobuf.Timestamp
+	35, // 3: authlayer.v1.ServiceAccountInfo.last_authenticated_at:type_name -> google.protobuf.Timestamp
+	36, // 4: authlayer.v1.ServiceAccountInfo.roles:type_name -> authlayer.v1.RoleInfo
+	31, // 5: authlayer.v1.ServiceAccountInfo.labels:type_name -> authlayer.v1.ServiceAccountInfo.LabelsEntry
+	35, // 6: authlayer.v1.ServiceAccountKeyInfo.created_at:type_name -> google.protobuf.Timestamp
+	35, // 7: authlayer.v1.ServiceAccountKeyInfo.expires_at:type_name -> google.protobuf.Timestamp
+	35, // 8: authlayer.v1.ServiceAccountKeyInfo.last_used_at:type_name -> google.protobuf.Timestamp
+	1,  // 9: authlayer.v1.ServiceAccountKeyInfo.key_type:type_name -> authlayer.v1.ServiceAccountKeyType
+	32, // 10: authlayer.v1.CreateServiceAccountRequest.labels:type_name -> authlayer.v1.CreateServiceAccountRequest.LabelsEntry
+	2,  // 11: authlayer.v1.CreateServiceAccountResponse.service_account:type_name -> authlayer.v1.ServiceAccountInfo
+	2,  // 12: authlayer.v1.GetServiceAccountResponse.service_account:type_name -> authlayer.v1.ServiceAccountInfo
+	0,  // 13: authlayer.v1.UpdateServiceAccountRequest.status:type_name -> authlayer.v1.ServiceAccountStatus
+	37, // 14: authlayer.v1.UpdateServiceAccountRequest.labels:type_name -> authlayer.v1.LabelSet
+	38, // 15: authlayer.v1.UpdateServiceAccountRequest.scopes:type_name -> authlayer.v1.StringList
+	2,  // 16: authlayer.v1.UpdateServiceAccountResponse.service_account:type_name -> authlayer.v1.ServiceAccountInfo
+	39, // 17: authlayer.v1.ListServiceAccountsRequest.pagination:type_name -> authlayer.v1.PaginationRequest
+	2,  // 18: authlayer.v1.ListServiceAccountsResponse.service_accounts:type_name -> authlayer.v1.ServiceAccountInfo
+	40, // 19: authlayer.v1.ListServiceAccountsResponse.pagination:type_name -> authlayer.v1.PaginationResponse
+	35, // 20: authlayer.v1.CreateServiceAccountKeyRequest.expires_at:type_name -> google.protobuf.Timestamp
+	3,  // 21: authlayer.v1.CreateServiceAccountKeyResponse.key_info:type_name -> authlayer.v1.ServiceAccountKeyInfo
+	39, // 22: authlayer.v1.ListServiceAccountKeysRequest.pagination:type_name -> authlayer.v1.PaginationRequest
+	3,  // 23: authlayer.v1.ListServiceAccountKeysResponse.keys:type_name -> authlayer.v1.ServiceAccountKeyInfo
+	40, // 24: authlayer.v1.ListServiceAccountKeysResponse.pagination:type_name -> authlayer.v1.PaginationResponse
+	33, // 25: authlayer.v1.WorkloadIdentityTrustInfo.conditions:type_name -> authlayer.v1.WorkloadIdentityTrustInfo.ConditionsEntry
+	35, // 26: authlayer.v1.WorkloadIdentityTrustInfo.created_at:type_name -> google.protobuf.Timestamp
+	35, // 27: authlayer.v1.WorkloadIdentityTrustInfo.last_used_at:type_name -> google.protobuf.Timestamp
+	34, // 28: authlayer.v1.CreateWorkloadIdentityTrustRequest.conditions:type_name -> authlayer.v1.CreateWorkloadIdentityTrustRequest.ConditionsEntry
+	24, // 29: authlayer.v1.CreateWorkloadIdentityTrustResponse.trust:type_name -> authlayer.v1.WorkloadIdentityTrustInfo
+	24, // 30: authlayer.v1.ListWorkloadIdentityTrustsResponse.trusts:type_name -> authlayer.v1.WorkloadIdentityTrustInfo
+	4,  // 31: authlayer.v1.ServiceAccountService.CreateServiceAccount:input_type -> authlayer.v1.CreateServiceAccountRequest
+	6,  // 32: authlayer.v1.ServiceAccountService.GetServiceAccount:input_type -> authlayer.v1.GetServiceAccountRequest
+	8,  // 33: authlayer.v1.ServiceAccountService.UpdateServiceAccount:input_type -> authlayer.v1.UpdateServiceAccountRequest
+	10, // 34: authlayer.v1.ServiceAccountService.DeleteServiceAccount:input_type -> authlayer.v1.DeleteServiceAccountRequest
+	12, // 35: authlayer.v1.ServiceAccountService.ListServiceAccounts:input_type -> authlayer.v1.ListServiceAccountsRequest
+	14, // 36: authlayer.v1.ServiceAccountService.CreateServiceAccountKey:input_type -> authlayer.v1.CreateServiceAccountKeyRequest
+	16, // 37: authlayer.v1.ServiceAccountService.RevokeServiceAccountKey:input_type -> authlayer.v1.RevokeServiceAccountKeyRequest
+	18, // 38: authlayer.v1.ServiceAccountService.ListServiceAccountKeys:input_type -> authlayer.v1.ListServiceAccountKeysRequest
+	20, // 39: authlayer.v1.ServiceAccountService.AssignRole:input_type -> authlayer.v1.AssignServiceAccountRoleRequest
+	22, // 40: authlayer.v1.ServiceAccountService.RevokeRole:input_type -> authlayer.v1.RevokeServiceAccountRoleRequest
+	25, // 41: authlayer.v1.ServiceAccountService.CreateWorkloadIdentityTrust:input_type -> authlayer.v1.CreateWorkloadIdentityTrustRequest
+	27, // 42: authlayer.v1.ServiceAccountService.ListWorkloadIdentityTrusts:input_type -> authlayer.v1.ListWorkloadIdentityTrustsRequest
+	29, // 43: authlayer.v1.ServiceAccountService.DeleteWorkloadIdentityTrust:input_type -> authlayer.v1.DeleteWorkloadIdentityTrustRequest
+	5,  // 44: authlayer.v1.ServiceAccountService.CreateServiceAccount:output_type -> authlayer.v1.CreateServiceAccountResponse
+	7,  // 45: authlayer.v1.ServiceAccountService.GetServiceAccount:output_type -> authlayer.v1.GetServiceAccountResponse
+	9,  // 46: authlayer.v1.ServiceAccountService.UpdateServiceAccount:output_type -> authlayer.v1.UpdateServiceAccountResponse
+	11, // 47: authlayer.v1.ServiceAccountService.DeleteServiceAccount:output_type -> authlayer.v1.DeleteServiceAccountResponse
+	13, // 48: authlayer.v1.ServiceAccountService.ListServiceAccounts:output_type -> authlayer.v1.ListServiceAccountsResponse
+	15, // 49: authlayer.v1.ServiceAccountService.CreateServiceAccountKey:output_type -> authlayer.v1.CreateServiceAccountKeyResponse
+	17, // 50: authlayer.v1.ServiceAccountService.RevokeServiceAccountKey:output_type -> authlayer.v1.RevokeServiceAccountKeyResponse
+	19, // 51: authlayer.v1.ServiceAccountService.ListServiceAccountKeys:output_type -> authlayer.v1.ListServiceAccountKeysResponse
+	21, // 52: authlayer.v1.ServiceAccountService.AssignRole:output_type -> authlayer.v1.AssignServiceAccountRoleResponse
+	23, // 53: authlayer.v1.ServiceAccountService.RevokeRole:output_type -> authlayer.v1.RevokeServiceAccountRoleResponse
+	26, // 54: authlayer.v1.ServiceAccountService.CreateWorkloadIdentityTrust:output_type -> authlayer.v1.CreateWorkloadIdentityTrustResponse
+	28, // 55: authlayer.v1.ServiceAccountService.ListWorkloadIdentityTrusts:output_type -> authlayer.v1.ListWorkloadIdentityTrustsResponse
+	30, // 56: authlayer.v1.ServiceAccountService.DeleteWorkloadIdentityTrust:output_type -> authlayer.v1.DeleteWorkloadIdentityTrustResponse
+	44, // [44:57] is the sub-list for method output_type
+	31, // [31:44] is the sub-list for method input_type
+	31, // [31:31] is the sub-list for extension type_name
+	31, // [31:31] is the sub-list for extension extendee
+	0,  // [0:31] is the sub-list for field type_name
 }
 
 func init() { file_authlayer_v1_service_account_proto_init() }
@@ -1483,14 +2179,16 @@ func file_authlayer_v1_service_account_proto_init() {
 	file_authlayer_v1_service_account_proto_msgTypes[0].OneofWrappers = []any{}
 	file_authlayer_v1_service_account_proto_msgTypes[1].OneofWrappers = []any{}
 	file_authlayer_v1_service_account_proto_msgTypes[6].OneofWrappers = []any{}
+	file_authlayer_v1_service_account_proto_msgTypes[10].OneofWrappers = []any{}
 	file_authlayer_v1_service_account_proto_msgTypes[12].OneofWrappers = []any{}
+	file_authlayer_v1_service_account_proto_msgTypes[22].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_authlayer_v1_service_account_proto_rawDesc), len(file_authlayer_v1_service_account_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   22,
+			NumEnums:      2,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,16 +19,19 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ServiceAccountService_CreateServiceAccount_FullMethodName    = "/authlayer.v1.ServiceAccountService/CreateServiceAccount"
-	ServiceAccountService_GetServiceAccount_FullMethodName       = "/authlayer.v1.ServiceAccountService/GetServiceAccount"
-	ServiceAccountService_UpdateServiceAccount_FullMethodName    = "/authlayer.v1.ServiceAccountService/UpdateServiceAccount"
-	ServiceAccountService_DeleteServiceAccount_FullMethodName    = "/authlayer.v1.ServiceAccountService/DeleteServiceAccount"
-	ServiceAccountService_ListServiceAccounts_FullMethodName     = "/authlayer.v1.ServiceAccountService/ListServiceAccounts"
-	ServiceAccountService_CreateServiceAccountKey_FullMethodName = "/authlayer.v1.ServiceAccountService/CreateServiceAccountKey"
-	ServiceAccountService_RevokeServiceAccountKey_FullMethodName = "/authlayer.v1.ServiceAccountService/RevokeServiceAccountKey"
-	ServiceAccountService_ListServiceAccountKeys_FullMethodName  = "/authlayer.v1.ServiceAccountService/ListServiceAccountKeys"
-	ServiceAccountService_AssignRole_FullMethodName              = "/authlayer.v1.ServiceAccountService/AssignRole"
-	ServiceAccountService_RevokeRole_FullMethodName              = "/authlayer.v1.ServiceAccountService/RevokeRole"
+	ServiceAccountService_CreateServiceAccount_FullMethodName        = "/authlayer.v1.ServiceAccountService/CreateServiceAccount"
+	ServiceAccountService_GetServiceAccount_FullMethodName           = "/authlayer.v1.ServiceAccountService/GetServiceAccount"
+	ServiceAccountService_UpdateServiceAccount_FullMethodName        = "/authlayer.v1.ServiceAccountService/UpdateServiceAccount"
+	ServiceAccountService_DeleteServiceAccount_FullMethodName        = "/authlayer.v1.ServiceAccountService/DeleteServiceAccount"
+	ServiceAccountService_ListServiceAccounts_FullMethodName         = "/authlayer.v1.ServiceAccountService/ListServiceAccounts"
+	ServiceAccountService_CreateServiceAccountKey_FullMethodName     = "/authlayer.v1.ServiceAccountService/CreateServiceAccountKey"
+	ServiceAccountService_RevokeServiceAccountKey_FullMethodName     = "/authlayer.v1.ServiceAccountService/RevokeServiceAccountKey"
+	ServiceAccountService_ListServiceAccountKeys_FullMethodName      = "/authlayer.v1.ServiceAccountService/ListServiceAccountKeys"
+	ServiceAccountService_AssignRole_FullMethodName                  = "/authlayer.v1.ServiceAccountService/AssignRole"
+	ServiceAccountService_RevokeRole_FullMethodName                  = "/authlayer.v1.ServiceAccountService/RevokeRole"
+	ServiceAccountService_CreateWorkloadIdentityTrust_FullMethodName = "/authlayer.v1.ServiceAccountService/CreateWorkloadIdentityTrust"
+	ServiceAccountService_ListWorkloadIdentityTrusts_FullMethodName  = "/authlayer.v1.ServiceAccountService/ListWorkloadIdentityTrusts"
+	ServiceAccountService_DeleteWorkloadIdentityTrust_FullMethodName = "/authlayer.v1.ServiceAccountService/DeleteWorkloadIdentityTrust"
 )
 
 // ServiceAccountServiceClient is the client API for ServiceAccountService service.
@@ -49,6 +52,11 @@ type ServiceAccountServiceClient interface {
 	ListServiceAccountKeys(ctx context.Context, in *ListServiceAccountKeysRequest, opts ...grpc.CallOption) (*ListServiceAccountKeysResponse, error)
 	AssignRole(ctx context.Context, in *AssignServiceAccountRoleRequest, opts ...grpc.CallOption) (*AssignServiceAccountRoleResponse, error)
 	RevokeRole(ctx context.Context, in *RevokeServiceAccountRoleRequest, opts ...grpc.CallOption) (*RevokeServiceAccountRoleResponse, error)
+	// Workload identity federation: workloads holding tokens from a trusted external OIDC
+	// issuer exchange them at the token endpoint for tokens of the service account.
+	CreateWorkloadIdentityTrust(ctx context.Context, in *CreateWorkloadIdentityTrustRequest, opts ...grpc.CallOption) (*CreateWorkloadIdentityTrustResponse, error)
+	ListWorkloadIdentityTrusts(ctx context.Context, in *ListWorkloadIdentityTrustsRequest, opts ...grpc.CallOption) (*ListWorkloadIdentityTrustsResponse, error)
+	DeleteWorkloadIdentityTrust(ctx context.Context, in *DeleteWorkloadIdentityTrustRequest, opts ...grpc.CallOption) (*DeleteWorkloadIdentityTrustResponse, error)
 }
 
 type serviceAccountServiceClient struct {
@@ -159,6 +167,36 @@ func (c *serviceAccountServiceClient) RevokeRole(ctx context.Context, in *Revoke
 	return out, nil
 }
 
+func (c *serviceAccountServiceClient) CreateWorkloadIdentityTrust(ctx context.Context, in *CreateWorkloadIdentityTrustRequest, opts ...grpc.CallOption) (*CreateWorkloadIdentityTrustResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateWorkloadIdentityTrustResponse)
+	err := c.cc.Invoke(ctx, ServiceAccountService_CreateWorkloadIdentityTrust_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceAccountServiceClient) ListWorkloadIdentityTrusts(ctx context.Context, in *ListWorkloadIdentityTrustsRequest, opts ...grpc.CallOption) (*ListWorkloadIdentityTrustsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWorkloadIdentityTrustsResponse)
+	err := c.cc.Invoke(ctx, ServiceAccountService_ListWorkloadIdentityTrusts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceAccountServiceClient) DeleteWorkloadIdentityTrust(ctx context.Context, in *DeleteWorkloadIdentityTrustRequest, opts ...grpc.CallOption) (*DeleteWorkloadIdentityTrustResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteWorkloadIdentityTrustResponse)
+	err := c.cc.Invoke(ctx, ServiceAccountService_DeleteWorkloadIdentityTrust_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ServiceAccountServiceServer is the server API for ServiceAccountService service.
 // All implementations must embed UnimplementedServiceAccountServiceServer
 // for forward compatibility.
@@ -177,6 +215,11 @@ type ServiceAccountServiceServer interface {
 	ListServiceAccountKeys(context.Context, *ListServiceAccountKeysRequest) (*ListServiceAccountKeysResponse, error)
 	AssignRole(context.Context, *AssignServiceAccountRoleRequest) (*AssignServiceAccountRoleResponse, error)
 	RevokeRole(context.Context, *RevokeServiceAccountRoleRequest) (*RevokeServiceAccountRoleResponse, error)
+	// Workload identity federation: workloads holding tokens from a trusted external OIDC
+	// issuer exchange them at the token endpoint for tokens of the service account.
+	CreateWorkloadIdentityTrust(context.Context, *CreateWorkloadIdentityTrustRequest) (*CreateWorkloadIdentityTrustResponse, error)
+	ListWorkloadIdentityTrusts(context.Context, *ListWorkloadIdentityTrustsRequest) (*ListWorkloadIdentityTrustsResponse, error)
+	DeleteWorkloadIdentityTrust(context.Context, *DeleteWorkloadIdentityTrustRequest) (*DeleteWorkloadIdentityTrustResponse, error)
 	mustEmbedUnimplementedServiceAccountServiceServer()
 }
 
@@ -217,6 +260,15 @@ func (UnimplementedServiceAccountServiceServer) AssignRole(context.Context, *Ass
 func (UnimplementedServiceAccountServiceServer) RevokeRole(context.Context, *RevokeServiceAccountRoleRequest) (*RevokeServiceAccountRoleResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method RevokeRole not implemented")
 }
+func (UnimplementedServiceAccountServiceServer) CreateWorkloadIdentityTrust(context.Context, *CreateWorkloadIdentityTrustRequest) (*CreateWorkloadIdentityTrustResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateWorkloadIdentityTrust not implemented")
+}
+func (UnimplementedServiceAccountServiceServer) ListWorkloadIdentityTrusts(context.Context, *ListWorkloadIdentityTrustsRequest) (*ListWorkloadIdentityTrustsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListWorkloadIdentityTrusts not implemented")
+}
+func (UnimplementedServiceAccountServiceServer) DeleteWorkloadIdentityTrust(context.Context, *DeleteWorkloadIdentityTrustRequest) (*DeleteWorkloadIdentityTrustResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteWorkloadIdentityTrust not implemented")
+}
 func (UnimplementedServiceAccountServiceServer) mustEmbedUnimplementedServiceAccountServiceServer() {}
 func (UnimplementedServiceAccountServiceServer) testEmbeddedByValue()                               {}
 
@@ -418,6 +470,60 @@ func _ServiceAccountService_RevokeRole_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ServiceAccountService_CreateWorkloadIdentityTrust_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWorkloadIdentityTrustRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceAccountServiceServer).CreateWorkloadIdentityTrust(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServiceAccountService_CreateWorkloadIdentityTrust_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceAccountServiceServer).CreateWorkloadIdentityTrust(ctx, req.(*CreateWorkloadIdentityTrustRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceAccountService_ListWorkloadIdentityTrusts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWorkloadIdentityTrustsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceAccountServiceServer).ListWorkloadIdentityTrusts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServiceAccountService_ListWorkloadIdentityTrusts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceAccountServiceServer).ListWorkloadIdentityTrusts(ctx, req.(*ListWorkloadIdentityTrustsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceAccountService_DeleteWorkloadIdentityTrust_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWorkloadIdentityTrustRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceAccountServiceServer).DeleteWorkloadIdentityTrust(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServiceAccountService_DeleteWorkloadIdentityTrust_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceAccountServiceServer).DeleteWorkloadIdentityTrust(ctx, req.(*DeleteWorkloadIdentityTrustRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ServiceAccountService_ServiceDesc is the grpc.ServiceDesc for ServiceAccountService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -465,6 +571,18 @@ var ServiceAccountService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RevokeRole",
 			Handler:    _ServiceAccountService_RevokeRole_Handler,
 		},
+		{
+			MethodName: "CreateWorkloadIdentityTrust",
+			Handler:    _ServiceAccountService_CreateWorkloadIdentityTrust_Handler,
+		},
+		{
+			MethodName: "ListWorkloadIdentityTrusts",
+			Handler:    _ServiceAccountService_ListWorkloadIdentityTrusts_Handler,
+		},
+		{
+			MethodName: "DeleteWorkloadIdentityTrust",
+			Handler:    _ServiceAccountService_DeleteWorkloadIdentityTrust_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "authlayer/v1/service_account.proto",
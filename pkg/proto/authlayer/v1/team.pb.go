@@ -27,6 +27,7 @@ type TeamInfo struct {
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	OrgId         string                 `protobuf:"bytes,3,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
 	MemberCount   int32                  `protobuf:"varint,4,opt,name=member_count,json=memberCount,proto3" json:"member_count,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -89,10 +90,18 @@ func (x *TeamInfo) GetMemberCount() int32 {
 	return 0
 }
 
+func (x *TeamInfo) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
 type CreateTeamRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -141,6 +150,13 @@ func (x *CreateTeamRequest) GetName() string {
 	return ""
 }
 
+func (x *CreateTeamRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
 type CreateTeamResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Team          *TeamInfo              `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
@@ -274,9 +290,11 @@ func (x *GetTeamResponse) GetTeam() *TeamInfo {
 }
 
 type UpdateTeamRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TeamId        string                 `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
-	Name          *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TeamId string                 `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	Name   *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// Replaces all labels when set.
+	Labels        *LabelSet `protobuf:"bytes,3,opt,name=labels,proto3,oneof" json:"labels,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -325,6 +343,13 @@ func (x *UpdateTeamRequest) GetName() string {
 	return ""
 }
 
+func (x *UpdateTeamRequest) GetLabels() *LabelSet {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
 type UpdateTeamResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Team          *TeamInfo              `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
@@ -453,6 +478,7 @@ type ListTeamsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
 	Pagination    *PaginationRequest     `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	LabelSelector *string                `protobuf:"bytes,3,opt,name=label_selector,json=labelSelector,proto3,oneof" json:"label_selector,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -501,6 +527,13 @@ func (x *ListTeamsRequest) GetPagination() *PaginationRequest {
 	return nil
 }
 
+func (x *ListTeamsRequest) GetLabelSelector() string {
+	if x != nil && x.LabelSelector != nil {
+		return *x.LabelSelector
+	}
+	return ""
+}
+
 type ListTeamsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Teams         []*TeamInfo            `protobuf:"bytes,1,rep,name=teams,proto3" json:"teams,omitempty"`
@@ -845,35 +878,47 @@ var File_authlayer_v1_team_proto protoreflect.FileDescriptor
 
 const file_authlayer_v1_team_proto_rawDesc = "" +
 	"\n" +
-	"\x17authlayer/v1/team.proto\x12\fauthlayer.v1\x1a\x19authlayer/v1/common.proto\"h\n" +
+	"\x17authlayer/v1/team.proto\x12\fauthlayer.v1\x1a\x19authlayer/v1/common.proto\"\xdf\x01\n" +
 	"\bTeamInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x15\n" +
 	"\x06org_id\x18\x03 \x01(\tR\x05orgId\x12!\n" +
-	"\fmember_count\x18\x04 \x01(\x05R\vmemberCount\">\n" +
+	"\fmember_count\x18\x04 \x01(\x05R\vmemberCount\x12:\n" +
+	"\x06labels\x18\x05 \x03(\v2\".authlayer.v1.TeamInfo.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xbe\x01\n" +
 	"\x11CreateTeamRequest\x12\x15\n" +
 	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"@\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12C\n" +
+	"\x06labels\x18\x03 \x03(\v2+.authlayer.v1.CreateTeamRequest.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"@\n" +
 	"\x12CreateTeamResponse\x12*\n" +
 	"\x04team\x18\x01 \x01(\v2\x16.authlayer.v1.TeamInfoR\x04team\")\n" +
 	"\x0eGetTeamRequest\x12\x17\n" +
 	"\ateam_id\x18\x01 \x01(\tR\x06teamId\"=\n" +
 	"\x0fGetTeamResponse\x12*\n" +
-	"\x04team\x18\x01 \x01(\v2\x16.authlayer.v1.TeamInfoR\x04team\"N\n" +
+	"\x04team\x18\x01 \x01(\v2\x16.authlayer.v1.TeamInfoR\x04team\"\x8e\x01\n" +
 	"\x11UpdateTeamRequest\x12\x17\n" +
 	"\ateam_id\x18\x01 \x01(\tR\x06teamId\x12\x17\n" +
-	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01B\a\n" +
-	"\x05_name\"@\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x123\n" +
+	"\x06labels\x18\x03 \x01(\v2\x16.authlayer.v1.LabelSetH\x01R\x06labels\x88\x01\x01B\a\n" +
+	"\x05_nameB\t\n" +
+	"\a_labels\"@\n" +
 	"\x12UpdateTeamResponse\x12*\n" +
 	"\x04team\x18\x01 \x01(\v2\x16.authlayer.v1.TeamInfoR\x04team\",\n" +
 	"\x11DeleteTeamRequest\x12\x17\n" +
 	"\ateam_id\x18\x01 \x01(\tR\x06teamId\"\x14\n" +
-	"\x12DeleteTeamResponse\"j\n" +
+	"\x12DeleteTeamResponse\"\xa9\x01\n" +
 	"\x10ListTeamsRequest\x12\x15\n" +
 	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12?\n" +
 	"\n" +
 	"pagination\x18\x02 \x01(\v2\x1f.authlayer.v1.PaginationRequestR\n" +
-	"pagination\"\x83\x01\n" +
+	"pagination\x12*\n" +
+	"\x0elabel_selector\x18\x03 \x01(\tH\x00R\rlabelSelector\x88\x01\x01B\x11\n" +
+	"\x0f_label_selector\"\x83\x01\n" +
 	"\x11ListTeamsResponse\x12,\n" +
 	"\x05teams\x18\x01 \x03(\v2\x16.authlayer.v1.TeamInfoR\x05teams\x12@\n" +
 	"\n" +
@@ -923,7 +968,7 @@ func file_authlayer_v1_team_proto_rawDescGZIP() []byte {
 	return file_authlayer_v1_team_proto_rawDescData
 }
 
-var file_authlayer_v1_team_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_authlayer_v1_team_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_authlayer_v1_team_proto_goTypes = []any{
 	(*TeamInfo)(nil),                 // 0: authlayer.v1.TeamInfo
 	(*CreateTeamRequest)(nil),        // 1: authlayer.v1.CreateTeamRequest
@@ -942,41 +987,47 @@ var file_authlayer_v1_team_proto_goTypes = []any{
 	(*RemoveTeamMemberResponse)(nil), // 14: authlayer.v1.RemoveTeamMemberResponse
 	(*ListTeamMembersRequest)(nil),   // 15: authlayer.v1.ListTeamMembersRequest
 	(*ListTeamMembersResponse)(nil),  // 16: authlayer.v1.ListTeamMembersResponse
-	(*PaginationRequest)(nil),        // 17: authlayer.v1.PaginationRequest
-	(*PaginationResponse)(nil),       // 18: authlayer.v1.PaginationResponse
-	(*MemberInfo)(nil),               // 19: authlayer.v1.MemberInfo
+	nil,                              // 17: authlayer.v1.TeamInfo.LabelsEntry
+	nil,                              // 18: authlayer.v1.CreateTeamRequest.LabelsEntry
+	(*LabelSet)(nil),                 // 19: authlayer.v1.LabelSet
+	(*PaginationRequest)(nil),        // 20: authlayer.v1.PaginationRequest
+	(*PaginationResponse)(nil),       // 21: authlayer.v1.PaginationResponse
+	(*MemberInfo)(nil),               // 22: authlayer.v1.MemberInfo
 }
 var file_authlayer_v1_team_proto_depIdxs = []int32{
-	0,  // 0: authlayer.v1.CreateTeamResponse.team:type_name -> authlayer.v1.TeamInfo
-	0,  // 1: authlayer.v1.GetTeamResponse.team:type_name -> authlayer.v1.TeamInfo
-	0,  // 2: authlayer.v1.UpdateTeamResponse.team:type_name -> authlayer.v1.TeamInfo
-	17, // 3: authlayer.v1.ListTeamsRequest.pagination:type_name -> authlayer.v1.PaginationRequest
-	0,  // 4: authlayer.v1.ListTeamsResponse.teams:type_name -> authlayer.v1.TeamInfo
-	18, // 5: authlayer.v1.ListTeamsResponse.pagination:type_name -> authlayer.v1.PaginationResponse
-	17, // 6: authlayer.v1.ListTeamMembersRequest.pagination:type_name -> authlayer.v1.PaginationRequest
-	19, // 7: authlayer.v1.ListTeamMembersResponse.members:type_name -> authlayer.v1.MemberInfo
-	18, // 8: authlayer.v1.ListTeamMembersResponse.pagination:type_name -> authlayer.v1.PaginationResponse
-	1,  // 9: authlayer.v1.TeamService.CreateTeam:input_type -> authlayer.v1.CreateTeamRequest
-	3,  // 10: authlayer.v1.TeamService.GetTeam:input_type -> authlayer.v1.GetTeamRequest
-	5,  // 11: authlayer.v1.TeamService.UpdateTeam:input_type -> authlayer.v1.UpdateTeamRequest
-	7,  // 12: authlayer.v1.TeamService.DeleteTeam:input_type -> authlayer.v1.DeleteTeamRequest
-	9,  // 13: authlayer.v1.TeamService.ListTeams:input_type -> authlayer.v1.ListTeamsRequest
-	11, // 14: authlayer.v1.TeamService.AddMember:input_type -> authlayer.v1.AddTeamMemberRequest
-	13, // 15: authlayer.v1.TeamService.RemoveMember:input_type -> authlayer.v1.RemoveTeamMemberRequest
-	15, // 16: authlayer.v1.TeamService.ListMembers:input_type -> authlayer.v1.ListTeamMembersRequest
-	2,  // 17: authlayer.v1.TeamService.CreateTeam:output_type -> authlayer.v1.CreateTeamResponse
-	4,  // 18: authlayer.v1.TeamService.GetTeam:output_type -> authlayer.v1.GetTeamResponse
-	6,  // 19: authlayer.v1.TeamService.UpdateTeam:output_type -> authlayer.v1.UpdateTeamResponse
-	8,  // 20: authlayer.v1.TeamService.DeleteTeam:output_type -> authlayer.v1.DeleteTeamResponse
-	10, // 21: authlayer.v1.TeamService.ListTeams:output_type -> authlayer.v1.ListTeamsResponse
-	12, // 22: authlayer.v1.TeamService.AddMember:output_type -> authlayer.v1.AddTeamMemberResponse
-	14, // 23: authlayer.v1.TeamService.RemoveMember:output_type -> authlayer.v1.RemoveTeamMemberResponse
-	16, // 24: authlayer.v1.TeamService.ListMembers:output_type -> authlayer.v1.ListTeamMembersResponse
-	17, // [17:25] is the sub-list for method output_type
-	9,  // [9:17] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	17, // 0: authlayer.v1.TeamInfo.labels:type_name -> authlayer.v1.TeamInfo.LabelsEntry
+	18, // 1: authlayer.v1.CreateTeamRequest.labels:type_name -> authlayer.v1.CreateTeamRequest.LabelsEntry
+	0,  // 2: authlayer.v1.CreateTeamResponse.team:type_name -> authlayer.v1.TeamInfo
+	0,  // 3: authlayer.v1.GetTeamResponse.team:type_name -> authlayer.v1.TeamInfo
+	19, // 4: authlayer.v1.UpdateTeamRequest.labels:type_name -> authlayer.v1.LabelSet
+	0,  // 5: authlayer.v1.UpdateTeamResponse.team:type_name -> authlayer.v1.TeamInfo
+	20, // 6: authlayer.v1.ListTeamsRequest.pagination:type_name -> authlayer.v1.PaginationRequest
+	0,  // 7: authlayer.v1.ListTeamsResponse.teams:type_name -> authlayer.v1.TeamInfo
+	21, // 8: authlayer.v1.ListTeamsResponse.pagination:type_name -> authlayer.v1.PaginationResponse
+	20, // 9: authlayer.v1.ListTeamMembersRequest.pagination:type_name -> authlayer.v1.PaginationRequest
+	22, // 10: authlayer.v1.ListTeamMembersResponse.members:type_name -> authlayer.v1.MemberInfo
+	21, // 11: authlayer.v1.ListTeamMembersResponse.pagination:type_name -> authlayer.v1.PaginationResponse
+	1,  // 12: authlayer.v1.TeamService.CreateTeam:input_type -> authlayer.v1.CreateTeamRequest
+	3,  // 13: authlayer.v1.TeamService.GetTeam:input_type -> authlayer.v1.GetTeamRequest
+	5,  // 14: authlayer.v1.TeamService.UpdateTeam:input_type -> authlayer.v1.UpdateTeamRequest
+	7,  // 15: authlayer.v1.TeamService.DeleteTeam:input_type -> authlayer.v1.DeleteTeamRequest
+	9,  // 16: authlayer.v1.TeamService.ListTeams:input_type -> authlayer.v1.ListTeamsRequest
+	11, // 17: authlayer.v1.TeamService.AddMember:input_type -> authlayer.v1.AddTeamMemberRequest
+	13, // 18: authlayer.v1.TeamService.RemoveMember:input_type -> authlayer.v1.RemoveTeamMemberRequest
+	15, // 19: authlayer.v1.TeamService.ListMembers:input_type -> authlayer.v1.ListTeamMembersRequest
+	2,  // 20: authlayer.v1.TeamService.CreateTeam:output_type -> authlayer.v1.CreateTeamResponse
+	4,  // 21: authlayer.v1.TeamService.GetTeam:output_type -> authlayer.v1.GetTeamResponse
+	6,  // 22: authlayer.v1.TeamService.UpdateTeam:output_type -> authlayer.v1.UpdateTeamResponse
+	8,  // 23: authlayer.v1.TeamService.DeleteTeam:output_type -> authlayer.v1.DeleteTeamResponse
+	10, // 24: authlayer.v1.TeamService.ListTeams:output_type -> authlayer.v1.ListTeamsResponse
+	12, // 25: authlayer.v1.TeamService.AddMember:output_type -> authlayer.v1.AddTeamMemberResponse
+	14, // 26: authlayer.v1.TeamService.RemoveMember:output_type -> authlayer.v1.RemoveTeamMemberResponse
+	16, // 27: authlayer.v1.TeamService.ListMembers:output_type -> authlayer.v1.ListTeamMembersResponse
+	20, // [20:28] is the sub-list for method output_type
+	12, // [12:20] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_authlayer_v1_team_proto_init() }
@@ -986,13 +1037,14 @@ func file_authlayer_v1_team_proto_init() {
 	}
 	file_authlayer_v1_common_proto_init()
 	file_authlayer_v1_team_proto_msgTypes[5].OneofWrappers = []any{}
+	file_authlayer_v1_team_proto_msgTypes[9].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_authlayer_v1_team_proto_rawDesc), len(file_authlayer_v1_team_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   17,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -295,10 +295,12 @@ func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
 }
 
 type ListUsersRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Pagination    *PaginationRequest     `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
-	Search        *string                `protobuf:"bytes,2,opt,name=search,proto3,oneof" json:"search,omitempty"`
-	Status        *UserStatus            `protobuf:"varint,3,opt,name=status,proto3,enum=authlayer.v1.UserStatus,oneof" json:"status,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Pagination *PaginationRequest     `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	Search     *string                `protobuf:"bytes,2,opt,name=search,proto3,oneof" json:"search,omitempty"`
+	Status     *UserStatus            `protobuf:"varint,3,opt,name=status,proto3,enum=authlayer.v1.UserStatus,oneof" json:"status,omitempty"`
+	// Label selector, e.g. "env=prod,team in (a,b)".
+	LabelSelector *string `protobuf:"bytes,4,opt,name=label_selector,json=labelSelector,proto3,oneof" json:"label_selector,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -354,6 +356,13 @@ func (x *ListUsersRequest) GetStatus() UserStatus {
 	return UserStatus_USER_STATUS_UNSPECIFIED
 }
 
+func (x *ListUsersRequest) GetLabelSelector() string {
+	if x != nil && x.LabelSelector != nil {
+		return *x.LabelSelector
+	}
+	return ""
+}
+
 type ListUsersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Users         []*UserInfo            `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
@@ -494,6 +503,102 @@ func (*ChangePasswordResponse) Descriptor() ([]byte, []int) {
 	return file_authlayer_v1_user_proto_rawDescGZIP(), []int{9}
 }
 
+type SetUserLabelsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserLabelsRequest) Reset() {
+	*x = SetUserLabelsRequest{}
+	mi := &file_authlayer_v1_user_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserLabelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserLabelsRequest) ProtoMessage() {}
+
+func (x *SetUserLabelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_user_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserLabelsRequest.ProtoReflect.Descriptor instead.
+func (*SetUserLabelsRequest) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_user_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SetUserLabelsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetUserLabelsRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type SetUserLabelsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *UserInfo              `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserLabelsResponse) Reset() {
+	*x = SetUserLabelsResponse{}
+	mi := &file_authlayer_v1_user_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserLabelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserLabelsResponse) ProtoMessage() {}
+
+func (x *SetUserLabelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authlayer_v1_user_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserLabelsResponse.ProtoReflect.Descriptor instead.
+func (*SetUserLabelsResponse) Descriptor() ([]byte, []int) {
+	return file_authlayer_v1_user_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SetUserLabelsResponse) GetUser() *UserInfo {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
 var File_authlayer_v1_user_proto protoreflect.FileDescriptor
 
 const file_authlayer_v1_user_proto_rawDesc = "" +
@@ -513,15 +618,17 @@ const file_authlayer_v1_user_proto_rawDesc = "" +
 	"\x04user\x18\x01 \x01(\v2\x16.authlayer.v1.UserInfoR\x04user\",\n" +
 	"\x11DeleteUserRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x14\n" +
-	"\x12DeleteUserResponse\"\xbd\x01\n" +
+	"\x12DeleteUserResponse\"\xfc\x01\n" +
 	"\x10ListUsersRequest\x12?\n" +
 	"\n" +
 	"pagination\x18\x01 \x01(\v2\x1f.authlayer.v1.PaginationRequestR\n" +
 	"pagination\x12\x1b\n" +
 	"\x06search\x18\x02 \x01(\tH\x00R\x06search\x88\x01\x01\x125\n" +
-	"\x06status\x18\x03 \x01(\x0e2\x18.authlayer.v1.UserStatusH\x01R\x06status\x88\x01\x01B\t\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x18.authlayer.v1.UserStatusH\x01R\x06status\x88\x01\x01\x12*\n" +
+	"\x0elabel_selector\x18\x04 \x01(\tH\x02R\rlabelSelector\x88\x01\x01B\t\n" +
 	"\a_searchB\t\n" +
-	"\a_status\"\x83\x01\n" +
+	"\a_statusB\x11\n" +
+	"\x0f_label_selector\"\x83\x01\n" +
 	"\x11ListUsersResponse\x12,\n" +
 	"\x05users\x18\x01 \x03(\v2\x16.authlayer.v1.UserInfoR\x05users\x12@\n" +
 	"\n" +
@@ -530,7 +637,15 @@ const file_authlayer_v1_user_proto_rawDesc = "" +
 	"\x15ChangePasswordRequest\x12)\n" +
 	"\x10current_password\x18\x01 \x01(\tR\x0fcurrentPassword\x12!\n" +
 	"\fnew_password\x18\x02 \x01(\tR\vnewPassword\"\x18\n" +
-	"\x16ChangePasswordResponse2\xb1\x04\n" +
+	"\x16ChangePasswordResponse\"\xb2\x01\n" +
+	"\x14SetUserLabelsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12F\n" +
+	"\x06labels\x18\x02 \x03(\v2..authlayer.v1.SetUserLabelsRequest.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"C\n" +
+	"\x15SetUserLabelsResponse\x12*\n" +
+	"\x04user\x18\x01 \x01(\v2\x16.authlayer.v1.UserInfoR\x04user2\xb2\x05\n" +
 	"\vUserService\x12c\n" +
 	"\aGetUser\x12\x1c.authlayer.v1.GetUserRequest\x1a\x1d.authlayer.v1.GetUserResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/users/{user_id}\x12o\n" +
 	"\n" +
@@ -538,7 +653,8 @@ const file_authlayer_v1_user_proto_rawDesc = "" +
 	"\n" +
 	"DeleteUser\x12\x1f.authlayer.v1.DeleteUserRequest\x1a .authlayer.v1.DeleteUserResponse\"\x1b\x82\xd3\xe4\x93\x02\x15*\x13/v1/users/{user_id}\x12_\n" +
 	"\tListUsers\x12\x1e.authlayer.v1.ListUsersRequest\x1a\x1f.authlayer.v1.ListUsersResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/users\x12}\n" +
-	"\x0eChangePassword\x12#.authlayer.v1.ChangePasswordRequest\x1a$.authlayer.v1.ChangePasswordResponse\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/v1/users/me/passwordBJZHgithub.com/bernardoforcillo/authlayer/pkg/proto/authlayer/v1;authlayerv1b\x06proto3"
+	"\x0eChangePassword\x12#.authlayer.v1.ChangePasswordRequest\x1a$.authlayer.v1.ChangePasswordResponse\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/v1/users/me/password\x12\x7f\n" +
+	"\rSetUserLabels\x12\".authlayer.v1.SetUserLabelsRequest\x1a#.authlayer.v1.SetUserLabelsResponse\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\x1a\x1a/v1/users/{user_id}/labelsBJZHgithub.com/bernardoforcillo/authlayer/pkg/proto/authlayer/v1;authlayerv1b\x06proto3"
 
 var (
 	file_authlayer_v1_user_proto_rawDescOnce sync.Once
@@ -552,7 +668,7 @@ func file_authlayer_v1_user_proto_rawDescGZIP() []byte {
 	return file_authlayer_v1_user_proto_rawDescData
 }
 
-var file_authlayer_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_authlayer_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_authlayer_v1_user_proto_goTypes = []any{
 	(*GetUserRequest)(nil),         // 0: authlayer.v1.GetUserRequest
 	(*GetUserResponse)(nil),        // 1: authlayer.v1.GetUserResponse
@@ -564,33 +680,40 @@ var file_authlayer_v1_user_proto_goTypes = []any{
 	(*ListUsersResponse)(nil),      // 7: authlayer.v1.ListUsersResponse
 	(*ChangePasswordRequest)(nil),  // 8: authlayer.v1.ChangePasswordRequest
 	(*ChangePasswordResponse)(nil), // 9: authlayer.v1.ChangePasswordResponse
-	(*UserInfo)(nil),               // 10: authlayer.v1.UserInfo
-	(*PaginationRequest)(nil),      // 11: authlayer.v1.PaginationRequest
-	(UserStatus)(0),                // 12: authlayer.v1.UserStatus
-	(*PaginationResponse)(nil),     // 13: authlayer.v1.PaginationResponse
+	(*SetUserLabelsRequest)(nil),   // 10: authlayer.v1.SetUserLabelsRequest
+	(*SetUserLabelsResponse)(nil),  // 11: authlayer.v1.SetUserLabelsResponse
+	nil,                            // 12: authlayer.v1.SetUserLabelsRequest.LabelsEntry
+	(*UserInfo)(nil),               // 13: authlayer.v1.UserInfo
+	(*PaginationRequest)(nil),      // 14: authlayer.v1.PaginationRequest
+	(UserStatus)(0),                // 15: authlayer.v1.UserStatus
+	(*PaginationResponse)(nil),     // 16: authlayer.v1.PaginationResponse
 }
 var file_authlayer_v1_user_proto_depIdxs = []int32{
-	10, // 0: authlayer.v1.GetUserResponse.user:type_name -> authlayer.v1.UserInfo
-	10, // 1: authlayer.v1.UpdateUserResponse.user:type_name -> authlayer.v1.UserInfo
-	11, // 2: authlayer.v1.ListUsersRequest.pagination:type_name -> authlayer.v1.PaginationRequest
-	12, // 3: authlayer.v1.ListUsersRequest.status:type_name -> authlayer.v1.UserStatus
-	10, // 4: authlayer.v1.ListUsersResponse.users:type_name -> authlayer.v1.UserInfo
-	13, // 5: authlayer.v1.ListUsersResponse.pagination:type_name -> authlayer.v1.PaginationResponse
-	0,  // 6: authlayer.v1.UserService.GetUser:input_type -> authlayer.v1.GetUserRequest
-	2,  // 7: authlayer.v1.UserService.UpdateUser:input_type -> authlayer.v1.UpdateUserRequest
-	4,  // 8: authlayer.v1.UserService.DeleteUser:input_type -> authlayer.v1.DeleteUserRequest
-	6,  // 9: authlayer.v1.UserService.ListUsers:input_type -> authlayer.v1.ListUsersRequest
-	8,  // 10: authlayer.v1.UserService.ChangePassword:input_type -> authlayer.v1.ChangePasswordRequest
-	1,  // 11: authlayer.v1.UserService.GetUser:output_type -> authlayer.v1.GetUserResponse
-	3,  // 12: authlayer.v1.UserService.UpdateUser:output_type -> authlayer.v1.UpdateUserResponse
-	5,  // 13: authlayer.v1.UserService.DeleteUser:output_type -> authlayer.v1.DeleteUserResponse
-	7,  // 14: authlayer.v1.UserService.ListUsers:output_type -> authlayer.v1.ListUsersResponse
-	9,  // 15: authlayer.v1.UserService.ChangePassword:output_type -> authlayer.v1.ChangePasswordResponse
-	11, // [11:16] is the sub-list for method output_type
-	6,  // [6:11] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	13, // 0: authlayer.v1.GetUserResponse.user:type_name -> authlayer.v1.UserInfo
+	13, // 1: authlayer.v1.UpdateUserResponse.user:type_name -> authlayer.v1.UserInfo
+	14, // 2: authlayer.v1.ListUsersRequest.pagination:type_name -> authlayer.v1.PaginationRequest
+	15, // 3: authlayer.v1.ListUsersRequest.status:type_name -> authlayer.v1.UserStatus
+	13, // 4: authlayer.v1.ListUsersResponse.users:type_name -> authlayer.v1.UserInfo
+	16, // 5: authlayer.v1.ListUsersResponse.pagination:type_name -> authlayer.v1.PaginationResponse
+	12, // 6: authlayer.v1.SetUserLabelsRequest.labels:type_name -> authlayer.v1.SetUserLabelsRequest.LabelsEntry
+	13, // 7: authlayer.v1.SetUserLabelsResponse.user:type_name -> authlayer.v1.UserInfo
+	0,  // 8: authlayer.v1.UserService.GetUser:input_type -> authlayer.v1.GetUserRequest
+	2,  // 9: authlayer.v1.UserService.UpdateUser:input_type -> authlayer.v1.UpdateUserRequest
+	4,  // 10: authlayer.v1.UserService.DeleteUser:input_type -> authlayer.v1.DeleteUserRequest
+	6,  // 11: authlayer.v1.UserService.ListUsers:input_type -> authlayer.v1.ListUsersRequest
+	8,  // 12: authlayer.v1.UserService.ChangePassword:input_type -> authlayer.v1.ChangePasswordRequest
+	10, // 13: authlayer.v1.UserService.SetUserLabels:input_type -> authlayer.v1.SetUserLabelsRequest
+	1,  // 14: authlayer.v1.UserService.GetUser:output_type -> authlayer.v1.GetUserResponse
+	3,  // 15: authlayer.v1.UserService.UpdateUser:output_type -> authlayer.v1.UpdateUserResponse
+	5,  // 16: authlayer.v1.UserService.DeleteUser:output_type -> authlayer.v1.DeleteUserResponse
+	7,  // 17: authlayer.v1.UserService.ListUsers:output_type -> authlayer.v1.ListUsersResponse
+	9,  // 18: authlayer.v1.UserService.ChangePassword:output_type -> authlayer.v1.ChangePasswordResponse
+	11, // 19: authlayer.v1.UserService.SetUserLabels:output_type -> authlayer.v1.SetUserLabelsResponse
+	14, // [14:20] is the sub-list for method output_type
+	8,  // [8:14] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_authlayer_v1_user_proto_init() }
@@ -607,7 +730,7 @@ func file_authlayer_v1_user_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_authlayer_v1_user_proto_rawDesc), len(file_authlayer_v1_user_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -24,6 +24,7 @@ const (
 	UserService_DeleteUser_FullMethodName     = "/authlayer.v1.UserService/DeleteUser"
 	UserService_ListUsers_FullMethodName      = "/authlayer.v1.UserService/ListUsers"
 	UserService_ChangePassword_FullMethodName = "/authlayer.v1.UserService/ChangePassword"
+	UserService_SetUserLabels_FullMethodName  = "/authlayer.v1.UserService/SetUserLabels"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -35,6 +36,9 @@ type UserServiceClient interface {
 	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserResponse, error)
 	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
 	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponse, error)
+	// SetUserLabels replaces a user's labels. Labels can grant roles through label
+	// role bindings, so they are managed by admins rather than through UpdateUser.
+	SetUserLabels(ctx context.Context, in *SetUserLabelsRequest, opts ...grpc.CallOption) (*SetUserLabelsResponse, error)
 }
 
 type userServiceClient struct {
@@ -95,6 +99,16 @@ func (c *userServiceClient) ChangePassword(ctx context.Context, in *ChangePasswo
 	return out, nil
 }
 
+func (c *userServiceClient) SetUserLabels(ctx context.Context, in *SetUserLabelsRequest, opts ...grpc.CallOption) (*SetUserLabelsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetUserLabelsResponse)
+	err := c.cc.Invoke(ctx, UserService_SetUserLabels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility.
@@ -104,6 +118,9 @@ type UserServiceServer interface {
 	DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error)
 	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
 	ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error)
+	// SetUserLabels replaces a user's labels. Labels can grant roles through label
+	// role bindings, so they are managed by admins rather than through UpdateUser.
+	SetUserLabels(context.Context, *SetUserLabelsRequest) (*SetUserLabelsResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -129,6 +146,9 @@ func (UnimplementedUserServiceServer) ListUsers(context.Context, *ListUsersReque
 func (UnimplementedUserServiceServer) ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ChangePassword not implemented")
 }
+func (UnimplementedUserServiceServer) SetUserLabels(context.Context, *SetUserLabelsRequest) (*SetUserLabelsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetUserLabels not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
 
@@ -240,6 +260,24 @@ func _UserService_ChangePassword_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_SetUserLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUserLabelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SetUserLabels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SetUserLabels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SetUserLabels(ctx, req.(*SetUserLabelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -267,6 +305,10 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ChangePassword",
 			Handler:    _UserService_ChangePassword_Handler,
 		},
+		{
+			MethodName: "SetUserLabels",
+			Handler:    _UserService_SetUserLabels_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "authlayer/v1/user.proto",